@@ -0,0 +1,133 @@
+//go:build js && wasm
+
+// Package main provides WebAssembly bindings for the goreasoner library.
+//
+// The bindings expose the reasoner to JavaScript through a global
+// "goreasoner" object, enabling client-side reasoning in the browser.
+// Only the in-memory core of pkg/reasoner is used; no file system access
+// is required.
+//
+// # Building
+//
+//	GOOS=js GOARCH=wasm go build -o goreasoner.wasm ./cmd/goreasoner-wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .
+//
+// # Usage
+//
+//	const go = new Go();
+//	const result = await WebAssembly.instantiateStreaming(fetch("goreasoner.wasm"), go.importObject);
+//	go.run(result.instance);
+//
+//	const { triples, error } = goreasoner.reason(aboxTurtle, tboxTurtle);
+//	const matches = goreasoner.query(aboxTurtle, tboxTurtle, "", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "");
+//	const satisfied = goreasoner.dlquery(datalogProgram, "?- Ancestor(john, jane).");
+//
+// Every function returns an object with an "error" property that is null
+// on success.
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+	"github.com/beyondcivic/goreasoner/pkg/version"
+)
+
+// programs caches compiled Datalog programs, since pages typically query
+// the same program repeatedly
+//
+//nolint:gochecknoglobals
+var programs = reasoner.NewProgramCache(16)
+
+func main() {
+	js.Global().Set("goreasoner", js.ValueOf(map[string]any{
+		"version": version.Version,
+		"reason":  js.FuncOf(reason),
+		"query":   js.FuncOf(query),
+		"dlquery": js.FuncOf(dlquery),
+	}))
+
+	// Keep the Go runtime alive so the exported functions stay callable
+	select {}
+}
+
+// reason(abox, tbox) -> { triples: string[], error: string|null }
+func reason(_ js.Value, args []js.Value) any {
+	if len(args) < 2 {
+		return result(nil, "reason expects (abox, tbox)")
+	}
+
+	triples, err := reasoner.ForwardReason(args[0].String(), args[1].String())
+	if err != nil {
+		return result(nil, err.Error())
+	}
+
+	return result(map[string]any{"triples": toJSArray(triples)}, "")
+}
+
+// query(abox, tbox, subject, predicate, object) -> { triples: string[], error: string|null }
+// Empty strings act as wildcards.
+func query(_ js.Value, args []js.Value) any {
+	if len(args) < 5 {
+		return result(nil, "query expects (abox, tbox, subject, predicate, object)")
+	}
+
+	r := reasoner.NewReasoner()
+	if tbox := args[1].String(); tbox != "" {
+		if err := r.LoadTurtle(tbox); err != nil {
+			return result(nil, "failed to load TBox: "+err.Error())
+		}
+	}
+	if abox := args[0].String(); abox != "" {
+		if err := r.LoadTurtle(abox); err != nil {
+			return result(nil, "failed to load ABox: "+err.Error())
+		}
+	}
+	r.RunForwardReasoning()
+
+	matches := r.Query(args[2].String(), args[3].String(), args[4].String())
+	triples := make([]string, len(matches))
+	for i, t := range matches {
+		triples[i] = t.String()
+	}
+
+	return result(map[string]any{"triples": toJSArray(triples)}, "")
+}
+
+// dlquery(program, query) -> { result: boolean, error: string|null }
+func dlquery(_ js.Value, args []js.Value) any {
+	if len(args) < 2 {
+		return result(nil, "dlquery expects (program, query)")
+	}
+
+	compiled, err := programs.Compile(args[0].String())
+	if err != nil {
+		return result(nil, err.Error())
+	}
+	satisfied, err := compiled.Query(args[1].String())
+	if err != nil {
+		return result(nil, err.Error())
+	}
+
+	return result(map[string]any{"result": satisfied}, "")
+}
+
+func result(fields map[string]any, errMsg string) any {
+	if fields == nil {
+		fields = make(map[string]any)
+	}
+	if errMsg != "" {
+		fields["error"] = errMsg
+	} else {
+		fields["error"] = nil
+	}
+	return js.ValueOf(fields)
+}
+
+func toJSArray(values []string) []any {
+	array := make([]any, len(values))
+	for i, v := range values {
+		array[i] = v
+	}
+	return array
+}
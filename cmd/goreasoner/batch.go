@@ -0,0 +1,314 @@
+// batch.go
+// Contains the run-batch command, which reasons over many ABox files
+// against one TBox
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+	"github.com/spf13/cobra"
+)
+
+// batchSummaryFile is the name of the summary report in the output directory
+const batchSummaryFile = "summary.json"
+
+// batchResult is the outcome of reasoning over one ABox file
+type batchResult struct {
+	ABox         string                `json:"abox"`
+	Output       string                `json:"output,omitempty"`
+	Asserted     int                   `json:"asserted"`
+	Inferred     int                   `json:"inferred"`
+	Milliseconds int64                 `json:"milliseconds"`
+	Error        string                `json:"error,omitempty"`
+	Diagnostics  []reasoner.Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// batchSummary is the summary report of a batch
+type batchSummary struct {
+	TBox    []string      `json:"tbox"`
+	Files   int           `json:"files"`
+	Failed  int           `json:"failed"`
+	Results []batchResult `json:"results"`
+}
+
+// runBatchCmd reasons over every ABox file of a directory against one TBox
+func runBatchCmd() *cobra.Command {
+	var runBatchCmd = &cobra.Command{
+		Use:   "run-batch",
+		Short: "Run forward reasoning on many ABox files against one TBox",
+		Long: `Run forward reasoning on every Turtle and N-Triples file below the ABox
+directory, each independently of the others, against one TBox. The TBox is
+loaded and saturated once and copied for each ABox file.
+
+The closure of each file is written below the output directory under the
+file's relative path, with the extension of the output type. A summary
+report listing the triples asserted and inferred for each file, its
+diagnostics and any error is written to summary.json in the output
+directory. The command fails if any file fails.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			flagTBoxPath, _ := cmd.Flags().GetString("tbox")
+			flagABoxDir, _ := cmd.Flags().GetString("abox-dir")
+			flagOutDir, _ := cmd.Flags().GetString("out-dir")
+			flagConcurrency, _ := cmd.Flags().GetInt("concurrency")
+			flagOutputType, _ := cmd.Flags().GetString("outputType")
+			flagFormat, _ := cmd.Flags().GetString("format")
+			flagRuleSet, _ := cmd.Flags().GetString("ruleset")
+			flagCheckConsistency, _ := cmd.Flags().GetBool("check-consistency")
+			flagSignKey, _ := cmd.Flags().GetString("sign-key")
+
+			if flagTBoxPath == "" || flagABoxDir == "" || flagOutDir == "" {
+				fmt.Printf("Error: --tbox, --abox-dir and --out-dir are required.\n")
+				os.Exit(1)
+			}
+			if flagOutputType != "ntriple" && flagOutputType != "turtle" {
+				fmt.Printf("Error: Invalid output type '%s'. Must be 'ntriple' or 'turtle'.\n", flagOutputType)
+				os.Exit(1)
+			}
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			if flagConcurrency < 1 {
+				fmt.Printf("Error: --concurrency must be at least 1.\n")
+				os.Exit(1)
+			}
+			if info, err := os.Stat(flagABoxDir); err != nil || !info.IsDir() {
+				fmt.Printf("Error: '%s' is not a directory.\n", flagABoxDir)
+				os.Exit(1)
+			}
+
+			rules := reasoner.DefaultRules()
+			if flagRuleSet != "" {
+				var err error
+				rules, err = ruleSetFromConfig(flagRuleSet)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var signer *outputSigner
+			if flagSignKey != "" {
+				var err error
+				signer, err = newOutputSigner(flagSignKey, rules, flagRuleSet)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			// Saturate the TBox once
+			var summary inputSummary
+			tboxInputs := readInputs("TBox", flagTBoxPath, flagFormat, &summary)
+			if len(tboxInputs) == 0 {
+				fmt.Printf("Error: no loadable TBox files in '%s'.\n", flagTBoxPath)
+				os.Exit(1)
+			}
+			opts := []reasoner.Option{reasoner.WithRules(rules)}
+			if flagCheckConsistency {
+				opts = append(opts, reasoner.WithConsistencyCheck())
+			}
+			tbox, tboxResult, err := runReasoner("", tboxInputs, &summary, nil, opts...)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(summary.failed) > 0 {
+				summary.print()
+				os.Exit(1)
+			}
+			tboxDiagnostics := tboxResult.Diagnostics
+			reportDiagnostics(tboxDiagnostics)
+
+			aboxPaths, err := expandInput(flagABoxDir)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(aboxPaths) == 0 {
+				fmt.Printf("Error: no loadable ABox files in '%s'.\n", flagABoxDir)
+				os.Exit(1)
+			}
+			if err := os.MkdirAll(flagOutDir, 0o755); err != nil {
+				fmt.Printf("Error creating output directory: %v\n", err)
+				os.Exit(1)
+			}
+
+			infof("Running forward reasoning on %d ABox files against '%s'...\n", len(aboxPaths), flagTBoxPath)
+			batch := batchSummary{TBox: summary.loaded, Files: len(aboxPaths), Results: make([]batchResult, len(aboxPaths))}
+			job := batchJob{
+				tbox:             tbox,
+				tboxDiagnostics:  tboxDiagnostics,
+				format:           flagFormat,
+				outputType:       flagOutputType,
+				checkConsistency: flagCheckConsistency,
+				signer:           signer,
+			}
+
+			sem := make(chan struct{}, flagConcurrency)
+			var wg sync.WaitGroup
+			for i, aboxPath := range aboxPaths {
+				wg.Add(1)
+				sem <- struct{}{}
+				go func() {
+					defer wg.Done()
+					defer func() { <-sem }()
+					output := batchOutputPath(flagABoxDir, aboxPath, flagOutDir, flagOutputType)
+					batch.Results[i] = job.run(aboxPath, output)
+					verbosef("Reasoned over '%s'\n", aboxPath)
+				}()
+			}
+			wg.Wait()
+
+			for _, result := range batch.Results {
+				if result.Error != "" {
+					batch.Failed++
+					infof("  failed: %s: %s\n", result.ABox, result.Error)
+					continue
+				}
+				note := ""
+				if n := len(result.Diagnostics); n > 0 {
+					note = fmt.Sprintf(" (%d diagnostics)", n)
+				}
+				infof("  %s: %d asserted, %d inferred -> %s%s\n", result.ABox, result.Asserted, result.Inferred, result.Output, note)
+			}
+
+			summaryPath := filepath.Join(flagOutDir, batchSummaryFile)
+			data, err := json.MarshalIndent(batch, "", "  ")
+			if err == nil {
+				err = os.WriteFile(summaryPath, append(data, '\n'), 0o644)
+			}
+			if err != nil {
+				fmt.Printf("Error writing summary report: %v\n", err)
+				os.Exit(1)
+			}
+
+			if batch.Failed > 0 {
+				fmt.Printf("Error: %d of %d ABox files failed; see %s\n", batch.Failed, batch.Files, summaryPath)
+				os.Exit(1)
+			}
+			infof("✓ Reasoned over %d ABox files; summary saved to: %s\n", batch.Files, summaryPath)
+		},
+	}
+	runBatchCmd.Flags().String("tbox", "", "Schema file, directory or pattern, saturated once and shared by all ABox files")
+	runBatchCmd.Flags().String("abox-dir", "", "Directory of ABox files, each reasoned over independently")
+	runBatchCmd.Flags().String("out-dir", "", "Directory to write the outputs and the summary report to")
+	runBatchCmd.Flags().Int("concurrency", runtime.NumCPU(), "Number of ABox files reasoned over at the same time")
+	runBatchCmd.Flags().String("outputType", "ntriple", "Output format: 'ntriple' or 'turtle' (sorted by subject, for version control)")
+	runBatchCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+	runBatchCmd.Flags().String("ruleset", "", "Apply a rule set defined under 'rulesets' in the configuration file instead of the default rules")
+	runBatchCmd.Flags().String("sign-key", "", "PEM-encoded Ed25519 private key to sign each output file with, writing the signature next to it with '.sig' appended")
+	runBatchCmd.Flags().Bool("check-consistency", false, "Fail a file without writing its output if its data contradicts owl:differentFrom, owl:disjointWith, owl:Nothing or property declarations")
+
+	return runBatchCmd
+}
+
+// batchJob holds what reasoning over each ABox file of a batch shares
+type batchJob struct {
+	tbox             *reasoner.Reasoner
+	tboxDiagnostics  []reasoner.Diagnostic
+	format           string
+	outputType       string
+	checkConsistency bool
+	signer           *outputSigner
+}
+
+// run reasons over one ABox file and records how long it took
+func (j batchJob) run(aboxPath, output string) batchResult {
+	start := time.Now()
+	result := j.reason(aboxPath, output)
+	result.Milliseconds = time.Since(start).Milliseconds()
+	return result
+}
+
+// reason reasons over one ABox file on a copy of the saturated TBox and
+// writes the closure to output
+func (j batchJob) reason(aboxPath, output string) batchResult {
+	result := batchResult{ABox: aboxPath}
+	content, err := readFile(aboxPath)
+	format := reasoner.FormatUnknown
+	if err == nil {
+		format, err = checkInputFormat(aboxPath, content, j.format)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	r := j.tbox.Fork()
+	before := r.GetStore().Size()
+	if err := loadInput(r, aboxPath, content, format); err != nil {
+		result.Error = fmt.Sprintf("failed to load ABox file '%s': %v", aboxPath, err)
+		return result
+	}
+	result.Asserted = r.GetStore().Size() - before
+	result.Inferred = r.RunForwardReasoning()
+
+	// Diagnostics of the TBox are reported once, not for every file
+	known := make(map[string]bool, len(j.tboxDiagnostics))
+	for _, d := range j.tboxDiagnostics {
+		known[d.String()] = true
+	}
+	inconsistencies := 0
+	for _, d := range r.Diagnostics() {
+		if known[d.String()] {
+			continue
+		}
+		result.Diagnostics = append(result.Diagnostics, d)
+		if d.Code == reasoner.CodeInconsistency {
+			inconsistencies++
+		}
+	}
+	if j.checkConsistency && inconsistencies > 0 {
+		result.Error = fmt.Sprintf("%d inconsistencies found", inconsistencies)
+		return result
+	}
+
+	lines := r.GetAllTriples()
+	if j.outputType == "turtle" {
+		snapshot := reasoner.SerializeTurtleSorted(r.GetStore().All(), r.Prefixes())
+		lines = []string{strings.TrimSuffix(snapshot, "\n")}
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		result.Error = fmt.Sprintf("failed to create output directory: %v", err)
+		return result
+	}
+	if err := writeTriplesToFile(lines, output); err != nil {
+		result.Error = fmt.Sprintf("failed to write output file: %v", err)
+		return result
+	}
+	result.Output = output
+
+	if j.signer != nil {
+		if _, err := j.signer.sign(output); err != nil {
+			result.Error = err.Error()
+		}
+	}
+	return result
+}
+
+// batchOutputPath returns the output path of an ABox file: its path
+// relative to the ABox directory, below the output directory, with the
+// extension of the output type
+func batchOutputPath(aboxDir, aboxPath, outDir, outputType string) string {
+	rel, err := filepath.Rel(aboxDir, aboxPath)
+	if err != nil {
+		rel = filepath.Base(aboxPath)
+	}
+	ext := ".nt"
+	if outputType == "turtle" {
+		ext = ".ttl"
+	}
+	return filepath.Join(outDir, strings.TrimSuffix(rel, filepath.Ext(rel))+ext)
+}
@@ -0,0 +1,208 @@
+// bench.go
+// Contains the bench commands measuring and comparing reasoning performance
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+	"github.com/spf13/cobra"
+)
+
+// benchCmd measures reasoning performance and compares it between versions
+func benchCmd() *cobra.Command {
+	var benchCmd = &cobra.Command{
+		Use:   "bench",
+		Short: "Measure and compare reasoning performance",
+		Long: `Measure how long reasoning takes and how much memory it allocates on your own
+data, and compare the reports of two versions to catch regressions before
+upgrading:
+
+  goreasoner bench run data/a.ttl data/b.ttl --tbox schema.ttl -o old.json
+  # upgrade goreasoner
+  goreasoner bench run data/a.ttl data/b.ttl --tbox schema.ttl -o new.json
+  goreasoner bench compare old.json new.json`,
+	}
+
+	var runCmd = &cobra.Command{
+		Use:   "run [dataPath...]",
+		Short: "Measure reasoning over data sets and write a report",
+		Long: `Reason over each data path, a file, directory or pattern, as one scenario
+named after the path, and write a JSON report of the median time and memory
+allocated by reasoning over --runs runs, and the number of triples inferred.
+Loading the data is not measured.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			flagTBoxPath, _ := cmd.Flags().GetString("tbox")
+			flagRuns, _ := cmd.Flags().GetInt("runs")
+			flagOutputPath, _ := cmd.Flags().GetString("output")
+			flagFormat, _ := cmd.Flags().GetString("format")
+
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var summary inputSummary
+			var tboxInputs []inputFile
+			if flagTBoxPath != "" {
+				tboxInputs = readInputs("TBox", flagTBoxPath, flagFormat, &summary)
+			}
+
+			report := reasoner.NewBenchmarkReport()
+			for _, dataPath := range args {
+				dataInputs := readInputs("data", dataPath, flagFormat, &summary)
+				if len(dataInputs) == 0 {
+					fmt.Printf("Error: no loadable data files in '%s'.\n", dataPath)
+					os.Exit(1)
+				}
+				inputs := append(append([]inputFile(nil), tboxInputs...), dataInputs...)
+				scenario, err := reasoner.MeasureScenario(dataPath, flagRuns, func() (*reasoner.Reasoner, error) {
+					r := reasoner.NewReasoner()
+					for _, in := range inputs {
+						if err := loadInput(r, in.Path, in.Content, in.Format); err != nil {
+							return nil, fmt.Errorf("failed to load %s file '%s': %w", in.Label, in.Path, err)
+						}
+					}
+					return r, nil
+				})
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				notef("%s: %.2f ms, %s, %d inferred\n", scenario.Name, scenario.Milliseconds,
+					formatBytes(int64(scenario.AllocatedBytes)), scenario.Inferred)
+				report.Scenarios = append(report.Scenarios, scenario)
+			}
+			if len(summary.failed) > 0 {
+				summary.print()
+			}
+
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				fmt.Printf("Error encoding report: %v\n", err)
+				os.Exit(1)
+			}
+			if flagOutputPath == "" || flagOutputPath == "-" {
+				fmt.Println(string(data))
+				return
+			}
+			if err := os.WriteFile(flagOutputPath, append(data, '\n'), 0o644); err != nil {
+				fmt.Printf("Error writing report: %v\n", err)
+				os.Exit(1)
+			}
+			infof("✓ Benchmark report of %d scenarios saved to: %s\n", len(report.Scenarios), flagOutputPath)
+		},
+	}
+	runCmd.Flags().String("tbox", "", "Schema file, directory or pattern to load before the data of every scenario")
+	runCmd.Flags().Int("runs", 5, "Number of times each scenario is reasoned over")
+	runCmd.Flags().StringP("output", "o", "", "Report file; by default or with '-' the report is written to stdout")
+	runCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+
+	var compareCmd = &cobra.Command{
+		Use:   "compare [baseline.json] [candidate.json]",
+		Short: "Compare two benchmark reports and fail on regressions",
+		Long: `Compare the scenarios of a candidate report with those of a baseline, as
+written by 'goreasoner bench run', and print the change of time, memory and
+inferred triples of each. The command exits with status 1 if a scenario
+regressed: its time or memory grew by more than the thresholds, it inferred
+a different number of triples, or it is missing from the candidate.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			flagMaxTime, _ := cmd.Flags().GetFloat64("max-time-increase")
+			flagMaxMemory, _ := cmd.Flags().GetFloat64("max-memory-increase")
+			flagMinTime, _ := cmd.Flags().GetFloat64("min-time-delta")
+			flagJSON, _ := cmd.Flags().GetBool("json")
+
+			baseline, err := readBenchmarkReport(args[0])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			candidate, err := readBenchmarkReport(args[1])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			comparisons := reasoner.CompareBenchmarks(baseline, candidate, reasoner.BenchmarkThresholds{
+				Time:            flagMaxTime / 100,
+				Memory:          flagMaxMemory / 100,
+				MinMilliseconds: flagMinTime,
+			})
+			regressed := 0
+			for _, c := range comparisons {
+				if c.Regressed() {
+					regressed++
+				}
+			}
+
+			if flagJSON {
+				data, err := json.MarshalIndent(comparisons, "", "  ")
+				if err != nil {
+					fmt.Printf("Error encoding comparison: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Println(string(data))
+			} else {
+				printBenchmarkComparison(baseline, candidate, comparisons)
+			}
+
+			if regressed > 0 {
+				fmt.Printf("Error: %d of %d scenarios regressed.\n", regressed, len(comparisons))
+				os.Exit(1)
+			}
+			infof("✓ No regressions in %d scenarios\n", len(comparisons))
+		},
+	}
+	compareCmd.Flags().Float64("max-time-increase", 10, "Largest accepted increase of the time of a scenario, in percent")
+	compareCmd.Flags().Float64("max-memory-increase", 10, "Largest accepted increase of the memory allocated by a scenario, in percent")
+	compareCmd.Flags().Float64("min-time-delta", 1, "Ignore time increases of fewer milliseconds, which are within the noise of short scenarios")
+	compareCmd.Flags().Bool("json", false, "Print the comparison as JSON")
+
+	benchCmd.AddCommand(runCmd)
+	benchCmd.AddCommand(compareCmd)
+	return benchCmd
+}
+
+// readBenchmarkReport reads a report written by 'bench run'
+func readBenchmarkReport(path string) (reasoner.BenchmarkReport, error) {
+	var report reasoner.BenchmarkReport
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report, fmt.Errorf("failed to read benchmark report '%s': %w", path, err)
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return report, fmt.Errorf("failed to parse benchmark report '%s': %w", path, err)
+	}
+	return report, nil
+}
+
+// printBenchmarkComparison prints a line per scenario with its changes and
+// regressions
+func printBenchmarkComparison(baseline, candidate reasoner.BenchmarkReport, comparisons []reasoner.ScenarioComparison) {
+	fmt.Printf("Baseline: %s (%s), candidate: %s (%s)\n\n",
+		baseline.Version, baseline.GoVersion, candidate.Version, candidate.GoVersion)
+	for _, c := range comparisons {
+		status := "ok"
+		if c.Regressed() {
+			status = "REGRESSED: " + strings.Join(c.Regressions, "; ")
+		}
+		switch {
+		case c.Candidate == nil:
+			fmt.Printf("  %s: %s\n", c.Name, status)
+		case c.Baseline == nil:
+			fmt.Printf("  %s: new scenario, %.2f ms, %s, %d inferred\n", c.Name, c.Candidate.Milliseconds,
+				formatBytes(int64(c.Candidate.AllocatedBytes)), c.Candidate.Inferred)
+		default:
+			fmt.Printf("  %s: time %+.1f%%, memory %+.1f%%, %d inferred: %s\n", c.Name,
+				100*c.TimeChange, 100*c.MemoryChange, c.Candidate.Inferred, status)
+		}
+	}
+	fmt.Println()
+}
@@ -5,9 +5,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/beyondcivic/goreasoner/pkg/reasoner"
@@ -32,75 +37,442 @@ func versionCmd() *cobra.Command {
 	}
 }
 
+// capabilitiesCmd describes the features of this build
+func capabilitiesCmd() *cobra.Command {
+	var capabilitiesCmd = &cobra.Command{
+		Use:   "capabilities",
+		Short: "Print the supported syntaxes, entailment regimes, rules and limits",
+		Long: `Print the syntaxes, entailment regimes, rules, Datalog builtins and parser
+limits supported by this build. With --json the description is printed as a
+JSON object, so orchestrating systems can check for a feature before
+dispatching work to it.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			flagJSON, _ := cmd.Flags().GetBool("json")
+			c := reasoner.Capabilities()
+
+			if flagJSON {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(c); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			fmt.Printf("%s version %s\n", version.AppName, c.Version)
+			fmt.Printf("\nSyntaxes:\n")
+			for _, s := range c.Syntaxes {
+				support := "detected only"
+				if s.Load {
+					support = "load"
+				}
+				fmt.Printf("  %-10s %-14s %s\n", s.Name, support, strings.Join(s.Extensions, " "))
+			}
+			fmt.Printf("\nUnsupported Turtle constructs:\n")
+			for _, construct := range c.UnsupportedSyntax {
+				fmt.Printf("  %s\n", construct)
+			}
+			fmt.Printf("\nEntailment regimes:\n")
+			for _, e := range c.EntailmentRegimes {
+				coverage := "partial"
+				if e.Complete {
+					coverage = "complete"
+				}
+				fmt.Printf("  %s (%s): %s\n", e.Name, coverage, e.Coverage)
+			}
+			fmt.Printf("\nRules:\n")
+			for _, rule := range c.Rules {
+				fmt.Printf("  %s\n", rule.Name)
+			}
+			fmt.Printf("\nDatalog builtins: %s\n", strings.Join(c.DatalogBuiltins, ", "))
+			fmt.Printf("\nParser limits (0 = unlimited):\n")
+			for _, l := range c.Limits {
+				fmt.Printf("  %-17s %d  %s\n", l.Name, l.Default, l.Description)
+			}
+		},
+	}
+	capabilitiesCmd.Flags().Bool("json", false, "Print the capabilities as JSON")
+
+	return capabilitiesCmd
+}
+
 // Run command
 func runCmd() *cobra.Command {
 	var runCmd = &cobra.Command{
 		Use:   "run [aboxPath] [tboxPath]",
 		Short: "Run forward reasoning on RDF data",
-		Long:  `Run forward reasoning on RDF data, applying RDFS/OWL inference rules to derive new facts from TBox and ABox.`,
-		Args:  cobra.ExactArgs(2),
+		Long: `Run forward reasoning on RDF data, applying RDFS/OWL inference rules to derive new facts from TBox and ABox.
+With --single, the schema and the instance assertions are read from one input
+and told apart automatically.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if single, _ := cmd.Flags().GetString("single"); single != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			aboxPath := args[0]
-			tboxPath := args[1]
+			flagSingle, _ := cmd.Flags().GetString("single")
+			aboxPath, tboxPath := flagSingle, flagSingle
+			if flagSingle == "" {
+				aboxPath, tboxPath = args[0], args[1]
+			}
 			flagOutputPath, _ := cmd.Flags().GetString("output")
 			flagOutputType, _ := cmd.Flags().GetString("outputType")
+			flagScopeClasses, _ := cmd.Flags().GetStringSlice("scope-class")
+			flagScopePredicates, _ := cmd.Flags().GetStringSlice("scope-predicate")
+			flagPrevious, _ := cmd.Flags().GetString("previous")
+			flagPartitionBy, _ := cmd.Flags().GetString("partition-by")
+			flagDryRun, _ := cmd.Flags().GetBool("dry-run")
+			flagLiteralMatching, _ := cmd.Flags().GetString("literal-matching")
+			flagGraphs, _ := cmd.Flags().GetString("graphs")
+			flagQuoteLiterals, _ := cmd.Flags().GetBool("quote-literals")
+			flagDatalogConstraints, _ := cmd.Flags().GetBool("datalog-constraints")
+			flagCheckConsistency, _ := cmd.Flags().GetBool("check-consistency")
+			flagFormat, _ := cmd.Flags().GetString("format")
+			flagProvenance, _ := cmd.Flags().GetBool("provenance")
+			flagLimit, _ := cmd.Flags().GetInt("limit")
+			flagMergeDuplicates, _ := cmd.Flags().GetBool("merge-duplicate-literals")
+			flagCoerceIRILiterals, _ := cmd.Flags().GetBool("coerce-iri-literals")
+			flagSample, _ := cmd.Flags().GetInt("sample")
+			flagNormalizeIRIs, _ := cmd.Flags().GetStringSlice("normalize-iris")
+			flagMaxRuleInferences, _ := cmd.Flags().GetInt("max-rule-inferences")
+			flagMaxRuleTime, _ := cmd.Flags().GetDuration("max-rule-time")
+			flagRuleSet, _ := cmd.Flags().GetString("ruleset")
+			flagLabelFallback, _ := cmd.Flags().GetBool("label-fallback")
+			flagLabelLanguage, _ := cmd.Flags().GetString("label-language")
+			flagPartitionParallel, _ := cmd.Flags().GetBool("partition-parallel")
+			flagInferredOnly, _ := cmd.Flags().GetBool("inferred-only")
+			flagSignKey, _ := cmd.Flags().GetString("sign-key")
+			flagClosedWorld, _ := cmd.Flags().GetStringSlice("closed-world")
+			flagTruncateLiterals, _ := cmd.Flags().GetInt("truncate-literals")
+			flagRedactPredicates, _ := cmd.Flags().GetStringSlice("redact-predicate")
 
-			// Validate input files
-			if !fileExists(aboxPath) {
-				fmt.Printf("Error: ABox file '%s' does not exist.\n", aboxPath)
-				os.Exit(1)
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
 			}
 
-			if !fileExists(tboxPath) {
-				fmt.Printf("Error: TBox file '%s' does not exist.\n", tboxPath)
-				os.Exit(1)
+			// Determine output path, "-" meaning stdout
+			outputPath := determineOutputPath(flagOutputPath, inputBase(aboxPath))
+			if outputPath == "-" {
+				outputPath = ""
+			}
+			// Keep status messages out of results written to stdout
+			statusf := infof
+			if outputPath == "" {
+				statusf = notef
 			}
 
-			if !isTurtleFile(aboxPath) {
-				fmt.Printf("Error: File '%s' does not appear to be a Turtle file.\n", aboxPath)
+			// Validate output type
+			if flagOutputType != "ntriple" && flagOutputType != "datalog" && flagOutputType != "turtle" {
+				fmt.Printf("Error: Invalid output type '%s'. Must be 'ntriple', 'datalog' or 'turtle'.\n", flagOutputType)
 				os.Exit(1)
 			}
 
-			if !isTurtleFile(tboxPath) {
-				fmt.Printf("Error: File '%s' does not appear to be a Turtle file.\n", tboxPath)
+			literalMatching, err := reasoner.ParseLiteralMatching(flagLiteralMatching)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			graphReasoning, err := reasoner.ParseGraphReasoning(flagGraphs)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			iriNormalization, err := reasoner.ParseIRINormalization(flagNormalizeIRIs)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			constraints, err := reasoner.ParseConstraints(flagClosedWorld)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
 			}
 
-			// Determine output path
-			outputPath := determineOutputPath(flagOutputPath, aboxPath)
-
-			// Validate output type
-			if flagOutputType != "ntriple" && flagOutputType != "datalog" {
-				fmt.Printf("Error: Invalid output type '%s'. Must be 'ntriple' or 'datalog'.\n", flagOutputType)
+			// Validate partitioning
+			if flagPartitionBy != "" && flagPartitionBy != "namespace" && flagPartitionBy != "class" {
+				fmt.Printf("Error: Invalid partitioning '%s'. Must be 'namespace' or 'class'.\n", flagPartitionBy)
+				os.Exit(1)
+			}
+			if flagPartitionBy != "" && flagOutputType != "ntriple" {
+				fmt.Printf("Error: --partition-by only supports the 'ntriple' output type.\n")
 				os.Exit(1)
 			}
 
-			// Read input files
-			aboxContent, err := readFile(aboxPath)
-			if err != nil {
-				fmt.Printf("Error reading ABox file: %v\n", err)
+			// Validate result selection
+			if flagLimit < 0 || flagSample < 0 {
+				fmt.Printf("Error: --limit and --sample must not be negative.\n")
+				os.Exit(1)
+			}
+			if flagLimit > 0 && flagSample > 0 {
+				fmt.Printf("Error: --limit and --sample cannot be combined.\n")
+				os.Exit(1)
+			}
+			if flagPartitionBy != "" && (flagLimit > 0 || flagSample > 0) {
+				fmt.Printf("Error: --partition-by cannot be combined with --limit or --sample.\n")
+				os.Exit(1)
+			}
+			if flagSignKey != "" && outputPath == "" {
+				fmt.Printf("Error: --sign-key requires an output file.\n")
 				os.Exit(1)
 			}
+			if flagPartitionBy != "" && flagInferredOnly {
+				fmt.Printf("Error: --partition-by cannot be combined with --inferred-only.\n")
+				os.Exit(1)
+			}
+			// Partitions are written to a directory named after the output
+			// file, which must not hold partitions of an earlier run
+			outputDir := strings.TrimSuffix(outputPath, filepath.Ext(outputPath))
+			if flagPartitionBy != "" {
+				if outputPath == "" {
+					fmt.Printf("Error: --partition-by requires an output directory.\n")
+					os.Exit(1)
+				}
+				if entries, err := os.ReadDir(outputDir); err == nil && len(entries) > 0 {
+					fmt.Printf("Error: output directory '%s' is not empty.\n", outputDir)
+					os.Exit(1)
+				}
+			}
 
-			tboxContent, err := readFile(tboxPath)
-			if err != nil {
-				fmt.Printf("Error reading TBox file: %v\n", err)
+			// Validate output masking
+			if flagTruncateLiterals < 0 {
+				fmt.Printf("Error: --truncate-literals must not be negative.\n")
 				os.Exit(1)
 			}
+			masking := outputMasking(flagTruncateLiterals, flagRedactPredicates)
+			if flagPartitionBy != "" && masking.Enabled() {
+				fmt.Printf("Error: --partition-by cannot be combined with --truncate-literals or redacted predicates.\n")
+				os.Exit(1)
+			}
+
+			// Read input files, expanding directories and patterns
+			summary := inputSummary{stderr: outputPath == ""}
+			var tboxInputs, aboxInputs []inputFile
+			if flagSingle != "" {
+				aboxInputs = readInputs("Input", flagSingle, flagFormat, &summary)
+				if len(aboxInputs) == 0 {
+					fmt.Printf("Error: no loadable files in '%s'.\n", flagSingle)
+					os.Exit(1)
+				}
+			} else {
+				tboxInputs = readInputs("TBox", tboxPath, flagFormat, &summary)
+				aboxInputs = readInputs("ABox", aboxPath, flagFormat, &summary)
+				if len(tboxInputs) == 0 {
+					fmt.Printf("Error: no loadable TBox files in '%s'.\n", tboxPath)
+					os.Exit(1)
+				}
+				if len(aboxInputs) == 0 {
+					fmt.Printf("Error: no loadable ABox files in '%s'.\n", aboxPath)
+					os.Exit(1)
+				}
+			}
+
+			previousContent := ""
+			if flagPrevious != "" {
+				previousContent, err = readFile(flagPrevious)
+				if err != nil {
+					fmt.Printf("Error reading previous output file: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			rules := reasoner.DefaultRules()
+			if flagRuleSet != "" {
+				rules, err = ruleSetFromConfig(flagRuleSet)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			if flagLabelFallback {
+				rules = append(rules, &reasoner.LabelFallback{Language: flagLabelLanguage})
+			}
+
+			var signer *outputSigner
+			if flagSignKey != "" {
+				signer, err = newOutputSigner(flagSignKey, rules, flagRuleSet)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			opts := []reasoner.Option{reasoner.WithRules(rules), reasoner.WithLiteralMatching(literalMatching), reasoner.WithGraphReasoning(graphReasoning)}
+			if flagProvenance {
+				opts = append(opts, reasoner.WithProvenance())
+			}
+			if len(flagNormalizeIRIs) > 0 {
+				opts = append(opts, reasoner.WithIRINormalization(iriNormalization))
+			}
+			if flagMaxRuleInferences > 0 || flagMaxRuleTime > 0 {
+				opts = append(opts, reasoner.WithRuleBudget("", reasoner.RuleBudget{
+					MaxInferences: flagMaxRuleInferences,
+					MaxDuration:   flagMaxRuleTime,
+				}))
+			}
+			if flagPartitionParallel {
+				opts = append(opts, reasoner.WithPartitionParallel(runtime.NumCPU()))
+			}
+			if len(constraints) > 0 {
+				opts = append(opts, reasoner.WithClosedWorld(constraints...))
+			}
+			if flagCheckConsistency {
+				opts = append(opts, reasoner.WithConsistencyCheck())
+			}
+			if len(flagScopeClasses) > 0 || len(flagScopePredicates) > 0 {
+				opts = append(opts, reasoner.WithScope(reasoner.ReasoningScope{
+					Classes:    flagScopeClasses,
+					Predicates: flagScopePredicates,
+				}))
+			}
+
+			// Report what would be done without reasoning
+			if flagDryRun {
+				inputs := []inputFile{{Label: "Previous", Path: flagPrevious, Content: previousContent}}
+				inputs = append(inputs, tboxInputs...)
+				inputs = append(inputs, aboxInputs...)
+				printDryRun(inputs, flagRuleSet, rules, flagScopeClasses, flagScopePredicates)
+				return
+			}
 
 			// Run forward reasoning
-			fmt.Printf("Running forward reasoning on '%s' and '%s'...\n", aboxPath, tboxPath)
-			inferredTriples, err := reasoner.ForwardReason(aboxContent, tboxContent)
+			if flagSingle != "" {
+				statusf("Running forward reasoning on '%s'...\n", flagSingle)
+			} else {
+				statusf("Running forward reasoning on '%s' and '%s'...\n", aboxPath, tboxPath)
+			}
+			progress := newProgressReporter()
+			opts = append(opts, progress.options()...)
+			cleanup := func(r *reasoner.Reasoner) {
+				if flagSingle != "" {
+					tbox, abox := reasoner.CountTBoxABox(r.GetStore())
+					statusf("Separated %d schema (TBox) triples from %d instance (ABox) triples\n", tbox, abox)
+				}
+				if flagMergeDuplicates {
+					if merged := r.MergeDuplicateLiterals(); merged > 0 {
+						statusf("Merged %d near-duplicate literals\n", merged)
+					}
+				}
+				if flagCoerceIRILiterals {
+					if coerced := r.CoerceIRILiterals(); coerced > 0 {
+						statusf("Replaced %d IRI-shaped literals of object properties with IRIs\n", coerced)
+					}
+				}
+				if flagPartitionParallel {
+					if err := r.PartitionSafety(); err != nil {
+						notef("Not partitioning the ABox: %v\n", err)
+					} else if runtime.NumCPU() < 2 {
+						notef("Not partitioning the ABox: only one CPU is available\n")
+					} else {
+						verbosef("Materializing the ABox in %d partitions\n", runtime.NumCPU())
+					}
+				}
+			}
+			r, result, err := runReasoner(previousContent, append(tboxInputs, aboxInputs...), &summary, cleanup, opts...)
+			progress.finish()
 			if err != nil {
-				fmt.Printf("Error running forward reasoning: %v\n", err)
+				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
 			}
+			summary.print()
+
+			diagnostics := result.Diagnostics
+			reportDiagnostics(diagnostics)
+
+			// Refuse to write the output of an inconsistent ontology
+			if flagCheckConsistency {
+				inconsistencies := 0
+				for _, d := range diagnostics {
+					if d.Code == reasoner.CodeInconsistency {
+						inconsistencies++
+					}
+				}
+				if inconsistencies > 0 {
+					fmt.Printf("Error: %d inconsistencies found.\n", inconsistencies)
+					os.Exit(1)
+				}
+				statusf("✓ No inconsistencies found\n")
+			}
+
+			// Refuse to write the output of data violating closed-world checks
+			if len(constraints) > 0 {
+				violations := 0
+				for _, d := range diagnostics {
+					if d.Code == reasoner.CodeConstraintViolation {
+						violations++
+					}
+				}
+				if violations > 0 {
+					fmt.Printf("Error: %d constraint violations found.\n", violations)
+					os.Exit(1)
+				}
+				statusf("✓ No constraint violations found\n")
+			}
+
+			// Write one file per partition into the output directory
+			if flagPartitionBy != "" {
+				keyFunc := reasoner.PartitionByNamespace
+				if flagPartitionBy == "class" {
+					keyFunc = reasoner.PartitionByClass(r.GetStore())
+				}
+				partitions, err := r.WritePartitioned(outputDir, keyFunc)
+				if err != nil {
+					fmt.Printf("Error writing partitioned output: %v\n", err)
+					os.Exit(1)
+				}
+				statusf("✓ Forward reasoning completed successfully and saved to: %s\n", outputDir)
+				for _, p := range partitions {
+					statusf("  %s: %d triples\n", filepath.Base(p.Path), p.Triples)
+					if signer != nil {
+						if _, err := signer.sign(p.Path); err != nil {
+							fmt.Printf("Error: %v\n", err)
+							os.Exit(1)
+						}
+					}
+				}
+				return
+			}
+
+			// With --inferred-only, leave out the triples the pipeline
+			// already holds: the inputs and the previous output
+			closure := result.AllTriples
+			total := result.TotalCount
+			if flagInferredOnly {
+				closure = result.InferredTriples
+				total = len(closure)
+			}
+			if masking.Enabled() {
+				triples := r.GetStore().All()
+				if flagInferredOnly {
+					triples = triples[result.OriginalCount:]
+				}
+				closure = tripleLines(masking.ApplyInStore(triples, r.GetStore()))
+			}
+			inferredTriples := selectResults(closure, flagLimit, flagSample)
 
 			// Convert output format if needed
 			var outputTriples []string
-			if flagOutputType == "datalog" {
-				outputTriples = reasoner.ConvertTriplesToDatalog(inferredTriples)
-			} else {
+			switch flagOutputType {
+			case "datalog":
+				outputTriples = reasoner.ConvertTriplesToDatalogWithOptions(inferredTriples, reasoner.DatalogOptions{
+					LiteralMatching: literalMatching,
+					QuoteLiterals:   flagQuoteLiterals,
+					Constraints:     flagDatalogConstraints,
+				})
+			case "turtle":
+				triples := r.GetStore().All()
+				if flagInferredOnly {
+					triples = triples[result.OriginalCount:]
+				}
+				triples = selectResults(masking.ApplyInStore(triples, r.GetStore()), flagLimit, flagSample)
+				snapshot := reasoner.SerializeTurtleSorted(triples, r.Prefixes())
+				outputTriples = []string{strings.TrimSuffix(snapshot, "\n")}
+			default:
 				outputTriples = inferredTriples
 			}
 
@@ -111,18 +483,61 @@ func runCmd() *cobra.Command {
 					fmt.Printf("Error writing output file: %v\n", err)
 					os.Exit(1)
 				}
-				fmt.Printf("✓ Forward reasoning completed successfully and saved to: %s\n", outputPath)
-				fmt.Printf("  Total triples: %d (format: %s)\n", len(outputTriples), flagOutputType)
+				statusf("✓ Forward reasoning completed successfully and saved to: %s\n", outputPath)
+				statusf("  Total triples: %d (format: %s)\n", total, flagOutputType)
+				if shown := len(inferredTriples); shown < total {
+					statusf("  Written: %d of %d triples\n", shown, total)
+				}
+				if signer != nil {
+					sigPath, err := signer.sign(outputPath)
+					if err != nil {
+						fmt.Printf("Error: %v\n", err)
+						os.Exit(1)
+					}
+					statusf("  Signature: %s\n", sigPath)
+				}
 			} else {
 				// Print to stdout if no output file specified
 				for _, triple := range outputTriples {
 					fmt.Println(triple)
 				}
+				if shown := len(inferredTriples); shown < total {
+					notef("Showing %d of %d triples\n", shown, total)
+				}
 			}
 		},
 	}
-	runCmd.Flags().StringP("output", "o", "", "Output path for the N-Triples file")
-	runCmd.Flags().String("outputType", "ntriple", "Output format: 'ntriple' or 'datalog' (default: ntriple)")
+	runCmd.Flags().StringP("output", "o", "", "Output path for the N-Triples file, or '-' for stdout")
+	runCmd.Flags().String("single", "", "Read the TBox and ABox from one file, directory or pattern instead of two, telling schema and instance triples apart automatically")
+	runCmd.Flags().String("outputType", "ntriple", "Output format: 'ntriple', 'datalog' or 'turtle' (sorted by subject, for version control) (default: ntriple)")
+	runCmd.Flags().StringSlice("scope-class", nil, "Only materialize rdf:type assertions for these class IRIs (repeatable)")
+	runCmd.Flags().StringSlice("scope-predicate", nil, "Only materialize assertions of these property IRIs (repeatable)")
+	runCmd.Flags().String("partition-by", "", "Split the output into one N-Triples file per subject 'namespace' or 'class', written to the output directory")
+	runCmd.Flags().String("graphs", "merge", "Reason over the named graphs of N-Quads, TriG or JSON-LD input 'merge'd into one, or 'per-graph', each with the default graph")
+	runCmd.Flags().String("literal-matching", "lexical", "Compare literals by 'lexical' form or by 'value' (e.g. \"01\"^^xsd:integer = \"1\"^^xsd:integer)")
+	runCmd.Flags().Bool("quote-literals", false, "In Datalog output, keep literals as quoted constants with their full lexical form (needed by builtins such as sfWithin)")
+	runCmd.Flags().Bool("datalog-constraints", false, "In Datalog output, also emit owl:disjointWith and owl:differentFrom axioms as integrity constraints")
+	runCmd.Flags().Bool("check-consistency", false, "After reasoning, fail without writing output if the data contradicts owl:differentFrom, owl:disjointWith, owl:Nothing or property declarations")
+	runCmd.Flags().StringSlice("closed-world", nil, "Check these axioms against the data instead of inferring from them, failing without writing output on violations: 'domain', 'range', 'cardinality' or 'all' (repeatable)")
+	runCmd.Flags().Bool("provenance", false, "Record the input file of every asserted triple, so inconsistencies name the files they come from")
+	runCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+	runCmd.Flags().Bool("merge-duplicate-literals", false, "Before reasoning, keep only the first of literal values of a subject and predicate that differ only in case, whitespace or diacritics")
+	runCmd.Flags().Bool("coerce-iri-literals", false, "Before reasoning, replace literal values of object properties that are http, https or urn IRIs with those IRIs")
+	runCmd.Flags().StringSlice("normalize-iris", nil, "Rewrite IRIs while loading so inconsistent references join: 'trailing-slash', 'host' (lowercase), 'scheme' (https as http) or 'all' (repeatable)")
+	runCmd.Flags().Int("max-rule-inferences", 0, "Disable a rule for the rest of the run, with a warning, once it has inferred this many triples (0 = no limit)")
+	runCmd.Flags().Duration("max-rule-time", 0, "Disable a rule for the rest of the run, with a warning, once applying it has taken this long in total, e.g. '30s' (0 = no limit)")
+	runCmd.Flags().Int("limit", 0, "Only output the first N triples of the closure; the total is still reported")
+	runCmd.Flags().Int("sample", 0, "Only output N triples of the closure chosen at random; the total is still reported")
+	runCmd.Flags().Bool("dry-run", false, "Parse the inputs and report counts, prefixes, unsupported constructs, rules and estimated memory without reasoning")
+	runCmd.Flags().Bool("partition-parallel", false, "Materialize the ABox in partitions by subject on all CPUs when every rule joins at most one ABox triple, as RDFS typing does; otherwise reason sequentially")
+	runCmd.Flags().String("ruleset", "", "Apply a rule set defined under 'rulesets' in the configuration file instead of the default rules")
+	runCmd.Flags().Bool("label-fallback", false, "Also label resources without an rdfs:label or skos:prefLabel with their local name split into words, e.g. ex:postalCode as \"postal code\"")
+	runCmd.Flags().String("label-language", "", "Language tag of the labels added by --label-fallback, e.g. 'en' (default: plain literals)")
+	runCmd.Flags().String("sign-key", "", "PEM-encoded Ed25519 private key to sign the output with, writing the signature next to it with '.sig' appended")
+	runCmd.Flags().Int("truncate-literals", 0, "Truncate literals longer than N characters in the output, noting how many were cut (0 = no limit)")
+	runCmd.Flags().StringSlice("redact-predicate", nil, "Replace the values of this predicate IRI with \"[redacted]\" wherever they occur in the output; 'redact-predicates' in the configuration file adds more (repeatable)")
+	runCmd.Flags().Bool("inferred-only", false, "Only output the triples inferred by this run, not the asserted ones or those of --previous")
+	runCmd.Flags().String("previous", "", "N-Triples output of a previous run to extend; only consequences of the new input are computed")
 
 	return runCmd
 }
@@ -130,13 +545,14 @@ func runCmd() *cobra.Command {
 // dlQueryCmd command
 func dlQueryCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "dlquery [datalogPath] [query]",
+		Use:   "dlquery [datalogPath] [query...]",
 		Short: "Query a Datalog file with facts and rules",
-		Long:  `Query a Datalog file with facts and rules using forward reasoning.`,
-		Args:  cobra.ExactArgs(2),
+		Long: `Query a Datalog file with facts and rules using forward reasoning.
+The program is evaluated once and every query is answered against the
+derived facts, printing true or false per query.`,
+		Args: cobra.MinimumNArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			datalogPath := args[0]
-			queryStr := args[1]
 
 			// Validate input file
 			if !fileExists(datalogPath) {
@@ -151,21 +567,1120 @@ func dlQueryCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
-			// Run Datalog query
-			result, err := reasoner.DLQuery(datalogContent, queryStr)
+			// Derive the facts once for all queries
+			compiled, err := reasoner.CompileDatalog(datalogContent)
 			if err != nil {
 				fmt.Printf("Error running Datalog query: %v\n", err)
 				os.Exit(1)
 			}
 
-			// Print result
-			if result {
-				fmt.Println("true")
+			for _, queryStr := range args[1:] {
+				result, err := compiled.Query(queryStr)
+				if err != nil {
+					fmt.Printf("Error running Datalog query: %v\n", err)
+					os.Exit(1)
+				}
+
+				// Print result
+				if result {
+					fmt.Println("true")
+				} else {
+					fmt.Println("false")
+				}
+			}
+		},
+	}
+}
+
+// rulesCmd groups commands that inspect the inference rules
+func rulesCmd() *cobra.Command {
+	var rulesCmd = &cobra.Command{
+		Use:   "rules",
+		Short: "Inspect the inference rules used by the reasoner",
+		Long:  `Inspect the inference rules applied by the run command.`,
+	}
+
+	var exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export the active rule set as Datalog",
+		Long: `Export the active rule set as a documented Datalog program over
+triple(Subject, Predicate, Object), so the inference semantics can be reviewed.
+The exported rules can be evaluated with the dlquery command.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			flagOutputPath, _ := cmd.Flags().GetString("output")
+			flagRuleSet, _ := cmd.Flags().GetString("ruleset")
+
+			rules := reasoner.DefaultRules()
+			if flagRuleSet != "" {
+				var err error
+				rules, err = ruleSetFromConfig(flagRuleSet)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			exported := reasoner.ExportRules(rules)
+
+			if flagOutputPath == "" {
+				fmt.Print(exported)
+				return
+			}
+
+			if err := os.WriteFile(flagOutputPath, []byte(exported), 0600); err != nil {
+				fmt.Printf("Error writing output file: %v\n", err)
+				os.Exit(1)
+			}
+			infof("✓ Rules exported to: %s\n", flagOutputPath)
+		},
+	}
+	exportCmd.Flags().StringP("output", "o", "", "Output path for the exported rules (default: stdout)")
+	exportCmd.Flags().String("ruleset", "", "Export a rule set defined under 'rulesets' in the configuration file instead of the default rules")
+
+	var showCmd = &cobra.Command{
+		Use:   "show",
+		Short: "List the rules of the active rule set",
+		Long: `List the rules of the default rule set, or of a rule set defined in the
+configuration file, with whether each is built in or custom. With --effective,
+every rule is explained with a description, its Datalog definition and an
+example inference, and the built-in rules the rule set leaves out are listed
+as disabled, so the inferences of a deployment can be reviewed without
+reading its configuration and the source.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			flagRuleSet, _ := cmd.Flags().GetString("ruleset")
+			flagEffective, _ := cmd.Flags().GetBool("effective")
+			flagJSON, _ := cmd.Flags().GetBool("json")
+
+			rules, err := ruleSetByName(flagRuleSet)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			descriptions := reasoner.DescribeRules(rules)
+			if !flagEffective {
+				descriptions = descriptions[:len(rules)]
+			}
+
+			if flagJSON {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetEscapeHTML(false)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(descriptions); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			for _, d := range descriptions {
+				status := "✓"
+				origin := d.Origin
+				if !d.Active {
+					status = "✗"
+					origin += ", disabled"
+				}
+				fmt.Printf("%s %s (%s)\n", status, d.Name, origin)
+				if !flagEffective {
+					continue
+				}
+				if d.Description != "" {
+					fmt.Printf("    %s\n", d.Description)
+				}
+				if d.Definition != "" {
+					for _, line := range strings.Split(d.Definition, "\n") {
+						fmt.Printf("    %s\n", line)
+					}
+				}
+				if d.Example != "" {
+					fmt.Printf("    Example: %s\n", d.Example)
+				}
+				fmt.Println()
+			}
+		},
+	}
+	showCmd.Flags().String("ruleset", "", "Show a rule set defined under 'rulesets' in the configuration file instead of the default rules")
+	showCmd.Flags().Bool("effective", false, "Explain every rule and also list the built-in rules that are disabled")
+	showCmd.Flags().Bool("json", false, "Print the rules as JSON")
+
+	var diffCmd = &cobra.Command{
+		Use:   "diff [rulesetA] [rulesetB]",
+		Short: "Compare two rule sets",
+		Long: `Compare two rule sets defined under 'rulesets' in the configuration file,
+listing the rules only in the second (+), only in the first (-), and those
+whose Datalog definitions differ (~). "default" names the default rules unless
+the configuration defines a rule set of that name. The order of rules is
+ignored, as it does not change the inferences.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			flagJSON, _ := cmd.Flags().GetBool("json")
+
+			sets := make([][]reasoner.Rule, len(args))
+			for i, name := range args {
+				rules, err := ruleSetByName(name)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				sets[i] = rules
+			}
+			changes := reasoner.DiffRules(sets[0], sets[1])
+
+			if flagJSON {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(changes); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			if len(changes) == 0 {
+				fmt.Printf("✓ Rule sets '%s' and '%s' infer the same (%d rules)\n", args[0], args[1], len(sets[0]))
+				return
+			}
+			counts := make(map[string]int)
+			for _, c := range changes {
+				counts[c.Change]++
+				switch c.Change {
+				case "added":
+					fmt.Printf("+ %s\n", c.Name)
+				case "removed":
+					fmt.Printf("- %s\n", c.Name)
+				default:
+					fmt.Printf("~ %s\n", c.Name)
+					fmt.Printf("    - %s\n", valueOrNone(c.Before))
+					fmt.Printf("    + %s\n", valueOrNone(c.After))
+				}
+			}
+			fmt.Printf("\nRules: %d added, %d removed, %d changed\n", counts["added"], counts["removed"], counts["changed"])
+		},
+	}
+	diffCmd.Flags().Bool("json", false, "Print the changes as JSON")
+
+	rulesCmd.AddCommand(exportCmd)
+	rulesCmd.AddCommand(showCmd)
+	rulesCmd.AddCommand(diffCmd)
+
+	return rulesCmd
+}
+
+// crosscheckCmd compares our closure with the output of another reasoner
+func crosscheckCmd() *cobra.Command {
+	var crosscheckCmd = &cobra.Command{
+		Use:   "crosscheck [mine.nt] [other.nt]",
+		Short: "Compare two reasoner outputs",
+		Long: `Compare two reasoner outputs, e.g. the closure computed by goreasoner and the
+entailments reported by another reasoner. Both graphs are canonicalized (blank
+node labels, xsd:string literals) and the triples present in only one of them
+are listed, grouped by predicate. Exits with status 1 if the graphs differ.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			flagIgnoreTrivial, _ := cmd.Flags().GetBool("ignore-trivial")
+
+			graphs := make([][]reasoner.Triple, len(args))
+			for i, path := range args {
+				if !fileExists(path) {
+					fmt.Printf("Error: File '%s' does not exist.\n", path)
+					os.Exit(1)
+				}
+				triples, err := readTriplesFile(path)
+				if err != nil {
+					fmt.Printf("Error reading '%s': %v\n", path, err)
+					os.Exit(1)
+				}
+				if flagIgnoreTrivial {
+					triples = reasoner.DropTrivialEntailments(triples)
+				}
+				graphs[i] = triples
+			}
+
+			diff := reasoner.CompareGraphs(graphs[0], graphs[1])
+
+			printGroups := func(label string, triples []reasoner.Triple) {
+				if len(triples) == 0 {
+					return
+				}
+				fmt.Printf("\nOnly in %s (%d):\n", label, len(triples))
+				groups := reasoner.GroupByPredicate(triples)
+				predicates := make([]string, 0, len(groups))
+				for predicate := range groups {
+					predicates = append(predicates, predicate)
+				}
+				sort.Strings(predicates)
+				for _, predicate := range predicates {
+					fmt.Printf("  %s (%d)\n", predicate, len(groups[predicate]))
+					for _, t := range groups[predicate] {
+						fmt.Printf("    %s\n", t)
+					}
+				}
+			}
+			printGroups(args[0], diff.OnlyInFirst)
+			printGroups(args[1], diff.OnlyInSecond)
+
+			if !diff.Equal() {
+				fmt.Printf("\n✗ Graphs differ: %d common, %d only in '%s', %d only in '%s'\n",
+					diff.Common, len(diff.OnlyInFirst), args[0], len(diff.OnlyInSecond), args[1])
+				os.Exit(1)
+			}
+			fmt.Printf("✓ Graphs match (%d triples)\n", diff.Common)
+		},
+	}
+	crosscheckCmd.Flags().Bool("ignore-trivial", false, "Ignore reflexive axioms and owl:Thing typing, which some reasoners omit")
+
+	return crosscheckCmd
+}
+
+// versionCheckCmd compares two versions of an ontology
+func versionCheckCmd() *cobra.Command {
+	var versionCheckCmd = &cobra.Command{
+		Use:   "version-check [older.ttl] [newer.ttl]",
+		Short: "Compare two versions of an ontology",
+		Long: `Compare two versions of an ontology. Verifies that both declare the same
+owl:Ontology and that the newer one lists the older versionIRI as
+owl:priorVersion or has a greater versionIRI, then lists the axioms added and
+removed between the versions. Exits with status 1 if the version order is invalid.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			graphs := make([][]reasoner.Triple, len(args))
+			headers := make([]reasoner.OntologyHeader, len(args))
+			for i, path := range args {
+				if !fileExists(path) {
+					fmt.Printf("Error: File '%s' does not exist.\n", path)
+					os.Exit(1)
+				}
+				triples, err := readTriplesFile(path)
+				if err != nil {
+					fmt.Printf("Error reading '%s': %v\n", path, err)
+					os.Exit(1)
+				}
+				store := reasoner.NewTripleStore()
+				for _, t := range triples {
+					store.Add(t)
+				}
+				header, err := store.OntologyHeader()
+				if err != nil {
+					fmt.Printf("Error: '%s': %v\n", path, err)
+					os.Exit(1)
+				}
+				graphs[i] = triples
+				headers[i] = header
+			}
+
+			fmt.Printf("Ontology: %s\n", headers[0].IRI)
+			for i, path := range args {
+				h := headers[i]
+				fmt.Printf("  %s: versionIRI %s", path, valueOrNone(h.VersionIRI))
+				if len(h.PriorVersions) > 0 {
+					fmt.Printf(", priorVersion %s", strings.Join(h.PriorVersions, ", "))
+				}
+				if h.VersionInfo != "" {
+					fmt.Printf(", versionInfo %q", h.VersionInfo)
+				}
+				fmt.Println()
+			}
+
+			addedImports, removedImports := stringSetDiff(headers[0].Imports, headers[1].Imports)
+			for _, imp := range addedImports {
+				fmt.Printf("  + owl:imports %s\n", imp)
+			}
+			for _, imp := range removedImports {
+				fmt.Printf("  - owl:imports %s\n", imp)
+			}
+
+			diff := reasoner.DiffOntologies(graphs[0], graphs[1])
+			fmt.Printf("\nAxioms: %d added, %d removed, %d unchanged\n",
+				len(diff.OnlyInSecond), len(diff.OnlyInFirst), diff.Common)
+			for _, t := range diff.OnlyInSecond {
+				fmt.Printf("  + %s\n", t)
+			}
+			for _, t := range diff.OnlyInFirst {
+				fmt.Printf("  - %s\n", t)
+			}
+
+			if err := reasoner.CheckVersionOrder(headers[0], headers[1]); err != nil {
+				fmt.Printf("\n✗ Invalid version order: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("\n✓ %s is a later version of %s\n", args[1], args[0])
+		},
+	}
+
+	return versionCheckCmd
+}
+
+// stringSetDiff returns the strings only in newer and those only in older
+func stringSetDiff(older, newer []string) (added, removed []string) {
+	inOlder := make(map[string]bool, len(older))
+	for _, s := range older {
+		inOlder[s] = true
+	}
+	inNewer := make(map[string]bool, len(newer))
+	for _, s := range newer {
+		inNewer[s] = true
+		if !inOlder[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range older {
+		if !inNewer[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+// valueOrNone returns s, or "(none)" if it is empty
+func valueOrNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// describeCmd prints everything known about a single resource
+func describeCmd() *cobra.Command {
+	var describeCmd = &cobra.Command{
+		Use:   "describe [dataPath] [resource]",
+		Short: "Print all triples about a resource",
+		Long: `Print all triples about a resource after reasoning, both those with the
+resource as subject and those referencing it as object, grouped by predicate
+and with IRIs compacted using the prefixes declared in the input.
+The resource can be a prefixed name (ex:Alice) or a full IRI.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			dataPath := args[0]
+			flagTBoxPath, _ := cmd.Flags().GetString("tbox")
+			flagNoReasoning, _ := cmd.Flags().GetBool("no-reasoning")
+			flagReadOnly, _ := cmd.Flags().GetBool("read-only")
+
+			if flagReadOnly && flagTBoxPath != "" {
+				fmt.Printf("Error: --read-only cannot be combined with --tbox.\n")
+				os.Exit(1)
+			}
+			if !fileExists(dataPath) {
+				fmt.Printf("Error: Data file '%s' does not exist.\n", dataPath)
+				os.Exit(1)
+			}
+			if flagTBoxPath != "" && !fileExists(flagTBoxPath) {
+				fmt.Printf("Error: TBox file '%s' does not exist.\n", flagTBoxPath)
+				os.Exit(1)
+			}
+
+			r := reasoner.NewReasoner()
+			for _, path := range []string{flagTBoxPath, dataPath} {
+				if path == "" {
+					continue
+				}
+				content, err := readFile(path)
+				if err != nil {
+					fmt.Printf("Error reading file '%s': %v\n", path, err)
+					os.Exit(1)
+				}
+				if err := loadInput(r, path, content, reasoner.FormatUnknown); err != nil {
+					fmt.Printf("Error loading file '%s': %v\n", path, err)
+					os.Exit(1)
+				}
+			}
+			if flagReadOnly {
+				r.Freeze()
+			} else if !flagNoReasoning {
+				r.RunForwardReasoning()
+			}
+			reportDiagnostics(r.Diagnostics())
+
+			prefixes := r.Prefixes()
+			resource := reasoner.ExpandPrefixedName(args[1], prefixes)
+			fmt.Print(r.GetStore().Describe(resource).Format(prefixes))
+		},
+	}
+	describeCmd.Flags().String("tbox", "", "Schema file to load before the data")
+	describeCmd.Flags().Bool("no-reasoning", false, "Describe the resource as asserted, without inferred triples")
+	describeCmd.Flags().Bool("read-only", false, "Open the data as a published closure: frozen as loaded, without reasoning")
+
+	return describeCmd
+}
+
+// instancesCmd lists the instances of a class expression
+func instancesCmd() *cobra.Command {
+	var instancesCmd = &cobra.Command{
+		Use:   "instances [dataPath] [expression]",
+		Short: "List the instances of a class expression",
+		Long: `List the individuals that are instances of a class expression in
+Manchester syntax after reasoning, one IRI per line, as a DL query in Protégé
+would. Expressions combine class names with and, or, "p some C",
+"p value v", "{a, b}" and parentheses, e.g.
+
+  goreasoner instances data.ttl "Person and worksFor some GovernmentAgency"
+
+Names may be prefixed names declared in the input, full IRIs in angle
+brackets or bare names if the empty prefix is declared. Negation, only and
+cardinality restrictions are not supported.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			dataPath := args[0]
+			flagTBoxPath, _ := cmd.Flags().GetString("tbox")
+			flagNoReasoning, _ := cmd.Flags().GetBool("no-reasoning")
+			flagReadOnly, _ := cmd.Flags().GetBool("read-only")
+			flagCSV, _ := cmd.Flags().GetBool("csv")
+			flagFormat, _ := cmd.Flags().GetString("format")
+
+			if flagReadOnly && flagTBoxPath != "" {
+				fmt.Printf("Error: --read-only cannot be combined with --tbox.\n")
+				os.Exit(1)
+			}
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var summary inputSummary
+			var inputs []inputFile
+			if flagTBoxPath != "" {
+				inputs = readInputs("TBox", flagTBoxPath, flagFormat, &summary)
+			}
+			dataInputs := readInputs("data", dataPath, flagFormat, &summary)
+			if len(dataInputs) == 0 {
+				fmt.Printf("Error: no loadable data files in '%s'.\n", dataPath)
+				os.Exit(1)
+			}
+			inputs = append(inputs, dataInputs...)
+
+			r := reasoner.NewReasoner()
+			for _, in := range inputs {
+				if err := loadInput(r, in.Path, in.Content, in.Format); err != nil {
+					summary.fail(in.Path, fmt.Errorf("failed to load %s file '%s': %w", in.Label, in.Path, err))
+					continue
+				}
+				summary.loaded = append(summary.loaded, in.Path)
+			}
+			if len(summary.failed) > 0 {
+				summary.print()
+			}
+			if flagReadOnly {
+				r.Freeze()
+			} else if !flagNoReasoning {
+				r.RunForwardReasoning()
+			}
+			reportDiagnostics(r.Diagnostics())
+
+			instances, err := r.InstancesOfExpression(args[1])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if flagCSV {
+				bindings := make([]reasoner.Binding, len(instances))
+				for i, instance := range instances {
+					bindings[i] = reasoner.Binding{"instance": instance}
+				}
+				if err := reasoner.WriteBindingsCSV(os.Stdout, bindings); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				for _, instance := range instances {
+					fmt.Println(instance)
+				}
+			}
+			notef("%d instances\n", len(instances))
+		},
+	}
+	instancesCmd.Flags().String("tbox", "", "Schema file, directory or pattern to load before the data")
+	instancesCmd.Flags().Bool("no-reasoning", false, "Only consider asserted triples, without inferred ones")
+	instancesCmd.Flags().Bool("read-only", false, "Open the data as a published closure: frozen as loaded, without reasoning")
+	instancesCmd.Flags().Bool("csv", false, "Print the instances as CSV with an 'instance' column")
+	instancesCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+
+	return instancesCmd
+}
+
+// validateRDFCmd checks the syntax of RDF files
+func validateRDFCmd() *cobra.Command {
+	var validateRDFCmd = &cobra.Command{
+		Use:   "validate-rdf [path]",
+		Short: "Check the syntax of RDF files",
+		Long: `Check the syntax of a file, or of the loadable files in a directory or
+matching a pattern, without reasoning. Besides statements that cannot be
+parsed, it reports prefixes used without a declaration and relative IRIs
+without a base as errors, and literals with unknown datatypes as warnings.
+Use --diagnostics json for machine-readable output. The data is not checked
+against the ontology. Exits with status 1 if any errors are found.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			flagFormat, _ := cmd.Flags().GetString("format")
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var summary inputSummary
+			inputs := readInputs("input", args[0], flagFormat, &summary)
+			if len(inputs) == 0 {
+				summary.print()
+				fmt.Printf("Error: no loadable files in '%s'.\n", args[0])
+				os.Exit(1)
+			}
+
+			errorCount, invalid := 0, 0
+			for _, in := range inputs {
+				diagnostics, err := reasoner.ValidateRDF(in.Path, in.Content, reasoner.ParserOptions{})
+				if err != nil {
+					summary.fail(in.Path, err)
+					continue
+				}
+				summary.loaded = append(summary.loaded, in.Path)
+				reportDiagnostics(diagnostics)
+
+				fileErrors := 0
+				for _, d := range diagnostics {
+					if d.Severity == reasoner.SeverityError {
+						fileErrors++
+					}
+				}
+				if fileErrors > 0 {
+					errorCount += fileErrors
+					invalid++
+				}
+			}
+			summary.print()
+
+			total := len(summary.loaded) + len(summary.failed)
+			if errorCount > 0 || len(summary.failed) > 0 {
+				fmt.Printf("Error: %d of %d files are invalid (%d errors).\n", invalid+len(summary.failed), total, errorCount)
+				os.Exit(1)
+			}
+			infof("✓ %d of %d files are valid\n", total, total)
+		},
+	}
+	validateRDFCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples'")
+
+	return validateRDFCmd
+}
+
+// statsCmd reports statistics about the literals of each predicate
+func statsCmd() *cobra.Command {
+	var statsCmd = &cobra.Command{
+		Use:   "stats [dataPath]",
+		Short: "Report literal datatype and language statistics",
+		Long: `Report, for each predicate with literal values, how the literals are
+distributed over datatypes and which share of the subjects have a value in
+each language, e.g. that 80% of the subjects with an rdfs:label have one in
+@de and 20% have one without a language tag. Literals without a datatype
+count as xsd:string and language-tagged ones as rdf:langString.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			dataPath := args[0]
+			flagTBoxPath, _ := cmd.Flags().GetString("tbox")
+			flagNoReasoning, _ := cmd.Flags().GetBool("no-reasoning")
+			flagReadOnly, _ := cmd.Flags().GetBool("read-only")
+			flagPredicates, _ := cmd.Flags().GetStringArray("predicate")
+			flagJSON, _ := cmd.Flags().GetBool("json")
+			flagFormat, _ := cmd.Flags().GetString("format")
+
+			if flagReadOnly && flagTBoxPath != "" {
+				fmt.Printf("Error: --read-only cannot be combined with --tbox.\n")
+				os.Exit(1)
+			}
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var summary inputSummary
+			var inputs []inputFile
+			if flagTBoxPath != "" {
+				inputs = readInputs("TBox", flagTBoxPath, flagFormat, &summary)
+			}
+			dataInputs := readInputs("data", dataPath, flagFormat, &summary)
+			if len(dataInputs) == 0 {
+				fmt.Printf("Error: no loadable data files in '%s'.\n", dataPath)
+				os.Exit(1)
+			}
+			inputs = append(inputs, dataInputs...)
+
+			r := reasoner.NewReasoner()
+			for _, in := range inputs {
+				if err := loadInput(r, in.Path, in.Content, in.Format); err != nil {
+					summary.fail(in.Path, fmt.Errorf("failed to load %s file '%s': %w", in.Label, in.Path, err))
+					continue
+				}
+				summary.loaded = append(summary.loaded, in.Path)
+			}
+			if len(summary.failed) > 0 {
+				summary.print()
+			}
+			if flagReadOnly {
+				r.Freeze()
+			} else if !flagNoReasoning {
+				r.RunForwardReasoning()
+			}
+			reportDiagnostics(r.Diagnostics())
+
+			prefixes := r.Prefixes()
+			stats := r.GetStore().LiteralStatistics()
+			if len(flagPredicates) > 0 {
+				wanted := make(map[string]bool)
+				for _, p := range flagPredicates {
+					wanted[reasoner.ExpandPrefixedName(p, prefixes)] = true
+				}
+				selected := []reasoner.LiteralStats{}
+				for _, s := range stats {
+					if wanted[s.Predicate] {
+						selected = append(selected, s)
+					}
+				}
+				stats = selected
+			}
+
+			if flagJSON {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(stats); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+			for i, s := range stats {
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Print(s.Format(prefixes))
+			}
+			notef("%d predicates with literal values in %d triples\n", len(stats), r.GetStore().Size())
+		},
+	}
+	statsCmd.Flags().String("tbox", "", "Schema file, directory or pattern to load before the data")
+	statsCmd.Flags().Bool("no-reasoning", false, "Report on the asserted triples only, without inferred triples")
+	statsCmd.Flags().Bool("read-only", false, "Open the data as a published closure: frozen as loaded, without reasoning")
+	statsCmd.Flags().StringArray("predicate", nil, "Only report this predicate, a prefixed name or IRI (repeatable)")
+	statsCmd.Flags().Bool("json", false, "Print the statistics as JSON")
+	statsCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+
+	return statsCmd
+}
+
+// doctorCmd checks the consistency of the triple store after loading data
+func doctorCmd() *cobra.Command {
+	var doctorCmd = &cobra.Command{
+		Use:   "doctor [dataPath]",
+		Short: "Check the integrity of the triple store",
+		Long: `Load the data, reason over it and verify that the triple store is
+consistent: every triple is stored once and found in the subject,
+predicate and object indexes, and no index entry refers to a missing
+triple or a triple with another term. Exits with status 1 if a problem
+is found.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			dataPath := args[0]
+			flagTBoxPath, _ := cmd.Flags().GetString("tbox")
+			flagNoReasoning, _ := cmd.Flags().GetBool("no-reasoning")
+			flagFormat, _ := cmd.Flags().GetString("format")
+
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var summary inputSummary
+			var inputs []inputFile
+			if flagTBoxPath != "" {
+				inputs = readInputs("TBox", flagTBoxPath, flagFormat, &summary)
+			}
+			dataInputs := readInputs("data", dataPath, flagFormat, &summary)
+			if len(dataInputs) == 0 {
+				fmt.Printf("Error: no loadable data files in '%s'.\n", dataPath)
+				os.Exit(1)
+			}
+			inputs = append(inputs, dataInputs...)
+
+			r := reasoner.NewReasoner(reasoner.WithConsistencyCheck())
+			for _, in := range inputs {
+				if err := loadInput(r, in.Path, in.Content, in.Format); err != nil {
+					summary.fail(in.Path, fmt.Errorf("failed to load %s file '%s': %w", in.Label, in.Path, err))
+					continue
+				}
+				summary.loaded = append(summary.loaded, in.Path)
+			}
+			if len(summary.failed) > 0 {
+				summary.print()
+			}
+			if !flagNoReasoning {
+				r.RunForwardReasoning()
+			}
+			reportDiagnostics(r.Diagnostics())
+
+			problems := r.GetStore().CheckIntegrity()
+			if len(problems) > 0 {
+				for _, p := range problems {
+					fmt.Printf("✗ %s\n", p)
+				}
+				fmt.Printf("Error: %d integrity problems in a store of %d triples.\n", len(problems), r.GetStore().Size())
+				os.Exit(1)
+			}
+			infof("✓ Store is consistent: %d triples indexed\n", r.GetStore().Size())
+		},
+	}
+	doctorCmd.Flags().String("tbox", "", "Schema file, directory or pattern to load before the data")
+	doctorCmd.Flags().Bool("no-reasoning", false, "Check the store with the asserted triples only, without reasoning")
+	doctorCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+
+	return doctorCmd
+}
+
+// fetchCmd dereferences an IRI and prints the loaded description
+func fetchCmd() *cobra.Command {
+	var fetchCmd = &cobra.Command{
+		Use:   "fetch [IRI]",
+		Short: "Dereference an IRI as Linked Data",
+		Long: `Fetch the description of an http or https IRI, asking for Turtle or
+N-Triples, and print it after reasoning as sorted N-Triples or Turtle.
+With --depth, the IRI values of --follow predicates (by default owl:sameAs
+and rdfs:seeAlso) are dereferenced in turn, up to that many links away;
+links that cannot be loaded are reported as dereference-failed warnings.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			flagDepth, _ := cmd.Flags().GetInt("depth")
+			flagFollow, _ := cmd.Flags().GetStringArray("follow")
+			flagTimeout, _ := cmd.Flags().GetDuration("timeout")
+			flagNoReasoning, _ := cmd.Flags().GetBool("no-reasoning")
+			flagOutputPath, _ := cmd.Flags().GetString("output")
+			flagOutputType, _ := cmd.Flags().GetString("outputType")
+
+			if flagOutputType != "ntriple" && flagOutputType != "turtle" {
+				fmt.Printf("Error: Invalid output type '%s'. Must be 'ntriple' or 'turtle'.\n", flagOutputType)
+				os.Exit(1)
+			}
+			if flagDepth < 0 {
+				fmt.Printf("Error: --depth must not be negative.\n")
+				os.Exit(1)
+			}
+
+			opts := reasoner.DereferenceOptions{
+				Client: &http.Client{Timeout: flagTimeout},
+				Depth:  flagDepth,
+			}
+			for _, p := range flagFollow {
+				opts.Follow = append(opts.Follow, reasoner.ExpandPrefixedName(p, reasoner.StandardPrefixes()))
+			}
+
+			r := reasoner.NewReasoner()
+			fetched, err := r.DereferenceContext(cmd.Context(), args[0], opts)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			for _, doc := range fetched {
+				verbosef("Loaded %s\n", doc)
+			}
+			if !flagNoReasoning {
+				r.RunForwardReasoning()
+			}
+			reportDiagnostics(r.Diagnostics())
+
+			triples := r.GetStore().All()
+			var lines []string
+			if flagOutputType == "turtle" {
+				snapshot := reasoner.SerializeTurtleSorted(triples, r.Prefixes())
+				lines = []string{strings.TrimSuffix(snapshot, "\n")}
 			} else {
-				fmt.Println("false")
+				lines = r.GetAllTriples()
 			}
+
+			if flagOutputPath == "" || flagOutputPath == "-" {
+				for _, line := range lines {
+					fmt.Println(line)
+				}
+				notef("Fetched %d documents, %d triples\n", len(fetched), len(triples))
+				return
+			}
+			if err := writeTriplesToFile(lines, flagOutputPath); err != nil {
+				fmt.Printf("Error writing output file: %v\n", err)
+				os.Exit(1)
+			}
+			infof("✓ Fetched %d documents, %d triples saved to: %s\n", len(fetched), len(triples), flagOutputPath)
 		},
 	}
+	fetchCmd.Flags().Int("depth", 0, "Number of links to follow from the fetched description")
+	fetchCmd.Flags().StringArray("follow", nil, "Predicate whose IRI values are fetched in turn (repeatable, default: owl:sameAs and rdfs:seeAlso)")
+	fetchCmd.Flags().Duration("timeout", reasoner.DefaultDereferenceTimeout, "Timeout of each request")
+	fetchCmd.Flags().Bool("no-reasoning", false, "Print the fetched triples only, without inferred triples")
+	fetchCmd.Flags().StringP("output", "o", "", "Output file; by default or with '-' the triples are written to stdout")
+	fetchCmd.Flags().String("outputType", "ntriple", "Output format: 'ntriple' or 'turtle'")
+
+	return fetchCmd
+}
+
+// exportCmd writes the triples matching --where conditions
+func exportCmd() *cobra.Command {
+	var exportCmd = &cobra.Command{
+		Use:   "export [dataPath]",
+		Short: "Export the triples matching conditions",
+		Long: `Export the triples matching all --where conditions after reasoning, as
+sorted N-Triples or Turtle. A condition names the subject (s), predicate (p)
+or object (o), an operator and a value:
+
+  p=rdf:type                        predicate is rdf:type
+  o!=ex:Draft                       object is not ex:Draft
+  o startsWith http://example.org/  IRI or literal text starts with the value
+  o endsWith / o contains           likewise for suffixes and substrings
+  o > "100"^^xsd:integer            literal is greater by value (also <)
+
+Prefixed names use the prefixes declared in the input and the standard rdf,
+rdfs, owl and xsd prefixes. Quoted values are literals.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			dataPath := args[0]
+			flagTBoxPath, _ := cmd.Flags().GetString("tbox")
+			flagNoReasoning, _ := cmd.Flags().GetBool("no-reasoning")
+			flagReadOnly, _ := cmd.Flags().GetBool("read-only")
+			flagWhere, _ := cmd.Flags().GetStringArray("where")
+			flagOutputPath, _ := cmd.Flags().GetString("output")
+			flagOutputType, _ := cmd.Flags().GetString("outputType")
+			flagFormat, _ := cmd.Flags().GetString("format")
+
+			if flagReadOnly && flagTBoxPath != "" {
+				fmt.Printf("Error: --read-only cannot be combined with --tbox.\n")
+				os.Exit(1)
+			}
+			if flagOutputType != "ntriple" && flagOutputType != "turtle" {
+				fmt.Printf("Error: Invalid output type '%s'. Must be 'ntriple' or 'turtle'.\n", flagOutputType)
+				os.Exit(1)
+			}
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var summary inputSummary
+			var inputs []inputFile
+			if flagTBoxPath != "" {
+				inputs = readInputs("TBox", flagTBoxPath, flagFormat, &summary)
+			}
+			dataInputs := readInputs("data", dataPath, flagFormat, &summary)
+			if len(dataInputs) == 0 {
+				fmt.Printf("Error: no loadable data files in '%s'.\n", dataPath)
+				os.Exit(1)
+			}
+			inputs = append(inputs, dataInputs...)
+
+			r := reasoner.NewReasoner()
+			for _, in := range inputs {
+				if err := loadInput(r, in.Path, in.Content, in.Format); err != nil {
+					summary.fail(in.Path, fmt.Errorf("failed to load %s file '%s': %w", in.Label, in.Path, err))
+					continue
+				}
+				summary.loaded = append(summary.loaded, in.Path)
+			}
+			if len(summary.failed) > 0 {
+				summary.print()
+			}
+			if flagReadOnly {
+				r.Freeze()
+			} else if !flagNoReasoning {
+				r.RunForwardReasoning()
+			}
+			reportDiagnostics(r.Diagnostics())
+
+			// Conditions are parsed after loading to know the declared prefixes
+			filters := make([]reasoner.TripleFilter, 0, len(flagWhere))
+			for _, where := range flagWhere {
+				filter, err := reasoner.ParseCondition(where, r.Prefixes())
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				filters = append(filters, filter)
+			}
+			triples := r.QueryFiltered("", "", "", filters...)
+
+			var lines []string
+			if flagOutputType == "turtle" {
+				snapshot := reasoner.SerializeTurtleSorted(triples, r.Prefixes())
+				lines = []string{strings.TrimSuffix(snapshot, "\n")}
+			} else {
+				for _, t := range triples {
+					lines = append(lines, t.String())
+				}
+				sort.Strings(lines)
+			}
+
+			if flagOutputPath == "" || flagOutputPath == "-" {
+				for _, line := range lines {
+					fmt.Println(line)
+				}
+				notef("Exported %d of %d triples\n", len(triples), r.GetStore().Size())
+				return
+			}
+			if err := writeTriplesToFile(lines, flagOutputPath); err != nil {
+				fmt.Printf("Error writing output file: %v\n", err)
+				os.Exit(1)
+			}
+			infof("✓ Exported %d of %d triples to: %s\n", len(triples), r.GetStore().Size(), flagOutputPath)
+		},
+	}
+	exportCmd.Flags().StringArray("where", nil, "Condition triples must match, e.g. 'p=rdf:type' or 'o startsWith http://example.org/' (repeatable, all must match)")
+	exportCmd.Flags().String("tbox", "", "Schema file, directory or pattern to load before the data")
+	exportCmd.Flags().Bool("no-reasoning", false, "Export the asserted triples only, without inferred triples")
+	exportCmd.Flags().Bool("read-only", false, "Open the data as a published closure: frozen as loaded, without reasoning")
+	exportCmd.Flags().StringP("output", "o", "", "Output file; by default or with '-' the triples are written to stdout")
+	exportCmd.Flags().String("outputType", "ntriple", "Output format: 'ntriple' or 'turtle'")
+	exportCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+
+	return exportCmd
+}
+
+// Helper function to load TBox and ABox files, optionally on top of the
+// output of a previous run, and run forward reasoning. Files found in
+// directories or by patterns that fail to load are recorded in the summary
+// and skipped; a file named on the command line failing to load is an
+// error. cleanup, if not nil, is
+// called on the loaded data before reasoning. The result separates the
+// triples inferred by this run from those loaded.
+func runReasoner(previousContent string, inputs []inputFile, summary *inputSummary, cleanup func(*reasoner.Reasoner), opts ...reasoner.Option) (*reasoner.Reasoner, *reasoner.ReasoningResult, error) {
+	r := reasoner.NewReasoner(opts...)
+
+	if previousContent != "" {
+		if err := r.LoadMaterialized(previousContent); err != nil {
+			return nil, nil, fmt.Errorf("failed to load previous output: %w", err)
+		}
+	}
+
+	for _, in := range inputs {
+		if err := loadInput(r, in.Path, in.Content, in.Format); err != nil {
+			err = fmt.Errorf("failed to load %s file '%s': %w", in.Label, in.Path, err)
+			if in.Named {
+				return nil, nil, err
+			}
+			summary.fail(in.Path, err)
+			continue
+		}
+		summary.loaded = append(summary.loaded, in.Path)
+	}
+
+	if cleanup != nil {
+		cleanup(r)
+	}
+
+	result := r.RunForwardReasoningWithDetails()
+	verbosef("Inferred %d triples (%d in total)\n", result.InferredCount, result.TotalCount)
+
+	return r, result, nil
+}
+
+// inputFile is an input file with a label for messages
+type inputFile struct {
+	Label   string
+	Path    string
+	Content string
+	Format  reasoner.Format
+	Named   bool // named on the command line rather than found in a directory or by a pattern
+}
+
+// Helper function to print the plan for a run without reasoning
+func printDryRun(inputs []inputFile, ruleSet string, rules []reasoner.Rule, scopeClasses, scopePredicates []string) {
+	var all []reasoner.Triple
+	prefixes := make(map[string]string)
+	type skipped struct {
+		path string
+		err  reasoner.ParseError
+	}
+	var problems, warnings []skipped
+
+	fmt.Println("Dry run: inputs parsed, no reasoning performed")
+	fmt.Println()
+	fmt.Println("Inputs:")
+	for _, in := range inputs {
+		if in.Path == "" {
+			continue
+		}
+		triples, declared, errs, warns, err := parseInput(in.Path, in.Content, in.Format)
+		if err != nil {
+			fmt.Printf("Error parsing %s file '%s': %v\n", in.Label, in.Path, err)
+			os.Exit(1)
+		}
+		all = append(all, triples...)
+		for prefix, iri := range declared {
+			prefixes[prefix] = iri
+		}
+		for _, e := range errs {
+			problems = append(problems, skipped{path: in.Path, err: e})
+		}
+		for _, w := range warns {
+			warnings = append(warnings, skipped{path: in.Path, err: w})
+		}
+		fmt.Printf("  %s %s: %d triples, %d skipped statements\n", in.Label, in.Path, len(triples), len(errs))
+	}
+
+	fmt.Println()
+	fmt.Printf("Prefixes (%d):\n", len(prefixes))
+	names := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		names = append(names, prefix)
+	}
+	sort.Strings(names)
+	for _, prefix := range names {
+		fmt.Printf("  %s: <%s>\n", prefix, prefixes[prefix])
+	}
+
+	if len(problems) > 0 {
+		fmt.Println()
+		fmt.Printf("Skipped statements (%d):\n", len(problems))
+		for _, p := range problems {
+			fmt.Printf("  %s:%d: %s\n", p.path, p.err.Line, p.err.Message)
+		}
+	}
+
+	if len(warnings) > 0 {
+		fmt.Println()
+		fmt.Printf("Warnings (%d):\n", len(warnings))
+		for _, w := range warnings {
+			fmt.Printf("  %s:%d: %s\n", w.path, w.err.Line, w.err.Message)
+		}
+	}
+
+	fmt.Println()
+	profile := "default"
+	if ruleSet != "" {
+		profile = ruleSet
+	}
+	if len(scopeClasses) > 0 || len(scopePredicates) > 0 {
+		profile = fmt.Sprintf("%s, scoped to classes %v and predicates %v", profile, scopeClasses, scopePredicates)
+	}
+	fmt.Printf("Rule profile: %s (%d rules)\n", profile, len(rules))
+	for _, rule := range rules {
+		fmt.Printf("  %s\n", rule.Name())
+	}
+
+	fmt.Println()
+	fmt.Printf("Estimated memory before inference: %s for %d triples\n",
+		formatBytes(reasoner.EstimateStoreMemory(all)), len(all))
+}
+
+// Helper function to format a byte count for humans
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
 }
 
 // Helper function to check if file exists
@@ -177,10 +1692,66 @@ func fileExists(filename string) bool {
 	return !info.IsDir()
 }
 
-// Helper function to check if file is a Turtle file
-func isTurtleFile(filename string) bool {
-	ext := strings.ToLower(filename[strings.LastIndex(filename, ".")+1:])
-	return ext == "ttl" || ext == "turtle" || ext == "n3"
+// checkInputFormat returns the format of a file, failing unless the parser
+// reads it. The format is detected from the content unless formatName
+// overrides it.
+func checkInputFormat(filename, content, formatName string) (reasoner.Format, error) {
+	format := reasoner.DetectFormat(filename, content)
+	if formatName != "auto" {
+		format, _ = reasoner.ParseFormat(formatName)
+	}
+	switch {
+	case format == reasoner.FormatUnknown:
+		return format, fmt.Errorf("could not detect the format of '%s', use --format", filename)
+	case !format.CanLoad():
+		return format, fmt.Errorf("file '%s' is %s, which is not supported; convert it to Turtle, N-Triples, N-Quads, RDF/XML, JSON-LD, TriG or N3", filename, format)
+	}
+	verbosef("%s: %s\n", filename, format)
+	return format, nil
+}
+
+// loadInput loads a file with the parser for format, or for the format
+// detected from its content if format is unknown
+func loadInput(r *reasoner.Reasoner, path, content string, format reasoner.Format) error {
+	return r.LoadDocument(path, content, format)
+}
+
+// parseInput parses a file like loadInput without loading it, returning
+// its triples, prefixes, skipped statements and warnings
+func parseInput(path, content string, format reasoner.Format) ([]reasoner.Triple, map[string]string, []reasoner.ParseError, []reasoner.ParseError, error) {
+	if format == reasoner.FormatUnknown {
+		format = reasoner.DetectFormat(path, content)
+	}
+	switch format {
+	case reasoner.FormatNTriples:
+		parser := reasoner.NewNTriplesParser()
+		triples, err := parser.Parse(content)
+		return triples, nil, parser.Errors(), parser.Warnings(), err
+	case reasoner.FormatNQuads:
+		parser := reasoner.NewNQuadsParser()
+		triples, err := parser.Parse(content)
+		return triples, nil, parser.Errors(), parser.Warnings(), err
+	case reasoner.FormatRDFXML:
+		parser := reasoner.NewRDFXMLParser()
+		triples, err := parser.Parse(content)
+		return triples, parser.Prefixes(), parser.Errors(), parser.Warnings(), err
+	case reasoner.FormatJSONLD:
+		parser := reasoner.NewJSONLDParser()
+		triples, err := parser.Parse(content)
+		return triples, parser.Prefixes(), parser.Errors(), parser.Warnings(), err
+	case reasoner.FormatTriG:
+		parser := reasoner.NewTriGParser()
+		triples, err := parser.Parse(content)
+		return triples, parser.Prefixes(), parser.Errors(), parser.Warnings(), err
+	case reasoner.FormatN3:
+		parser := reasoner.NewN3Parser()
+		triples, err := parser.Parse(content)
+		return triples, parser.Prefixes(), parser.Errors(), parser.Warnings(), err
+	default:
+		parser := reasoner.NewTurtleParser()
+		triples, err := parser.Parse(content)
+		return triples, parser.Prefixes(), parser.Errors(), parser.Warnings(), err
+	}
 }
 
 // Helper function to read file contents
@@ -199,6 +1770,15 @@ func readFile(filename string) (string, error) {
 	return string(content), nil
 }
 
+// Helper function to parse an N-Triples or Turtle file
+func readTriplesFile(filename string) ([]reasoner.Triple, error) {
+	content, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return reasoner.NewTurtleParser().Parse(content)
+}
+
 // Helper function to write triples to file
 func writeTriplesToFile(triples []string, filename string) error {
 	file, err := os.Create(filename)
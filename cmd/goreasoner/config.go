@@ -0,0 +1,77 @@
+// config.go
+// Contains reading of the configuration file
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+	"github.com/spf13/viper"
+)
+
+// Path of the configuration file, bound in Init.
+// nolint:gochecknoglobals
+var flagConfig string
+
+// readConfig reads the file given with --config, or goreasoner.yaml in the
+// current directory if it exists
+func readConfig() error {
+	if flagConfig != "" {
+		viper.SetConfigFile(flagConfig)
+	} else {
+		viper.SetConfigName("goreasoner")
+		viper.AddConfigPath(".")
+	}
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) {
+			return errors.New("no configuration file found; create goreasoner.yaml or use --config")
+		}
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+	verbosef("Using configuration %s\n", viper.ConfigFileUsed())
+	return nil
+}
+
+// ruleSetFromConfig returns the rules of a rule set defined under
+// 'rulesets' in the configuration file. Names are case-insensitive.
+func ruleSetFromConfig(name string) ([]reasoner.Rule, error) {
+	if err := readConfig(); err != nil {
+		return nil, err
+	}
+	var sets map[string]reasoner.RuleSetDefinition
+	if err := viper.UnmarshalKey("rulesets", &sets); err != nil {
+		return nil, fmt.Errorf("invalid rule sets in %s: %w", viper.ConfigFileUsed(), err)
+	}
+
+	def, ok := sets[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(sets))
+		for n := range sets {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("rule set '%s' is not defined in %s (defined: %s)", name, viper.ConfigFileUsed(), strings.Join(names, ", "))
+	}
+	rules, err := def.Build()
+	if err != nil {
+		return nil, fmt.Errorf("rule set '%s': %w", name, err)
+	}
+	return rules, nil
+}
+
+// ruleSetByName returns the default rules for "" or "default", unless the
+// configuration file defines a rule set named "default", and otherwise the
+// rules of a rule set defined in the configuration file
+func ruleSetByName(name string) ([]reasoner.Rule, error) {
+	if name != "" && !strings.EqualFold(name, "default") {
+		return ruleSetFromConfig(name)
+	}
+	if readConfig() != nil || !viper.IsSet("rulesets.default") {
+		return reasoner.DefaultRules(), nil
+	}
+	return ruleSetFromConfig("default")
+}
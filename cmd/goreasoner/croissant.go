@@ -0,0 +1,276 @@
+// croissant.go
+// Contains the commands working with Croissant metadata
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beyondcivic/goreasoner/pkg/inference"
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+	"github.com/spf13/cobra"
+)
+
+// suggestMappingsCmd proposes TBox properties for the fields of Croissant
+// metadata
+func suggestMappingsCmd() *cobra.Command {
+	var suggestMappingsCmd = &cobra.Command{
+		Use:   "suggest-mappings [dataset] [tboxPath]",
+		Short: "Suggest ontology properties for the columns of a dataset",
+		Long: `Read the columns of a dataset and propose, for each column, properties of
+the TBox to map its values to, as a starting point for the CSV-to-RDF
+mapping. The dataset is described by Croissant JSON-LD metadata, whose
+record set fields are the columns, or given as a CSV file or Excel workbook
+(.csv, .xlsx), whose header row names them; the types of their columns are
+then inferred from a sample of the rows. Candidates are ranked by the
+similarity of the column name to the property's local name or labels and by
+the compatibility of the field's dataType with the property's range, e.g.
+
+  birth_date -> ex:birthDate (1.00): name similarity 1.00 with "birth date"; date column matches the range`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			datasetPath, tboxPath := args[0], args[1]
+			flagSheet, _ := cmd.Flags().GetString("sheet")
+			flagHeaderRow, _ := cmd.Flags().GetInt("header-row")
+			flagSampleSize, _ := cmd.Flags().GetInt("sample-size")
+			flagMinConfidence, _ := cmd.Flags().GetFloat64("min-confidence")
+			flagMaxCandidates, _ := cmd.Flags().GetInt("max-candidates")
+			flagNoReasoning, _ := cmd.Flags().GetBool("no-reasoning")
+			flagJSON, _ := cmd.Flags().GetBool("json")
+			flagFormat, _ := cmd.Flags().GetString("format")
+
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			columns, err := readColumns(datasetPath, reasoner.TableOptions{Sheet: flagSheet, HeaderRow: flagHeaderRow}, flagSampleSize)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			var summary inputSummary
+			inputs := readInputs("TBox", tboxPath, flagFormat, &summary)
+			if len(inputs) == 0 {
+				fmt.Printf("Error: no loadable TBox files in '%s'.\n", tboxPath)
+				os.Exit(1)
+			}
+			r := reasoner.NewReasoner()
+			for _, in := range inputs {
+				if err := loadInput(r, in.Path, in.Content, in.Format); err != nil {
+					summary.fail(in.Path, fmt.Errorf("failed to load %s file '%s': %w", in.Label, in.Path, err))
+					continue
+				}
+				summary.loaded = append(summary.loaded, in.Path)
+			}
+			if len(summary.failed) > 0 {
+				summary.print()
+			}
+			if !flagNoReasoning {
+				r.RunForwardReasoning()
+			}
+			reportDiagnostics(r.Diagnostics())
+
+			candidates := reasoner.SuggestColumnMappings(r.GetStore(), columns, reasoner.MappingOptions{
+				MinConfidence: flagMinConfidence,
+				MaxCandidates: flagMaxCandidates,
+			})
+			if flagJSON {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(candidates); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+			prefixes := r.Prefixes()
+			for _, c := range candidates {
+				fmt.Print(c.Format(prefixes))
+			}
+
+			mapped := make(map[string]bool)
+			for _, c := range candidates {
+				mapped[c.Column] = true
+			}
+			notef("%d candidates for %d of %d columns\n", len(candidates), len(mapped), len(columns))
+		},
+	}
+	suggestMappingsCmd.Flags().String("sheet", "", "Worksheet of an Excel workbook to read (default: the first)")
+	suggestMappingsCmd.Flags().Int("header-row", 1, "Row of a CSV file or worksheet holding the column names")
+	suggestMappingsCmd.Flags().Int("sample-size", 1000, "Rows of a CSV file or worksheet sampled to infer the column types")
+	suggestMappingsCmd.Flags().Float64("min-confidence", 0.6, "Drop candidates scoring below this confidence")
+	suggestMappingsCmd.Flags().Int("max-candidates", 3, "Maximum number of candidates per column")
+	suggestMappingsCmd.Flags().Bool("no-reasoning", false, "Only consider the asserted TBox, without inferred triples")
+	suggestMappingsCmd.Flags().Bool("json", false, "Print the candidates as JSON")
+	suggestMappingsCmd.Flags().String("format", "auto", "TBox format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+
+	return suggestMappingsCmd
+}
+
+// readColumns reads the columns of a dataset from Croissant metadata, or
+// from the header of a CSV file or Excel workbook, with their types inferred
+// from the first sampleSize rows
+func readColumns(path string, opts reasoner.TableOptions, sampleSize int) ([]reasoner.Column, error) {
+	opts.MaxRows = sampleSize
+	var table *reasoner.Table
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".xlsx":
+		var err error
+		if table, err = readTable(path, opts); err != nil {
+			return nil, err
+		}
+	default:
+		metadata, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata: %w", err)
+		}
+		return reasoner.ParseCroissantFields(metadata)
+	}
+	columns := table.Columns()
+	inferred := inference.InferColumns(table.Header, table.Rows, inference.Options{SampleSize: sampleSize})
+	for i, c := range inferred {
+		if c.Confidence > 0 {
+			columns[i].DataType = c.Type.XSD()
+		}
+	}
+	return columns, nil
+}
+
+// enrichCroissantCmd writes the inferences about a dataset back into its
+// Croissant metadata
+func enrichCroissantCmd() *cobra.Command {
+	var enrichCroissantCmd = &cobra.Command{
+		Use:   "enrich-croissant [metadataPath] [dataPath]",
+		Short: "Add inferred triples to Croissant metadata",
+		Long: `Reason over the RDF representation of a dataset and add the inferred
+triples about the dataset, its record sets and fields to their nodes in the
+Croissant JSON-LD metadata, e.g. an inferred class marking a field as
+personal data, the unit of a field or the categories of its values.
+
+Nodes are matched by their "@id", expanded with the prefixes of the
+metadata's @context or resolved against --base if relative. Inferred types
+are added to "@type" and other values as properties named with the
+context's vocabulary and prefixes.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			metadataPath, dataPath := args[0], args[1]
+			flagTBoxPath, _ := cmd.Flags().GetString("tbox")
+			flagBase, _ := cmd.Flags().GetString("base")
+			flagOutputPath, _ := cmd.Flags().GetString("output")
+			flagFormat, _ := cmd.Flags().GetString("format")
+
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			metadata, err := os.ReadFile(metadataPath)
+			if err != nil {
+				fmt.Printf("Error reading metadata: %v\n", err)
+				os.Exit(1)
+			}
+
+			var summary inputSummary
+			var inputs []inputFile
+			if flagTBoxPath != "" {
+				inputs = readInputs("TBox", flagTBoxPath, flagFormat, &summary)
+			}
+			dataInputs := readInputs("data", dataPath, flagFormat, &summary)
+			if len(dataInputs) == 0 {
+				fmt.Printf("Error: no loadable data files in '%s'.\n", dataPath)
+				os.Exit(1)
+			}
+			inputs = append(inputs, dataInputs...)
+
+			r := reasoner.NewReasoner()
+			for _, in := range inputs {
+				if err := loadInput(r, in.Path, in.Content, in.Format); err != nil {
+					summary.fail(in.Path, fmt.Errorf("failed to load %s file '%s': %w", in.Label, in.Path, err))
+					continue
+				}
+				summary.loaded = append(summary.loaded, in.Path)
+			}
+			if len(summary.failed) > 0 {
+				summary.print()
+			}
+			// Reasoning only appends to the store
+			asserted := r.GetStore().Size()
+			r.RunForwardReasoning()
+			reportDiagnostics(r.Diagnostics())
+			inferred := r.GetStore().All()[asserted:]
+
+			enriched, added, err := reasoner.EnrichCroissant(metadata, inferred, flagBase)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if flagOutputPath == "" || flagOutputPath == "-" {
+				fmt.Print(string(enriched))
+				notef("Added %d inferred values\n", added)
+				return
+			}
+			if err := os.WriteFile(flagOutputPath, enriched, 0o644); err != nil {
+				fmt.Printf("Error writing output file: %v\n", err)
+				os.Exit(1)
+			}
+			infof("✓ Added %d inferred values to: %s\n", added, flagOutputPath)
+		},
+	}
+	enrichCroissantCmd.Flags().String("tbox", "", "Schema file, directory or pattern to load before the data")
+	enrichCroissantCmd.Flags().String("base", "", "Base IRI of relative @id values (default: the @base of the metadata's @context)")
+	enrichCroissantCmd.Flags().StringP("output", "o", "", "Output file; by default or with '-' the metadata is written to stdout")
+	enrichCroissantCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+
+	return enrichCroissantCmd
+}
+
+// hashCmd prints the hashes of dataset distribution files
+func hashCmd() *cobra.Command {
+	var hashCmd = &cobra.Command{
+		Use:   "hash [file...]",
+		Short: "Hash distribution files for Croissant metadata",
+		Long: `Print the hashes of dataset distribution files, for the sha256 (or md5)
+property of their Croissant file objects. Files are streamed, so files
+larger than memory can be hashed.
+
+With one algorithm the output has the format of sha256sum, so it can be
+checked with "sha256sum -c"; with several, each line is tagged with its
+algorithm, as "SHA256 (data.csv) = ...".`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			flagAlgorithms, _ := cmd.Flags().GetStringSlice("algorithm")
+
+			if len(flagAlgorithms) == 0 {
+				fmt.Printf("Error: --algorithm must name at least one of %s.\n", strings.Join(reasoner.HashAlgorithms(), ", "))
+				os.Exit(1)
+			}
+
+			for _, path := range args {
+				hashes, err := reasoner.HashDistributionWith(path, flagAlgorithms...)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				if len(flagAlgorithms) == 1 {
+					fmt.Printf("%s  %s\n", hashes[flagAlgorithms[0]], path)
+					continue
+				}
+				for _, algorithm := range flagAlgorithms {
+					fmt.Printf("%s (%s) = %s\n", strings.ToUpper(algorithm), path, hashes[algorithm])
+				}
+			}
+		},
+	}
+	hashCmd.Flags().StringSlice("algorithm", []string{"sha256"}, "Hash algorithms, comma-separated: "+strings.Join(reasoner.HashAlgorithms(), ", "))
+
+	return hashCmd
+}
@@ -0,0 +1,141 @@
+// expand.go
+// Contains the expand command generating triples from templates
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+	"github.com/spf13/cobra"
+)
+
+// expandCmd instantiates a triple template for every row of a binding table
+func expandCmd() *cobra.Command {
+	var expandCmd = &cobra.Command{
+		Use:   "expand [templatePath]",
+		Short: "Generate triples from a template for every row of a table",
+		Long: `Expand a triple template once per row of a binding table, e.g. to generate
+the standard annotations of every municipality. The template is Turtle with
+{name} placeholders in IRIs, prefixed names and literals, naming columns of
+the table:
+
+  @prefix ex: <http://example.org/> .
+  ex:municipality-{bfs} a ex:Municipality ;
+      ex:name "{name}"@de ;
+      ex:population "{population}"^^xsd:integer .
+
+The bindings are a CSV file or Excel workbook (.csv, .xlsx) with a header
+row, or CSV on stdin with '-', such as query results written by
+'goreasoner instances --csv'. Values are percent-encoded where IRIs cannot
+contain them; a triple whose placeholders have an empty cell in a row is
+left out for that row, and blank nodes are distinct for every row.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			templatePath := args[0]
+			flagBindings, _ := cmd.Flags().GetString("bindings")
+			flagSheet, _ := cmd.Flags().GetString("sheet")
+			flagHeaderRow, _ := cmd.Flags().GetInt("header-row")
+			flagOutputPath, _ := cmd.Flags().GetString("output")
+			flagOutputType, _ := cmd.Flags().GetString("outputType")
+
+			if flagBindings == "" {
+				fmt.Printf("Error: --bindings is required.\n")
+				os.Exit(1)
+			}
+			if flagOutputType != "ntriple" && flagOutputType != "turtle" {
+				fmt.Printf("Error: Invalid output type '%s'. Must be 'ntriple' or 'turtle'.\n", flagOutputType)
+				os.Exit(1)
+			}
+
+			content, err := readFile(templatePath)
+			if err != nil {
+				fmt.Printf("Error: failed to read template: %v\n", err)
+				os.Exit(1)
+			}
+			template, err := reasoner.ParseTemplate(content)
+			if err != nil {
+				fmt.Printf("Error: '%s': %v\n", templatePath, err)
+				os.Exit(1)
+			}
+			table, err := readTable(flagBindings, reasoner.TableOptions{Sheet: flagSheet, HeaderRow: flagHeaderRow})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			columns := make(map[string]bool, len(table.Header))
+			for _, name := range table.Header {
+				columns[name] = true
+			}
+			for _, name := range template.Variables() {
+				if !columns[name] {
+					fmt.Printf("Error: the template uses {%s}, which is not a column of the bindings.\n", name)
+					os.Exit(1)
+				}
+			}
+
+			triples := template.Expand(reasoner.BindingsFromTable(table))
+			var lines []string
+			if flagOutputType == "turtle" {
+				snapshot := reasoner.SerializeTurtleSorted(triples, template.Prefixes())
+				lines = []string{strings.TrimSuffix(snapshot, "\n")}
+			} else {
+				lines = tripleLines(triples)
+			}
+
+			if flagOutputPath == "" || flagOutputPath == "-" {
+				for _, line := range lines {
+					fmt.Println(line)
+				}
+				notef("Generated %d triples from %d rows\n", len(triples), len(table.Rows))
+				return
+			}
+			if err := writeTriplesToFile(lines, flagOutputPath); err != nil {
+				fmt.Printf("Error writing output file: %v\n", err)
+				os.Exit(1)
+			}
+			infof("✓ Generated %d triples from %d rows to: %s\n", len(triples), len(table.Rows), flagOutputPath)
+		},
+	}
+	expandCmd.Flags().String("bindings", "", "CSV file or Excel workbook whose rows bind the placeholders, or '-' for CSV on stdin")
+	expandCmd.Flags().String("sheet", "", "Worksheet of an Excel workbook to read (default: the first)")
+	expandCmd.Flags().Int("header-row", 1, "Row of a CSV file or worksheet holding the column names")
+	expandCmd.Flags().StringP("output", "o", "", "Output file; by default or with '-' the triples are written to stdout")
+	expandCmd.Flags().String("outputType", "ntriple", "Output format: 'ntriple' or 'turtle'")
+
+	return expandCmd
+}
+
+// readTable reads a CSV file or Excel workbook, or CSV from stdin if path
+// is "-"
+func readTable(path string, opts reasoner.TableOptions) (*reasoner.Table, error) {
+	if path == "-" {
+		table, err := reasoner.ReadCSV(os.Stdin, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return table, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+	defer f.Close()
+
+	var table *reasoner.Table
+	if strings.ToLower(filepath.Ext(path)) == ".xlsx" {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dataset: %w", err)
+		}
+		table, err = reasoner.ReadXLSX(f, info.Size(), opts)
+	} else {
+		table, err = reasoner.ReadCSV(f, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+	return table, nil
+}
@@ -0,0 +1,188 @@
+// inputs.go
+// Contains expansion of input arguments into files
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+)
+
+// expandInput resolves an input argument to the files it names: a single
+// file, every Turtle and N-Triples file below a directory, or the files
+// matching a glob pattern, where '**' matches any number of directories.
+// Patterns are expanded here rather than by the shell, so they must be quoted.
+func expandInput(arg string) ([]string, error) {
+	if !isGlobPattern(arg) {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' does not exist", arg)
+		}
+		if !info.IsDir() {
+			return []string{arg}, nil
+		}
+		return walkFiles(arg, func(rel string) bool {
+			return reasoner.FormatFromExtension(rel).CanLoad()
+		})
+	}
+
+	root := globRoot(arg)
+	pattern := filepath.ToSlash(arg)
+	if root != "." {
+		pattern = strings.TrimPrefix(pattern, filepath.ToSlash(root)+"/")
+	}
+	if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s': %w", arg, err)
+	}
+
+	files, err := walkFiles(root, func(rel string) bool {
+		return matchGlob(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match '%s'", arg)
+	}
+	return files, nil
+}
+
+// isGlobPattern reports whether an argument contains glob metacharacters
+func isGlobPattern(arg string) bool {
+	return strings.ContainsAny(arg, "*?[")
+}
+
+// globRoot returns the directory before the first path element of a
+// pattern that contains metacharacters
+func globRoot(pattern string) string {
+	dir := pattern
+	for isGlobPattern(dir) {
+		dir = filepath.Dir(dir)
+	}
+	return dir
+}
+
+// walkFiles lists the regular files below root, in lexical order, whose
+// slash-separated path relative to root satisfies keep
+func walkFiles(root string, keep func(rel string) bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if keep(filepath.ToSlash(rel)) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list '%s': %w", root, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// matchGlob matches path elements against pattern elements, where a '**'
+// element matches zero or more path elements
+func matchGlob(pattern, elems []string) bool {
+	if len(pattern) == 0 {
+		return len(elems) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(elems); i++ {
+			if matchGlob(pattern[1:], elems[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(elems) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], elems[0]); !ok {
+		return false
+	}
+	return matchGlob(pattern[1:], elems[1:])
+}
+
+// inputSummary records which input files were loaded and which failed
+type inputSummary struct {
+	loaded []string
+	failed []string
+	stderr bool // report on stderr, when stdout carries results
+}
+
+// fail reports a file that could not be loaded
+func (s *inputSummary) fail(path string, err error) {
+	if s.stderr {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	} else {
+		fmt.Printf("Error: %v\n", err)
+	}
+	s.failed = append(s.failed, path)
+}
+
+// print reports how many files were loaded, listing the failed ones
+func (s *inputSummary) print() {
+	printf := infof
+	if s.stderr {
+		printf = notef
+	}
+	total := len(s.loaded) + len(s.failed)
+	if total <= 2 && len(s.failed) == 0 {
+		return
+	}
+	printf("Loaded %d of %d input files\n", len(s.loaded), total)
+	for _, p := range s.failed {
+		printf("  failed: %s\n", p)
+	}
+}
+
+// readInputs expands an input argument and reads the files it names that are
+// in a loadable format, recording failures in the summary
+func readInputs(label, arg, formatName string, summary *inputSummary) []inputFile {
+	paths, err := expandInput(arg)
+	if err != nil {
+		summary.fail(arg, err)
+		return nil
+	}
+
+	var inputs []inputFile
+	for _, p := range paths {
+		content, err := readFile(p)
+		if err != nil {
+			summary.fail(p, err)
+			continue
+		}
+		format, err := checkInputFormat(p, content, formatName)
+		if err != nil {
+			summary.fail(p, err)
+			continue
+		}
+		named := len(paths) == 1 && p == arg
+		inputs = append(inputs, inputFile{Label: label, Path: p, Content: content, Format: format, Named: named})
+	}
+	return inputs
+}
+
+// inputBase returns the path an input argument's default output is named
+// after: the directory of a pattern, or the argument itself
+func inputBase(arg string) string {
+	if isGlobPattern(arg) {
+		return globRoot(arg)
+	}
+	return strings.TrimSuffix(arg, string(filepath.Separator))
+}
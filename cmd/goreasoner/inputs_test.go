@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+)
+
+func TestReadInputsFormat(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "data")
+	content := "# Exported without an extension\n" +
+		"@prefix ex: <http://example.org/> .\nex:alice a ex:Student .\n"
+	if err := os.WriteFile(data, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	alice := reasoner.Triple{Subject: "http://example.org/alice", Predicate: reasoner.RDFType, Object: "http://example.org/Student"}
+
+	tests := []struct {
+		format string
+		want   reasoner.Format
+		loaded bool
+	}{
+		{"auto", reasoner.FormatTurtle, true},
+		{"turtle", reasoner.FormatTurtle, true},
+		// The N-Triples parser skips the prefixed statement
+		{"ntriples", reasoner.FormatNTriples, false},
+	}
+	for _, tt := range tests {
+		var summary inputSummary
+		inputs := readInputs("ABox", data, tt.format, &summary)
+		if len(inputs) != 1 || inputs[0].Format != tt.want {
+			t.Errorf("--format %s: expected '%s' to be read as %s, got %v", tt.format, data, tt.want, inputs)
+			continue
+		}
+
+		r, _, err := runReasoner("", inputs, &summary, nil)
+		if err != nil {
+			t.Errorf("--format %s: runReasoner failed: %v", tt.format, err)
+			continue
+		}
+		if r.GetStore().Contains(alice) != tt.loaded {
+			t.Errorf("--format %s: expected the triple to be loaded: %v", tt.format, tt.loaded)
+		}
+
+		triples, _, _, _, err := parseInput(data, content, inputs[0].Format)
+		if err != nil || (len(triples) == 1) != tt.loaded {
+			t.Errorf("--format %s: unexpected dry run result: %d triples, %v", tt.format, len(triples), err)
+		}
+	}
+}
@@ -96,10 +96,42 @@ func Init() {
 	viper.SetEnvPrefix("GOREASONER")
 	viper.AutomaticEnv()
 
+	// Output control shared by all commands
+	RootCmd.PersistentFlags().BoolVarP(&flagQuiet, "quiet", "q", false, "Only print errors and requested output")
+	RootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false, "Print progress details to stderr")
+	RootCmd.PersistentFlags().StringVar(&flagDiagnostics, "diagnostics", "text", "Format of warnings and errors about the input on stderr: 'text' or 'json'")
+	RootCmd.PersistentFlags().StringVar(&flagConfig, "config", "", "Configuration file defining rule sets (default: goreasoner.yaml in the current directory)")
+
+	RootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if flagDiagnostics != "text" && flagDiagnostics != "json" {
+			fmt.Printf("Error: Invalid diagnostics format '%s'. Must be 'text' or 'json'.\n", flagDiagnostics)
+			os.Exit(1)
+		}
+	}
+
 	// Add child commands
 	RootCmd.AddCommand(versionCmd())
+	RootCmd.AddCommand(capabilitiesCmd())
 	RootCmd.AddCommand(runCmd())
+	RootCmd.AddCommand(runBatchCmd())
 	RootCmd.AddCommand(dlQueryCmd())
+	RootCmd.AddCommand(rulesCmd())
+	RootCmd.AddCommand(crosscheckCmd())
+	RootCmd.AddCommand(describeCmd())
+	RootCmd.AddCommand(instancesCmd())
+	RootCmd.AddCommand(suggestMappingsCmd())
+	RootCmd.AddCommand(enrichCroissantCmd())
+	RootCmd.AddCommand(hashCmd())
+	RootCmd.AddCommand(exportCmd())
+	RootCmd.AddCommand(sampleCmd())
+	RootCmd.AddCommand(expandCmd())
+	RootCmd.AddCommand(validateRDFCmd())
+	RootCmd.AddCommand(statsCmd())
+	RootCmd.AddCommand(doctorCmd())
+	RootCmd.AddCommand(fetchCmd())
+	RootCmd.AddCommand(versionCheckCmd())
+	RootCmd.AddCommand(verifyCmd())
+	RootCmd.AddCommand(benchCmd())
 }
 
 func Execute() {
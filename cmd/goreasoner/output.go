@@ -0,0 +1,193 @@
+// output.go
+// Contains output verbosity control and progress reporting
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+	"github.com/spf13/viper"
+)
+
+// Global output flags, bound in Init.
+// nolint:gochecknoglobals
+var (
+	flagQuiet       bool
+	flagVerbose     bool
+	flagDiagnostics string
+)
+
+// progressBarWidth is the number of cells in the parsing progress bar
+const progressBarWidth = 30
+
+// infof prints an informational message to stdout unless --quiet is set
+func infof(format string, args ...any) {
+	if !flagQuiet {
+		fmt.Printf(format, args...)
+	}
+}
+
+// notef prints an informational message to stderr unless --quiet is set,
+// for use when stdout carries results
+func notef(format string, args ...any) {
+	if !flagQuiet {
+		fmt.Fprintf(os.Stderr, format, args...)
+	}
+}
+
+// verbosef prints a diagnostic message to stderr if --verbose is set
+func verbosef(format string, args ...any) {
+	if flagVerbose && !flagQuiet {
+		fmt.Fprintf(os.Stderr, format, args...)
+	}
+}
+
+// reportDiagnostics prints diagnostics to stderr, one per line, as text
+// or as JSON objects depending on --diagnostics. With --quiet only errors
+// are printed.
+func reportDiagnostics(diagnostics []reasoner.Diagnostic) {
+	encoder := json.NewEncoder(os.Stderr)
+	for _, d := range diagnostics {
+		if flagQuiet && d.Severity < reasoner.SeverityError {
+			continue
+		}
+		if flagDiagnostics == "json" {
+			_ = encoder.Encode(d)
+		} else {
+			fmt.Fprintln(os.Stderr, d)
+		}
+	}
+}
+
+// selectResults returns the first limit items, or sample items chosen at
+// random in their original order, or all items if both are zero
+func selectResults[T any](items []T, limit, sample int) []T {
+	switch {
+	case limit > 0 && limit < len(items):
+		return items[:limit]
+	case sample > 0 && sample < len(items):
+		picked := rand.Perm(len(items))[:sample]
+		sort.Ints(picked)
+		result := make([]T, len(picked))
+		for i, idx := range picked {
+			result[i] = items[idx]
+		}
+		return result
+	default:
+		return items
+	}
+}
+
+// outputMasking returns the masking of run output: literals truncated to
+// maxLiteralLength characters, and the values of the given predicates and
+// of those listed under 'redact-predicates' in the configuration file
+// redacted
+func outputMasking(maxLiteralLength int, redactPredicates []string) reasoner.OutputMasking {
+	if readConfig() == nil {
+		redactPredicates = append(redactPredicates, viper.GetStringSlice("redact-predicates")...)
+	}
+	return reasoner.OutputMasking{MaxLiteralLength: maxLiteralLength, RedactPredicates: redactPredicates}
+}
+
+// tripleLines formats triples as sorted N-Triples lines
+func tripleLines(triples []reasoner.Triple) []string {
+	lines := make([]string, len(triples))
+	for i, t := range triples {
+		lines[i] = t.String()
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// progressReporter renders reasoner progress on stderr, either as a
+// progress bar on a terminal or as log lines with --verbose
+type progressReporter struct {
+	bar      bool
+	lastLen  int
+	lastRule int
+}
+
+// newProgressReporter returns a reporter for the current output flags, or
+// nil if progress should not be shown
+func newProgressReporter() *progressReporter {
+	if flagQuiet {
+		return nil
+	}
+	if flagVerbose {
+		return &progressReporter{}
+	}
+	if isTerminal(os.Stderr) {
+		return &progressReporter{bar: true}
+	}
+	return nil
+}
+
+// options returns the reasoner options that feed events to the reporter
+func (p *progressReporter) options() []reasoner.Option {
+	if p == nil {
+		return nil
+	}
+	return []reasoner.Option{reasoner.WithProgress(p.report)}
+}
+
+func (p *progressReporter) report(ev reasoner.ProgressEvent) {
+	switch ev.Stage {
+	case reasoner.ProgressParsing:
+		if !p.bar {
+			if ev.Done == ev.Total {
+				fmt.Fprintf(os.Stderr, "Parsed %d triples\n", ev.Triples)
+			}
+			return
+		}
+		filled := progressBarWidth
+		if ev.Total > 0 {
+			filled = ev.Done * progressBarWidth / ev.Total
+		}
+		p.render(fmt.Sprintf("Parsing   [%s%s] %3d%%  %d triples",
+			strings.Repeat("#", filled), strings.Repeat(".", progressBarWidth-filled),
+			filled*100/progressBarWidth, ev.Triples))
+		if ev.Done == ev.Total {
+			p.finish()
+		}
+	case reasoner.ProgressReasoning:
+		if !p.bar {
+			fmt.Fprintf(os.Stderr, "Round %d: %s (%d inferred, +%d)\n", ev.Done, ev.Rule, ev.Triples, ev.Triples-p.lastRule)
+			p.lastRule = ev.Triples
+			return
+		}
+		p.render(fmt.Sprintf("Reasoning round %d  %d inferred  %s", ev.Done, ev.Triples, ev.Rule))
+	}
+}
+
+// render overwrites the current progress line
+func (p *progressReporter) render(line string) {
+	padding := ""
+	if len(line) < p.lastLen {
+		padding = strings.Repeat(" ", p.lastLen-len(line))
+	}
+	fmt.Fprintf(os.Stderr, "\r%s%s", line, padding)
+	p.lastLen = len(line)
+}
+
+// finish ends the current progress line
+func (p *progressReporter) finish() {
+	if p == nil || !p.bar || p.lastLen == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr)
+	p.lastLen = 0
+}
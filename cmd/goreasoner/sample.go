@@ -0,0 +1,144 @@
+// sample.go
+// Contains the sample command drawing small, anonymized repro cases
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+	"github.com/spf13/cobra"
+)
+
+// sampleCmd writes a random, optionally anonymized subset of the input
+func sampleCmd() *cobra.Command {
+	var sampleCmd = &cobra.Command{
+		Use:   "sample [dataPath]",
+		Short: "Draw a small, anonymized subset of the data for bug reports",
+		Long: `Draw a small subset of the input that keeps its structure, to attach to an
+issue as a repro case. The schema is kept in full, together with every triple
+of a random fraction of the resources described by the data, the blank nodes
+they lead to and the types of the resources they refer to. The same --seed
+draws the same subset.
+
+With --anonymize, the IRIs of resources are replaced by pseudonyms in
+` + reasoner.AnonymizedNamespace + ` and text literals by numbered values,
+while classes, properties, the schema and typed values such as dates and
+numbers are kept, so the subset is reasoned over as the original is:
+
+  goreasoner sample data.ttl --seed 42 --ratio 0.01 --anonymize -o repro.ttl --outputType turtle`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			dataPath := args[0]
+			flagTBoxPath, _ := cmd.Flags().GetString("tbox")
+			flagRatio, _ := cmd.Flags().GetFloat64("ratio")
+			flagSeed, _ := cmd.Flags().GetInt64("seed")
+			flagAnonymize, _ := cmd.Flags().GetBool("anonymize")
+			flagOutputPath, _ := cmd.Flags().GetString("output")
+			flagOutputType, _ := cmd.Flags().GetString("outputType")
+			flagFormat, _ := cmd.Flags().GetString("format")
+
+			if flagOutputType != "ntriple" && flagOutputType != "turtle" {
+				fmt.Printf("Error: Invalid output type '%s'. Must be 'ntriple' or 'turtle'.\n", flagOutputType)
+				os.Exit(1)
+			}
+			if flagFormat != "auto" {
+				if _, err := reasoner.ParseFormat(flagFormat); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var summary inputSummary
+			var inputs []inputFile
+			if flagTBoxPath != "" {
+				inputs = readInputs("TBox", flagTBoxPath, flagFormat, &summary)
+			}
+			dataInputs := readInputs("data", dataPath, flagFormat, &summary)
+			if len(dataInputs) == 0 {
+				fmt.Printf("Error: no loadable data files in '%s'.\n", dataPath)
+				os.Exit(1)
+			}
+			inputs = append(inputs, dataInputs...)
+
+			r := reasoner.NewReasoner()
+			for _, in := range inputs {
+				if err := loadInput(r, in.Path, in.Content, in.Format); err != nil {
+					summary.fail(in.Path, fmt.Errorf("failed to load %s file '%s': %w", in.Label, in.Path, err))
+					continue
+				}
+				summary.loaded = append(summary.loaded, in.Path)
+			}
+			if len(summary.failed) > 0 {
+				summary.print()
+			}
+			reportDiagnostics(r.Diagnostics())
+
+			triples, err := reasoner.SampleGraph(r.GetStore(), reasoner.SampleOptions{
+				Ratio:     flagRatio,
+				Seed:      flagSeed,
+				Anonymize: flagAnonymize,
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			var lines []string
+			if flagOutputType == "turtle" {
+				prefixes := r.Prefixes()
+				if flagAnonymize {
+					prefixes = usedPrefixes(triples, prefixes)
+					prefixes["anon"] = reasoner.AnonymizedNamespace
+				}
+				snapshot := reasoner.SerializeTurtleSorted(triples, prefixes)
+				lines = []string{strings.TrimSuffix(snapshot, "\n")}
+			} else {
+				for _, t := range triples {
+					lines = append(lines, t.String())
+				}
+				sort.Strings(lines)
+			}
+
+			if flagOutputPath == "" || flagOutputPath == "-" {
+				for _, line := range lines {
+					fmt.Println(line)
+				}
+				notef("Sampled %d of %d triples\n", len(triples), r.GetStore().Size())
+				return
+			}
+			if err := writeTriplesToFile(lines, flagOutputPath); err != nil {
+				fmt.Printf("Error writing output file: %v\n", err)
+				os.Exit(1)
+			}
+			infof("✓ Sampled %d of %d triples to: %s\n", len(triples), r.GetStore().Size(), flagOutputPath)
+		},
+	}
+	sampleCmd.Flags().Float64("ratio", 0.01, "Fraction of the described resources to keep, greater than 0 and at most 1")
+	sampleCmd.Flags().Int64("seed", 0, "Seed of the random choice of resources")
+	sampleCmd.Flags().Bool("anonymize", false, "Replace the IRIs of resources and text literals by pseudonyms")
+	sampleCmd.Flags().String("tbox", "", "Schema file, directory or pattern to load before the data")
+	sampleCmd.Flags().StringP("output", "o", "", "Output file; by default or with '-' the triples are written to stdout")
+	sampleCmd.Flags().String("outputType", "ntriple", "Output format: 'ntriple' or 'turtle'")
+	sampleCmd.Flags().String("format", "auto", "Input format: 'auto' to detect it from the content, or 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig', 'n3'")
+
+	return sampleCmd
+}
+
+// usedPrefixes returns the prefixes whose namespace starts an IRI of the
+// triples, so that an anonymized sample does not declare the namespaces of
+// the resources it hides
+func usedPrefixes(triples []reasoner.Triple, prefixes map[string]string) map[string]string {
+	used := make(map[string]string)
+	for prefix, namespace := range prefixes {
+		for _, t := range triples {
+			if strings.HasPrefix(t.Subject, namespace) || strings.HasPrefix(t.Predicate, namespace) || strings.HasPrefix(t.Object, namespace) {
+				used[prefix] = namespace
+				break
+			}
+		}
+	}
+	return used
+}
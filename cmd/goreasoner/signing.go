@@ -0,0 +1,149 @@
+// signing.go
+// Contains signing of output files and the verify command
+package cmd
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/beyondcivic/goreasoner/pkg/reasoner"
+	"github.com/spf13/cobra"
+)
+
+// signatureExtension is appended to the path of a signed file to name its
+// signature
+const signatureExtension = ".sig"
+
+// outputSigner signs output files as produced with a rule configuration
+type outputSigner struct {
+	key     ed25519.PrivateKey
+	rules   []reasoner.Rule
+	ruleSet string
+}
+
+// newOutputSigner reads the private key given with --sign-key
+func newOutputSigner(keyPath string, rules []reasoner.Rule, ruleSet string) (*outputSigner, error) {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	key, err := reasoner.ParseSigningKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key '%s': %w", keyPath, err)
+	}
+	return &outputSigner{key: key, rules: rules, ruleSet: ruleSet}, nil
+}
+
+// sign writes the signature of a file next to it, returning its path
+func (s *outputSigner) sign(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read '%s' for signing: %w", path, err)
+	}
+	signature := reasoner.SignArtifact(content, s.key, s.rules, s.ruleSet)
+	data, err := json.MarshalIndent(signature, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode signature: %w", err)
+	}
+	sigPath := path + signatureExtension
+	if err := os.WriteFile(sigPath, append(data, '\n'), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write signature: %w", err)
+	}
+	return sigPath, nil
+}
+
+// verifyCmd checks the signature of an output file
+func verifyCmd() *cobra.Command {
+	var verifyCmd = &cobra.Command{
+		Use:   "verify [file]",
+		Short: "Verify the signature of an output file",
+		Long: `Verify that an output file written with --sign-key is unchanged and was
+signed with the private key of the given public key, and print the
+goreasoner version and rule configuration it was produced with.
+
+With --ruleset or --default-rules, the file must also have been produced
+with exactly those rules.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := args[0]
+			flagPublicKey, _ := cmd.Flags().GetString("public-key")
+			flagSignature, _ := cmd.Flags().GetString("signature")
+			flagRuleSet, _ := cmd.Flags().GetString("ruleset")
+			flagDefaultRules, _ := cmd.Flags().GetBool("default-rules")
+
+			if flagPublicKey == "" {
+				fmt.Printf("Error: --public-key is required.\n")
+				os.Exit(1)
+			}
+			if flagRuleSet != "" && flagDefaultRules {
+				fmt.Printf("Error: --ruleset and --default-rules cannot be combined.\n")
+				os.Exit(1)
+			}
+			if flagSignature == "" {
+				flagSignature = path + signatureExtension
+			}
+
+			keyData, err := os.ReadFile(flagPublicKey)
+			if err != nil {
+				fmt.Printf("Error reading public key: %v\n", err)
+				os.Exit(1)
+			}
+			key, err := reasoner.ParseVerifyingKey(keyData)
+			if err != nil {
+				fmt.Printf("Error: invalid public key '%s': %v\n", flagPublicKey, err)
+				os.Exit(1)
+			}
+			sigData, err := os.ReadFile(flagSignature)
+			if err != nil {
+				fmt.Printf("Error reading signature: %v\n", err)
+				os.Exit(1)
+			}
+			var signature reasoner.ArtifactSignature
+			if err := json.Unmarshal(sigData, &signature); err != nil {
+				fmt.Printf("Error: invalid signature file '%s': %v\n", flagSignature, err)
+				os.Exit(1)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				fmt.Printf("Error reading file: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := signature.Verify(content, key); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			var expected []reasoner.Rule
+			switch {
+			case flagDefaultRules:
+				expected = reasoner.DefaultRules()
+			case flagRuleSet != "":
+				expected, err = ruleSetFromConfig(flagRuleSet)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			if expected != nil && reasoner.RulesFingerprint(expected) != signature.Rules {
+				fmt.Printf("Error: '%s' was not produced with the expected rules.\n", path)
+				os.Exit(1)
+			}
+
+			infof("✓ Valid signature of '%s'\n", path)
+			infof("  goreasoner version: %s\n", signature.Version)
+			infof("  Rules fingerprint: %s\n", signature.Rules)
+			if signature.RuleSet != "" {
+				infof("  Rule set: %s\n", signature.RuleSet)
+			}
+		},
+	}
+	verifyCmd.Flags().String("public-key", "", "PEM-encoded Ed25519 public key of the signer")
+	verifyCmd.Flags().String("signature", "", "Signature file (default: the file's path with '.sig' appended)")
+	verifyCmd.Flags().String("ruleset", "", "Require the file to have been produced with this rule set from the configuration file")
+	verifyCmd.Flags().Bool("default-rules", false, "Require the file to have been produced with the default rules")
+
+	return verifyCmd
+}
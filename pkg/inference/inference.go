@@ -0,0 +1,245 @@
+// Package inference infers the types of the columns of tabular data.
+//
+// Each column is classified as integer, float, boolean, date, URL or text
+// from a sample of its values, skipping null markers such as "" or "NA".
+// Every type is scored with the share of the non-null values it accepts, so
+// callers can see how certain a classification is and which values do not
+// fit.
+//
+// # Usage
+//
+//	columns := inference.InferColumns(header, rows, inference.Options{SampleSize: 500})
+//	for _, c := range columns {
+//		fmt.Printf("%s: %s (%.0f%%)\n", c.Name, c.Type, c.Confidence*100)
+//	}
+package inference
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type is the inferred type of a column
+type Type int
+
+const (
+	// Text accepts any value
+	Text Type = iota
+	// Integer accepts whole numbers such as "-42"
+	Integer
+	// Float accepts decimal numbers such as "3.14" or "1e-3", and integers
+	Float
+	// Boolean accepts true/false and yes/no in any case
+	Boolean
+	// Date accepts dates and date-times in ISO 8601 and common local formats
+	Date
+	// URL accepts absolute http, https and ftp URLs
+	URL
+)
+
+// candidates lists the types tried, most specific first
+var candidates = []Type{Boolean, Integer, Float, Date, URL} //nolint:gochecknoglobals
+
+// String returns the name of the type
+func (t Type) String() string {
+	switch t {
+	case Integer:
+		return "integer"
+	case Float:
+		return "float"
+	case Boolean:
+		return "boolean"
+	case Date:
+		return "date"
+	case URL:
+		return "url"
+	default:
+		return "text"
+	}
+}
+
+// MarshalText encodes the type by its name, also as a JSON object key
+func (t Type) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// XSD returns the XML Schema datatype IRI for values of the type
+func (t Type) XSD() string {
+	const xsd = "http://www.w3.org/2001/XMLSchema#"
+	switch t {
+	case Integer:
+		return xsd + "integer"
+	case Float:
+		return xsd + "double"
+	case Boolean:
+		return xsd + "boolean"
+	case Date:
+		return xsd + "date"
+	case URL:
+		return xsd + "anyURI"
+	default:
+		return xsd + "string"
+	}
+}
+
+// Options configures type inference
+type Options struct {
+	// SampleSize is the number of values of each column considered
+	// (default: 1000)
+	SampleSize int
+	// NullValues are the values treated as missing, compared after
+	// trimming spaces and case-insensitively (default: "", "NA", "N/A",
+	// "null", "none", "-")
+	NullValues []string
+	// Threshold is the share of non-null values a type must accept to be
+	// inferred (default: 0.95)
+	Threshold float64
+}
+
+// DefaultNullValues are the values treated as missing by default
+func DefaultNullValues() []string {
+	return []string{"", "NA", "N/A", "null", "none", "-"}
+}
+
+func (o Options) withDefaults() Options {
+	if o.SampleSize == 0 {
+		o.SampleSize = 1000
+	}
+	if o.NullValues == nil {
+		o.NullValues = DefaultNullValues()
+	}
+	if o.Threshold == 0 {
+		o.Threshold = 0.95
+	}
+	return o
+}
+
+// Column is the inferred type of a column
+type Column struct {
+	Name string `json:"name"`
+	Type Type   `json:"type"`
+	// Confidence is the share of the non-null values accepted by Type,
+	// or 0 if all sampled values are null
+	Confidence float64 `json:"confidence"`
+	// Scores holds the share of the non-null values accepted by each type
+	Scores map[Type]float64 `json:"scores"`
+	// Values and Nulls count the sampled values and the null ones
+	Values int `json:"values"`
+	Nulls  int `json:"nulls"`
+}
+
+// InferColumns infers the type of each column of a table, given its header
+// and rows. Rows may be shorter than the header; missing cells are null.
+func InferColumns(header []string, rows [][]string, opts Options) []Column {
+	opts = opts.withDefaults()
+	columns := make([]Column, len(header))
+	values := make([]string, 0, min(len(rows), opts.SampleSize))
+	for i, name := range header {
+		values = values[:0]
+		for _, row := range rows {
+			if len(values) == opts.SampleSize {
+				break
+			}
+			if i < len(row) {
+				values = append(values, row[i])
+			} else {
+				values = append(values, "")
+			}
+		}
+		columns[i] = Infer(values, opts)
+		columns[i].Name = name
+	}
+	return columns
+}
+
+// Infer infers the type of the values of a single column
+func Infer(values []string, opts Options) Column {
+	opts = opts.withDefaults()
+	nulls := make(map[string]bool, len(opts.NullValues))
+	for _, v := range opts.NullValues {
+		nulls[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+	if len(values) > opts.SampleSize {
+		values = values[:opts.SampleSize]
+	}
+
+	c := Column{Type: Text, Scores: make(map[Type]float64), Values: len(values)}
+	accepted := make(map[Type]int)
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if nulls[strings.ToLower(v)] {
+			c.Nulls++
+			continue
+		}
+		for _, t := range candidates {
+			if accepts(t, v) {
+				accepted[t]++
+			}
+		}
+	}
+
+	present := c.Values - c.Nulls
+	if present == 0 {
+		return c
+	}
+	c.Scores[Text] = 1
+	for _, t := range candidates {
+		c.Scores[t] = float64(accepted[t]) / float64(present)
+	}
+	c.Confidence = 1
+	for _, t := range candidates {
+		if c.Scores[t] >= opts.Threshold {
+			c.Type, c.Confidence = t, c.Scores[t]
+			break
+		}
+	}
+	return c
+}
+
+// dateLayouts are the accepted date and date-time formats
+var dateLayouts = []string{ //nolint:gochecknoglobals
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006/01/02",
+	"02.01.2006",
+	"2.1.2006",
+	"01/02/2006",
+	"1/2/2006",
+}
+
+// accepts reports whether a non-null value is of a type
+func accepts(t Type, v string) bool {
+	switch t {
+	case Integer:
+		_, err := strconv.ParseInt(v, 10, 64)
+		return err == nil
+	case Float:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return false
+		}
+		// ParseFloat also accepts "inf" and "nan"
+		return strings.ContainsAny(v, "0123456789")
+	case Boolean:
+		switch strings.ToLower(v) {
+		case "true", "false", "yes", "no":
+			return true
+		}
+		return false
+	case Date:
+		for _, layout := range dateLayouts {
+			if _, err := time.Parse(layout, v); err == nil {
+				return true
+			}
+		}
+		return false
+	case URL:
+		u, err := url.Parse(v)
+		return err == nil && u.Host != "" && (u.Scheme == "http" || u.Scheme == "https" || u.Scheme == "ftp")
+	default:
+		return true
+	}
+}
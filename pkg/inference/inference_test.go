@@ -0,0 +1,75 @@
+package inference
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInfer(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []string
+		expected   Type
+		confidence float64
+	}{
+		{"integers", []string{"1", "-42", " 7 ", "NA"}, Integer, 1},
+		{"floats", []string{"1.5", "2", "1e-3"}, Float, 1},
+		{"booleans", []string{"true", "False", "YES", "no"}, Boolean, 1},
+		{"zero and one are integers", []string{"0", "1", "1"}, Integer, 1},
+		{"dates", []string{"2024-01-31", "31.01.2024", "2024-01-31T10:00:00Z"}, Date, 1},
+		{"urls", []string{"https://example.org/a", "http://example.org", "ftp://files.example.org/x.csv"}, URL, 1},
+		{"text", []string{"Bern", "Thun", "3"}, Text, 1},
+		{"not a number", []string{"nan", "inf", "1"}, Text, 1},
+		{"only nulls", []string{"", "null", "-"}, Text, 0},
+	}
+	for _, tt := range tests {
+		c := Infer(tt.values, Options{})
+		if c.Type != tt.expected || c.Confidence != tt.confidence {
+			t.Errorf("%s: Infer() = %s (%.2f), want %s (%.2f)", tt.name, c.Type, c.Confidence, tt.expected, tt.confidence)
+		}
+	}
+
+	// A stray value lowers the confidence below the default threshold
+	values := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "unknown"}
+	if c := Infer(values, Options{}); c.Type != Text || c.Scores[Integer] != 0.9 {
+		t.Errorf("Expected text with an integer score of 0.9, got %s %v", c.Type, c.Scores)
+	}
+	if c := Infer(values, Options{Threshold: 0.9}); c.Type != Integer || c.Confidence != 0.9 {
+		t.Errorf("Expected integer with a lower threshold, got %s (%.2f)", c.Type, c.Confidence)
+	}
+	if c := Infer(values, Options{NullValues: []string{"Unknown"}}); c.Type != Integer || c.Nulls != 1 {
+		t.Errorf("Expected integer with custom null values, got %s with %d nulls", c.Type, c.Nulls)
+	}
+	if c := Infer(values, Options{SampleSize: 5}); c.Type != Integer || c.Values != 5 {
+		t.Errorf("Expected integer from a sample of 5, got %s from %d", c.Type, c.Values)
+	}
+}
+
+func TestInferColumns(t *testing.T) {
+	header := []string{"name", "population", "website"}
+	rows := [][]string{
+		{"Bern", "134600", "https://www.bern.ch"},
+		{"Thun", "43000"},
+		{"Biel", "N/A", "https://www.biel-bienne.ch"},
+	}
+	columns := InferColumns(header, rows, Options{})
+	got := make([]string, len(columns))
+	for i, c := range columns {
+		got[i] = c.Name + ":" + c.Type.String()
+	}
+	if strings.Join(got, " ") != "name:text population:integer website:url" {
+		t.Errorf("InferColumns() = %v", got)
+	}
+	if columns[2].Nulls != 1 || columns[2].Type.XSD() != "http://www.w3.org/2001/XMLSchema#anyURI" {
+		t.Errorf("Unexpected website column %+v", columns[2])
+	}
+
+	data, err := json.Marshal(columns[1])
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"type":"integer"`) || !strings.Contains(string(data), `"float":1`) {
+		t.Errorf("Expected types to be encoded by name, got %s", data)
+	}
+}
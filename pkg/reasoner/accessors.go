@@ -0,0 +1,71 @@
+package reasoner
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StringValue returns the lexical form of the first string literal of a
+// subject and predicate: a plain, xsd:string or language-tagged literal.
+// Literals of other datatypes are skipped.
+func (ts *TripleStore) StringValue(subject, predicate string) (string, bool) {
+	for _, t := range ts.FindBySubjectPredicate(subject, predicate) {
+		term := ParseTerm(t.Object)
+		if term.IsLiteral() && (term.Datatype == "" || term.Datatype == XSDString) {
+			return term.Value, true
+		}
+	}
+	return "", false
+}
+
+// IntValue returns the value of the first literal of a subject and predicate
+// typed xsd:integer or one of its derived types, such as xsd:int or
+// xsd:nonNegativeInteger. Literals of other datatypes, and values that do
+// not fit in an int64, are skipped.
+func (ts *TripleStore) IntValue(subject, predicate string) (int64, bool) {
+	for _, t := range ts.FindBySubjectPredicate(subject, predicate) {
+		term := ParseTerm(t.Object)
+		if !term.IsLiteral() || !isIntegerDatatype(term.Datatype) {
+			continue
+		}
+		if value, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(term.Value), "+"), 10, 64); err == nil {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// TimeValue returns the value of the first literal of a subject and
+// predicate typed xsd:dateTime, xsd:date or xsd:time, parsed as by
+// ParseDateTime. Untyped literals and invalid lexical forms are skipped.
+func (ts *TripleStore) TimeValue(subject, predicate string) (time.Time, bool) {
+	for _, t := range ts.FindBySubjectPredicate(subject, predicate) {
+		term := ParseTerm(t.Object)
+		if !term.IsLiteral() || (term.Datatype != XSDDateTime && term.Datatype != XSDDate && term.Datatype != XSDTime) {
+			continue
+		}
+		if value, err := ParseDateTime(t.Object); err == nil {
+			return value, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// IRIValues returns the IRI objects of a subject and predicate in store
+// order, skipping blank nodes and literals
+func (ts *TripleStore) IRIValues(subject, predicate string) []string {
+	var result []string
+	for _, t := range ts.FindBySubjectPredicate(subject, predicate) {
+		if ParseTerm(t.Object).IsIRI() {
+			result = append(result, t.Object)
+		}
+	}
+	return result
+}
+
+// isIntegerDatatype reports whether a datatype is xsd:integer or derived
+// from it
+func isIntegerDatatype(datatype string) bool {
+	return xsdNumericTypes[datatype] && datatype != XSDDecimal && datatype != XSDDouble && datatype != XSDFloat
+}
@@ -0,0 +1,75 @@
+package reasoner
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypedAccessors(t *testing.T) {
+	store := NewTripleStore()
+	for _, tr := range loadTriples(t, `@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:alice ex:name "Alice"@en ;
+    ex:nick "7"^^xsd:integer , "Al" ;
+    ex:age "forty"^^xsd:integer , "42"^^xsd:int ;
+    ex:height "1.70"^^xsd:decimal ;
+    ex:born "1990-05-17"^^xsd:date ;
+    ex:seen "yesterday" , "2024-01-02T03:04:05Z"^^xsd:dateTime ;
+    ex:knows _:b , ex:bob , "carol" , ex:dave .
+`) {
+		store.Add(tr)
+	}
+	const ex = "http://example.org/"
+
+	stringTests := []struct {
+		predicate string
+		want      string
+		ok        bool
+	}{
+		{ex + "name", "Alice", true},
+		{ex + "nick", "Al", true},
+		{ex + "age", "", false},
+		{ex + "missing", "", false},
+	}
+	for _, tt := range stringTests {
+		if got, ok := store.StringValue(ex+"alice", tt.predicate); got != tt.want || ok != tt.ok {
+			t.Errorf("StringValue(%s) = %q, %v; want %q, %v", tt.predicate, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	intTests := []struct {
+		predicate string
+		want      int64
+		ok        bool
+	}{
+		{ex + "age", 42, true},
+		{ex + "nick", 7, true},
+		{ex + "height", 0, false},
+		{ex + "name", 0, false},
+	}
+	for _, tt := range intTests {
+		if got, ok := store.IntValue(ex+"alice", tt.predicate); got != tt.want || ok != tt.ok {
+			t.Errorf("IntValue(%s) = %d, %v; want %d, %v", tt.predicate, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	timeTests := []struct {
+		predicate string
+		want      time.Time
+		ok        bool
+	}{
+		{ex + "born", time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC), true},
+		{ex + "seen", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), true},
+		{ex + "name", time.Time{}, false},
+	}
+	for _, tt := range timeTests {
+		if got, ok := store.TimeValue(ex+"alice", tt.predicate); !got.Equal(tt.want) || ok != tt.ok {
+			t.Errorf("TimeValue(%s) = %v, %v; want %v, %v", tt.predicate, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if got, want := store.IRIValues(ex+"alice", ex+"knows"), []string{ex + "bob", ex + "dave"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IRIValues() = %v, want %v", got, want)
+	}
+}
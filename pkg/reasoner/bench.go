@@ -0,0 +1,173 @@
+package reasoner
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/beyondcivic/goreasoner/pkg/version"
+)
+
+// BenchmarkReport records how the reasoner performed on a set of
+// scenarios, to be compared with the report of another version
+type BenchmarkReport struct {
+	Version   string              `json:"version"`
+	GoVersion string              `json:"goVersion"`
+	Scenarios []BenchmarkScenario `json:"scenarios"`
+}
+
+// BenchmarkScenario records the reasoning over one data set
+type BenchmarkScenario struct {
+	Name           string  `json:"name"`
+	Runs           int     `json:"runs"`
+	Triples        int     `json:"triples"`        // Triples loaded
+	Inferred       int     `json:"inferred"`       // Triples inferred
+	Milliseconds   float64 `json:"milliseconds"`   // Median time of reasoning
+	AllocatedBytes uint64  `json:"allocatedBytes"` // Median memory allocated while reasoning
+}
+
+// NewBenchmarkReport returns an empty report for this build
+func NewBenchmarkReport() BenchmarkReport {
+	return BenchmarkReport{Version: version.Version, GoVersion: runtime.Version()}
+}
+
+// MeasureScenario runs forward reasoning runs times on a reasoner returned
+// by setup, which loads the data of the scenario, and records the median
+// time and memory allocated. Loading is not measured.
+func MeasureScenario(name string, runs int, setup func() (*Reasoner, error)) (BenchmarkScenario, error) {
+	if runs < 1 {
+		return BenchmarkScenario{}, fmt.Errorf("invalid number of runs %d, must be at least 1", runs)
+	}
+
+	s := BenchmarkScenario{Name: name, Runs: runs}
+	durations := make([]float64, runs)
+	allocations := make([]uint64, runs)
+	for i := 0; i < runs; i++ {
+		r, err := setup()
+		if err != nil {
+			return BenchmarkScenario{}, fmt.Errorf("failed to set up scenario %s: %w", name, err)
+		}
+		s.Triples = r.GetStore().Size()
+
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		inferred := r.RunForwardReasoning()
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		if i > 0 && inferred != s.Inferred {
+			return BenchmarkScenario{}, fmt.Errorf("scenario %s inferred %d triples, then %d", name, s.Inferred, inferred)
+		}
+		s.Inferred = inferred
+		durations[i] = float64(elapsed.Microseconds()) / 1000
+		allocations[i] = after.TotalAlloc - before.TotalAlloc
+	}
+
+	sort.Float64s(durations)
+	sort.Slice(allocations, func(i, j int) bool { return allocations[i] < allocations[j] })
+	s.Milliseconds = durations[runs/2]
+	s.AllocatedBytes = allocations[runs/2]
+	return s, nil
+}
+
+// BenchmarkThresholds sets how much worse a scenario may perform before
+// CompareBenchmarks reports a regression
+type BenchmarkThresholds struct {
+	// Time and Memory are the largest accepted relative increases of the
+	// time and memory of a scenario, e.g. 0.1 for 10%
+	Time   float64
+	Memory float64
+	// MinMilliseconds ignores time increases smaller than this, which are
+	// within the noise of measuring short scenarios
+	MinMilliseconds float64
+}
+
+// ScenarioComparison compares the results of a scenario in two reports.
+// Baseline or Candidate is nil if the scenario is missing from that report.
+type ScenarioComparison struct {
+	Name         string             `json:"name"`
+	Baseline     *BenchmarkScenario `json:"baseline,omitempty"`
+	Candidate    *BenchmarkScenario `json:"candidate,omitempty"`
+	TimeChange   float64            `json:"timeChange"`   // Relative change of the time
+	MemoryChange float64            `json:"memoryChange"` // Relative change of the memory allocated
+	Regressions  []string           `json:"regressions,omitempty"`
+}
+
+// Regressed reports whether the scenario regressed
+func (c ScenarioComparison) Regressed() bool {
+	return len(c.Regressions) > 0
+}
+
+// CompareBenchmarks compares the scenarios of a candidate report with those
+// of a baseline by name, in the order of the baseline followed by scenarios
+// only in the candidate. A scenario regresses if its time or memory grew by
+// more than the thresholds, if it inferred a different number of triples,
+// which means the versions reason differently, or if it is missing from
+// the candidate.
+func CompareBenchmarks(baseline, candidate BenchmarkReport, thresholds BenchmarkThresholds) []ScenarioComparison {
+	byName := make(map[string]*BenchmarkScenario, len(candidate.Scenarios))
+	for i := range candidate.Scenarios {
+		byName[candidate.Scenarios[i].Name] = &candidate.Scenarios[i]
+	}
+
+	var comparisons []ScenarioComparison
+	compared := make(map[string]bool, len(baseline.Scenarios))
+	for i := range baseline.Scenarios {
+		o := &baseline.Scenarios[i]
+		compared[o.Name] = true
+		c := ScenarioComparison{Name: o.Name, Baseline: o, Candidate: byName[o.Name]}
+		if c.Candidate == nil {
+			c.Regressions = append(c.Regressions, "missing from the candidate report")
+			comparisons = append(comparisons, c)
+			continue
+		}
+
+		n := c.Candidate
+		c.TimeChange = relativeChange(o.Milliseconds, n.Milliseconds)
+		c.MemoryChange = relativeChange(float64(o.AllocatedBytes), float64(n.AllocatedBytes))
+		if c.TimeChange > thresholds.Time && n.Milliseconds-o.Milliseconds >= thresholds.MinMilliseconds {
+			c.Regressions = append(c.Regressions, fmt.Sprintf("time %+.1f%% (%.2f ms to %.2f ms)",
+				100*c.TimeChange, o.Milliseconds, n.Milliseconds))
+		}
+		if c.MemoryChange > thresholds.Memory {
+			c.Regressions = append(c.Regressions, fmt.Sprintf("memory %+.1f%% (%s to %s)",
+				100*c.MemoryChange, formatBytes(o.AllocatedBytes), formatBytes(n.AllocatedBytes)))
+		}
+		if o.Inferred != n.Inferred {
+			c.Regressions = append(c.Regressions, fmt.Sprintf("inferred %d triples instead of %d", n.Inferred, o.Inferred))
+		}
+		comparisons = append(comparisons, c)
+	}
+	for i := range candidate.Scenarios {
+		if n := &candidate.Scenarios[i]; !compared[n.Name] {
+			comparisons = append(comparisons, ScenarioComparison{Name: n.Name, Candidate: n})
+		}
+	}
+	return comparisons
+}
+
+// relativeChange returns the change from a to b relative to a, or 0 if a
+// is 0
+func relativeChange(a, b float64) float64 {
+	if a == 0 {
+		return 0
+	}
+	return (b - a) / a
+}
+
+// formatBytes formats a byte count for humans
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
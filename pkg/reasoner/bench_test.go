@@ -0,0 +1,74 @@
+package reasoner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMeasureScenario(t *testing.T) {
+	setup := func() (*Reasoner, error) {
+		r := NewReasoner()
+		return r, r.LoadTurtle(sampleSchema + sampleData(10))
+	}
+	s, err := MeasureScenario("customers", 3, setup)
+	if err != nil {
+		t.Fatalf("MeasureScenario failed: %v", err)
+	}
+	if s.Name != "customers" || s.Runs != 3 || s.Triples == 0 || s.Inferred == 0 || s.AllocatedBytes == 0 {
+		t.Errorf("Unexpected scenario %+v", s)
+	}
+
+	if _, err := MeasureScenario("customers", 0, setup); err == nil {
+		t.Errorf("Expected an error for 0 runs")
+	}
+	failed := errors.New("no data")
+	if _, err := MeasureScenario("broken", 1, func() (*Reasoner, error) { return nil, failed }); !errors.Is(err, failed) {
+		t.Errorf("Expected the setup error, got %v", err)
+	}
+}
+
+func TestCompareBenchmarks(t *testing.T) {
+	baseline := BenchmarkReport{Scenarios: []BenchmarkScenario{
+		{Name: "stable", Inferred: 10, Milliseconds: 100, AllocatedBytes: 1 << 20},
+		{Name: "slower", Inferred: 10, Milliseconds: 100, AllocatedBytes: 1 << 20},
+		{Name: "noise", Inferred: 10, Milliseconds: 0.2, AllocatedBytes: 1 << 20},
+		{Name: "changed", Inferred: 10, Milliseconds: 100, AllocatedBytes: 1 << 20},
+		{Name: "removed", Inferred: 10, Milliseconds: 100, AllocatedBytes: 1 << 20},
+	}}
+	candidate := BenchmarkReport{Scenarios: []BenchmarkScenario{
+		{Name: "added", Inferred: 10, Milliseconds: 100, AllocatedBytes: 1 << 20},
+		{Name: "changed", Inferred: 12, Milliseconds: 90, AllocatedBytes: 1 << 20},
+		{Name: "noise", Inferred: 10, Milliseconds: 0.5, AllocatedBytes: 1 << 20},
+		{Name: "slower", Inferred: 10, Milliseconds: 150, AllocatedBytes: 3 << 20},
+		{Name: "stable", Inferred: 10, Milliseconds: 105, AllocatedBytes: 1 << 20},
+	}}
+
+	comparisons := CompareBenchmarks(baseline, candidate, BenchmarkThresholds{Time: 0.1, Memory: 0.1, MinMilliseconds: 1})
+	expected := []struct {
+		name        string
+		regressions string
+	}{
+		{"stable", ""},
+		{"slower", "time +50.0% (100.00 ms to 150.00 ms); memory +200.0% (1.0 MiB to 3.0 MiB)"},
+		{"noise", ""},
+		{"changed", "inferred 12 triples instead of 10"},
+		{"removed", "missing from the candidate report"},
+		{"added", ""},
+	}
+	if len(comparisons) != len(expected) {
+		t.Fatalf("Expected %d comparisons, got %+v", len(expected), comparisons)
+	}
+	for i, want := range expected {
+		c := comparisons[i]
+		if got := strings.Join(c.Regressions, "; "); c.Name != want.name || got != want.regressions {
+			t.Errorf("Comparison %d = %s: %q, want %s: %q", i, c.Name, got, want.name, want.regressions)
+		}
+		if c.Regressed() != (want.regressions != "") {
+			t.Errorf("%s: Regressed() = %v", c.Name, c.Regressed())
+		}
+	}
+	if comparisons[1].TimeChange != 0.5 || comparisons[5].Baseline != nil {
+		t.Errorf("Unexpected comparisons %+v and %+v", comparisons[1], comparisons[5])
+	}
+}
@@ -0,0 +1,217 @@
+package reasoner
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Binding maps variable names, without the leading "?", to terms
+type Binding map[string]string
+
+// IsVariable reports whether a pattern position is a variable such as "?x"
+func IsVariable(s string) bool {
+	return len(s) > 1 && s[0] == '?'
+}
+
+// MatchBGP matches a basic graph pattern, a list of triple patterns that
+// must all hold, against the store. Pattern positions are terms, variables
+// such as "?x", which must take the same value wherever they occur, or ""
+// for any term. It returns one binding per solution, in no particular
+// order. Patterns are joined in order of selectivity, each looked up
+// through the store's indexes with the variables bound so far.
+func (ts *TripleStore) MatchBGP(patterns []TriplePattern) []Binding {
+	return ts.matchBGP(patterns, nil)
+}
+
+// matchBGP implements MatchBGP, recording each step in plan if it is not
+// nil
+func (ts *TripleStore) matchBGP(patterns []TriplePattern, plan *QueryPlan) []Binding {
+	if len(patterns) == 0 {
+		return nil
+	}
+	solutions := []Binding{{}}
+	remaining := append([]TriplePattern(nil), patterns...)
+	bound := make(map[string]bool)
+	estimated := 1.0
+
+	// A plan covers every pattern, even once no solutions are left
+	for len(remaining) > 0 && (len(solutions) > 0 || plan != nil) {
+		next := mostSelective(remaining, bound)
+		pattern := remaining[next]
+		remaining = append(remaining[:next], remaining[next+1:]...)
+
+		var extended []Binding
+		for _, b := range solutions {
+			s, p, o := b.resolve(pattern.Subject), b.resolve(pattern.Predicate), b.resolve(pattern.Object)
+			for _, t := range ts.match(s, p, o) {
+				if nb, ok := b.extend(s, t.Subject, p, t.Predicate, o, t.Object); ok {
+					extended = append(extended, nb)
+				}
+			}
+		}
+		if plan != nil {
+			estimated *= ts.estimateMatches(pattern, bound)
+			plan.Steps = append(plan.Steps, ts.explainStep(pattern, bound, solutions, len(extended), estimated))
+		}
+		solutions = extended
+
+		for _, v := range []string{pattern.Subject, pattern.Predicate, pattern.Object} {
+			if IsVariable(v) {
+				bound[v] = true
+			}
+		}
+	}
+	return solutions
+}
+
+// mostSelective returns the index of the pattern with the most positions
+// that are terms or already bound variables, preferring bound subjects and
+// objects over predicates, which are rarely selective
+func mostSelective(patterns []TriplePattern, bound map[string]bool) int {
+	fixed := func(s string) bool { return s != "" && (!IsVariable(s) || bound[s]) }
+	best, bestScore := 0, -1
+	for i, p := range patterns {
+		score := 0
+		if fixed(p.Subject) {
+			score += 4
+		}
+		if fixed(p.Object) {
+			score += 3
+		}
+		if fixed(p.Predicate) {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// match returns the triples matching terms, where variables and "" match
+// anything, using the most specific index
+func (ts *TripleStore) match(s, p, o string) []Triple {
+	fixed := func(x string) bool { return x != "" && !IsVariable(x) }
+	var candidates []Triple
+	switch {
+	case fixed(s) && fixed(p):
+		candidates = ts.FindBySubjectPredicate(s, p)
+	case fixed(p) && fixed(o):
+		return ts.FindByPredicateObject(p, o)
+	case fixed(s):
+		candidates = ts.FindBySubject(s)
+	case fixed(o):
+		candidates = ts.FindByObject(o)
+	case fixed(p):
+		return ts.FindByPredicate(p)
+	default:
+		return ts.All()
+	}
+
+	var result []Triple
+	for _, t := range candidates {
+		if (!fixed(p) || t.Predicate == p) && (!fixed(o) || ts.objectKey(t.Predicate, t.Object) == ts.objectKey(t.Predicate, o)) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// resolve returns the value of a bound variable, or the position unchanged
+func (b Binding) resolve(position string) string {
+	if IsVariable(position) {
+		if value, ok := b[position[1:]]; ok {
+			return value
+		}
+	}
+	return position
+}
+
+// extend returns a copy of the binding with the variables among the
+// positions bound to the corresponding terms of a triple, or false if a
+// variable repeated within the pattern would need two values
+func (b Binding) extend(positionsAndTerms ...string) (Binding, bool) {
+	nb := make(Binding, len(b)+3)
+	for k, v := range b {
+		nb[k] = v
+	}
+	for i := 0; i < len(positionsAndTerms); i += 2 {
+		position, term := positionsAndTerms[i], positionsAndTerms[i+1]
+		if !IsVariable(position) {
+			continue
+		}
+		if value, ok := nb[position[1:]]; ok && value != term {
+			return nil, false
+		}
+		nb[position[1:]] = term
+	}
+	return nb, true
+}
+
+// String formats the binding as ?name=term pairs sorted by name
+func (b Binding) String() string {
+	names := make([]string, 0, len(b))
+	for name := range b {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = "?" + name + "=" + b[name]
+	}
+	return strings.Join(pairs, " ")
+}
+
+// WriteBindingsCSV writes bindings as CSV, in the SPARQL 1.1 CSV results
+// format: a header row with the variable names, sorted, then one row per
+// binding. IRIs are written bare, blank nodes as _:label and literals as
+// their lexical form without datatype or language tag; unbound variables
+// are left empty.
+func WriteBindingsCSV(w io.Writer, bindings []Binding) error {
+	seen := make(map[string]bool)
+	var names []string
+	for _, b := range bindings {
+		for name := range b {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if err := cw.Write(names); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	row := make([]string, len(names))
+	for _, b := range bindings {
+		for i, name := range names {
+			row[i] = ""
+			if value, ok := b[name]; ok {
+				row[i] = csvValue(value)
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// csvValue formats a term for the CSV results format
+func csvValue(term string) string {
+	t := ParseTerm(term)
+	if t.Kind == TermBlankNode {
+		return term
+	}
+	return t.Value
+}
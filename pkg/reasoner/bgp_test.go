@@ -0,0 +1,82 @@
+package reasoner
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+const bgpData = `@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:Employee rdfs:subClassOf ex:Person .
+ex:alice a ex:Employee ; ex:worksFor ex:acme ; ex:knows ex:bob .
+ex:bob a ex:Person ; ex:worksFor ex:globex ; ex:knows ex:bob .
+ex:carol a ex:Employee ; ex:worksFor ex:acme .
+ex:acme ex:name "ACME" .
+`
+
+func bindingStrings(bindings []Binding) []string {
+	result := make([]string, len(bindings))
+	for i, b := range bindings {
+		result[i] = strings.ReplaceAll(b.String(), "http://example.org/", "ex:")
+	}
+	return result
+}
+
+func TestMatchBGP(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(bgpData); err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+	r.RunForwardReasoning()
+
+	ex := "http://example.org/"
+	tests := []struct {
+		name     string
+		patterns []TriplePattern
+		want     []string
+	}{
+		{"join", []TriplePattern{
+			{Subject: "?p", Predicate: RDFType, Object: ex + "Person"},
+			{Subject: "?p", Predicate: ex + "worksFor", Object: "?org"},
+			{Subject: "?org", Predicate: ex + "name", Object: "?name"},
+		}, []string{`?name="ACME" ?org=ex:acme ?p=ex:alice`, `?name="ACME" ?org=ex:acme ?p=ex:carol`}},
+		{"repeated variable", []TriplePattern{
+			{Subject: "?x", Predicate: ex + "knows", Object: "?x"},
+		}, []string{"?x=ex:bob"}},
+		{"wildcard", []TriplePattern{
+			{Subject: ex + "acme", Predicate: "", Object: "?v"},
+		}, []string{`?v="ACME"`}},
+		{"no match", []TriplePattern{
+			{Subject: "?p", Predicate: ex + "worksFor", Object: ex + "initech"},
+		}, nil},
+		{"no patterns", nil, nil},
+	}
+
+	for _, tt := range tests {
+		got := bindingStrings(r.GetStore().MatchBGP(tt.patterns))
+		sort.Strings(got)
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: MatchBGP() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWriteBindingsCSV(t *testing.T) {
+	bindings := []Binding{
+		{"org": "http://example.org/acme", "name": `"ACME, Inc."`},
+		{"org": "_:b0", "name": `"Stadt \"Bern\""@de`, "founded": `"1191"^^<http://www.w3.org/2001/XMLSchema#gYear>`},
+		{"org": "http://example.org/initech"},
+	}
+	var sb strings.Builder
+	if err := WriteBindingsCSV(&sb, bindings); err != nil {
+		t.Fatalf("WriteBindingsCSV failed: %v", err)
+	}
+	want := "founded,name,org\r\n" +
+		",\"ACME, Inc.\",http://example.org/acme\r\n" +
+		"1191,\"Stadt \"\"Bern\"\"\",_:b0\r\n" +
+		",,http://example.org/initech\r\n"
+	if sb.String() != want {
+		t.Errorf("WriteBindingsCSV() = %q, want %q", sb.String(), want)
+	}
+}
@@ -0,0 +1,52 @@
+package reasoner
+
+// BlobThreshold is the length in bytes above which a literal is stored out
+// of line, as a blob: the store keeps one copy of its text, shared by every
+// triple it is the object of and by the object index, and keys triples and
+// the predicate-object index with a digest of the text instead of the text
+// itself. Datasets embedding documents as literals then take about the
+// size of their text, instead of a copy per triple and per key.
+const BlobThreshold = 256
+
+// blobKeyPrefix starts the digests standing for blobs in keys; no term
+// starts with a NUL byte
+const blobKeyPrefix = "\x00blob:"
+
+// BlobStats describes the literals a store holds out of line
+type BlobStats struct {
+	Blobs int   // Distinct literals longer than BlobThreshold
+	Bytes int64 // Total length of their text
+}
+
+// termKey returns the term, or a digest of it if it is a blob, for
+// building keys
+func termKey(term string) string {
+	if len(term) <= BlobThreshold {
+		return term
+	}
+	return blobKeyPrefix + hashString(term)
+}
+
+// internBlob returns the stored copy of a blob, storing it if it is new,
+// so that equal blobs share their text
+func (ts *TripleStore) internBlob(term string) string {
+	key := termKey(term)
+	if stored, ok := ts.blobs[key]; ok {
+		return stored
+	}
+	if ts.blobs == nil {
+		ts.blobs = make(map[string]string)
+	}
+	ts.blobs[key] = term
+	return term
+}
+
+// BlobStats returns the number and size of the literals stored out of line
+func (ts *TripleStore) BlobStats() BlobStats {
+	var stats BlobStats
+	for _, term := range ts.blobs {
+		stats.Blobs++
+		stats.Bytes += int64(len(term))
+	}
+	return stats
+}
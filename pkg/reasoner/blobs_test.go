@@ -0,0 +1,65 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestBlobs(t *testing.T) {
+	ex := "http://example.org/"
+	text := NewLiteral(strings.Repeat("lorem ipsum ", 1000), "").String()
+	// Parsed documents hold separate copies of equal literals
+	copied := string([]byte(text))
+
+	store := NewTripleStore()
+	store.Add(Triple{Subject: ex + "a", Predicate: ex + "text", Object: text})
+	store.Add(Triple{Subject: ex + "b", Predicate: ex + "text", Object: copied})
+	store.Add(Triple{Subject: ex + "b", Predicate: ex + "title", Object: `"short"`})
+
+	if stats := store.BlobStats(); stats.Blobs != 1 || stats.Bytes != int64(len(text)) {
+		t.Errorf("BlobStats() = %+v, want one blob of %d bytes", stats, len(text))
+	}
+	found := store.FindByObject(copied)
+	if len(found) != 2 || unsafe.StringData(found[0].Object) != unsafe.StringData(found[1].Object) {
+		t.Errorf("Expected both triples to share the stored text, got %d triples", len(found))
+	}
+	if !store.Contains(Triple{Subject: ex + "b", Predicate: ex + "text", Object: text}) {
+		t.Errorf("Expected the triple to be found by its full text")
+	}
+	if store.Add(Triple{Subject: ex + "a", Predicate: ex + "text", Object: copied}) {
+		t.Errorf("Expected a duplicate triple with a long literal not to be added")
+	}
+	if key := tripleKey(found[0]); len(key) > 100 {
+		t.Errorf("Expected a short key, got %d bytes", len(key))
+	}
+
+	frozen := store.Freeze()
+	if len(frozen.FindByPredicateObject(ex+"text", copied)) != 2 || frozen.BlobStats().Blobs != 1 {
+		t.Errorf("Expected the frozen store to find the long literals")
+	}
+
+	// Committed transactions keep the blobs of the staged triples
+	txn := store.Begin()
+	txn.Add(Triple{Subject: ex + "c", Predicate: ex + "text", Object: text + " "})
+	if err := txn.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if stats := store.BlobStats(); stats.Blobs != 2 {
+		t.Errorf("BlobStats() = %+v after committing a new blob", stats)
+	}
+	store.removeAll(map[string]bool{tripleKey(Triple{Subject: ex + "c", Predicate: ex + "text", Object: text + " "}): true})
+
+	// Blobs no longer used are dropped
+	store.removeAll(map[string]bool{tripleKey(found[0]): true, tripleKey(found[1]): true})
+	if stats := store.BlobStats(); stats.Blobs != 0 {
+		t.Errorf("BlobStats() = %+v after removing the triples", stats)
+	}
+
+	// Keys of long literals are counted as digests
+	short := []Triple{{Subject: ex + "a", Predicate: ex + "text", Object: `"short"`}}
+	long := []Triple{{Subject: ex + "a", Predicate: ex + "text", Object: text}}
+	if overhead := EstimateStoreMemory(long) - EstimateStoreMemory(short); overhead > int64(len(text))+100 {
+		t.Errorf("Expected the text to be counted once, got %d bytes more for %d bytes", overhead, len(text))
+	}
+}
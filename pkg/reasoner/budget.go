@@ -0,0 +1,81 @@
+package reasoner
+
+import (
+	"fmt"
+	"time"
+)
+
+// RuleBudget limits the work a single rule may do in one reasoning run.
+// A rule that exhausts its budget is disabled for the rest of the run and
+// reported with a rule-disabled diagnostic, so one pathological rule, such
+// as owl:sameAs transitivity over a huge clique, cannot starve the others.
+// Zero values mean no limit.
+type RuleBudget struct {
+	// MaxInferences limits the number of triples the rule adds
+	MaxInferences int
+	// MaxDuration limits the total time spent applying the rule. It is
+	// checked between applications, so a single application can overrun it.
+	MaxDuration time.Duration
+}
+
+// WithRuleBudget limits the rule with the given name, or every rule without
+// a budget of its own if ruleName is empty. A zero budget for a named rule
+// exempts it from the default.
+func WithRuleBudget(ruleName string, budget RuleBudget) Option {
+	return func(r *Reasoner) {
+		if r.budgets == nil {
+			r.budgets = make(map[string]RuleBudget)
+		}
+		r.budgets[ruleName] = budget
+	}
+}
+
+// ruleUsage tracks the budget of a rule during one reasoning run
+type ruleUsage struct {
+	budget   RuleBudget
+	added    int
+	elapsed  time.Duration
+	disabled bool
+}
+
+// newRuleUsage returns the budget tracking for a rule, or nil if it has no
+// budget
+func (r *Reasoner) newRuleUsage(name string) *ruleUsage {
+	budget, ok := r.budgets[name]
+	if !ok {
+		budget, ok = r.budgets[""]
+	}
+	if !ok || budget == (RuleBudget{}) {
+		return nil
+	}
+	return &ruleUsage{budget: budget}
+}
+
+// allows reports whether the rule may add another triple
+func (u *ruleUsage) allows() bool {
+	return u == nil || u.budget.MaxInferences <= 0 || u.added < u.budget.MaxInferences
+}
+
+// exhausted reports why the rule has used up its budget, or "" if it has not
+func (u *ruleUsage) exhausted() string {
+	switch {
+	case u == nil:
+		return ""
+	case u.budget.MaxInferences > 0 && u.added >= u.budget.MaxInferences:
+		return fmt.Sprintf("reached its limit of %d inferences", u.budget.MaxInferences)
+	case u.budget.MaxDuration > 0 && u.elapsed >= u.budget.MaxDuration:
+		return fmt.Sprintf("ran for %s, exceeding its limit of %s", u.elapsed.Round(time.Millisecond), u.budget.MaxDuration)
+	default:
+		return ""
+	}
+}
+
+// disableRule records that a rule was disabled for the run
+func (r *Reasoner) disableRule(name, reason string) {
+	r.runDiagnostics = append(r.runDiagnostics, Diagnostic{
+		Severity:   SeverityWarning,
+		Code:       CodeRuleDisabled,
+		Message:    fmt.Sprintf("rule %s was disabled for this run: it %s; the results may be incomplete", name, reason),
+		Suggestion: "raise the rule's budget or remove the data that makes it expensive",
+	})
+}
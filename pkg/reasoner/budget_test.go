@@ -0,0 +1,78 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+// sameAsChain links ex:a0 to ex:a5 with owl:sameAs, whose closure has 30
+// sameAs triples between distinct individuals
+const sameAsChain = `@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:a0 owl:sameAs ex:a1 . ex:a1 owl:sameAs ex:a2 . ex:a2 owl:sameAs ex:a3 .
+ex:a3 owl:sameAs ex:a4 . ex:a4 owl:sameAs ex:a5 .
+ex:Dog rdfs:subClassOf ex:Animal .
+ex:rex a ex:Dog .
+`
+
+func TestRuleBudget(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantDisabled string
+	}{
+		{"no budget", nil, ""},
+		{"generous budget", []Option{WithRuleBudget("", RuleBudget{MaxInferences: 1000})}, ""},
+		{"inference limit", []Option{WithRuleBudget("owl:sameAs-transitivity", RuleBudget{MaxInferences: 3})}, "owl:sameAs-transitivity"},
+		{"default limit", []Option{WithRuleBudget("", RuleBudget{MaxInferences: 3}), WithRuleBudget("owl:sameAs-transitivity", RuleBudget{})}, "owl:sameAs-symmetry"},
+		{"time limit", []Option{WithRuleBudget("owl:sameAs-symmetry", RuleBudget{MaxDuration: 1})}, "owl:sameAs-symmetry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReasoner(tt.opts...)
+			if err := r.LoadTurtle(sameAsChain); err != nil {
+				t.Fatalf("LoadTurtle() error = %v", err)
+			}
+			r.RunForwardReasoning()
+
+			var disabled []string
+			for _, d := range r.Diagnostics() {
+				if d.Code == CodeRuleDisabled {
+					disabled = append(disabled, d.Message)
+				}
+			}
+			if tt.wantDisabled == "" {
+				if len(disabled) > 0 {
+					t.Errorf("rules disabled: %q", disabled)
+				}
+			} else if len(disabled) != 1 || !strings.Contains(disabled[0], "rule "+tt.wantDisabled+" ") {
+				t.Errorf("rules disabled: %q, want %s", disabled, tt.wantDisabled)
+			}
+
+			// Other rules still run to completion
+			if types := r.GetInferredTypes("http://example.org/rex"); len(types) != 2 {
+				t.Errorf("GetInferredTypes(ex:rex) = %v, want Dog and Animal", types)
+			}
+		})
+	}
+}
+
+func TestRuleBudgetLimitsInferences(t *testing.T) {
+	r := NewReasoner(WithRuleBudget("owl:sameAs-transitivity", RuleBudget{MaxInferences: 3}))
+	if err := r.LoadTurtle(sameAsChain); err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+
+	full := NewReasoner()
+	if err := full.LoadTurtle(sameAsChain); err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+	full.RunForwardReasoning()
+
+	r.RunForwardReasoning()
+	if got, all := len(r.Query("", OWLSameAs, "")), len(full.Query("", OWLSameAs, "")); got >= all {
+		t.Errorf("budgeted run has %d sameAs triples, want fewer than %d", got, all)
+	}
+}
@@ -0,0 +1,104 @@
+package reasoner
+
+import "container/list"
+
+// QueryCacheStats reports the effectiveness of the query-result cache
+type QueryCacheStats struct {
+	Hits     uint64 // Lookups answered from the cache
+	Misses   uint64 // Lookups that had to scan the store
+	Size     int    // Number of cached patterns
+	Capacity int    // Maximum number of cached patterns
+}
+
+// queryCache is an in-process LRU cache of Query results.
+// Entries are only valid for the store generation they were computed against;
+// any mutation of the store invalidates the whole cache.
+type queryCache struct {
+	capacity   int
+	generation uint64
+	order      *list.List
+	entries    map[string]*list.Element
+	hits       uint64
+	misses     uint64
+}
+
+type queryCacheEntry struct {
+	key     string
+	triples []Triple
+}
+
+func newQueryCache(capacity int) *queryCache {
+	return &queryCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// queryCacheKey builds the cache key for a subject/predicate/object pattern
+func queryCacheKey(subject, predicate, object string) string {
+	return subject + "|" + predicate + "|" + termKey(object)
+}
+
+// get returns the cached result for key if it is still valid for generation
+func (c *queryCache) get(key string, generation uint64) ([]Triple, bool) {
+	c.invalidateIfStale(generation)
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(elem)
+	return copyTriples(elem.Value.(*queryCacheEntry).triples), true
+}
+
+// put stores a result computed against the given store generation
+func (c *queryCache) put(key string, generation uint64, triples []Triple) {
+	c.invalidateIfStale(generation)
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*queryCacheEntry).triples = copyTriples(triples)
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&queryCacheEntry{key: key, triples: copyTriples(triples)})
+	c.entries[key] = elem
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*queryCacheEntry).key)
+	}
+}
+
+func (c *queryCache) invalidateIfStale(generation uint64) {
+	if generation == c.generation {
+		return
+	}
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	c.generation = generation
+}
+
+func (c *queryCache) stats() QueryCacheStats {
+	return QueryCacheStats{
+		Hits:     c.hits,
+		Misses:   c.misses,
+		Size:     c.order.Len(),
+		Capacity: c.capacity,
+	}
+}
+
+// copyTriples returns a copy so callers cannot mutate cached results
+func copyTriples(triples []Triple) []Triple {
+	if triples == nil {
+		return nil
+	}
+	result := make([]Triple, len(triples))
+	copy(result, triples)
+	return result
+}
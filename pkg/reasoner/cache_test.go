@@ -0,0 +1,43 @@
+package reasoner
+
+import (
+	"testing"
+)
+
+func TestQueryCache(t *testing.T) {
+	r := NewReasoner()
+	r.EnableQueryCache(2)
+
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+ex:alice a ex:Person .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	if got := len(r.Query("", RDFType, "")); got != 1 {
+		t.Errorf("Expected 1 result, got %d", got)
+	}
+	if got := len(r.Query("", RDFType, "")); got != 1 {
+		t.Errorf("Expected 1 cached result, got %d", got)
+	}
+
+	stats := r.QueryCacheStats()
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Expected 1 hit and 1 miss, got %d hits and %d misses", stats.Hits, stats.Misses)
+	}
+
+	// Mutating the store must invalidate cached results
+	r.GetStore().Add(Triple{Subject: "http://example.org/bob", Predicate: RDFType, Object: "http://example.org/Person"})
+	if got := len(r.Query("", RDFType, "")); got != 2 {
+		t.Errorf("Expected 2 results after mutation, got %d", got)
+	}
+
+	// Least recently used patterns are evicted beyond capacity
+	r.Query("http://example.org/alice", "", "")
+	r.Query("http://example.org/bob", "", "")
+	if stats := r.QueryCacheStats(); stats.Size != 2 {
+		t.Errorf("Expected cache size 2, got %d", stats.Size)
+	}
+}
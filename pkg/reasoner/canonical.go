@@ -0,0 +1,193 @@
+package reasoner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// DefaultSkolemBase is the IRI prefix used when Skolemize is called with an
+// empty base. RDF 1.1 recommends a "/.well-known/genid/" path under an
+// authority controlled by the publisher, which callers should prefer.
+const DefaultSkolemBase = "urn:genid:"
+
+// isBlankNode reports whether a term is a blank node label
+func isBlankNode(term string) bool {
+	return strings.HasPrefix(term, "_:")
+}
+
+// Skolemize replaces every blank node in the store with an IRI derived from
+// a hash of the blank node's surrounding triples. Blank nodes with the same
+// description in repeated runs therefore always receive the same IRI,
+// regardless of the labels chosen by the parser. The sources of the renamed
+// triples are kept, and subscribers are not notified of them.
+// Returns the number of blank nodes replaced, 0 for a frozen store.
+func (ts *TripleStore) Skolemize(base string) int {
+	if ts.frozen {
+		return 0
+	}
+	if base == "" {
+		base = DefaultSkolemBase
+	}
+
+	triples := ts.All()
+	labels := canonicalBlankNodeLabels(triples)
+	if len(labels) == 0 {
+		return 0
+	}
+
+	rename := func(term string) string {
+		if label, ok := labels[term]; ok {
+			return base + label
+		}
+		return term
+	}
+
+	// Like removeAll, the store is rebuilt without notifying subscribers,
+	// the sources follow the renamed triples and only renamed triples are
+	// recorded in the history
+	history := ts.history
+	sources := make(map[string][]string, len(ts.sources))
+	renamed := make([]Triple, len(triples))
+	for i, t := range triples {
+		renamed[i] = Triple{Subject: rename(t.Subject), Predicate: t.Predicate, Object: rename(t.Object), Graph: rename(t.Graph)}
+		if files, ok := ts.sources[tripleKey(t)]; ok {
+			sources[tripleKey(renamed[i])] = files
+		}
+		if history != nil && renamed[i] != t {
+			history.record(t, true)
+		}
+	}
+
+	ts.reset()
+	for i, t := range renamed {
+		if t == triples[i] {
+			ts.history = nil
+		} else {
+			ts.history = history
+		}
+		ts.add(t)
+	}
+	ts.sources = sources
+	ts.history = history
+
+	return len(labels)
+}
+
+// canonicalBlankNodeLabels computes a deterministic, content-derived label
+// for each blank node in triples.
+//
+// Labels are found by iteratively hashing each blank node together with the
+// triples it occurs in, as subject, object or graph label (color refinement). Blank nodes that remain
+// indistinguishable are split one at a time in label order and refinement is
+// repeated, so every blank node ends up with a distinct label.
+func canonicalBlankNodeLabels(triples []Triple) map[string]string {
+	incident := make(map[string][]Triple)
+	for _, t := range triples {
+		if isBlankNode(t.Subject) {
+			incident[t.Subject] = append(incident[t.Subject], t)
+		}
+		if isBlankNode(t.Object) && t.Object != t.Subject {
+			incident[t.Object] = append(incident[t.Object], t)
+		}
+		if isBlankNode(t.Graph) && t.Graph != t.Subject && t.Graph != t.Object {
+			incident[t.Graph] = append(incident[t.Graph], t)
+		}
+	}
+	if len(incident) == 0 {
+		return nil
+	}
+
+	nodes := make([]string, 0, len(incident))
+	for b := range incident {
+		nodes = append(nodes, b)
+	}
+	sort.Strings(nodes)
+
+	hashes := make(map[string]string, len(nodes))
+	for _, b := range nodes {
+		hashes[b] = ""
+	}
+
+	refineBlankNodeHashes(nodes, incident, hashes)
+
+	for {
+		// Find the smallest hash shared by more than one blank node
+		members := make(map[string][]string)
+		for _, b := range nodes {
+			members[hashes[b]] = append(members[hashes[b]], b)
+		}
+		tied := ""
+		for h, bs := range members {
+			if len(bs) > 1 && (tied == "" || h < tied) {
+				tied = h
+			}
+		}
+		if tied == "" {
+			break
+		}
+
+		// Individualize the first tied node and refine again
+		chosen := members[tied][0]
+		hashes[chosen] = hashString(tied + "|individualized")
+		refineBlankNodeHashes(nodes, incident, hashes)
+	}
+
+	return hashes
+}
+
+// refineBlankNodeHashes rehashes blank nodes from their neighbourhoods until
+// the number of distinct hashes stops growing
+func refineBlankNodeHashes(nodes []string, incident map[string][]Triple, hashes map[string]string) {
+	distinct := countDistinct(hashes)
+
+	for range nodes {
+		next := make(map[string]string, len(nodes))
+		for _, b := range nodes {
+			term := func(x string) string {
+				if x == b {
+					return "_:self"
+				}
+				if isBlankNode(x) {
+					return "_:" + hashes[x]
+				}
+				return x
+			}
+
+			signatures := make([]string, 0, len(incident[b]))
+			for _, t := range incident[b] {
+				signature := term(t.Subject) + " " + t.Predicate + " " + term(t.Object)
+				if t.Graph != "" {
+					signature += " " + term(t.Graph)
+				}
+				signatures = append(signatures, signature)
+			}
+			sort.Strings(signatures)
+			next[b] = hashString(hashes[b] + "\n" + strings.Join(signatures, "\n"))
+		}
+
+		for b, h := range next {
+			hashes[b] = h
+		}
+
+		newDistinct := countDistinct(hashes)
+		if newDistinct == distinct {
+			return
+		}
+		distinct = newDistinct
+	}
+}
+
+func countDistinct(hashes map[string]string) int {
+	seen := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		seen[h] = true
+	}
+	return len(seen)
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,102 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSkolemize(t *testing.T) {
+	load := func(content string) *TripleStore {
+		r := NewReasoner()
+		if err := r.LoadTurtle(content); err != nil {
+			t.Fatalf("LoadTurtle failed: %v", err)
+		}
+		return r.GetStore()
+	}
+
+	// The same graph with different blank node labels and statement order
+	first := load(`
+@prefix ex: <http://example.org/> .
+ex:alice ex:address _:a1 .
+_:a1 ex:city "Zürich" .
+_:a2 ex:next _:a3 .
+_:a3 ex:next _:a2 .
+`)
+	second := load(`
+@prefix ex: <http://example.org/> .
+_:x ex:next _:y .
+_:y ex:next _:x .
+_:b ex:city "Zürich" .
+ex:alice ex:address _:b .
+`)
+
+	base := "http://example.org/.well-known/genid/"
+	if n := first.Skolemize(base); n != 3 {
+		t.Errorf("Expected 3 blank nodes replaced, got %d", n)
+	}
+	second.Skolemize(base)
+
+	serialize := func(ts *TripleStore) string {
+		r := &Reasoner{store: ts}
+		return strings.Join(r.GetAllTriples(), "\n")
+	}
+
+	if serialize(first) != serialize(second) {
+		t.Errorf("Skolemized graphs differ:\n%s\n---\n%s", serialize(first), serialize(second))
+	}
+	if strings.Contains(serialize(first), "_:") {
+		t.Errorf("Blank nodes remain after skolemization:\n%s", serialize(first))
+	}
+	if first.Size() != 4 {
+		t.Errorf("Expected 4 triples after skolemization, got %d", first.Size())
+	}
+}
+
+func TestSkolemizeKeepsSources(t *testing.T) {
+	r := NewReasoner(WithProvenance())
+	if err := r.LoadTurtleFrom("a.ttl", `
+@prefix ex: <http://example.org/> .
+ex:alice ex:address _:a .
+_:a ex:city "Zürich" .
+`); err != nil {
+		t.Fatalf("LoadTurtleFrom failed: %v", err)
+	}
+	store := r.GetStore()
+	added, cancel := store.Subscribe(TriplePattern{})
+	defer cancel()
+
+	store.Skolemize("")
+	for _, triple := range store.All() {
+		if sources := store.SourceOf(triple); len(sources) != 1 || sources[0] != "a.ttl" {
+			t.Errorf("Expected %v to be from a.ttl, got %v", triple, sources)
+		}
+	}
+	select {
+	case triple := <-added:
+		t.Errorf("Expected no notification for the skolemized triples, got %v", triple)
+	default:
+	}
+}
+
+func TestSkolemizeKeepsGraphs(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadNQuads(`
+_:a <http://example.org/city> "Zürich" <http://example.org/addresses> .
+<http://example.org/alice> <http://example.org/knows> <http://example.org/bob> _:claims .
+`); err != nil {
+		t.Fatalf("LoadNQuads failed: %v", err)
+	}
+	store := r.GetStore()
+	if n := store.Skolemize(""); n != 2 {
+		t.Errorf("Expected 2 blank nodes replaced, got %d", n)
+	}
+
+	city := store.FindByPredicate("http://example.org/city")
+	if len(city) != 1 || city[0].Graph != "http://example.org/addresses" {
+		t.Errorf("Expected the named graph to be kept, got %v", city)
+	}
+	knows := store.FindByPredicate("http://example.org/knows")
+	if len(knows) != 1 || !strings.HasPrefix(knows[0].Graph, DefaultSkolemBase) {
+		t.Errorf("Expected the blank node graph label to be skolemized, got %v", knows)
+	}
+}
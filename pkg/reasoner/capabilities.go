@@ -0,0 +1,116 @@
+package reasoner
+
+import (
+	"sort"
+
+	"github.com/beyondcivic/goreasoner/pkg/version"
+)
+
+// CapabilityReport describes what this build of the reasoner supports, so
+// that systems dispatching work to several deployed versions can check for
+// a feature before relying on it
+type CapabilityReport struct {
+	Version           string             `json:"version"`
+	Syntaxes          []SyntaxSupport    `json:"syntaxes"`
+	UnsupportedSyntax []string           `json:"unsupportedSyntax"`
+	EntailmentRegimes []EntailmentRegime `json:"entailmentRegimes"`
+	Rules             []RuleInfo         `json:"rules"`
+	DatalogBuiltins   []string           `json:"datalogBuiltins"`
+	Limits            []LimitInfo        `json:"limits"`
+}
+
+// SyntaxSupport describes an RDF serialization and whether it can be loaded
+// or only detected
+type SyntaxSupport struct {
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+	Load       bool     `json:"load"`
+}
+
+// EntailmentRegime describes how much of a W3C entailment regime the
+// default rules implement
+type EntailmentRegime struct {
+	Name     string `json:"name"`
+	IRI      string `json:"iri"`
+	Complete bool   `json:"complete"`
+	Coverage string `json:"coverage"`
+}
+
+// RuleInfo names a default rule and gives its Datalog definition, if it
+// implements DefinedRule
+type RuleInfo struct {
+	Name       string `json:"name"`
+	Definition string `json:"definition,omitempty"`
+}
+
+// LimitInfo describes a resource limit that can be set in ParserOptions.
+// A default of zero means no limit.
+type LimitInfo struct {
+	Name        string `json:"name"`
+	Default     int    `json:"default"`
+	Description string `json:"description"`
+}
+
+// unsupportedSyntax lists the Turtle constructs the parser skips with an
+// unsupported-construct diagnostic
+//
+//nolint:gochecknoglobals
+var unsupportedSyntax = []string{
+	"quoted triple '<< >>'",
+	"single-quoted literal",
+}
+
+// Capabilities returns a description of the syntaxes, entailment regimes,
+// rules, Datalog builtins and limits supported by this build
+func Capabilities() CapabilityReport {
+	c := CapabilityReport{
+		Version:           version.Version,
+		UnsupportedSyntax: append([]string(nil), unsupportedSyntax...),
+		EntailmentRegimes: []EntailmentRegime{
+			{
+				Name:     "RDFS",
+				IRI:      "http://www.w3.org/ns/entailment/RDFS",
+				Coverage: "rdfs:subClassOf, rdfs:subPropertyOf, rdfs:domain and rdfs:range; no axiomatic triples",
+			},
+			{
+				Name: "OWL 2 RL",
+				IRI:  "http://www.w3.org/ns/owl-profile/RL",
+				Coverage: "owl:equivalentClass, owl:sameAs, owl:inverseOf, owl:TransitiveProperty, " +
+					"owl:SymmetricProperty, owl:AllDifferent, owl:AllDisjointClasses and owl:hasKey; " +
+					"consistency checks for owl:differentFrom, owl:disjointWith and owl:Nothing",
+			},
+		},
+		Limits: []LimitInfo{
+			{Name: "maxInputBytes", Description: "Size of a document in bytes"},
+			{Name: "maxTriples", Description: "Number of triples produced by a document"},
+			{Name: "maxLiteralLength", Description: "Length of a literal's lexical form in bytes"},
+			{Name: "maxDepth", Description: "Nesting of blank node property lists and collections"},
+		},
+	}
+
+	for f := FormatTurtle; f <= FormatN3; f++ {
+		s := SyntaxSupport{Name: f.String(), Load: f.CanLoad()}
+		for ext, format := range formatExtensions {
+			if format == f {
+				s.Extensions = append(s.Extensions, ext)
+			}
+		}
+		sort.Strings(s.Extensions)
+		c.Syntaxes = append(c.Syntaxes, s)
+	}
+
+	for _, rule := range DefaultRules() {
+		info := RuleInfo{Name: rule.Name()}
+		if defined, ok := rule.(DefinedRule); ok {
+			info.Definition = defined.Definition()
+		}
+		c.Rules = append(c.Rules, info)
+	}
+
+	for name := range datalogBuiltins {
+		c.DatalogBuiltins = append(c.DatalogBuiltins, name)
+	}
+	sort.Strings(c.DatalogBuiltins)
+
+	return c
+}
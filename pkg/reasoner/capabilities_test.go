@@ -0,0 +1,51 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	c := Capabilities()
+
+	if len(c.Rules) != len(DefaultRules()) {
+		t.Errorf("Capabilities() lists %d rules, want %d", len(c.Rules), len(DefaultRules()))
+	}
+	loadable := map[string]bool{}
+	for _, s := range c.Syntaxes {
+		loadable[s.Name] = s.Load
+	}
+	for _, name := range []string{"turtle", "ntriples", "nquads", "rdfxml", "jsonld", "trig"} {
+		if !loadable[name] {
+			t.Errorf("Capabilities() syntaxes = %+v, want %s loadable", c.Syntaxes, name)
+		}
+	}
+	if len(c.DatalogBuiltins) == 0 || c.DatalogBuiltins[0] > c.DatalogBuiltins[len(c.DatalogBuiltins)-1] {
+		t.Errorf("Capabilities() builtins = %v, want sorted names", c.DatalogBuiltins)
+	}
+}
+
+// TestCapabilitiesUnsupportedSyntax checks that the constructs reported as
+// unsupported are those the parser rejects
+func TestCapabilitiesUnsupportedSyntax(t *testing.T) {
+	samples := map[string]string{
+		"quoted triple '<< >>'": "<< ex:a ex:p ex:b >> ex:q ex:c .",
+		"single-quoted literal": "ex:a ex:p 'b' .",
+	}
+
+	for _, construct := range Capabilities().UnsupportedSyntax {
+		sample, ok := samples[construct]
+		if !ok {
+			t.Errorf("no sample for unsupported construct %q", construct)
+			continue
+		}
+		p := NewTurtleParser()
+		if _, err := p.Parse("@prefix ex: <http://example.org/> .\n" + sample); err != nil {
+			t.Fatalf("Parse(%q) error = %v", sample, err)
+		}
+		errs := p.Errors()
+		if len(errs) == 0 || !strings.Contains(errs[0].Message, "unsupported "+construct) {
+			t.Errorf("Parse(%q) errors = %v, want unsupported %s", sample, errs, construct)
+		}
+	}
+}
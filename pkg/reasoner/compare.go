@@ -0,0 +1,127 @@
+package reasoner
+
+import (
+	"sort"
+	"strings"
+)
+
+// GraphDiff lists the triples that occur in only one of two graphs
+type GraphDiff struct {
+	OnlyInFirst  []Triple // Triples entailed by the first graph only
+	OnlyInSecond []Triple // Triples entailed by the second graph only
+	Common       int      // Number of triples present in both graphs
+}
+
+// Equal reports whether both graphs contained the same triples
+func (d GraphDiff) Equal() bool {
+	return len(d.OnlyInFirst) == 0 && len(d.OnlyInSecond) == 0
+}
+
+// CompareGraphs canonicalizes two graphs and returns the triples present in
+// one but not the other. Blank nodes are relabelled from their content, so
+// graphs that differ only in blank node labels compare equal, and literals
+// typed as xsd:string are treated as plain literals.
+func CompareGraphs(first, second []Triple) GraphDiff {
+	return diffGraphs(first, second, true)
+}
+
+// diffGraphs compares graphs up to blank node relabelling, optionally
+// treating xsd:string literals as plain literals
+func diffGraphs(first, second []Triple, normalizeLiterals bool) GraphDiff {
+	a := canonicalizeGraph(first, normalizeLiterals)
+	b := canonicalizeGraph(second, normalizeLiterals)
+
+	var diff GraphDiff
+	for key, t := range a {
+		if _, ok := b[key]; ok {
+			diff.Common++
+		} else {
+			diff.OnlyInFirst = append(diff.OnlyInFirst, t)
+		}
+	}
+	for key, t := range b {
+		if _, ok := a[key]; !ok {
+			diff.OnlyInSecond = append(diff.OnlyInSecond, t)
+		}
+	}
+
+	sortTriples(diff.OnlyInFirst)
+	sortTriples(diff.OnlyInSecond)
+	return diff
+}
+
+// GroupByPredicate groups triples by predicate, preserving their order
+func GroupByPredicate(triples []Triple) map[string][]Triple {
+	groups := make(map[string][]Triple)
+	for _, t := range triples {
+		groups[t.Predicate] = append(groups[t.Predicate], t)
+	}
+	return groups
+}
+
+// DropTrivialEntailments removes entailments that hold in every graph and
+// that some reasoners report while others omit: reflexive subClassOf,
+// subPropertyOf, equivalentClass and sameAs, and typing with owl:Thing
+func DropTrivialEntailments(triples []Triple) []Triple {
+	result := make([]Triple, 0, len(triples))
+	for _, t := range triples {
+		switch {
+		case t.Subject == t.Object && (t.Predicate == RDFSSubClassOf || t.Predicate == RDFSSubPropertyOf ||
+			t.Predicate == OWLEquivalentClass || t.Predicate == OWLSameAs):
+			continue
+		case t.Predicate == RDFType && t.Object == OWLThing:
+			continue
+		case t.Predicate == RDFSSubClassOf && t.Object == OWLThing:
+			continue
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// canonicalizeGraph relabels blank nodes and optionally normalizes
+// literals, keyed by the canonical triple
+func canonicalizeGraph(triples []Triple, normalizeLiterals bool) map[string]Triple {
+	// Graphs are not compared, so they must not tell blank nodes apart
+	normalized := make([]Triple, len(triples))
+	for i, t := range triples {
+		normalized[i] = Triple{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object}
+		if normalizeLiterals {
+			normalized[i].Object = plainLiteral(t.Object)
+		}
+	}
+
+	labels := canonicalBlankNodeLabels(normalized)
+	term := func(x string) string {
+		if label, ok := labels[x]; ok {
+			return "_:c" + label
+		}
+		return x
+	}
+
+	result := make(map[string]Triple, len(normalized))
+	for _, t := range normalized {
+		ct := Triple{Subject: term(t.Subject), Predicate: t.Predicate, Object: term(t.Object)}
+		result[tripleKey(ct)] = ct
+	}
+	return result
+}
+
+// plainLiteral rewrites "v"^^xsd:string as the equivalent plain literal
+func plainLiteral(term string) string {
+	return strings.TrimSuffix(term, "^^<"+XSDString+">")
+}
+
+// sortTriples orders triples by subject, predicate and object
+func sortTriples(triples []Triple) {
+	sort.Slice(triples, func(i, j int) bool {
+		a, b := triples[i], triples[j]
+		if a.Subject != b.Subject {
+			return a.Subject < b.Subject
+		}
+		if a.Predicate != b.Predicate {
+			return a.Predicate < b.Predicate
+		}
+		return a.Object < b.Object
+	})
+}
@@ -0,0 +1,43 @@
+package reasoner
+
+import (
+	"testing"
+)
+
+func TestCompareGraphs(t *testing.T) {
+	const ex = "http://example.org/"
+	first := []Triple{
+		{Subject: ex + "alice", Predicate: RDFType, Object: ex + "Person"},
+		{Subject: ex + "alice", Predicate: ex + "address", Object: "_:a1"},
+		{Subject: "_:a1", Predicate: ex + "city", Object: `"Zürich"`},
+		{Subject: ex + "alice", Predicate: RDFType, Object: ex + "Agent"},
+	}
+	second := []Triple{
+		{Subject: "_:genid7", Predicate: ex + "city", Object: `"Zürich"^^<` + XSDString + `>`},
+		{Subject: ex + "alice", Predicate: ex + "address", Object: "_:genid7"},
+		{Subject: ex + "alice", Predicate: RDFType, Object: ex + "Person"},
+		{Subject: ex + "alice", Predicate: RDFType, Object: OWLThing},
+		{Subject: ex + "Person", Predicate: RDFSSubClassOf, Object: ex + "Person"},
+	}
+
+	diff := CompareGraphs(first, second)
+	if diff.Common != 3 {
+		t.Errorf("Expected 3 common triples, got %d", diff.Common)
+	}
+	if len(diff.OnlyInFirst) != 1 || diff.OnlyInFirst[0].Object != ex+"Agent" {
+		t.Errorf("Unexpected triples only in first graph: %v", diff.OnlyInFirst)
+	}
+	if len(diff.OnlyInSecond) != 2 {
+		t.Errorf("Expected 2 triples only in second graph, got %v", diff.OnlyInSecond)
+	}
+
+	groups := GroupByPredicate(diff.OnlyInSecond)
+	if len(groups[RDFType]) != 1 || len(groups[RDFSSubClassOf]) != 1 {
+		t.Errorf("Unexpected grouping: %v", groups)
+	}
+
+	diff = CompareGraphs(first[:3], DropTrivialEntailments(second))
+	if !diff.Equal() {
+		t.Errorf("Expected graphs to be equal without trivial entailments, got %+v", diff)
+	}
+}
@@ -0,0 +1,116 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Inconsistency is a contradiction between triples of a store
+type Inconsistency struct {
+	Message string   // Description of the contradiction
+	Triples []Triple // The conflicting triples
+}
+
+func (i Inconsistency) String() string {
+	return i.Message
+}
+
+// WithConsistencyCheck makes Diagnostics report the inconsistencies found
+// by CheckConsistency. The check scans the whole store, so it is left out
+// unless requested.
+func WithConsistencyCheck() Option {
+	return func(r *Reasoner) {
+		r.checkConsistency = true
+	}
+}
+
+// CheckConsistency reports individuals that are both owl:sameAs and
+// owl:differentFrom each other, instances of two disjoint classes,
+// instances of owl:Nothing, object properties with literal values and
+// datatype properties with IRI or blank node values. Run it after
+// reasoning, so that the pairwise axioms expanded from owl:AllDifferent
+// and owl:AllDisjointClasses and the inferred types are taken into account.
+func (ts *TripleStore) CheckConsistency() []Inconsistency {
+	var found []Inconsistency
+	reported := make(map[string]bool)
+	report := func(key string, i Inconsistency) {
+		if !reported[key] {
+			reported[key] = true
+			found = append(found, i)
+		}
+	}
+
+	for _, t := range ts.FindByPredicate(OWLDifferentFrom) {
+		a, b := orderedPair(t.Subject, t.Object)
+		if t.Subject == t.Object {
+			report("different|"+a, Inconsistency{
+				Message: fmt.Sprintf("%s is different from itself", t.Subject),
+				Triples: []Triple{t},
+			})
+			continue
+		}
+		same := Triple{Subject: t.Subject, Predicate: OWLSameAs, Object: t.Object}
+		if ts.Contains(same) {
+			report("different|"+a+"|"+b, Inconsistency{
+				Message: fmt.Sprintf("%s and %s are both the same and different", a, b),
+				Triples: []Triple{t, same},
+			})
+		}
+	}
+
+	for _, t := range ts.FindByPredicate(OWLDisjointWith) {
+		c, d := orderedPair(t.Subject, t.Object)
+		for _, typed := range ts.FindByPredicateObject(RDFType, t.Subject) {
+			other := Triple{Subject: typed.Subject, Predicate: RDFType, Object: t.Object}
+			if !ts.Contains(other) {
+				continue
+			}
+			report("disjoint|"+typed.Subject+"|"+c+"|"+d, Inconsistency{
+				Message: fmt.Sprintf("%s is an instance of the disjoint classes %s and %s", typed.Subject, c, d),
+				Triples: []Triple{t, typed, other},
+			})
+		}
+	}
+
+	for _, t := range ts.FindByPredicateObject(RDFType, OWLNothing) {
+		report("nothing|"+t.Subject, Inconsistency{
+			Message: fmt.Sprintf("%s is an instance of owl:Nothing", t.Subject),
+			Triples: []Triple{t},
+		})
+	}
+
+	for _, declaration := range ts.FindByPredicateObject(RDFType, OWLObjectProperty) {
+		for _, t := range ts.FindByPredicate(declaration.Subject) {
+			if ParseTerm(t.Object).IsLiteral() {
+				report("object|"+tripleKey(t), Inconsistency{
+					Message: fmt.Sprintf("%s is an object property but %s has the literal value %s", t.Predicate, t.Subject, t.Object),
+					Triples: []Triple{declaration, t},
+				})
+			}
+		}
+	}
+
+	for _, declaration := range ts.FindByPredicateObject(RDFType, OWLDatatypeProperty) {
+		for _, t := range ts.FindByPredicate(declaration.Subject) {
+			if !ParseTerm(t.Object).IsLiteral() {
+				report("datatype|"+tripleKey(t), Inconsistency{
+					Message: fmt.Sprintf("%s is a datatype property but %s has the resource value %s", t.Predicate, t.Subject, t.Object),
+					Triples: []Triple{declaration, t},
+				})
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Message < found[j].Message
+	})
+	return found
+}
+
+// orderedPair returns two terms in lexical order
+func orderedPair(a, b string) (string, string) {
+	if b < a {
+		return b, a
+	}
+	return a, b
+}
@@ -0,0 +1,369 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OWL restriction vocabulary read by cardinality constraints
+const (
+	OWLOnProperty              = "http://www.w3.org/2002/07/owl#onProperty"
+	OWLOnClass                 = "http://www.w3.org/2002/07/owl#onClass"
+	OWLCardinality             = "http://www.w3.org/2002/07/owl#cardinality"
+	OWLMinCardinality          = "http://www.w3.org/2002/07/owl#minCardinality"
+	OWLMaxCardinality          = "http://www.w3.org/2002/07/owl#maxCardinality"
+	OWLQualifiedCardinality    = "http://www.w3.org/2002/07/owl#qualifiedCardinality"
+	OWLMinQualifiedCardinality = "http://www.w3.org/2002/07/owl#minQualifiedCardinality"
+	OWLMaxQualifiedCardinality = "http://www.w3.org/2002/07/owl#maxQualifiedCardinality"
+	rdfLangString              = RDF + "langString"
+	rdfsDatatype               = "http://www.w3.org/2000/01/rdf-schema#Datatype"
+)
+
+// Constraint selects axioms that are checked under the closed-world
+// assumption instead of being used for inference
+type Constraint string
+
+const (
+	// ConstraintDomain checks that the subjects of a property with an
+	// rdfs:domain are asserted or inferred instances of the domain, instead
+	// of inferring that they are
+	ConstraintDomain Constraint = "domain"
+	// ConstraintRange checks that the values of a property with an
+	// rdfs:range are instances of the range, or literals of the range
+	// datatype, instead of inferring their type
+	ConstraintRange Constraint = "range"
+	// ConstraintCardinality checks owl:cardinality, owl:minCardinality and
+	// owl:maxCardinality restrictions and their qualified forms, as well as
+	// functional and inverse functional properties, counting distinct
+	// values as different individuals
+	ConstraintCardinality Constraint = "cardinality"
+)
+
+// ParseConstraints builds a list of constraints from their names: "domain",
+// "range", "cardinality" or "all"
+func ParseConstraints(names []string) ([]Constraint, error) {
+	var constraints []Constraint
+	seen := make(map[Constraint]bool)
+	add := func(c Constraint) {
+		if !seen[c] {
+			seen[c] = true
+			constraints = append(constraints, c)
+		}
+	}
+	for _, name := range names {
+		switch c := Constraint(strings.TrimSpace(name)); c {
+		case ConstraintDomain, ConstraintRange, ConstraintCardinality:
+			add(c)
+		case "all":
+			add(ConstraintDomain)
+			add(ConstraintRange)
+			add(ConstraintCardinality)
+		default:
+			return nil, fmt.Errorf("invalid constraint %q, must be 'domain', 'range', 'cardinality' or 'all'", name)
+		}
+	}
+	return constraints, nil
+}
+
+// WithClosedWorld treats the selected axioms as integrity constraints: the
+// rules inferring types from them are left out, and Diagnostics reports the
+// data violating them. Reason before checking, so that types inferred
+// through subclasses and values inferred through subproperties count.
+func WithClosedWorld(constraints ...Constraint) Option {
+	return func(r *Reasoner) {
+		r.constraints = constraints
+	}
+}
+
+// withoutCheckedRules removes the rules inferring from constrained axioms
+func withoutCheckedRules(rules []Rule, constraints []Constraint) []Rule {
+	checked := make(map[Constraint]bool, len(constraints))
+	for _, c := range constraints {
+		checked[c] = true
+	}
+	kept := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		switch rule.(type) {
+		case *DomainInference:
+			if checked[ConstraintDomain] {
+				continue
+			}
+		case *RangeInference:
+			if checked[ConstraintRange] {
+				continue
+			}
+		}
+		kept = append(kept, rule)
+	}
+	return kept
+}
+
+// ConstraintViolation is data that violates an axiom checked under the
+// closed-world assumption
+type ConstraintViolation struct {
+	Constraint Constraint
+	Message    string   // Description of the violation
+	Subject    string   // Resource violating the axiom
+	Triples    []Triple // The axiom, followed by the triples violating it
+}
+
+func (v ConstraintViolation) String() string {
+	return v.Message
+}
+
+// CheckConstraints reports the data violating the given kinds of axioms
+// when only what the store contains is taken to be true. Unlike
+// CheckConsistency, a missing type or value is a violation, and distinct
+// terms are counted as distinct individuals. Violations are sorted by
+// message.
+func (ts *TripleStore) CheckConstraints(constraints ...Constraint) []ConstraintViolation {
+	var found []ConstraintViolation
+	for _, c := range constraints {
+		switch c {
+		case ConstraintDomain:
+			found = append(found, ts.checkDomains()...)
+		case ConstraintRange:
+			found = append(found, ts.checkRanges()...)
+		case ConstraintCardinality:
+			found = append(found, ts.checkCardinalities()...)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Message < found[j].Message
+	})
+	return found
+}
+
+// CheckConstraints reports the data violating the axioms selected with
+// WithClosedWorld
+func (r *Reasoner) CheckConstraints() []ConstraintViolation {
+	return r.store.CheckConstraints(r.constraints...)
+}
+
+// checkDomains reports subjects of properties that are not instances of
+// their domains
+func (ts *TripleStore) checkDomains() []ConstraintViolation {
+	var found []ConstraintViolation
+	for _, axiom := range ts.FindByPredicate(RDFSDomain) {
+		reported := make(map[string]bool)
+		for _, t := range ts.FindByPredicate(axiom.Subject) {
+			typed := Triple{Subject: t.Subject, Predicate: RDFType, Object: axiom.Object}
+			if reported[t.Subject] || ts.Contains(typed) {
+				continue
+			}
+			reported[t.Subject] = true
+			found = append(found, ConstraintViolation{
+				Constraint: ConstraintDomain,
+				Message:    fmt.Sprintf("%s has a value for %s but is not an instance of its domain %s", t.Subject, t.Predicate, axiom.Object),
+				Subject:    t.Subject,
+				Triples:    []Triple{axiom, t},
+			})
+		}
+	}
+	return found
+}
+
+// checkRanges reports values of properties that are not instances of their
+// ranges
+func (ts *TripleStore) checkRanges() []ConstraintViolation {
+	var found []ConstraintViolation
+	for _, axiom := range ts.FindByPredicate(RDFSRange) {
+		isDatatype := ts.isDatatype(axiom.Object)
+		for _, t := range ts.FindByPredicate(axiom.Subject) {
+			object := t.ObjectTerm()
+			var format string
+			switch {
+			case isDatatype && !object.IsLiteral():
+				format = "%s has the resource value %s for %s, whose range is the datatype %s"
+			case isDatatype && !literalHasDatatype(object, axiom.Object):
+				format = "%s has the value %s for %s, which is not of its range datatype %s"
+			case !isDatatype && object.IsLiteral():
+				format = "%s has the literal value %s for %s, whose range is the class %s"
+			case !isDatatype && !ts.Contains(Triple{Subject: t.Object, Predicate: RDFType, Object: axiom.Object}):
+				format = "%s has the value %s for %s, which is not an instance of its range %s"
+			default:
+				continue
+			}
+			found = append(found, ConstraintViolation{
+				Constraint: ConstraintRange,
+				Message:    fmt.Sprintf(format, t.Subject, t.Object, t.Predicate, axiom.Object),
+				Subject:    t.Subject,
+				Triples:    []Triple{axiom, t},
+			})
+		}
+	}
+	return found
+}
+
+// isDatatype reports whether a range is a datatype rather than a class
+func (ts *TripleStore) isDatatype(iri string) bool {
+	return iri == rdfsLiteral || iri == rdfLangString || strings.HasPrefix(iri, XSD) ||
+		ts.Contains(Triple{Subject: iri, Predicate: RDFType, Object: rdfsDatatype})
+}
+
+// literalHasDatatype reports whether a literal is of a datatype, plain
+// literals being xsd:string and language-tagged ones rdf:langString
+func literalHasDatatype(literal Term, datatype string) bool {
+	if datatype == rdfsLiteral {
+		return true
+	}
+	switch {
+	case literal.Language != "":
+		return datatype == rdfLangString
+	case literal.Datatype == "":
+		return datatype == XSD+"string"
+	default:
+		return literal.Datatype == datatype
+	}
+}
+
+// cardinalityRestriction is a restriction on the number of values of a
+// property
+type cardinalityRestriction struct {
+	axiom    Triple // The triple stating the bound
+	property string
+	onClass  string // Class the counted values must be instances of, if qualified
+	min, max int    // max is -1 if unbounded
+}
+
+// checkCardinalities reports instances of restricted classes with too few
+// or too many values, and violations of functional and inverse functional
+// properties
+func (ts *TripleStore) checkCardinalities() []ConstraintViolation {
+	var found []ConstraintViolation
+
+	for _, r := range ts.cardinalityRestrictions() {
+		// The restriction applies to the instances of the classes it is a
+		// superclass of or equivalent to, named in messages, and to its own
+		var classes []string
+		for _, t := range ts.FindByObject(r.axiom.Subject) {
+			if t.Predicate == RDFSSubClassOf || t.Predicate == OWLEquivalentClass {
+				classes = append(classes, t.Subject)
+			}
+		}
+		classes = append(classes, r.axiom.Subject)
+		checked := make(map[string]bool)
+		for _, class := range classes {
+			for _, typed := range ts.FindByPredicateObject(RDFType, class) {
+				x := typed.Subject
+				if checked[x] {
+					continue
+				}
+				checked[x] = true
+
+				var values []Triple
+				for _, t := range ts.FindBySubjectPredicate(x, r.property) {
+					if r.onClass == "" || ts.Contains(Triple{Subject: t.Object, Predicate: RDFType, Object: r.onClass}) {
+						values = append(values, t)
+					}
+				}
+				if len(values) >= r.min && (r.max < 0 || len(values) <= r.max) {
+					continue
+				}
+				bound := fmt.Sprintf("at least %d", r.min)
+				switch {
+				case r.min == r.max:
+					bound = fmt.Sprintf("exactly %d", r.min)
+				case r.max >= 0 && len(values) > r.max:
+					bound = fmt.Sprintf("at most %d", r.max)
+				}
+				of := ""
+				if r.onClass != "" {
+					of = " of class " + r.onClass
+				}
+				found = append(found, ConstraintViolation{
+					Constraint: ConstraintCardinality,
+					Message:    fmt.Sprintf("%s has %d values for %s%s but %s requires %s", x, len(values), r.property, of, class, bound),
+					Subject:    x,
+					Triples:    append([]Triple{r.axiom, typed}, values...),
+				})
+			}
+		}
+	}
+
+	for _, declaration := range ts.FindByPredicateObject(RDFType, OWLFunctionalProperty) {
+		bySubject := make(map[string][]Triple)
+		for _, t := range ts.FindByPredicate(declaration.Subject) {
+			bySubject[t.Subject] = append(bySubject[t.Subject], t)
+		}
+		for subject, values := range bySubject {
+			if len(values) > 1 {
+				found = append(found, ConstraintViolation{
+					Constraint: ConstraintCardinality,
+					Message:    fmt.Sprintf("%s has %d values for the functional property %s", subject, len(values), declaration.Subject),
+					Subject:    subject,
+					Triples:    append([]Triple{declaration}, values...),
+				})
+			}
+		}
+	}
+
+	for _, declaration := range ts.FindByPredicateObject(RDFType, OWLInverseFunctionalProperty) {
+		byObject := make(map[string][]Triple)
+		for _, t := range ts.FindByPredicate(declaration.Subject) {
+			byObject[t.Object] = append(byObject[t.Object], t)
+		}
+		for object, subjects := range byObject {
+			if len(subjects) > 1 {
+				found = append(found, ConstraintViolation{
+					Constraint: ConstraintCardinality,
+					Message:    fmt.Sprintf("%s is the value of the inverse functional property %s for %d subjects", object, declaration.Subject, len(subjects)),
+					Subject:    object,
+					Triples:    append([]Triple{declaration}, subjects...),
+				})
+			}
+		}
+	}
+
+	return found
+}
+
+// cardinalityRestrictions returns the cardinality restrictions of the
+// store, skipping those without a property or with an invalid bound
+func (ts *TripleStore) cardinalityRestrictions() []cardinalityRestriction {
+	var restrictions []cardinalityRestriction
+	for _, bound := range []struct {
+		predicate string
+		exact     bool
+		isMax     bool
+		qualified bool
+	}{
+		{OWLCardinality, true, false, false},
+		{OWLMinCardinality, false, false, false},
+		{OWLMaxCardinality, false, true, false},
+		{OWLQualifiedCardinality, true, false, true},
+		{OWLMinQualifiedCardinality, false, false, true},
+		{OWLMaxQualifiedCardinality, false, true, true},
+	} {
+		for _, t := range ts.FindByPredicate(bound.predicate) {
+			n, err := strconv.Atoi(ParseTerm(t.Object).Value)
+			if err != nil || n < 0 {
+				continue
+			}
+			properties := ts.FindBySubjectPredicate(t.Subject, OWLOnProperty)
+			if len(properties) == 0 {
+				continue
+			}
+			r := cardinalityRestriction{axiom: t, property: properties[0].Object, min: 0, max: -1}
+			if bound.qualified {
+				classes := ts.FindBySubjectPredicate(t.Subject, OWLOnClass)
+				if len(classes) == 0 {
+					continue
+				}
+				r.onClass = classes[0].Object
+			}
+			switch {
+			case bound.exact:
+				r.min, r.max = n, n
+			case bound.isMax:
+				r.max = n
+			default:
+				r.min = n
+			}
+			restrictions = append(restrictions, r)
+		}
+	}
+	return restrictions
+}
@@ -0,0 +1,141 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+const constraintsDocument = `
+@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+
+ex:Mayor rdfs:subClassOf ex:Person .
+ex:governs rdfs:domain ex:Person .
+ex:governs rdfs:range ex:Municipality .
+ex:population rdfs:range xsd:integer .
+ex:bfsNumber a owl:FunctionalProperty .
+
+ex:Municipality rdfs:subClassOf _:oneMayor .
+_:oneMayor a owl:Restriction ;
+    owl:onProperty ex:hasMayor ;
+    owl:cardinality "1"^^xsd:nonNegativeInteger .
+
+ex:alice a ex:Mayor ; ex:governs ex:bern .
+ex:bob ex:governs ex:zurich .
+ex:bern a ex:Municipality ; ex:hasMayor ex:alice ; ex:population "134000"^^xsd:integer ; ex:bfsNumber "351" .
+ex:zurich ex:population "many" .
+ex:geneva a ex:Municipality ; ex:bfsNumber "6621", "6622" .
+`
+
+func TestParseConstraints(t *testing.T) {
+	constraints, err := ParseConstraints([]string{"range", " domain", "range"})
+	if err != nil {
+		t.Fatalf("ParseConstraints failed: %v", err)
+	}
+	if len(constraints) != 2 || constraints[0] != ConstraintRange || constraints[1] != ConstraintDomain {
+		t.Errorf("ParseConstraints() = %v, want [range domain]", constraints)
+	}
+	if all, _ := ParseConstraints([]string{"all"}); len(all) != 3 {
+		t.Errorf("ParseConstraints(all) = %v, want all three constraints", all)
+	}
+	if _, err := ParseConstraints([]string{"disjointness"}); err == nil {
+		t.Errorf("Expected an error for an unknown constraint")
+	}
+}
+
+func TestClosedWorldConstraints(t *testing.T) {
+	r := NewReasoner(WithClosedWorld(ConstraintDomain, ConstraintRange, ConstraintCardinality))
+	if err := r.LoadTurtle(constraintsDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	ex := "http://example.org/"
+	store := r.GetStore()
+	if store.Contains(Triple{Subject: ex + "bob", Predicate: RDFType, Object: ex + "Person"}) {
+		t.Errorf("Domain axioms must not be used for inference")
+	}
+	if store.Contains(Triple{Subject: ex + "zurich", Predicate: RDFType, Object: ex + "Municipality"}) {
+		t.Errorf("Range axioms must not be used for inference")
+	}
+	if !store.Contains(Triple{Subject: ex + "alice", Predicate: RDFType, Object: ex + "Person"}) {
+		t.Errorf("Other rules must still apply")
+	}
+
+	var messages []string
+	for _, v := range r.CheckConstraints() {
+		messages = append(messages, v.Message)
+	}
+	expected := []string{
+		ex + "bob has a value for " + ex + "governs but is not an instance of its domain " + ex + "Person",
+		ex + "bob has the value " + ex + "zurich for " + ex + "governs, which is not an instance of its range " + ex + "Municipality",
+		ex + "geneva has 0 values for " + ex + "hasMayor but " + ex + "Municipality requires exactly 1",
+		ex + "geneva has 2 values for the functional property " + ex + "bfsNumber",
+		ex + `zurich has the value "many" for ` + ex + "population, which is not of its range datatype " + XSD + "integer",
+	}
+	if !equalStrings(messages, expected) {
+		t.Errorf("CheckConstraints() = %v, want %v", messages, expected)
+	}
+
+	violations := 0
+	for _, d := range r.Diagnostics() {
+		if d.Code == CodeConstraintViolation {
+			violations++
+		}
+	}
+	if violations != len(expected) {
+		t.Errorf("Expected %d constraint violation diagnostics, got %d", len(expected), violations)
+	}
+}
+
+func TestClosedWorldSelectedAxiomsOnly(t *testing.T) {
+	r := NewReasoner(WithClosedWorld(ConstraintDomain))
+	if err := r.LoadTurtle(constraintsDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	if !r.GetStore().Contains(Triple{Subject: "http://example.org/zurich", Predicate: RDFType, Object: "http://example.org/Municipality"}) {
+		t.Errorf("Range axioms must still be used for inference")
+	}
+	for _, v := range r.CheckConstraints() {
+		if v.Constraint != ConstraintDomain {
+			t.Errorf("Unexpected %s violation: %s", v.Constraint, v)
+		}
+	}
+	if len(NewReasoner().CheckConstraints()) != 0 {
+		t.Errorf("Expected no violations without closed-world constraints")
+	}
+}
+
+func TestMaxQualifiedCardinality(t *testing.T) {
+	r := NewReasoner(WithClosedWorld(ConstraintCardinality))
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Canton owl:equivalentClass _:r .
+_:r owl:onProperty ex:capital ; owl:onClass ex:City ; owl:maxQualifiedCardinality "1"^^<http://www.w3.org/2001/XMLSchema#nonNegativeInteger> .
+ex:vaud a ex:Canton ; ex:capital ex:lausanne, ex:morges, ex:pully .
+ex:lausanne a ex:City .
+ex:morges a ex:City .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	violations := r.CheckConstraints()
+	if len(violations) != 1 {
+		t.Fatalf("Expected 1 violation, got %v", violations)
+	}
+	if !strings.Contains(violations[0].Message, "has 2 values for http://example.org/capital of class http://example.org/City") ||
+		!strings.HasSuffix(violations[0].Message, "requires at most 1") {
+		t.Errorf("Unexpected message: %s", violations[0].Message)
+	}
+	if len(violations[0].Triples) != 4 {
+		t.Errorf("Expected the axiom, the typing and both values, got %v", violations[0].Triples)
+	}
+}
@@ -4,8 +4,12 @@
 package reasoner
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 )
 
 // Reasoner performs forward reasoning on RDF data
@@ -13,65 +17,597 @@ type Reasoner struct {
 	store  *TripleStore
 	rules  []Rule
 	parser *TurtleParser
+	cache  *queryCache
+	tracer Tracer
+	scope  *ReasoningScope
+
+	progress func(ProgressEvent)
+
+	literalMatching LiteralMatching
+
+	// provenance records the source of every loaded triple in the store
+	provenance bool
+
+	// iriNormalization rewrites the IRIs of loaded triples
+	iriNormalization IRINormalization
+
+	// prefixes collects the prefixes declared in all loaded documents
+	prefixes map[string]string
+
+	// loadDiagnostics collects the parse errors and warnings of all loaded
+	// documents, labelled with their source
+	loadDiagnostics []Diagnostic
+
+	// budgets limits rules by name, "" applying to all others
+	budgets map[string]RuleBudget
+
+	// runDiagnostics collects the warnings of the last reasoning run
+	runDiagnostics []Diagnostic
+
+	// constraints are the axioms checked under the closed-world assumption
+	// instead of being used for inference
+	constraints []Constraint
+
+	// checkConsistency makes Diagnostics report inconsistencies
+	checkConsistency bool
+
+	// partitionWorkers is the number of ABox partitions materialized in
+	// parallel when that is safe
+	partitionWorkers int
+
+	// closed is the number of leading triples in the store that are known
+	// to be closed under the rules; only later triples need to be reasoned over
+	closed int
+
+	// graphReasoning selects whether named graphs are reasoned over
+	// together or separately
+	graphReasoning GraphReasoning
+
+	// graphs records the further graphs of triples loaded or inferred in
+	// several graphs, by triple key, when reasoning per graph
+	graphs map[string][]string
 }
 
 // NewReasoner creates a new reasoner with default rules
-func NewReasoner() *Reasoner {
-	return &Reasoner{
-		store:  NewTripleStore(),
-		rules:  DefaultRules(),
-		parser: NewTurtleParser(),
-	}
+func NewReasoner(opts ...Option) *Reasoner {
+	return NewReasonerWithRules(DefaultRules(), opts...)
 }
 
 // NewReasonerWithRules creates a new reasoner with custom rules
-func NewReasonerWithRules(rules []Rule) *Reasoner {
-	return &Reasoner{
-		store:  NewTripleStore(),
-		rules:  rules,
-		parser: NewTurtleParser(),
+func NewReasonerWithRules(rules []Rule, opts ...Option) *Reasoner {
+	r := &Reasoner{
+		store:    NewTripleStore(),
+		rules:    rules,
+		parser:   NewTurtleParser(),
+		tracer:   noopTracer{},
+		prefixes: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	if len(r.constraints) > 0 {
+		r.rules = withoutCheckedRules(r.rules, r.constraints)
+	}
+	return r
 }
 
 // LoadTurtle parses and loads Turtle content into the store
 func (r *Reasoner) LoadTurtle(content string) error {
+	return r.LoadTurtleContext(context.Background(), content)
+}
+
+// LoadTurtleFrom is like LoadTurtle but labels the diagnostics of the
+// document with source, typically its file name
+func (r *Reasoner) LoadTurtleFrom(source, content string) error {
+	return r.loadTurtle(context.Background(), source, content)
+}
+
+// LoadTurtleContext is like LoadTurtle but records its span as a child of ctx
+func (r *Reasoner) LoadTurtleContext(ctx context.Context, content string) error {
+	return r.loadTurtle(ctx, "", content)
+}
+
+func (r *Reasoner) loadTurtle(ctx context.Context, source, content string) error {
+	if r.store.frozen {
+		return ErrReadOnly
+	}
+
+	_, span := r.tracer.Start(ctx, SpanLoadTurtle)
+	defer span.End()
+
+	if r.progress != nil {
+		r.parser.progress = func(done, total, triples int) {
+			r.progress(ProgressEvent{Stage: ProgressParsing, Done: done, Total: total, Triples: triples})
+		}
+		defer func() { r.parser.progress = nil }()
+	}
+
 	triples, err := r.parser.Parse(content)
 	if err != nil {
 		return fmt.Errorf("failed to parse Turtle: %w", err)
 	}
 
+	r.recordParsed(span, source, triples, r.parser.Prefixes(), r.parser.Errors(), r.parser.Warnings())
+
+	return nil
+}
+
+// LoadNTriples parses and loads N-Triples content into the store with
+// NTriplesParser, which is stricter and faster than the Turtle parser on
+// line-based documents such as the output of a previous run
+func (r *Reasoner) LoadNTriples(content string) error {
+	return r.loadNTriples(context.Background(), "", content)
+}
+
+// LoadNTriplesFrom is like LoadNTriples but labels the diagnostics of the
+// document with source, typically its file name
+func (r *Reasoner) LoadNTriplesFrom(source, content string) error {
+	return r.loadNTriples(context.Background(), source, content)
+}
+
+func (r *Reasoner) loadNTriples(ctx context.Context, source, content string) error {
+	if r.store.frozen {
+		return ErrReadOnly
+	}
+
+	_, span := r.tracer.Start(ctx, SpanLoadNTriples)
+	defer span.End()
+
+	if err := r.loadLines(NewNTriplesParserWithOptions(r.parser.options), span, source, content); err != nil {
+		return fmt.Errorf("failed to parse N-Triples: %w", err)
+	}
+	return nil
+}
+
+// LoadNQuads parses and loads N-Quads content into the store. The graph
+// labels are kept in Triple.Graph; reasoning is over the union of the
+// graphs unless the reasoner was created WithGraphReasoning(PerGraph).
+func (r *Reasoner) LoadNQuads(content string) error {
+	return r.loadNQuads(context.Background(), "", content)
+}
+
+// LoadNQuadsFrom is like LoadNQuads but labels the diagnostics of the
+// document with source, typically its file name
+func (r *Reasoner) LoadNQuadsFrom(source, content string) error {
+	return r.loadNQuads(context.Background(), source, content)
+}
+
+func (r *Reasoner) loadNQuads(ctx context.Context, source, content string) error {
+	if r.store.frozen {
+		return ErrReadOnly
+	}
+
+	_, span := r.tracer.Start(ctx, SpanLoadNQuads)
+	defer span.End()
+
+	if err := r.loadLines(&NewNQuadsParserWithOptions(r.parser.options).NTriplesParser, span, source, content); err != nil {
+		return fmt.Errorf("failed to parse N-Quads: %w", err)
+	}
+	return nil
+}
+
+// LoadRDFXML parses and loads RDF/XML content into the store, keeping
+// its namespace declarations as prefixes
+func (r *Reasoner) LoadRDFXML(content string) error {
+	return r.loadRDFXML(context.Background(), "", content)
+}
+
+// LoadRDFXMLFrom is like LoadRDFXML but labels the diagnostics of the
+// document with source, typically its file name
+func (r *Reasoner) LoadRDFXMLFrom(source, content string) error {
+	return r.loadRDFXML(context.Background(), source, content)
+}
+
+func (r *Reasoner) loadRDFXML(ctx context.Context, source, content string) error {
+	if r.store.frozen {
+		return ErrReadOnly
+	}
+
+	_, span := r.tracer.Start(ctx, SpanLoadRDFXML)
+	defer span.End()
+
+	parser := NewRDFXMLParserWithOptions(r.parser.options)
+	triples, err := parser.Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse RDF/XML: %w", err)
+	}
+
+	r.recordParsed(span, source, triples, namedPrefixes(parser.Prefixes()), parser.Errors(), parser.Warnings())
+
+	return nil
+}
+
+// LoadTriG parses and loads TriG content into the store, keeping its
+// prefixes. Like LoadNQuads it keeps the graph labels in Triple.Graph.
+func (r *Reasoner) LoadTriG(content string) error {
+	return r.loadTriG(context.Background(), "", content)
+}
+
+// LoadTriGFrom is like LoadTriG but labels the diagnostics of the document
+// with source, typically its file name
+func (r *Reasoner) LoadTriGFrom(source, content string) error {
+	return r.loadTriG(context.Background(), source, content)
+}
+
+func (r *Reasoner) loadTriG(ctx context.Context, source, content string) error {
+	if r.store.frozen {
+		return ErrReadOnly
+	}
+
+	_, span := r.tracer.Start(ctx, SpanLoadTriG)
+	defer span.End()
+
+	parser := NewTriGParserWithOptions(r.parser.options)
+	if r.progress != nil {
+		parser.progress = func(done, total, triples int) {
+			r.progress(ProgressEvent{Stage: ProgressParsing, Done: done, Total: total, Triples: triples})
+		}
+	}
+	triples, err := parser.Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse TriG: %w", err)
+	}
+
+	r.recordParsed(span, source, triples, parser.Prefixes(), parser.Errors(), parser.Warnings())
+
+	return nil
+}
+
+// LoadN3 parses and loads Notation3 content: its triples are added to the
+// store and its rules, such as "{ ?x ex:hasChild ?y } => { ?y ex:hasParent ?x } .",
+// to the rules of the reasoner, after those it already has
+func (r *Reasoner) LoadN3(content string) error {
+	return r.loadN3(context.Background(), "", content)
+}
+
+// LoadN3From is like LoadN3 but labels the diagnostics of the document with
+// source, typically its file name, and names its rules after the file's
+// base name and their line, such as "family.n3:12"
+func (r *Reasoner) LoadN3From(source, content string) error {
+	return r.loadN3(context.Background(), source, content)
+}
+
+func (r *Reasoner) loadN3(ctx context.Context, source, content string) error {
+	if r.store.frozen {
+		return ErrReadOnly
+	}
+
+	_, span := r.tracer.Start(ctx, SpanLoadN3)
+	defer span.End()
+
+	parser := NewN3ParserWithOptions(r.parser.options)
+	if r.progress != nil {
+		parser.progress = func(done, total, triples int) {
+			r.progress(ProgressEvent{Stage: ProgressParsing, Done: done, Total: total, Triples: triples})
+		}
+	}
+	triples, err := parser.Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse N3: %w", err)
+	}
+
+	r.recordParsed(span, source, triples, parser.Prefixes(), parser.Errors(), parser.Warnings())
+
+	// Copy the rules, which may be shared with other reasoners
+	rules := append([]Rule(nil), r.rules...)
+	for _, rule := range parser.rules {
+		if source != "" {
+			rule.name = filepath.Base(source) + strings.TrimPrefix(rule.name, "n3")
+		}
+		rules = append(rules, rule)
+	}
+	r.rules = rules
+
+	return nil
+}
+
+// LoadJSONLD parses and loads a JSON-LD document, such as Croissant
+// metadata, into the store, keeping the namespaces of its context as
+// prefixes
+func (r *Reasoner) LoadJSONLD(content string) error {
+	return r.loadJSONLD(context.Background(), "", content)
+}
+
+// LoadJSONLDFrom is like LoadJSONLD but labels the diagnostics of the
+// document with source, typically its file name. If source is an http or
+// https IRI, relative IRIs are resolved against it.
+func (r *Reasoner) LoadJSONLDFrom(source, content string) error {
+	return r.loadJSONLD(context.Background(), source, content)
+}
+
+func (r *Reasoner) loadJSONLD(ctx context.Context, source, content string) error {
+	if r.store.frozen {
+		return ErrReadOnly
+	}
+
+	_, span := r.tracer.Start(ctx, SpanLoadJSONLD)
+	defer span.End()
+
+	parser := NewJSONLDParserWithOptions(r.parser.options)
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		parser.base = source
+	}
+	triples, err := parser.Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse JSON-LD: %w", err)
+	}
+
+	r.recordParsed(span, source, triples, namedPrefixes(parser.Prefixes()), parser.Errors(), parser.Warnings())
+
+	return nil
+}
+
+// LoadDocument loads a document with the parser for its format, detecting
+// the format from the content and source if it is FormatUnknown. Formats
+// that cannot be loaded fail with an error.
+func (r *Reasoner) LoadDocument(source, content string, format Format) error {
+	if format == FormatUnknown {
+		format = DetectFormat(source, content)
+	}
+	switch format {
+	case FormatTurtle:
+		return r.LoadTurtleFrom(source, content)
+	case FormatNTriples:
+		return r.LoadNTriplesFrom(source, content)
+	case FormatNQuads:
+		return r.LoadNQuadsFrom(source, content)
+	case FormatRDFXML:
+		return r.LoadRDFXMLFrom(source, content)
+	case FormatJSONLD:
+		return r.LoadJSONLDFrom(source, content)
+	case FormatTriG:
+		return r.LoadTriGFrom(source, content)
+	case FormatN3:
+		return r.LoadN3From(source, content)
+	default:
+		return fmt.Errorf("%s documents cannot be loaded", format)
+	}
+}
+
+// loadLines loads a document with an N-Triples or N-Quads parser
+func (r *Reasoner) loadLines(parser *NTriplesParser, span Span, source, content string) error {
+	if r.progress != nil {
+		parser.progress = func(done, total, triples int) {
+			r.progress(ProgressEvent{Stage: ProgressParsing, Done: done, Total: total, Triples: triples})
+		}
+	}
+
+	triples, err := parser.Parse(content)
+	if err != nil {
+		return err
+	}
+
+	r.recordParsed(span, source, triples, nil, parser.Errors(), parser.Warnings())
+
+	return nil
+}
+
+// recordParsed adds the triples, prefixes and diagnostics of a parsed
+// document to the reasoner
+func (r *Reasoner) recordParsed(span Span, source string, triples []Triple, prefixes map[string]string, errs, warnings []ParseError) {
+	r.addParsed(source, triples)
+	// Namespaces keep their trailing separator, so that normalized IRIs
+	// can still be compacted with them
+	namespaces := r.iriNormalization
+	namespaces.TrimTrailingSlash = false
+	for prefix, iri := range prefixes {
+		r.prefixes[prefix] = namespaces.Normalize(iri)
+	}
+	r.addParseDiagnostics(source, errs, warnings)
+	span.SetAttribute(AttrTriples, len(triples))
+}
+
+// namedPrefixes returns the prefixes without the default namespace, which
+// RDF/XML and JSON-LD declare for unprefixed names rather than as ":"
+func namedPrefixes(prefixes map[string]string) map[string]string {
+	named := make(map[string]string, len(prefixes))
+	for prefix, iri := range prefixes {
+		if prefix != "" {
+			named[prefix] = iri
+		}
+	}
+	return named
+}
+
+// addParsed adds the triples of a parsed document to the store
+func (r *Reasoner) addParsed(source string, triples []Triple) {
 	for _, t := range triples {
-		r.store.Add(t)
+		if r.iriNormalization.enabled() {
+			t = r.iriNormalization.triple(t)
+		}
+		var added bool
+		if r.provenance && source != "" {
+			added = r.store.AddFrom(t, source)
+		} else {
+			added = r.store.Add(t)
+		}
+		if !added && r.graphReasoning == PerGraph {
+			r.addGraphMember(t)
+		}
+	}
+}
+
+// LoadMaterialized loads the output of a previous reasoning run, such as the
+// N-Triples written by the run command, and treats it as already closed under
+// the rules. Triples loaded afterwards form the delta: the next reasoning run
+// only computes consequences that involve at least one of them.
+//
+// The previous output must have been produced with the same rules. If the
+// store is not empty, the loaded triples are reasoned over like any other input.
+func (r *Reasoner) LoadMaterialized(previousOutput string) error {
+	empty := r.store.Size() == 0
+
+	load := r.LoadTurtle
+	if isNTriples(previousOutput) {
+		load = r.LoadNTriples
+	}
+	if err := load(previousOutput); err != nil {
+		return fmt.Errorf("failed to load materialized triples: %w", err)
 	}
 
+	if empty {
+		r.closed = r.store.Size()
+	}
 	return nil
 }
 
 // RunForwardReasoning applies all rules until no new facts are derived
 // Returns the number of new triples inferred
 func (r *Reasoner) RunForwardReasoning() int {
+	return r.RunForwardReasoningContext(context.Background())
+}
+
+// RunForwardReasoningContext is like RunForwardReasoning but records a span
+// for the whole run, one per round and one per rule application
+func (r *Reasoner) RunForwardReasoningContext(ctx context.Context) int {
+	if r.store.frozen {
+		return 0
+	}
+
+	if r.partitionWorkers > 1 {
+		if inferred, ok := r.reasonPartitioned(ctx); ok {
+			return inferred
+		}
+	}
+	inferred, _ := r.reason(ctx, nil)
+	return inferred
+}
+
+// ReasonStream is like RunForwardReasoning but calls emit with every new
+// triple and the name of the rule that derived it, as soon as it is added to
+// the store. If emit returns an error reasoning stops and the error is
+// returned; the triple passed to emit remains in the store.
+func (r *Reasoner) ReasonStream(emit func(t Triple, ruleName string) error) (int, error) {
+	return r.reason(context.Background(), emit)
+}
+
+// reason applies all rules until fixpoint, passing new triples to emit
+// if it is not nil
+func (r *Reasoner) reason(ctx context.Context, emit func(Triple, string) error) (int, error) {
+	if r.store.frozen {
+		return 0, ErrReadOnly
+	}
+	if r.graphReasoning == PerGraph {
+		return r.reasonPerGraph(ctx, emit)
+	}
+
+	ctx, span := r.tracer.Start(ctx, SpanForwardReasoning)
+	defer span.End()
+
 	totalInferred := 0
+	round := 0
+
+	// Rounds after the first only join against the triples derived in the
+	// previous round (semi-naive evaluation). The first round does the same
+	// for triples added since the last run, if the store was closed before.
+	// Scoped runs always re-apply rules fully, since triples pruned earlier
+	// may become relevant as the schema grows.
+	useDelta := r.scope == nil && r.closed > 0
+	delta := r.store.since(r.closed)
+
+	r.runDiagnostics = nil
+	usage := make([]*ruleUsage, len(r.rules))
+	for i, rule := range r.rules {
+		usage[i] = r.newRuleUsage(rule.Name())
+	}
+	complete := true
 
 	for {
+		round++
+		roundCtx, roundSpan := r.tracer.Start(ctx, SpanReasoningRound)
+		roundSpan.SetAttribute(AttrRound, round)
 		newInThisRound := 0
+		mark := r.store.Size()
+
+		var filter *scopeFilter
+		if r.scope != nil {
+			filter = newScopeFilter(r.scope, r.store)
+		}
+
+		for i, rule := range r.rules {
+			u := usage[i]
+			if u != nil && u.disabled {
+				continue
+			}
+			start := time.Now()
 
-		for _, rule := range r.rules {
-			inferred := rule.Apply(r.store)
+			_, ruleSpan := r.tracer.Start(roundCtx, SpanRuleApply)
+			ruleSpan.SetAttribute(AttrRule, rule.Name())
+
+			var inferred []Triple
+			if dr, ok := rule.(DeltaRule); ok && useDelta {
+				inferred = dr.ApplyDelta(r.store, delta)
+			} else {
+				inferred = rule.Apply(r.store)
+			}
+			added := 0
 			for _, t := range inferred {
-				if r.store.Add(t) {
-					newInThisRound++
+				if !u.allows() {
+					break
+				}
+				if filter != nil && !filter.allows(t) {
+					continue
+				}
+				if !r.store.Add(t) {
+					continue
+				}
+				added++
+				if u != nil {
+					u.added++
+				}
+				if emit != nil {
+					if err := emit(t, rule.Name()); err != nil {
+						ruleSpan.End()
+						roundSpan.End()
+						return totalInferred + newInThisRound + added, fmt.Errorf("rule %s: %w", rule.Name(), err)
+					}
+				}
+			}
+			newInThisRound += added
+
+			if r.progress != nil {
+				r.progress(ProgressEvent{
+					Stage:   ProgressReasoning,
+					Done:    round,
+					Triples: totalInferred + newInThisRound,
+					Rule:    rule.Name(),
+				})
+			}
+
+			ruleSpan.SetAttribute(AttrInferred, added)
+			ruleSpan.End()
+
+			if u != nil {
+				u.elapsed += time.Since(start)
+				if reason := u.exhausted(); reason != "" {
+					u.disabled = true
+					complete = false
+					r.disableRule(rule.Name(), reason)
 				}
 			}
 		}
 
+		roundSpan.SetAttribute(AttrInferred, newInThisRound)
+		roundSpan.End()
+
 		if newInThisRound == 0 {
 			break
 		}
 
 		totalInferred += newInThisRound
+		useDelta = r.scope == nil
+		delta = r.store.since(mark)
+	}
+
+	// A run with disabled rules did not reach the fixpoint, so the next run
+	// starts over
+	if r.scope == nil && complete {
+		r.closed = r.store.Size()
 	}
 
-	return totalInferred
+	span.SetAttribute(AttrRounds, round)
+	span.SetAttribute(AttrInferred, totalInferred)
+	return totalInferred, nil
 }
 
 // GetAllTriples returns all triples in the store as strings
@@ -95,27 +631,96 @@ func (r *Reasoner) GetInferredTypes(subject string) []string {
 	return types
 }
 
+// EnableQueryCache turns on an in-process LRU cache for Query results
+// holding up to size patterns. The cache is invalidated whenever the store
+// is mutated. A size of zero or less disables the cache.
+func (r *Reasoner) EnableQueryCache(size int) {
+	if size <= 0 {
+		r.cache = nil
+		return
+	}
+	r.cache = newQueryCache(size)
+}
+
+// QueryCacheStats returns hit/miss statistics of the query cache
+func (r *Reasoner) QueryCacheStats() QueryCacheStats {
+	if r.cache == nil {
+		return QueryCacheStats{}
+	}
+	return r.cache.stats()
+}
+
 // Query returns all triples matching the given pattern
 // Use empty string "" as wildcard
 func (r *Reasoner) Query(subject, predicate, object string) []Triple {
+	return r.QueryContext(context.Background(), subject, predicate, object)
+}
+
+// QueryContext is like Query but records its span as a child of ctx
+func (r *Reasoner) QueryContext(ctx context.Context, subject, predicate, object string) []Triple {
+	_, span := r.tracer.Start(ctx, SpanQuery)
+	defer span.End()
+
+	key := queryCacheKey(subject, predicate, object)
+	span.SetAttribute(AttrPattern, key)
+
+	var results []Triple
+	cached := false
+	generation := r.store.Generation()
+	if r.cache != nil {
+		results, cached = r.cache.get(key, generation)
+	}
+	if !cached {
+		results = r.query(subject, predicate, object)
+		if r.cache != nil {
+			r.cache.put(key, generation, results)
+		}
+	}
+
+	span.SetAttribute(AttrResults, len(results))
+	return results
+}
+
+func (r *Reasoner) query(subject, predicate, object string) []Triple {
 	var results []Triple
 
+	// Literal objects may need to be compared by value rather than through
+	// the exact-match object index
+	objectMatches := func(o string) bool { return object == "" || o == object }
+	if equivalence := r.store.equivalences[predicate]; equivalence != nil && object != "" {
+		key := equivalence(object)
+		objectMatches = func(o string) bool { return equivalence(o) == key }
+	}
+	byValue := r.literalMatching == ValueMatching && ParseTerm(object).IsLiteral()
+	if byValue {
+		key := literalValueKey(object)
+		objectMatches = func(o string) bool { return literalValueKey(o) == key }
+		if _, custom := customEqual(object, object); custom {
+			objectMatches = func(o string) bool { return LiteralsEqual(o, object, ValueMatching) }
+		}
+	}
+
 	if subject != "" && predicate != "" {
 		for _, t := range r.store.FindBySubjectPredicate(subject, predicate) {
-			if object == "" || t.Object == object {
+			if objectMatches(t.Object) {
 				results = append(results, t)
 			}
 		}
 	} else if subject != "" {
 		for _, t := range r.store.FindBySubject(subject) {
-			if (predicate == "" || t.Predicate == predicate) &&
-				(object == "" || t.Object == object) {
+			if (predicate == "" || t.Predicate == predicate) && objectMatches(t.Object) {
 				results = append(results, t)
 			}
 		}
 	} else if predicate != "" {
 		for _, t := range r.store.FindByPredicate(predicate) {
-			if object == "" || t.Object == object {
+			if objectMatches(t.Object) {
+				results = append(results, t)
+			}
+		}
+	} else if byValue {
+		for _, t := range r.store.All() {
+			if objectMatches(t.Object) {
 				results = append(results, t)
 			}
 		}
@@ -130,6 +735,28 @@ func (r *Reasoner) Query(subject, predicate, object string) []Triple {
 	return results
 }
 
+// Warnings returns the problems found in loaded statements, such as
+// malformed date, time or duration literals, in load order
+func (r *Reasoner) Warnings() []ParseError {
+	var warnings []ParseError
+	for _, d := range r.loadDiagnostics {
+		if d.Code == CodeInvalidLiteral {
+			warnings = append(warnings, ParseError{Line: d.Line, Message: d.Message})
+		}
+	}
+	return warnings
+}
+
+// Prefixes returns the prefixes declared in the loaded documents. If a
+// prefix was declared several times, the last declaration wins.
+func (r *Reasoner) Prefixes() map[string]string {
+	prefixes := make(map[string]string, len(r.prefixes))
+	for prefix, iri := range r.prefixes {
+		prefixes[prefix] = iri
+	}
+	return prefixes
+}
+
 // GetStore returns the underlying triple store
 func (r *Reasoner) GetStore() *TripleStore {
 	return r.store
@@ -175,49 +802,50 @@ func ForwardReasonWithDetails(abox, tbox string) (*ReasoningResult, error) {
 
 	// Load TBox first
 	if tbox != "" {
-		if err := reasoner.LoadTurtle(tbox); err != nil {
+		if err := reasoner.LoadTurtleFrom("TBox", tbox); err != nil {
 			return nil, fmt.Errorf("failed to load TBox: %w", err)
 		}
 	}
 
 	// Load ABox
 	if abox != "" {
-		if err := reasoner.LoadTurtle(abox); err != nil {
+		if err := reasoner.LoadTurtleFrom("ABox", abox); err != nil {
 			return nil, fmt.Errorf("failed to load ABox: %w", err)
 		}
 	}
 
-	// Get original triples count
-	originalCount := reasoner.store.Size()
-	originalTriples := reasoner.GetAllTriples()
-
-	// Run forward reasoning
-	inferredCount := reasoner.RunForwardReasoning()
-
-	// Get all triples after reasoning
-	allTriples := reasoner.GetAllTriples()
+	return reasoner.RunForwardReasoningWithDetails(), nil
+}
 
-	// Separate inferred triples
-	originalSet := make(map[string]bool)
-	for _, t := range originalTriples {
-		originalSet[t] = true
+// RunForwardReasoningWithDetails is like RunForwardReasoning but returns the
+// triples in the store before reasoning separately from those it inferred,
+// so that callers holding the asserted data can use only the delta
+func (r *Reasoner) RunForwardReasoningWithDetails() *ReasoningResult {
+	originalCount := r.store.Size()
+	inferredCount := r.RunForwardReasoning()
+
+	// Reasoning only appends to the store
+	all := r.store.All()
+	originalTriples := make([]string, originalCount)
+	for i, t := range all[:originalCount] {
+		originalTriples[i] = t.String()
 	}
-
-	var inferredTriples []string
-	for _, t := range allTriples {
-		if !originalSet[t] {
-			inferredTriples = append(inferredTriples, t)
-		}
+	inferredTriples := make([]string, 0, len(all)-originalCount)
+	for _, t := range all[originalCount:] {
+		inferredTriples = append(inferredTriples, t.String())
 	}
+	sort.Strings(originalTriples)
+	sort.Strings(inferredTriples)
 
 	return &ReasoningResult{
 		OriginalTriples: originalTriples,
 		InferredTriples: inferredTriples,
-		AllTriples:      allTriples,
+		AllTriples:      r.GetAllTriples(),
 		OriginalCount:   originalCount,
 		InferredCount:   inferredCount,
-		TotalCount:      len(allTriples),
-	}, nil
+		TotalCount:      r.store.Size(),
+		Diagnostics:     r.Diagnostics(),
+	}
 }
 
 // ReasoningResult contains detailed results from forward reasoning
@@ -228,4 +856,6 @@ type ReasoningResult struct {
 	OriginalCount   int      // Number of original triples
 	InferredCount   int      // Number of inferred triples
 	TotalCount      int      // Total number of triples
+
+	Diagnostics []Diagnostic // Problems found in the input and the results
 }
@@ -0,0 +1,378 @@
+package reasoner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	name  string
+	attrs map[string]any
+	ended bool
+}
+
+func (rt *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordingSpan{name: name, attrs: make(map[string]any)}
+	rt.spans = append(rt.spans, span)
+	return ctx, span
+}
+
+func (s *recordingSpan) SetAttribute(key string, value any) {
+	s.attrs[key] = value
+}
+
+func (s *recordingSpan) End() {
+	s.ended = true
+}
+
+func TestTracing(t *testing.T) {
+	tracer := &recordingTracer{}
+	r := NewReasoner(WithTracer(tracer))
+
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:Car rdfs:subClassOf ex:Vehicle .
+ex:myCar a ex:Car .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+	r.Query("", RDFType, "")
+
+	counts := make(map[string]int)
+	for _, span := range tracer.spans {
+		counts[span.name]++
+		if !span.ended {
+			t.Errorf("Span %s was not ended", span.name)
+		}
+	}
+
+	if counts[SpanLoadTurtle] != 1 || counts[SpanForwardReasoning] != 1 || counts[SpanQuery] != 1 {
+		t.Errorf("Unexpected span counts: %v", counts)
+	}
+	if counts[SpanRuleApply] != counts[SpanReasoningRound]*len(DefaultRules()) {
+		t.Errorf("Expected one rule span per rule and round, got %v", counts)
+	}
+}
+
+func TestReasoningScope(t *testing.T) {
+	const ex = "http://example.org/"
+	r := NewReasoner(WithScope(ReasoningScope{Classes: []string{ex + "Agent"}}))
+
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Employee rdfs:subClassOf ex:Person .
+ex:Person rdfs:subClassOf ex:Agent .
+ex:worksFor rdfs:domain ex:Employee .
+ex:worksFor rdfs:range ex:Organization .
+ex:knows a owl:SymmetricProperty .
+ex:alice ex:worksFor ex:acme .
+ex:alice ex:knows ex:bob .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	tests := []struct {
+		triple   Triple
+		expected bool
+	}{
+		{Triple{Subject: ex + "alice", Predicate: RDFType, Object: ex + "Agent"}, true},
+		{Triple{Subject: ex + "alice", Predicate: RDFType, Object: ex + "Employee"}, true},
+		{Triple{Subject: ex + "Employee", Predicate: RDFSSubClassOf, Object: ex + "Agent"}, true},
+		{Triple{Subject: ex + "acme", Predicate: RDFType, Object: ex + "Organization"}, false},
+		{Triple{Subject: ex + "bob", Predicate: ex + "knows", Object: ex + "alice"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := r.GetStore().Contains(tt.triple); got != tt.expected {
+			t.Errorf("Contains(%s) = %v, expected %v", tt.triple, got, tt.expected)
+		}
+	}
+}
+
+func TestReasoningScopeEquivalences(t *testing.T) {
+	const ex = "http://example.org/"
+	r := NewReasoner(WithScope(ReasoningScope{Classes: []string{ex + "Agent"}, Predicates: []string{ex + "related"}}))
+
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Person rdfs:subClassOf ex:Agent .
+ex:Human owl:equivalentClass ex:Person .
+ex:worksFor rdfs:domain ex:Human .
+ex:knows owl:equivalentProperty ex:related .
+ex:Mensch owl:sameAs ex:Human .
+ex:colleague owl:sameAs ex:knows .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	f := newScopeFilter(r.scope, r.GetStore())
+	for _, class := range []string{ex + "Human", ex + "Mensch"} {
+		if !f.classes[class] {
+			t.Errorf("Expected %s to be in scope", class)
+		}
+	}
+	for _, predicate := range []string{ex + "worksFor", ex + "knows", ex + "colleague"} {
+		if !f.predicates[predicate] {
+			t.Errorf("Expected %s to be in scope", predicate)
+		}
+	}
+}
+
+// naiveRule hides ApplyDelta so the rule is always applied to the whole store
+type naiveRule struct{ Rule }
+
+const incrementalSchema = `
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Employee rdfs:subClassOf ex:Person .
+ex:Person rdfs:subClassOf ex:Agent .
+ex:Agent owl:equivalentClass ex:Actor .
+ex:worksFor rdfs:domain ex:Employee .
+ex:worksFor rdfs:range ex:Organization .
+ex:worksFor rdfs:subPropertyOf ex:affiliatedWith .
+ex:employs owl:inverseOf ex:worksFor .
+ex:partOf a owl:TransitiveProperty .
+ex:knows a owl:SymmetricProperty .
+`
+
+const incrementalData = `
+@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:alice ex:worksFor ex:acme .
+ex:globex ex:employs ex:bob .
+ex:acme ex:partOf ex:holding .
+ex:holding ex:partOf ex:group .
+ex:alice ex:knows ex:bob .
+ex:bob owl:sameAs ex:robert .
+ex:robert owl:sameAs ex:bobby .
+`
+
+func closureOf(t *testing.T, r *Reasoner, contents ...string) []string {
+	t.Helper()
+	for _, content := range contents {
+		if err := r.LoadTurtle(content); err != nil {
+			t.Fatalf("LoadTurtle failed: %v", err)
+		}
+		r.RunForwardReasoning()
+	}
+	return r.GetAllTriples()
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSemiNaiveMatchesNaive(t *testing.T) {
+	var naive []Rule
+	for _, rule := range DefaultRules() {
+		naive = append(naive, naiveRule{rule})
+	}
+
+	expected := closureOf(t, NewReasonerWithRules(naive), incrementalSchema+incrementalData)
+	got := closureOf(t, NewReasoner(), incrementalSchema+incrementalData)
+	if !equalStrings(got, expected) {
+		t.Errorf("Semi-naive closure differs from naive closure:\n got %v\nwant %v", got, expected)
+	}
+
+	// Incremental runs over a store that is already closed
+	got = closureOf(t, NewReasoner(), incrementalData, incrementalSchema)
+	if !equalStrings(got, expected) {
+		t.Errorf("Incremental closure differs from naive closure:\n got %v\nwant %v", got, expected)
+	}
+}
+
+func TestSchemaJoinRules(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(`@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:worksFor rdfs:domain ex:Person, ex:Agent ; rdfs:range ex:Organization ;
+    rdfs:subPropertyOf ex:memberOf, ex:affiliatedWith .
+ex:alice ex:worksFor ex:acme, ex:globex ; ex:worksFor "freelance" .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+
+	tests := []struct {
+		rule Rule
+		want []string
+	}{
+		{&DomainInference{}, []string{"alice Agent", "alice Person"}},
+		{&RangeInference{}, []string{"acme Organization", "globex Organization"}},
+		{&SubPropertyInheritance{}, []string{
+			`alice affiliatedWith "freelance"`, "alice affiliatedWith acme", "alice affiliatedWith globex",
+			`alice memberOf "freelance"`, "alice memberOf acme", "alice memberOf globex",
+		}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, triple := range tt.rule.Apply(r.GetStore()) {
+			object := strings.TrimPrefix(triple.Object, "http://example.org/")
+			subject := strings.TrimPrefix(triple.Subject, "http://example.org/")
+			if triple.Predicate != RDFType {
+				subject += " " + strings.TrimPrefix(triple.Predicate, "http://example.org/")
+			}
+			got = append(got, subject+" "+object)
+		}
+		sort.Strings(got)
+		if !equalStrings(got, tt.want) {
+			t.Errorf("%s.Apply() = %q, want %q", tt.rule.Name(), got, tt.want)
+		}
+	}
+}
+
+func TestLoadMaterialized(t *testing.T) {
+	expected := closureOf(t, NewReasoner(), incrementalSchema+incrementalData)
+	previous := closureOf(t, NewReasoner(), incrementalSchema)
+
+	r := NewReasoner()
+	if err := r.LoadMaterialized(strings.Join(previous, "\n")); err != nil {
+		t.Fatalf("LoadMaterialized failed: %v", err)
+	}
+	if inferred := r.RunForwardReasoning(); inferred != 0 {
+		t.Errorf("Expected no inferences from a closed graph, got %d", inferred)
+	}
+
+	if got := closureOf(t, r, incrementalData); !equalStrings(got, expected) {
+		t.Errorf("Closure after loading the delta differs:\n got %v\nwant %v", got, expected)
+	}
+}
+
+func TestRunForwardReasoningWithDetails(t *testing.T) {
+	expected := closureOf(t, NewReasoner(), incrementalSchema+incrementalData)
+
+	r := NewReasoner()
+	if err := r.LoadTurtle(incrementalSchema + incrementalData); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	asserted := r.GetAllTriples()
+	result := r.RunForwardReasoningWithDetails()
+
+	if !equalStrings(result.OriginalTriples, asserted) || result.OriginalCount != len(asserted) {
+		t.Errorf("Original triples differ:\n got %v\nwant %v", result.OriginalTriples, asserted)
+	}
+	if !equalStrings(result.AllTriples, expected) || result.TotalCount != len(expected) {
+		t.Errorf("All triples differ:\n got %v\nwant %v", result.AllTriples, expected)
+	}
+	if len(result.InferredTriples) != result.InferredCount || result.InferredCount != len(expected)-len(asserted) {
+		t.Errorf("Expected %d inferred triples, got %d (%d reported)", len(expected)-len(asserted), len(result.InferredTriples), result.InferredCount)
+	}
+	for _, triple := range result.InferredTriples {
+		if slices.Contains(asserted, triple) {
+			t.Errorf("Asserted triple reported as inferred: %s", triple)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	var events []ProgressEvent
+	r := NewReasoner(WithProgress(func(ev ProgressEvent) {
+		events = append(events, ev)
+	}))
+
+	content := incrementalSchema + incrementalData
+	if err := r.LoadTurtle(content); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	inferred := r.RunForwardReasoning()
+
+	if len(events) == 0 || events[0].Stage != ProgressParsing {
+		t.Fatalf("Expected a parsing event first, got %v", events)
+	}
+	if events[0].Done != events[0].Total || events[0].Triples != r.GetStore().Size()-inferred {
+		t.Errorf("Unexpected final parsing event: %+v", events[0])
+	}
+
+	last := events[len(events)-1]
+	if last.Stage != ProgressReasoning || last.Triples != inferred {
+		t.Errorf("Expected last reasoning event to report %d inferred triples, got %+v", inferred, last)
+	}
+	if want := 1 + last.Done*len(DefaultRules()); len(events) != want {
+		t.Errorf("Expected %d events, got %d", want, len(events))
+	}
+}
+
+func TestReasonStream(t *testing.T) {
+	content := incrementalSchema + incrementalData
+
+	expected := NewReasoner()
+	if err := expected.LoadTurtle(content); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	want := expected.RunForwardReasoning()
+
+	r := NewReasoner()
+	if err := r.LoadTurtle(content); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	seen := make(map[Triple]bool)
+	inferred, err := r.ReasonStream(func(tr Triple, ruleName string) error {
+		if ruleName == "" {
+			t.Errorf("Expected a rule name for %v", tr)
+		}
+		if seen[tr] {
+			t.Errorf("Triple emitted twice: %v", tr)
+		}
+		seen[tr] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReasonStream failed: %v", err)
+	}
+	if inferred != want || len(seen) != want {
+		t.Errorf("Expected %d emitted triples, got %d (returned %d)", want, len(seen), inferred)
+	}
+}
+
+func TestReasonStreamStopsOnError(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(incrementalSchema + incrementalData); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	inferred, err := r.ReasonStream(func(Triple, string) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("Expected the emit error, got %v", err)
+	}
+	if calls != 1 || inferred != 1 {
+		t.Errorf("Expected to stop after the first triple, got %d calls and %d inferred", calls, inferred)
+	}
+
+	// Resuming reasoning completes the closure
+	if r.RunForwardReasoning() == 0 {
+		t.Error("Expected remaining inferences after resuming")
+	}
+}
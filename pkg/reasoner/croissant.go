@@ -0,0 +1,205 @@
+package reasoner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// EnrichCroissant adds triples, typically the inferences drawn from a
+// dataset's RDF representation, to the nodes of Croissant JSON-LD metadata
+// they are about, and returns the enriched metadata with the number of
+// values added.
+//
+// A node is any object with an "@id", such as the dataset, a record set or a
+// field. Its IRI is the "@id" expanded with the prefixes of the top-level
+// "@context", or resolved against base (or the context's "@base") if it is
+// relative. rdf:type values are added to "@type"; other predicates become
+// properties named with the context's "@vocab" or prefixes where possible,
+// as are types and IRI values. IRIs are
+// written as {"@id": ...}, plain literals as strings and other literals as
+// value objects. Values already present are not repeated.
+func EnrichCroissant(metadata []byte, triples []Triple, base string) ([]byte, int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(metadata))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, 0, fmt.Errorf("invalid Croissant metadata: %w", err)
+	}
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid Croissant metadata: expected a JSON object")
+	}
+
+	e := &croissantEnricher{prefixes: make(map[string]string), bySubject: make(map[string][]Triple)}
+	if context, ok := root["@context"].(map[string]any); ok {
+		for prefix, value := range context {
+			iri, ok := value.(string)
+			if ok && !strings.HasPrefix(prefix, "@") && (strings.HasSuffix(iri, "/") || strings.HasSuffix(iri, "#")) {
+				e.prefixes[prefix] = iri
+			}
+		}
+		e.vocab, _ = context["@vocab"].(string)
+		if b, ok := context["@base"].(string); ok && base == "" {
+			base = b
+		}
+	}
+	e.base = base
+	for _, t := range triples {
+		e.bySubject[t.Subject] = append(e.bySubject[t.Subject], t)
+	}
+	e.walk(root)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, 0, fmt.Errorf("failed to encode Croissant metadata: %w", err)
+	}
+	return buf.Bytes(), e.added, nil
+}
+
+// croissantEnricher adds triples to the nodes of a JSON-LD document
+type croissantEnricher struct {
+	prefixes  map[string]string
+	vocab     string
+	base      string
+	bySubject map[string][]Triple
+	added     int
+}
+
+// walk enriches the nodes in a JSON value and everything nested in it
+func (e *croissantEnricher) walk(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if id, ok := v["@id"].(string); ok {
+			e.enrich(v, e.expand(id))
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if key != "@context" {
+				e.walk(v[key])
+			}
+		}
+	case []any:
+		for _, item := range v {
+			e.walk(item)
+		}
+	}
+}
+
+// enrich adds the triples about iri to a node
+func (e *croissantEnricher) enrich(node map[string]any, iri string) {
+	for _, t := range e.bySubject[iri] {
+		key := "@type"
+		var value any = e.compact(t.Object)
+		if t.Predicate != RDFType {
+			key = e.compact(t.Predicate)
+			value = e.value(t.Object)
+		} else if !ParseTerm(t.Object).IsIRI() {
+			continue
+		}
+		if e.merge(node, key, value) {
+			e.added++
+		}
+	}
+}
+
+// merge adds value to the values of key, reporting whether it was new
+func (e *croissantEnricher) merge(node map[string]any, key string, value any) bool {
+	existing, ok := node[key]
+	if !ok {
+		node[key] = value
+		return true
+	}
+	values, isList := existing.([]any)
+	if !isList {
+		values = []any{existing}
+	}
+	for _, v := range values {
+		if reflect.DeepEqual(e.normalize(v), e.normalize(value)) {
+			return false
+		}
+	}
+	node[key] = append(values, value)
+	return true
+}
+
+// value converts an RDF term to a JSON-LD value
+func (e *croissantEnricher) value(term string) any {
+	t := ParseTerm(term)
+	switch {
+	case t.Kind != TermLiteral:
+		return map[string]any{"@id": e.compact(term)}
+	case t.Language != "":
+		return map[string]any{"@value": t.Value, "@language": t.Language}
+	case t.Datatype != "" && t.Datatype != XSD+"string":
+		return map[string]any{"@value": t.Value, "@type": e.compact(t.Datatype)}
+	default:
+		return t.Value
+	}
+}
+
+// normalize expands the IRIs in a type or value, for comparison
+func (e *croissantEnricher) normalize(value any) any {
+	switch v := value.(type) {
+	case string:
+		return e.expandTerm(v)
+	case map[string]any:
+		if id, ok := v["@id"].(string); ok && len(v) == 1 {
+			return map[string]any{"@id": e.expand(id)}
+		}
+	}
+	return value
+}
+
+// expandTerm returns the IRI of a type, a term of the vocabulary or a
+// prefixed name
+func (e *croissantEnricher) expandTerm(term string) string {
+	if prefix, local, ok := strings.Cut(term, ":"); ok {
+		if ns, ok := e.prefixes[prefix]; ok {
+			return ns + local
+		}
+		return term
+	}
+	return e.vocab + term
+}
+
+// expand returns the IRI of a node identifier
+func (e *croissantEnricher) expand(id string) string {
+	if prefix, local, ok := strings.Cut(id, ":"); ok {
+		if ns, ok := e.prefixes[prefix]; ok {
+			return ns + local
+		}
+		if prefix == "_" || strings.HasPrefix(local, "//") || prefix == "urn" {
+			return id
+		}
+	}
+	return e.base + id
+}
+
+// compact abbreviates an IRI to a term of the vocabulary or a prefixed
+// name with the longest matching prefix
+func (e *croissantEnricher) compact(iri string) string {
+	if e.vocab != "" && strings.HasPrefix(iri, e.vocab) && !strings.ContainsAny(iri[len(e.vocab):], ":/#") {
+		return iri[len(e.vocab):]
+	}
+	best, bestNS := "", ""
+	for prefix, ns := range e.prefixes {
+		if strings.HasPrefix(iri, ns) && (len(ns) > len(bestNS) || len(ns) == len(bestNS) && prefix < best) {
+			best, bestNS = prefix, ns
+		}
+	}
+	if bestNS == "" {
+		return iri
+	}
+	return best + ":" + iri[len(bestNS):]
+}
@@ -0,0 +1,70 @@
+package reasoner
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEnrichCroissant(t *testing.T) {
+	metadata := `{
+  "@context": {"@vocab": "https://schema.org/", "sc": "https://schema.org/", "cr": "http://mlcommons.org/croissant/", "ex": "http://example.org/"},
+  "@type": "sc:Dataset",
+  "@id": "residents",
+  "name": "residents",
+  "recordSet": [{
+    "@type": "cr:RecordSet",
+    "@id": "people",
+    "field": [
+      {"@type": "cr:Field", "@id": "people/email", "name": "email", "dataType": "sc:Text"},
+      {"@type": "cr:Field", "@id": "people/height", "name": "height", "dataType": "sc:Float"}
+    ]
+  }]
+}`
+	base := "http://data.example.org/"
+	triples := []Triple{
+		{Subject: base + "people/email", Predicate: RDFType, Object: "http://example.org/PersonalData"},
+		{Subject: base + "people/email", Predicate: RDFType, Object: "http://mlcommons.org/croissant/Field"},
+		{Subject: base + "people/height", Predicate: "http://example.org/unit", Object: "http://qudt.org/vocab/unit/CentiM"},
+		{Subject: base + "people/height", Predicate: "http://example.org/note", Object: `"Körpergrösse"@de`},
+		{Subject: base + "residents", Predicate: "http://example.org/records", Object: `"1200"^^<http://www.w3.org/2001/XMLSchema#integer>`},
+		{Subject: base + "residents", Predicate: "https://schema.org/name", Object: `"residents"`},
+		{Subject: base + "residents", Predicate: RDFType, Object: "https://schema.org/Dataset"},
+		{Subject: base + "other", Predicate: RDFType, Object: "http://example.org/Ignored"},
+	}
+
+	enriched, added, err := EnrichCroissant([]byte(metadata), triples, base)
+	if err != nil {
+		t.Fatalf("EnrichCroissant failed: %v", err)
+	}
+	if added != 4 {
+		t.Errorf("Expected 4 added values, got %d", added)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(enriched, &doc); err != nil {
+		t.Fatalf("Enriched metadata is not valid JSON: %v", err)
+	}
+	if got := doc["ex:records"]; !reflect.DeepEqual(got, map[string]any{"@value": "1200", "@type": "http://www.w3.org/2001/XMLSchema#integer"}) {
+		t.Errorf("Unexpected dataset value: %v", got)
+	}
+	// Values already present are not repeated, however they are written
+	if doc["name"] != "residents" || doc["@type"] != "sc:Dataset" {
+		t.Errorf("Expected the name and type to be kept, got %v and %v", doc["name"], doc["@type"])
+	}
+	fields := doc["recordSet"].([]any)[0].(map[string]any)["field"].([]any)
+	email, height := fields[0].(map[string]any), fields[1].(map[string]any)
+	if got := email["@type"]; !reflect.DeepEqual(got, []any{"cr:Field", "ex:PersonalData"}) {
+		t.Errorf("Unexpected types of the email field: %v", got)
+	}
+	if got := height["ex:unit"]; !reflect.DeepEqual(got, map[string]any{"@id": "http://qudt.org/vocab/unit/CentiM"}) {
+		t.Errorf("Unexpected unit of the height field: %v", got)
+	}
+	if got := height["ex:note"]; !reflect.DeepEqual(got, map[string]any{"@value": "Körpergrösse", "@language": "de"}) {
+		t.Errorf("Unexpected note of the height field: %v", got)
+	}
+
+	if _, _, err := EnrichCroissant([]byte(`[1, 2]`), triples, base); err == nil {
+		t.Error("Expected an error for metadata that is not an object")
+	}
+}
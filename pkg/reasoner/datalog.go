@@ -24,10 +24,18 @@ type DLRule struct {
 	Body []DLAtom
 }
 
-// DatalogProgram represents a collection of facts and rules
+// DLConstraint represents an integrity constraint: :- Body1, Body2, ...
+// It is violated when its body is satisfied.
+type DLConstraint struct {
+	Body []DLAtom
+}
+
+// DatalogProgram represents a collection of facts, rules and constraints.
+// Constraints do not derive facts; see Violations.
 type DatalogProgram struct {
-	Facts []DLAtom
-	Rules []DLRule
+	Facts       []DLAtom
+	Rules       []DLRule
+	Constraints []DLConstraint
 }
 
 func (a DLAtom) String() string {
@@ -38,6 +46,14 @@ func (a DLAtom) String() string {
 	return fmt.Sprintf("%s(%s)", a.Predicate, strings.Join(terms, ", "))
 }
 
+func (c DLConstraint) String() string {
+	atoms := make([]string, len(c.Body))
+	for i, a := range c.Body {
+		atoms[i] = a.String()
+	}
+	return ":- " + strings.Join(atoms, ", ") + "."
+}
+
 // ParseDatalog parses a Datalog program from a string
 func ParseDatalog(input string) (*DatalogProgram, error) {
 	program := &DatalogProgram{}
@@ -49,7 +65,17 @@ func ParseDatalog(input string) (*DatalogProgram, error) {
 			continue
 		}
 
-		if strings.Contains(stmt, ":-") {
+		if strings.HasPrefix(stmt, ":-") {
+			// It's a constraint
+			body, err := parseBody(strings.TrimPrefix(stmt, ":-"))
+			if err != nil {
+				return nil, err
+			}
+			if len(body) == 0 {
+				return nil, fmt.Errorf("invalid constraint format: %s", stmt)
+			}
+			program.Constraints = append(program.Constraints, DLConstraint{Body: body})
+		} else if strings.Contains(stmt, ":-") {
 			// It's a rule
 			rule, err := parseRule(stmt)
 			if err != nil {
@@ -77,20 +103,19 @@ func splitDatalogStatements(input string) []string {
 	lines := strings.Split(input, "\n")
 	for _, line := range lines {
 		// Remove comments
-		if idx := strings.Index(line, "%"); idx != -1 {
-			line = line[:idx]
-		}
-		if idx := strings.Index(line, "//"); idx != -1 {
-			line = line[:idx]
-		}
-		line = strings.TrimSpace(line)
+		line = strings.TrimSpace(stripDatalogComment(line))
 		if line == "" {
 			continue
 		}
 
 		runes := []rune(line)
+		var quotes quoteState
 		for i := 0; i < len(runes); i++ {
 			r := runes[i]
+			if quotes.next(r) {
+				current.WriteRune(r)
+				continue
+			}
 			if r == '(' {
 				parenCount++
 			} else if r == ')' {
@@ -142,19 +167,27 @@ func parseRule(line string) (DLRule, error) {
 		return DLRule{}, err
 	}
 
-	bodyStr := strings.TrimSpace(parts[1])
+	body, err := parseBody(parts[1])
+	if err != nil {
+		return DLRule{}, err
+	}
+
+	return DLRule{Head: head, Body: body}, nil
+}
+
+// parseBody parses the comma-separated atoms of a rule or constraint body
+func parseBody(bodyStr string) ([]DLAtom, error) {
+	bodyStr = strings.TrimSpace(bodyStr)
 	bodyStr = strings.TrimSuffix(bodyStr, ".")
-	bodyParts := splitAtoms(bodyStr)
 	var body []DLAtom
-	for _, bp := range bodyParts {
+	for _, bp := range splitAtoms(bodyStr) {
 		atom, err := parseAtom(strings.TrimSpace(bp))
 		if err != nil {
-			return DLRule{}, err
+			return nil, err
 		}
 		body = append(body, atom)
 	}
-
-	return DLRule{Head: head, Body: body}, nil
+	return body, nil
 }
 
 func parseAtom(s string) (DLAtom, error) {
@@ -174,7 +207,7 @@ func parseAtom(s string) (DLAtom, error) {
 
 	predicate := strings.TrimSpace(s[:openParen])
 	termsStr := s[openParen+1 : closeParen]
-	termParts := strings.Split(termsStr, ",")
+	termParts := splitAtoms(termsStr)
 
 	var terms []DLTerm
 	for _, tp := range termParts {
@@ -190,7 +223,7 @@ func parseAtom(s string) (DLAtom, error) {
 }
 
 func isVariable(s string) bool {
-	if len(s) == 0 {
+	if len(s) == 0 || s[0] == '"' {
 		return false
 	}
 	// Support single-character uppercase variables (like X, Y) or multi-character starting with ?
@@ -218,12 +251,55 @@ func isVariable(s string) bool {
 	return hasLetter && allUpper
 }
 
+// quoteState tracks whether a scan is inside a double-quoted constant
+type quoteState struct {
+	inQuotes bool
+	escaped  bool
+}
+
+// next consumes a rune and reports whether it belongs to a quoted constant,
+// including its delimiting quotes
+func (q *quoteState) next(r rune) bool {
+	switch {
+	case q.escaped:
+		q.escaped = false
+		return true
+	case q.inQuotes && r == '\\':
+		q.escaped = true
+		return true
+	case r == '"':
+		q.inQuotes = !q.inQuotes
+		return true
+	default:
+		return q.inQuotes
+	}
+}
+
+// stripDatalogComment removes a % or // comment outside quoted constants
+func stripDatalogComment(line string) string {
+	var quotes quoteState
+	for i, r := range line {
+		if quotes.next(r) {
+			continue
+		}
+		if r == '%' || (r == '/' && strings.HasPrefix(line[i:], "//")) {
+			return line[:i]
+		}
+	}
+	return line
+}
+
 func splitAtoms(s string) []string {
 	var atoms []string
 	var current strings.Builder
+	var quotes quoteState
 	parenCount := 0
 
 	for _, r := range s {
+		if quotes.next(r) {
+			current.WriteRune(r)
+			continue
+		}
 		if r == '(' {
 			parenCount++
 		} else if r == ')' {
@@ -298,6 +374,18 @@ func (p *DatalogProgram) Reason() []DLAtom {
 	return factList
 }
 
+// Violations returns the constraints whose body is satisfied by the derived
+// facts
+func (p *DatalogProgram) Violations(derivedFacts []DLAtom) []DLConstraint {
+	var violated []DLConstraint
+	for _, c := range p.Constraints {
+		if len(p.findSubstitutions(c.Body, derivedFacts, make(map[string]string))) > 0 {
+			violated = append(violated, c)
+		}
+	}
+	return violated
+}
+
 func hasVariables(a DLAtom) bool {
 	for _, t := range a.Terms {
 		if t.IsVariable {
@@ -309,20 +397,17 @@ func hasVariables(a DLAtom) bool {
 
 // DLQuery is the main public API function for Datalog querying.
 // It accepts a Datalog program and a query, performs reasoning,
-// and returns true if the query is satisfied.
+// and returns true if the query is satisfied. To answer several queries
+// against the same program, compile it once with CompileDatalog.
 func DLQuery(datalogContent, queryStr string) (bool, error) {
-	program, err := ParseDatalog(datalogContent)
-	if err != nil {
-		return false, fmt.Errorf("failed to parse Datalog: %w", err)
+	if _, err := ParseQuery(queryStr); err != nil {
+		return false, fmt.Errorf("failed to parse query: %w", err)
 	}
-
-	query, err := ParseQuery(queryStr)
+	compiled, err := CompileDatalog(datalogContent)
 	if err != nil {
-		return false, fmt.Errorf("failed to parse query: %w", err)
+		return false, err
 	}
-
-	derivedFacts := program.Reason()
-	return program.EvaluateQuery(query, derivedFacts), nil
+	return compiled.Query(queryStr)
 }
 
 func (p *DatalogProgram) findSubstitutions(body []DLAtom, facts []DLAtom, currentSub map[string]string) []map[string]string {
@@ -334,6 +419,23 @@ func (p *DatalogProgram) findSubstitutions(body []DLAtom, facts []DLAtom, curren
 	first := body[0]
 	rest := body[1:]
 
+	if p.isBuiltin(first) {
+		atom := applySubstitution(first, currentSub)
+		if hasVariables(atom) {
+			// Evaluate the builtin once the remaining atoms have bound its variables
+			for _, a := range rest {
+				if !p.isBuiltin(a) {
+					return p.findSubstitutions(append(append([]DLAtom{}, rest...), first), facts, currentSub)
+				}
+			}
+			return nil
+		}
+		if evaluateBuiltin(atom) {
+			return p.findSubstitutions(rest, facts, currentSub)
+		}
+		return nil
+	}
+
 	// Find all facts that match 'first' under 'currentSub'
 	for _, f := range facts {
 		if f.Predicate != first.Predicate || len(f.Terms) != len(first.Terms) {
@@ -391,6 +493,10 @@ func applySubstitution(a DLAtom, sub map[string]string) DLAtom {
 
 // EvaluateQuery checks if a query matches any derived facts
 func (p *DatalogProgram) EvaluateQuery(query DLAtom, derivedFacts []DLAtom) bool {
+	if p.isBuiltin(query) {
+		return !hasVariables(query) && evaluateBuiltin(query)
+	}
+
 	for _, f := range derivedFacts {
 		if f.Predicate != query.Predicate || len(f.Terms) != len(query.Terms) {
 			continue
@@ -0,0 +1,81 @@
+package reasoner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DLBuiltin is a predicate evaluated in Go instead of matched against facts.
+// It receives the values of its arguments, which are all bound when it is
+// evaluated, and reports whether the atom holds. Quoted constants are passed
+// with their quotes; datalogLiteralValue strips them.
+type DLBuiltin func(args []string) bool
+
+// datalogBuiltins maps builtin predicate names to their implementation
+var datalogBuiltins = map[string]DLBuiltin{ //nolint:gochecknoglobals
+	"sfWithin":     geoBuiltin(SfWithin),
+	"sfIntersects": geoBuiltin(SfIntersects),
+	"dateBefore":   temporalBuiltin(time.Time.Before),
+	"dateAfter":    temporalBuiltin(time.Time.After),
+
+	"quantityGreater": quantityBuiltin(1),
+	"quantityLess":    quantityBuiltin(-1),
+
+	"greaterThan": comparisonBuiltin(1),
+	"lessThan":    comparisonBuiltin(-1),
+}
+
+// RegisterDatalogBuiltin makes a builtin predicate available in the body of
+// Datalog rules and queries. Registering an existing name replaces it.
+// It is not safe to call concurrently with reasoning.
+func RegisterDatalogBuiltin(name string, builtin DLBuiltin) error {
+	if name == "" || strings.ContainsAny(name, "(), ") {
+		return fmt.Errorf("invalid builtin name %q", name)
+	}
+	datalogBuiltins[name] = builtin
+	return nil
+}
+
+// isBuiltin reports whether an atom calls a builtin predicate. A program
+// that defines facts or rules for the name of a builtin shadows it, so
+// its own predicate is matched instead.
+func (p *DatalogProgram) isBuiltin(a DLAtom) bool {
+	if _, ok := datalogBuiltins[a.Predicate]; !ok {
+		return false
+	}
+	return !p.defines(a.Predicate)
+}
+
+// defines reports whether the program has facts or rules for a predicate
+func (p *DatalogProgram) defines(predicate string) bool {
+	for _, f := range p.Facts {
+		if f.Predicate == predicate {
+			return true
+		}
+	}
+	for _, r := range p.Rules {
+		if r.Head.Predicate == predicate {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateBuiltin calls the builtin of a ground atom
+func evaluateBuiltin(a DLAtom) bool {
+	args := make([]string, len(a.Terms))
+	for i, t := range a.Terms {
+		args[i] = t.Value
+	}
+	return datalogBuiltins[a.Predicate](args)
+}
+
+// datalogLiteralValue returns the unescaped content of a quoted Datalog
+// constant, or the constant itself if it is not quoted
+func datalogLiteralValue(s string) string {
+	if strings.HasPrefix(s, `"`) {
+		return ParseTerm(s).Value
+	}
+	return s
+}
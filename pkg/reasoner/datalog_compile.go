@@ -0,0 +1,121 @@
+package reasoner
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+)
+
+// CompiledProgram is a Datalog program whose facts have been derived once,
+// so that many queries can be answered without re-evaluating it. It is
+// safe for concurrent use.
+type CompiledProgram struct {
+	program     *DatalogProgram
+	facts       []DLAtom
+	byPredicate map[string][]DLAtom
+}
+
+// CompileDatalog parses a Datalog program and derives all its facts
+func CompileDatalog(content string) (*CompiledProgram, error) {
+	program, err := ParseDatalog(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Datalog: %w", err)
+	}
+
+	c := &CompiledProgram{
+		program:     program,
+		facts:       program.Reason(),
+		byPredicate: make(map[string][]DLAtom),
+	}
+	for _, f := range c.facts {
+		c.byPredicate[f.Predicate] = append(c.byPredicate[f.Predicate], f)
+	}
+	return c, nil
+}
+
+// Query parses a query such as "?- type(X, Vehicle)." and reports whether
+// it is satisfied by the derived facts
+func (c *CompiledProgram) Query(queryStr string) (bool, error) {
+	query, err := ParseQuery(queryStr)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse query: %w", err)
+	}
+	return c.program.EvaluateQuery(query, c.byPredicate[query.Predicate]), nil
+}
+
+// Violations returns the constraints of the program that the derived facts
+// violate
+func (c *CompiledProgram) Violations() []DLConstraint {
+	return c.program.Violations(c.facts)
+}
+
+// Facts returns the facts of the program and those derived from them
+func (c *CompiledProgram) Facts() []DLAtom {
+	return append([]DLAtom(nil), c.facts...)
+}
+
+// ProgramCache is an LRU cache of compiled Datalog programs keyed by the
+// SHA-256 hash of their content, for services that query the same programs
+// repeatedly. It is safe for concurrent use.
+type ProgramCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[[sha256.Size]byte]*list.Element
+}
+
+type programCacheEntry struct {
+	key      [sha256.Size]byte
+	compiled *CompiledProgram
+}
+
+// NewProgramCache returns a cache holding up to capacity programs
+func NewProgramCache(capacity int) *ProgramCache {
+	return &ProgramCache{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		entries:  make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+// Compile returns the compiled program for content, compiling it unless
+// it is cached. Programs that fail to compile are not cached.
+func (c *ProgramCache) Compile(content string) (*CompiledProgram, error) {
+	key := sha256.Sum256([]byte(content))
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		c.mu.Unlock()
+		return elem.Value.(*programCacheEntry).compiled, nil
+	}
+	c.mu.Unlock()
+
+	// Compile without holding the lock, so other programs can be served
+	compiled, err := CompileDatalog(content)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*programCacheEntry).compiled, nil
+	}
+	c.entries[key] = c.order.PushFront(&programCacheEntry{key: key, compiled: compiled})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*programCacheEntry).key)
+	}
+	return compiled, nil
+}
+
+// Len returns the number of cached programs
+func (c *ProgramCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,88 @@
+package reasoner
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+const ancestry = `parent(john, mary).
+parent(mary, jane).
+ancestor(X, Y) :- parent(X, Y).
+ancestor(X, Z) :- parent(X, Y), ancestor(Y, Z).
+`
+
+func TestCompileDatalog(t *testing.T) {
+	compiled, err := CompileDatalog(ancestry)
+	if err != nil {
+		t.Fatalf("CompileDatalog failed: %v", err)
+	}
+	if got := len(compiled.Facts()); got != 5 {
+		t.Errorf("Expected 5 facts, got %d", got)
+	}
+
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"?- ancestor(john, jane).", true},
+		{"?- ancestor(jane, john).", false},
+		{"?- ancestor(X, jane).", true},
+		{"?- sibling(X, Y).", false},
+	}
+	for _, tt := range tests {
+		got, err := compiled.Query(tt.query)
+		if err != nil {
+			t.Fatalf("Query(%s) failed: %v", tt.query, err)
+		}
+		if got != tt.expected {
+			t.Errorf("Query(%s) = %v, want %v", tt.query, got, tt.expected)
+		}
+	}
+
+	if _, err := CompileDatalog("ancestor(X, Y :- parent(X, Y)."); err == nil {
+		t.Error("Expected an invalid program to fail")
+	}
+}
+
+func TestProgramCache(t *testing.T) {
+	cache := NewProgramCache(2)
+	first, err := cache.Compile(ancestry)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	again, _ := cache.Compile(ancestry)
+	if again != first {
+		t.Error("Expected the cached program to be reused")
+	}
+
+	// The least recently used program is evicted
+	for i := range 2 {
+		if _, err := cache.Compile(fmt.Sprintf("fact(%d).", i)); err != nil {
+			t.Fatalf("Compile failed: %v", err)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 cached programs, got %d", cache.Len())
+	}
+	if evicted, _ := cache.Compile(ancestry); evicted == first {
+		t.Error("Expected the program to have been evicted")
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			compiled, err := cache.Compile(ancestry)
+			if err != nil {
+				t.Errorf("Compile failed: %v", err)
+				return
+			}
+			if ok, _ := compiled.Query("?- ancestor(john, jane)."); !ok {
+				t.Error("Expected the query to be satisfied")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,6 +1,7 @@
 package reasoner
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -42,6 +43,36 @@ Not_Kanton(X). :- Disjoint(Gemeinde,Kanton), Type(X,Gemeinde).
 	}
 }
 
+func TestDatalogShadowedBuiltins(t *testing.T) {
+	datalogContent := `
+lessThan(small, big).
+greaterThan(big, small).
+before(X,Y) :- lessThan(X,Y).
+`
+
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"?- lessThan(small, big).", true},
+		{"?- greaterThan(big, small).", true},
+		{"?- before(small, big).", true},
+		{"?- lessThan(apple, zebra).", false},
+		{`?- dateAfter("2024-02-01", "2024-01-01").`, true},
+	}
+
+	for _, tt := range tests {
+		result, err := DLQuery(datalogContent, tt.query)
+		if err != nil {
+			t.Errorf("DLQuery error for %s: %v", tt.query, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("DLQuery(%s) = %v, expected %v", tt.query, result, tt.expected)
+		}
+	}
+}
+
 func TestDatalogMultiCharVar(t *testing.T) {
 	datalogContent := `
 Parent(john, mary).
@@ -98,3 +129,44 @@ Human(X) :- Parent(X, Y). // C++ style comment.
 		t.Errorf("Expected 1 rule, got %d", len(program.Rules))
 	}
 }
+
+func TestDatalogConstraints(t *testing.T) {
+	triples := []string{
+		"<http://example.org/Cat> <" + OWLDisjointWith + "> <http://example.org/Dog> .",
+		"<http://example.org/tom> <" + OWLDifferentFrom + "> <http://example.org/jerry> .",
+		"<http://example.org/tom> <" + RDFType + "> <http://example.org/Cat> .",
+	}
+	facts := ConvertTriplesToDatalogWithOptions(triples, DatalogOptions{Constraints: true})
+	expected := []string{
+		"disjointWith(Cat, Dog).",
+		":- type(X, Cat), type(X, Dog).",
+		"differentFrom(tom, jerry).",
+		":- sameAs(tom, jerry).",
+		"type(tom, Cat).",
+	}
+	if strings.Join(facts, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Unexpected Datalog output:\n%s", strings.Join(facts, "\n"))
+	}
+
+	compiled, err := CompileDatalog(strings.Join(facts, "\n"))
+	if err != nil {
+		t.Fatalf("CompileDatalog failed: %v", err)
+	}
+	if violated := compiled.Violations(); len(violated) != 0 {
+		t.Errorf("Expected no violations, got %v", violated)
+	}
+
+	facts = append(facts, "type(tom, Dog).", "sameAs(tom, jerry).")
+	compiled, err = CompileDatalog(strings.Join(facts, "\n"))
+	if err != nil {
+		t.Fatalf("CompileDatalog failed: %v", err)
+	}
+	violated := compiled.Violations()
+	if len(violated) != 2 || violated[0].String() != ":- type(X, Cat), type(X, Dog)." {
+		t.Errorf("Expected both constraints to be violated, got %v", violated)
+	}
+
+	if _, err := ParseDatalog(":- ."); err == nil {
+		t.Error("Expected an error for a constraint without a body")
+	}
+}
@@ -0,0 +1,159 @@
+package reasoner
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// customDatatype holds the functions registered for a datatype IRI
+type customDatatype struct {
+	validate func(lexical string) error
+	compare  func(a, b string) int
+}
+
+// customDatatypes maps datatype IRIs to their registered functions
+var customDatatypes = map[string]customDatatype{} //nolint:gochecknoglobals
+
+// RegisterDatatype adds validation and comparison for literals of a domain
+// datatype, such as Swiss EGID building identifiers. validate is called with
+// the lexical form of every literal of the datatype that is loaded; invalid
+// literals are loaded anyway and reported as invalid-literal warnings.
+// compare orders two lexical forms like strings.Compare and is used to match
+// literals by value in queries, by CompareLiterals and by the lessThan and
+// greaterThan Datalog builtins. Either function may be nil. Registering an
+// existing IRI replaces it. It is not safe to call concurrently with loading
+// or reasoning.
+func RegisterDatatype(iri string, validate func(lexical string) error, compare func(a, b string) int) error {
+	if iri == "" || strings.ContainsAny(iri, "<>\" \t\n") {
+		return fmt.Errorf("invalid datatype IRI %q", iri)
+	}
+	customDatatypes[iri] = customDatatype{validate: validate, compare: compare}
+	return nil
+}
+
+// validateCustomLiteral checks a literal of a registered datatype with its
+// validation function. Other terms are accepted.
+func validateCustomLiteral(term string) error {
+	if len(term) == 0 || term[0] != '"' {
+		return nil
+	}
+	t := ParseTerm(term)
+	dt, ok := customDatatypes[t.Datatype]
+	if !ok || dt.validate == nil {
+		return nil
+	}
+	if err := dt.validate(t.Value); err != nil {
+		return fmt.Errorf("invalid %s literal %q: %w", t.Datatype, t.Value, err)
+	}
+	return nil
+}
+
+// CompareLiterals orders two literals by value and reports whether they are
+// comparable. Literals of a datatype registered with a compare function are
+// compared with it, numeric literals as numbers, xsd:dateTime, xsd:date and
+// xsd:time literals as instants, and plain or xsd:string literals as
+// strings. A plain literal compared with a typed one is read as having its
+// datatype, so "100" can be compared with "150"^^xsd:integer.
+func CompareLiterals(a, b string) (int, bool) {
+	x, y := ParseTerm(a), ParseTerm(b)
+	if !x.IsLiteral() || !y.IsLiteral() || x.Language != y.Language {
+		return 0, false
+	}
+	if x.Datatype == XSDString {
+		x.Datatype = ""
+	}
+	if y.Datatype == XSDString {
+		y.Datatype = ""
+	}
+	if x.Datatype == "" {
+		x.Datatype = y.Datatype
+	}
+	if y.Datatype == "" {
+		y.Datatype = x.Datatype
+	}
+
+	if dt, ok := customDatatypes[x.Datatype]; ok && x.Datatype == y.Datatype && dt.compare != nil {
+		return dt.compare(x.Value, y.Value), true
+	}
+
+	switch {
+	case xsdNumericTypes[x.Datatype] && xsdNumericTypes[y.Datatype]:
+		m, okM := new(big.Rat).SetString(strings.TrimSpace(x.Value))
+		n, okN := new(big.Rat).SetString(strings.TrimSpace(y.Value))
+		if !okM || !okN {
+			return 0, false
+		}
+		return m.Cmp(n), true
+	case isTemporalDatatype(x.Datatype) && x.Datatype == y.Datatype:
+		m, errM := ParseDateTime(x.String())
+		n, errN := ParseDateTime(y.String())
+		if errM != nil || errN != nil {
+			return 0, false
+		}
+		return m.Compare(n), true
+	case x.Datatype == "" && y.Datatype == "":
+		return cmp.Compare(x.Value, y.Value), true
+	default:
+		return 0, false
+	}
+}
+
+// ObjectGreaterThan returns a filter matching triples whose object is a
+// literal greater than value, as ordered by CompareLiterals
+func ObjectGreaterThan(value string) TripleFilter {
+	return func(t Triple) bool {
+		c, ok := CompareLiterals(t.Object, value)
+		return ok && c > 0
+	}
+}
+
+// ObjectLessThan returns a filter matching triples whose object is a literal
+// less than value, as ordered by CompareLiterals
+func ObjectLessThan(value string) TripleFilter {
+	return func(t Triple) bool {
+		c, ok := CompareLiterals(t.Object, value)
+		return ok && c < 0
+	}
+}
+
+// customEqual reports whether two terms are literals of the same registered
+// datatype that its compare function considers equal. ok is false if the
+// datatype has no compare function.
+func customEqual(a, b string) (equal, ok bool) {
+	x, y := ParseTerm(a), ParseTerm(b)
+	if !x.IsLiteral() || !y.IsLiteral() {
+		return false, false
+	}
+	dt, registered := customDatatypes[x.Datatype]
+	if !registered || dt.compare == nil {
+		return false, false
+	}
+	return x.Datatype == y.Datatype && dt.compare(x.Value, y.Value) == 0, true
+}
+
+// isTemporalDatatype reports whether a datatype is compared as an instant
+func isTemporalDatatype(datatype string) bool {
+	return datatype == XSDDateTime || datatype == XSDDate || datatype == XSDTime
+}
+
+// comparisonBuiltin is a Datalog builtin holding when CompareLiterals
+// orders its two arguments as sign. Unquoted arguments are read as plain
+// literals.
+func comparisonBuiltin(sign int) DLBuiltin {
+	return func(args []string) bool {
+		if len(args) != 2 {
+			return false
+		}
+		terms := make([]string, 2)
+		for i, arg := range args {
+			terms[i] = arg
+			if !strings.HasPrefix(arg, `"`) {
+				terms[i] = NewLiteral(arg, "").String()
+			}
+		}
+		c, ok := CompareLiterals(terms[0], terms[1])
+		return ok && cmp.Compare(c, 0) == sign
+	}
+}
@@ -0,0 +1,121 @@
+package reasoner
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const testEGID = "http://example.org/types#egid"
+
+// registerTestEGID registers a datatype of numeric building identifiers
+// compared as numbers, so "007" equals "7" and "9" is less than "10"
+func registerTestEGID(t *testing.T) {
+	t.Helper()
+	validate := func(lexical string) error {
+		if lexical == "" || len(lexical) > 9 || strings.Trim(lexical, "0123456789") != "" {
+			return errors.New("must be 1 to 9 digits")
+		}
+		return nil
+	}
+	compare := func(a, b string) int {
+		m, _ := strconv.Atoi(a)
+		n, _ := strconv.Atoi(b)
+		return m - n
+	}
+	if err := RegisterDatatype(testEGID, validate, compare); err != nil {
+		t.Fatalf("RegisterDatatype() error = %v", err)
+	}
+	t.Cleanup(func() { delete(customDatatypes, testEGID) })
+}
+
+func TestRegisterDatatypeValidation(t *testing.T) {
+	registerTestEGID(t)
+
+	r := NewReasoner()
+	err := r.LoadTurtle(`@prefix ex: <http://example.org/> .
+@prefix t: <http://example.org/types#> .
+ex:a ex:egid "190012"^^t:egid .
+ex:b ex:egid "19-0012"^^t:egid .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+	if n := len(r.Query("", "http://example.org/egid", "")); n != 2 {
+		t.Errorf("loaded %d triples, want 2", n)
+	}
+
+	var messages []string
+	for _, d := range r.Diagnostics() {
+		if d.Code == CodeInvalidLiteral {
+			messages = append(messages, d.Message)
+		}
+	}
+	if len(messages) != 1 || !strings.Contains(messages[0], `"19-0012"`) {
+		t.Errorf("invalid-literal diagnostics = %q, want one for \"19-0012\"", messages)
+	}
+
+	if err := RegisterDatatype("", nil, nil); err == nil {
+		t.Error("RegisterDatatype(\"\") succeeded, want error")
+	}
+}
+
+func TestCompareLiterals(t *testing.T) {
+	registerTestEGID(t)
+	egid := func(s string) string { return NewLiteral(s, testEGID).String() }
+
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{egid("9"), egid("10"), -1, true},
+		{egid("007"), egid("7"), 0, true},
+		{`"10"`, egid("9"), 1, true},
+		{`"9"^^<` + XSDInteger + `>`, `"10.5"^^<` + XSDDecimal + `>`, -1, true},
+		{`"2024-03-02"^^<` + XSDDate + `>`, `"2024-03-01"^^<` + XSDDate + `>`, 1, true},
+		{`"b"`, `"a"^^<` + XSDString + `>`, 1, true},
+		{`"a"@en`, `"a"@de`, 0, false},
+		{egid("1"), `"1"^^<` + XSDInteger + `>`, 0, false},
+		{"http://example.org/a", `"a"`, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := CompareLiterals(tt.a, tt.b)
+		if ok != tt.wantOK || (ok && (got > 0) != (tt.want > 0)) || (ok && (got < 0) != (tt.want < 0)) {
+			t.Errorf("CompareLiterals(%s, %s) = %d, %v, want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRegisteredDatatypeQueries(t *testing.T) {
+	registerTestEGID(t)
+
+	r := NewReasoner(WithLiteralMatching(ValueMatching))
+	err := r.LoadTurtle(`@prefix ex: <http://example.org/> .
+@prefix t: <http://example.org/types#> .
+ex:a ex:egid "007"^^t:egid .
+ex:b ex:egid "12"^^t:egid .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+
+	matches := r.Query("", "http://example.org/egid", NewLiteral("7", testEGID).String())
+	if len(matches) != 1 || matches[0].Subject != "http://example.org/a" {
+		t.Errorf("Query by value = %v, want ex:a", matches)
+	}
+
+	above := r.QueryFiltered("", "http://example.org/egid", "", ObjectGreaterThan(NewLiteral("8", testEGID).String()))
+	if len(above) != 1 || above[0].Subject != "http://example.org/b" {
+		t.Errorf("QueryFiltered(ObjectGreaterThan) = %v, want ex:b", above)
+	}
+
+	if !datalogBuiltins["lessThan"]([]string{NewLiteral("007", testEGID).String(), `"12"`}) {
+		t.Error("lessThan(007, 12) = false, want true")
+	}
+	if datalogBuiltins["greaterThan"]([]string{NewLiteral("007", testEGID).String(), `"12"`}) {
+		t.Error("greaterThan(007, 12) = true, want false")
+	}
+}
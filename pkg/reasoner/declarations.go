@@ -0,0 +1,148 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Declaration types of OWL and RDFS entities
+const (
+	OWLObjectProperty     = "http://www.w3.org/2002/07/owl#ObjectProperty"
+	OWLDatatypeProperty   = "http://www.w3.org/2002/07/owl#DatatypeProperty"
+	OWLAnnotationProperty = "http://www.w3.org/2002/07/owl#AnnotationProperty"
+	OWLNamedIndividual    = "http://www.w3.org/2002/07/owl#NamedIndividual"
+	RDFSClass             = "http://www.w3.org/2000/01/rdf-schema#Class"
+	RDFProperty           = "http://www.w3.org/1999/02/22-rdf-syntax-ns#Property"
+)
+
+// declarationTypes lists the types that declare an entity, with the kind
+// of entity they declare
+var declarationTypes = map[string]string{ //nolint:gochecknoglobals
+	OWLClass:              "class",
+	RDFSClass:             "class",
+	OWLObjectProperty:     "object property",
+	OWLDatatypeProperty:   "datatype property",
+	OWLAnnotationProperty: "annotation property",
+	RDFProperty:           "property",
+	OWLNamedIndividual:    "individual",
+}
+
+// Punning is an IRI declared as several kinds of entity in a way that
+// usually indicates a modeling error
+type Punning struct {
+	IRI          string
+	Declarations []string // Declaration types of the IRI, sorted
+}
+
+func (p Punning) String() string {
+	return fmt.Sprintf("%s is declared as %s", p.IRI, strings.Join(p.Declarations, " and "))
+}
+
+// DeclaredAs returns the declaration types of an IRI, i.e. those of its
+// rdf:type values that are owl:Class, rdfs:Class, owl:ObjectProperty,
+// owl:DatatypeProperty, owl:AnnotationProperty, rdf:Property or
+// owl:NamedIndividual, sorted
+func (r *Reasoner) DeclaredAs(iri string) []string {
+	var declared []string
+	for _, t := range r.store.FindBySubjectPredicate(iri, RDFType) {
+		if _, ok := declarationTypes[t.Object]; ok {
+			declared = append(declared, t.Object)
+		}
+	}
+	sort.Strings(declared)
+	return declared
+}
+
+// Punnings returns the IRIs declared both as a class and as a property, or
+// both as an object and a datatype property, sorted by IRI. Using an IRI as
+// a class and an individual is legitimate OWL 2 punning and not reported.
+func (r *Reasoner) Punnings() []Punning {
+	subjects := make(map[string]bool)
+	for declaration := range declarationTypes {
+		for _, t := range r.store.FindByPredicateObject(RDFType, declaration) {
+			subjects[t.Subject] = true
+		}
+	}
+
+	var punnings []Punning
+	for iri := range subjects {
+		declared := r.DeclaredAs(iri)
+
+		kinds := make(map[string]bool)
+		for _, d := range declared {
+			kinds[declarationTypes[d]] = true
+		}
+		property := kinds["object property"] || kinds["datatype property"] ||
+			kinds["annotation property"] || kinds["property"]
+		if (kinds["class"] && property) || (kinds["object property"] && kinds["datatype property"]) {
+			punnings = append(punnings, Punning{IRI: iri, Declarations: declared})
+		}
+	}
+
+	sort.Slice(punnings, func(i, j int) bool {
+		return punnings[i].IRI < punnings[j].IRI
+	})
+	return punnings
+}
+
+// CoerceIRILiterals replaces literal values of declared object properties
+// whose lexical form is an http, https or urn IRI, such as
+// "http://example.org/x", with that IRI, a common mistake in converted
+// data. Each replacement is recorded as a coerced-literal warning in
+// Diagnostics. Returns the number of triples replaced. Coerce before
+// reasoning, since triples inferred from the literals are not retracted.
+func (r *Reasoner) CoerceIRILiterals() int {
+	if r.store.frozen {
+		return 0
+	}
+
+	remove := make(map[string]bool)
+	var replacements []Triple
+	sources := make(map[Triple][]string)
+	for _, declaration := range r.store.FindByPredicateObject(RDFType, OWLObjectProperty) {
+		for _, t := range r.store.FindByPredicate(declaration.Subject) {
+			term := ParseTerm(t.Object)
+			if !term.IsLiteral() || (term.Datatype != "" && term.Datatype != XSDString) || !looksLikeIRI(term.Value) {
+				continue
+			}
+			remove[tripleKey(t)] = true
+			replacement := Triple{Subject: t.Subject, Predicate: t.Predicate, Object: term.Value}
+			replacements = append(replacements, replacement)
+			sources[replacement] = append(sources[replacement], r.store.SourceOf(t)...)
+			r.loadDiagnostics = append(r.loadDiagnostics, Diagnostic{
+				Severity:   SeverityWarning,
+				Code:       CodeCoercedLiteral,
+				Message:    fmt.Sprintf("literal %s of object property %s on %s was replaced by an IRI", t.Object, t.Predicate, t.Subject),
+				Subject:    t.Subject,
+				Suggestion: "write the value as <" + term.Value + ">",
+			})
+		}
+	}
+	if len(replacements) == 0 {
+		return 0
+	}
+
+	r.store.removeAll(remove)
+	for _, t := range replacements {
+		r.store.Add(t)
+		for _, source := range sources[t] {
+			r.store.AddFrom(t, source)
+		}
+	}
+	r.closed = 0
+	return len(replacements)
+}
+
+// looksLikeIRI reports whether a lexical form is an http, https or urn IRI
+func looksLikeIRI(s string) bool {
+	if strings.ContainsAny(s, " \t\r\n<>\"{}|\\^`") {
+		return false
+	}
+	for _, scheme := range []string{"http://", "https://", "urn:"} {
+		if strings.HasPrefix(s, scheme) && len(s) > len(scheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package reasoner
+
+import "testing"
+
+func TestDeclarationsAndPunning(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Person a owl:Class .
+ex:name a owl:DatatypeProperty .
+ex:address a owl:Class , owl:ObjectProperty .
+ex:knows a owl:ObjectProperty , owl:DatatypeProperty .
+ex:Eagle a owl:Class , owl:NamedIndividual .
+ex:alice a ex:Person , owl:NamedIndividual .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	ex := "http://example.org/"
+	if got := r.DeclaredAs(ex + "alice"); !equalStrings(got, []string{OWLNamedIndividual}) {
+		t.Errorf("DeclaredAs(alice) = %v", got)
+	}
+	if got := r.DeclaredAs(ex + "address"); !equalStrings(got, []string{OWLClass, OWLObjectProperty}) {
+		t.Errorf("DeclaredAs(address) = %v", got)
+	}
+	if got := r.DeclaredAs(ex + "unknown"); len(got) != 0 {
+		t.Errorf("DeclaredAs(unknown) = %v", got)
+	}
+
+	punnings := r.Punnings()
+	if len(punnings) != 2 || punnings[0].IRI != ex+"address" || punnings[1].IRI != ex+"knows" {
+		t.Errorf("Expected address and knows to be reported, got %v", punnings)
+	}
+}
+
+const propertyKindDocument = `
+@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:knows a owl:ObjectProperty .
+ex:name a owl:DatatypeProperty .
+ex:alice ex:knows ex:bob , "http://example.org/carol" , "Dave" ;
+    ex:name "Alice" , ex:AliceName .
+`
+
+func TestPropertyKindConsistency(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(propertyKindDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	ex := "http://example.org/"
+	var messages []string
+	for _, i := range r.GetStore().CheckConsistency() {
+		messages = append(messages, i.Message)
+	}
+	expected := []string{
+		ex + `knows is an object property but ` + ex + `alice has the literal value "Dave"`,
+		ex + `knows is an object property but ` + ex + `alice has the literal value "http://example.org/carol"`,
+		ex + `name is a datatype property but ` + ex + `alice has the resource value ` + ex + `AliceName`,
+	}
+	if !equalStrings(messages, expected) {
+		t.Errorf("CheckConsistency() = %v, want %v", messages, expected)
+	}
+}
+
+func TestCoerceIRILiterals(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(propertyKindDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	if n := r.CoerceIRILiterals(); n != 1 {
+		t.Errorf("Expected 1 coerced literal, got %d", n)
+	}
+	ex := "http://example.org/"
+	store := r.GetStore()
+	if !store.Contains(Triple{Subject: ex + "alice", Predicate: ex + "knows", Object: ex + "carol"}) || store.Contains(Triple{Subject: ex + "alice", Predicate: ex + "knows", Object: `"http://example.org/carol"`}) {
+		t.Error("Expected the IRI-shaped literal to be replaced by the IRI")
+	}
+	if !store.Contains(Triple{Subject: ex + "alice", Predicate: ex + "knows", Object: `"Dave"`}) {
+		t.Error("Literals that are not IRIs must be kept")
+	}
+
+	coerced := 0
+	for _, d := range r.Diagnostics() {
+		if d.Code == CodeCoercedLiteral {
+			coerced++
+		}
+	}
+	if coerced != 1 {
+		t.Errorf("Expected 1 coerced-literal diagnostic, got %d", coerced)
+	}
+	if r.CoerceIRILiterals() != 0 {
+		t.Error("Expected nothing left to coerce")
+	}
+}
@@ -0,0 +1,180 @@
+package reasoner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RDFSSeeAlso links a resource to another describing it
+const RDFSSeeAlso = "http://www.w3.org/2000/01/rdf-schema#seeAlso"
+
+// dereferenceAccept asks for the formats the parser reads
+const dereferenceAccept = "text/turtle, application/n-triples;q=0.9, application/rdf+xml;q=0.8, application/ld+json;q=0.8, application/trig;q=0.8, text/plain;q=0.1"
+
+// Defaults of DereferenceOptions
+const (
+	DefaultDereferenceTimeout  = 30 * time.Second
+	DefaultDereferenceMaxBytes = 16 << 20
+)
+
+// DereferenceOptions configures DereferenceContext
+type DereferenceOptions struct {
+	// Client performs the requests; by default a client with a timeout of
+	// DefaultDereferenceTimeout
+	Client *http.Client
+	// Depth is the number of times links are followed from the loaded
+	// descriptions; zero loads only the description of the IRI itself
+	Depth int
+	// Follow lists the predicates whose IRI values are dereferenced in
+	// turn, by default owl:sameAs and rdfs:seeAlso
+	Follow []string
+	// MaxBytes limits the size of each document, by default
+	// DefaultDereferenceMaxBytes
+	MaxBytes int64
+}
+
+// Dereference fetches the description of an http or https IRI with content
+// negotiation for Turtle and N-Triples and loads it, as Linked Data clients
+// do. It returns the URLs of the loaded documents.
+func (r *Reasoner) Dereference(iri string) ([]string, error) {
+	return r.DereferenceContext(context.Background(), iri, DereferenceOptions{})
+}
+
+// DereferenceContext is like Dereference but follows links to opts.Depth.
+// Only a failure to load the description of iri itself is returned as an
+// error; documents reached by following links that cannot be loaded are
+// reported as dereference-failed diagnostics.
+func (r *Reasoner) DereferenceContext(ctx context.Context, iri string, opts DereferenceOptions) ([]string, error) {
+	if r.store.frozen {
+		return nil, ErrReadOnly
+	}
+
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: DefaultDereferenceTimeout}
+	}
+	if opts.Follow == nil {
+		opts.Follow = []string{OWLSameAs, RDFSSeeAlso}
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultDereferenceMaxBytes
+	}
+
+	var fetched []string
+	visited := make(map[string]bool)
+	targets := []string{iri}
+	for depth := 0; depth <= opts.Depth && len(targets) > 0; depth++ {
+		var next []string
+		for _, target := range targets {
+			doc, err := documentURL(target)
+			if err == nil {
+				if visited[doc] {
+					continue
+				}
+				visited[doc] = true
+				doc, err = r.fetchDescription(ctx, doc, opts)
+				visited[doc] = true
+			}
+			if err != nil {
+				if depth == 0 {
+					return nil, err
+				}
+				r.loadDiagnostics = append(r.loadDiagnostics, Diagnostic{
+					Severity:   SeverityWarning,
+					Code:       CodeDereferenceFailed,
+					Message:    err.Error(),
+					Source:     target,
+					Suggestion: "check that the IRI is dereferenceable, or follow fewer links",
+				})
+				continue
+			}
+			fetched = append(fetched, doc)
+
+			for _, predicate := range opts.Follow {
+				for _, t := range r.store.FindBySubjectPredicate(target, predicate) {
+					if _, err := documentURL(t.Object); err == nil {
+						next = append(next, t.Object)
+					}
+				}
+			}
+		}
+		targets = next
+	}
+	return fetched, nil
+}
+
+// documentURL returns the URL of the document describing an http or https
+// IRI, without its fragment
+func documentURL(iri string) (string, error) {
+	u, err := url.Parse(iri)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("cannot dereference <%s>: not an http or https IRI", iri)
+	}
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+// fetchDescription requests a document and loads it, returning its URL
+// after redirects
+func (r *Reasoner) fetchDescription(ctx context.Context, doc string, opts DereferenceOptions) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, doc, nil)
+	if err != nil {
+		return doc, fmt.Errorf("failed to dereference <%s>: %w", doc, err)
+	}
+	req.Header.Set("Accept", dereferenceAccept)
+
+	resp, err := opts.Client.Do(req)
+	if err != nil {
+		return doc, fmt.Errorf("failed to dereference <%s>: %w", doc, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return doc, fmt.Errorf("failed to dereference <%s>: %s", doc, resp.Status)
+	}
+	final := resp.Request.URL.String()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, opts.MaxBytes+1))
+	if err != nil {
+		return final, fmt.Errorf("failed to read <%s>: %w", final, err)
+	}
+	if int64(len(body)) > opts.MaxBytes {
+		return final, fmt.Errorf("failed to read <%s>: larger than %d bytes", final, opts.MaxBytes)
+	}
+	content := string(body)
+
+	var format Format
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/turtle", "application/x-turtle":
+		format = FormatTurtle
+	case "application/n-triples":
+		format = FormatNTriples
+	case "application/n-quads":
+		format = FormatNQuads
+	case "application/rdf+xml":
+		format = FormatRDFXML
+	case "application/ld+json":
+		format = FormatJSONLD
+	case "application/trig":
+		format = FormatTriG
+	case "text/n3":
+		format = FormatN3
+	default:
+		format = DetectFormat(final, content)
+	}
+	if !format.CanLoad() {
+		if mediaType == "" {
+			mediaType = "no content type"
+		}
+		return final, fmt.Errorf("cannot load <%s>: %s (%s) is not supported", final, format, mediaType)
+	}
+
+	if err := r.LoadDocument(final, content, format); err != nil {
+		return final, fmt.Errorf("failed to load <%s>: %w", final, err)
+	}
+	return final, nil
+}
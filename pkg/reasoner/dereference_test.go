@@ -0,0 +1,118 @@
+package reasoner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// linkedDataServer serves Turtle descriptions of /alice, linked with
+// owl:sameAs to /person/1, which links with rdfs:seeAlso to /missing
+func linkedDataServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.Contains(req.Header.Get("Accept"), "text/turtle") {
+			t.Errorf("Expected Turtle to be accepted, got %q", req.Header.Get("Accept"))
+		}
+		base := server.URL
+		switch req.URL.Path {
+		case "/alice":
+			w.Header().Set("Content-Type", "text/turtle; charset=utf-8")
+			_, _ = w.Write([]byte(`<` + base + `/alice#me> a <http://xmlns.com/foaf/0.1/Person> ;
+    <http://www.w3.org/2002/07/owl#sameAs> <` + base + `/person/1> .
+`))
+		case "/person/1":
+			w.Header().Set("Content-Type", "application/n-triples")
+			_, _ = w.Write([]byte(`<` + base + `/person/1> <http://xmlns.com/foaf/0.1/name> "Alice" .
+<` + base + `/person/1> <http://www.w3.org/2000/01/rdf-schema#seeAlso> <` + base + `/missing> .
+`))
+		case "/json":
+			w.Header().Set("Content-Type", "application/ld+json")
+			_, _ = w.Write([]byte(`{"@id": "x", "@type": "http://xmlns.com/foaf/0.1/Person"}`))
+		case "/trig":
+			w.Header().Set("Content-Type", "application/trig")
+			_, _ = w.Write([]byte(`<` + base + `/g> { <` + base + `/a> <` + base + `/p> <` + base + `/b> . }`))
+		case "/empty":
+			w.Header().Set("Content-Type", "text/plain")
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDereference(t *testing.T) {
+	server := linkedDataServer(t)
+
+	r := NewReasoner()
+	fetched, err := r.Dereference(server.URL + "/alice#me")
+	if err != nil {
+		t.Fatalf("Dereference failed: %v", err)
+	}
+	if len(fetched) != 1 || fetched[0] != server.URL+"/alice" {
+		t.Errorf("Expected only the document of the IRI to be fetched, got %v", fetched)
+	}
+	if len(r.Query(server.URL+"/alice#me", RDFType, "")) != 1 {
+		t.Error("Expected the description to be loaded")
+	}
+
+	// Relative IRIs of JSON-LD are resolved against the document
+	if _, err := r.Dereference(server.URL + "/json"); err != nil {
+		t.Fatalf("Dereference failed: %v", err)
+	}
+	if len(r.Query(server.URL+"/x", RDFType, "")) != 1 {
+		t.Error("Expected the JSON-LD description to be loaded")
+	}
+
+	if _, err := r.Dereference(server.URL + "/trig"); err != nil {
+		t.Fatalf("Dereference failed: %v", err)
+	}
+	if got := r.Query(server.URL+"/a", "", ""); len(got) != 1 || got[0].Graph != server.URL+"/g" {
+		t.Errorf("Expected the TriG graph to be loaded, got %v", got)
+	}
+}
+
+func TestDereferenceFollowLinks(t *testing.T) {
+	server := linkedDataServer(t)
+
+	r := NewReasoner()
+	fetched, err := r.DereferenceContext(context.Background(), server.URL+"/alice#me", DereferenceOptions{Depth: 2})
+	if err != nil {
+		t.Fatalf("Dereference failed: %v", err)
+	}
+	if len(fetched) != 2 {
+		t.Errorf("Expected /alice and /person/1 to be fetched, got %v", fetched)
+	}
+	if len(r.Query(server.URL+"/person/1", "http://xmlns.com/foaf/0.1/name", "")) != 1 {
+		t.Error("Expected the owl:sameAs link to be followed")
+	}
+
+	var failed []Diagnostic
+	for _, d := range r.Diagnostics() {
+		if d.Code == CodeDereferenceFailed {
+			failed = append(failed, d)
+		}
+	}
+	if len(failed) != 1 || failed[0].Source != server.URL+"/missing" {
+		t.Errorf("Expected a dereference-failed diagnostic for /missing, got %v", failed)
+	}
+}
+
+func TestDereferenceErrors(t *testing.T) {
+	server := linkedDataServer(t)
+
+	for _, iri := range []string{"urn:isbn:123", server.URL + "/missing", server.URL + "/empty"} {
+		if _, err := NewReasoner().Dereference(iri); err == nil {
+			t.Errorf("Expected dereferencing %s to fail", iri)
+		}
+	}
+
+	_, err := NewReasoner().DereferenceContext(context.Background(), server.URL+"/alice", DereferenceOptions{MaxBytes: 10})
+	if err == nil || !strings.Contains(err.Error(), "larger than 10 bytes") {
+		t.Errorf("Expected the size limit to be enforced, got %v", err)
+	}
+}
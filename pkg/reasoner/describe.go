@@ -0,0 +1,98 @@
+package reasoner
+
+import (
+	"sort"
+	"strings"
+)
+
+// Description lists the triples about a single resource
+type Description struct {
+	Resource string   // The described resource
+	Outgoing []Triple // Triples with the resource as subject
+	Incoming []Triple // Triples with the resource as object
+}
+
+// Describe returns all triples in which the resource occurs as subject or
+// object. A triple with the resource in both positions is listed as outgoing.
+func (ts *TripleStore) Describe(resource string) Description {
+	d := Description{Resource: resource}
+	d.Outgoing = ts.FindBySubject(resource)
+	for _, t := range ts.FindByObject(resource) {
+		if t.Subject != resource {
+			d.Incoming = append(d.Incoming, t)
+		}
+	}
+	return d
+}
+
+// Format renders the description for humans, compacting IRIs with the given
+// prefixes and grouping values by predicate, types first
+func (d Description) Format(prefixes map[string]string) string {
+	w := newTurtleWriter(prefixes)
+
+	group := func(triples []Triple, value func(Triple) string) (keys []string, values map[string][]string) {
+		values = make(map[string][]string)
+		for _, t := range triples {
+			p := w.term(t.Predicate)
+			if t.Predicate == RDFType {
+				p = "a"
+			}
+			if _, ok := values[p]; !ok {
+				keys = append(keys, p)
+			}
+			values[p] = append(values[p], w.term(value(t)))
+		}
+		// rdf:type first, then by compacted predicate
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i] == "a" || keys[j] == "a" {
+				return keys[i] == "a" && keys[j] != "a"
+			}
+			return keys[i] < keys[j]
+		})
+		for _, k := range keys {
+			sort.Strings(values[k])
+		}
+		return keys, values
+	}
+
+	writeGroups := func(sb *strings.Builder, keys []string, values map[string][]string) {
+		for _, k := range keys {
+			sb.WriteString("  " + k + "\n")
+			for _, v := range values[k] {
+				sb.WriteString("    " + v + "\n")
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(w.term(d.Resource) + "\n")
+	if len(d.Outgoing) == 0 && len(d.Incoming) == 0 {
+		sb.WriteString("  (no triples)\n")
+		return sb.String()
+	}
+
+	keys, values := group(d.Outgoing, func(t Triple) string { return t.Object })
+	writeGroups(&sb, keys, values)
+
+	if len(d.Incoming) > 0 {
+		sb.WriteString("\nReferenced by:\n")
+		keys, values = group(d.Incoming, func(t Triple) string { return t.Subject })
+		writeGroups(&sb, keys, values)
+	}
+
+	return sb.String()
+}
+
+// ExpandPrefixedName expands a prefixed name such as ex:alice with the given
+// prefixes and strips angle brackets from <iri>. Other names are returned as is.
+func ExpandPrefixedName(name string, prefixes map[string]string) string {
+	if strings.HasPrefix(name, "<") && strings.HasSuffix(name, ">") {
+		return name[1 : len(name)-1]
+	}
+	if i := strings.Index(name, ":"); i >= 0 && !strings.HasPrefix(name[i:], "://") {
+		if ns, ok := prefixes[name[:i]]; ok {
+			return ns + name[i+1:]
+		}
+	}
+	return name
+}
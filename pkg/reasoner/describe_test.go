@@ -0,0 +1,55 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:Employee rdfs:subClassOf ex:Person .
+ex:alice a ex:Employee ; ex:name "Alice" ; ex:knows ex:bob .
+ex:bob ex:knows ex:alice .
+ex:acme ex:employs ex:alice .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	prefixes := r.Prefixes()
+	alice := ExpandPrefixedName("ex:alice", prefixes)
+	if alice != "http://example.org/alice" {
+		t.Fatalf("ExpandPrefixedName = %s", alice)
+	}
+
+	d := r.GetStore().Describe(alice)
+	if len(d.Outgoing) != 4 || len(d.Incoming) != 2 {
+		t.Errorf("Expected 4 outgoing and 2 incoming triples, got %d and %d", len(d.Outgoing), len(d.Incoming))
+	}
+
+	expected := `ex:alice
+  a
+    ex:Employee
+    ex:Person
+  ex:knows
+    ex:bob
+  ex:name
+    "Alice"
+
+Referenced by:
+  ex:employs
+    ex:acme
+  ex:knows
+    ex:bob
+`
+	if got := d.Format(prefixes); got != expected {
+		t.Errorf("Unexpected format:\n%s\nexpected:\n%s", got, expected)
+	}
+	if !strings.Contains(r.GetStore().Describe("http://example.org/nobody").Format(prefixes), "(no triples)") {
+		t.Errorf("Expected unknown resource to be reported as empty")
+	}
+}
@@ -0,0 +1,215 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity ranks diagnostics
+type Severity int
+
+const (
+	// SeverityInfo is a notice that needs no action
+	SeverityInfo Severity = iota
+	// SeverityWarning is a likely problem that did not stop processing
+	SeverityWarning
+	// SeverityError is a problem that made input be ignored or results unreliable
+	SeverityError
+)
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// MarshalText encodes the severity by name, e.g. in JSON
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Diagnostic codes
+const (
+	CodeSkippedStatement     = "skipped-statement"
+	CodeUnsupportedConstruct = "unsupported-construct"
+	CodeInvalidLiteral       = "invalid-literal"
+	CodePunning              = "punning"
+	CodeMalformedList        = "malformed-list"
+	CodeInconsistency        = "inconsistency"
+	CodeDuplicateLiteral     = "duplicate-literal"
+	CodeCoercedLiteral       = "coerced-literal"
+	CodeRuleDisabled         = "rule-disabled"
+	CodeUndefinedPrefix      = "undefined-prefix"
+	CodeRelativeIRI          = "relative-iri"
+	CodeUnknownDatatype      = "unknown-datatype"
+	CodeDereferenceFailed    = "dereference-failed"
+	CodeConstraintViolation  = "constraint-violation"
+)
+
+// Diagnostic is a problem or notice found while loading or reasoning
+type Diagnostic struct {
+	Severity   Severity `json:"severity"`
+	Code       string   `json:"code"`                 // Stable identifier such as "invalid-literal"
+	Message    string   `json:"message"`              // Human-readable description
+	Source     string   `json:"source,omitempty"`     // Document the problem was found in
+	Line       int      `json:"line,omitempty"`       // 1-based line in Source, if known
+	Subject    string   `json:"subject,omitempty"`    // Resource the problem is about, if any
+	Suggestion string   `json:"suggestion,omitempty"` // How to fix the problem
+}
+
+// Location returns "source:line", "line N" or "" depending on the known fields
+func (d Diagnostic) Location() string {
+	switch {
+	case d.Source != "" && d.Line > 0:
+		return fmt.Sprintf("%s:%d", d.Source, d.Line)
+	case d.Line > 0:
+		return fmt.Sprintf("line %d", d.Line)
+	default:
+		return d.Source
+	}
+}
+
+// String formats the diagnostic as "severity[code] location: message (suggestion)"
+func (d Diagnostic) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s[%s] ", d.Severity, d.Code)
+	if location := d.Location(); location != "" {
+		sb.WriteString(location)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(d.Message)
+	if d.Suggestion != "" {
+		fmt.Fprintf(&sb, " (%s)", d.Suggestion)
+	}
+	return sb.String()
+}
+
+// Diagnostics returns everything worth reporting about the loaded data:
+// skipped statements and invalid literals in load order, rules disabled in
+// the last reasoning run, then punning, near-duplicate literals, malformed
+// rdf:List axioms, inconsistencies if enabled with WithConsistencyCheck and
+// violations of the constraints selected with WithClosedWorld. Call it
+// after reasoning to include inconsistencies between inferred triples.
+func (r *Reasoner) Diagnostics() []Diagnostic {
+	diagnostics := make([]Diagnostic, len(r.loadDiagnostics), len(r.loadDiagnostics)+len(r.runDiagnostics))
+	copy(diagnostics, r.loadDiagnostics)
+	diagnostics = append(diagnostics, r.runDiagnostics...)
+
+	for _, p := range r.Punnings() {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity:   SeverityWarning,
+			Code:       CodePunning,
+			Message:    p.String(),
+			Subject:    p.IRI,
+			Suggestion: "use separate IRIs for classes and properties",
+		})
+	}
+	for _, d := range r.store.FindDuplicateLiterals() {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity:   SeverityWarning,
+			Code:       CodeDuplicateLiteral,
+			Message:    d.String(),
+			Subject:    d.Subject,
+			Suggestion: "keep a single spelling of the value",
+		})
+	}
+	diagnostics = append(diagnostics, r.listDiagnostics()...)
+
+	var inconsistencies []Inconsistency
+	if r.checkConsistency {
+		inconsistencies = r.store.CheckConsistency()
+	}
+	for _, i := range inconsistencies {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityError,
+			Code:     CodeInconsistency,
+			Message:  i.Message,
+			Source:   strings.Join(r.store.sourcesOf(i.Triples), ", "),
+			Subject:  i.Triples[0].Subject,
+		})
+	}
+	for _, v := range r.CheckConstraints() {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity:   SeverityError,
+			Code:       CodeConstraintViolation,
+			Message:    v.Message,
+			Source:     strings.Join(r.store.sourcesOf(v.Triples[1:]), ", "),
+			Subject:    v.Subject,
+			Suggestion: "assert the missing facts or correct the data",
+		})
+	}
+
+	return diagnostics
+}
+
+// addParseDiagnostics records the errors and warnings of the last parse
+func (r *Reasoner) addParseDiagnostics(source string, errs, warnings []ParseError) {
+	r.loadDiagnostics = append(r.loadDiagnostics, parseDiagnostics(errs, warnings, source)...)
+}
+
+// parseDiagnostics returns the errors and warnings of a parse in line order
+func parseDiagnostics(errs, warnings []ParseError, source string) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, e := range errs {
+		d := Diagnostic{
+			Severity:   SeverityError,
+			Code:       CodeSkippedStatement,
+			Message:    "statement skipped: " + e.Message,
+			Source:     source,
+			Line:       e.Line,
+			Suggestion: "fix the syntax of the statement",
+		}
+		if strings.HasPrefix(e.Message, "unsupported ") {
+			d.Code = CodeUnsupportedConstruct
+			d.Suggestion = "rewrite the statement with plain triples"
+		}
+		diagnostics = append(diagnostics, d)
+	}
+
+	for _, w := range warnings {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity:   SeverityWarning,
+			Code:       CodeInvalidLiteral,
+			Message:    w.Message,
+			Source:     source,
+			Line:       w.Line,
+			Suggestion: "fix the lexical form or change the datatype",
+		})
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Line < diagnostics[j].Line
+	})
+	return diagnostics
+}
+
+// listDiagnostics reports rdf:List values of axioms that rules expect to
+// be lists, but which are malformed and therefore ignored
+func (r *Reasoner) listDiagnostics() []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, predicate := range []string{OWLMembers, OWLDistinctMembers, OWLHasKey} {
+		for _, t := range r.store.FindByPredicate(predicate) {
+			if _, err := r.store.ListMembers(t.Object); err != nil {
+				diagnostics = append(diagnostics, Diagnostic{
+					Severity:   SeverityWarning,
+					Code:       CodeMalformedList,
+					Message:    fmt.Sprintf("%s of %s is ignored: %v", predicate, t.Subject, err),
+					Subject:    t.Subject,
+					Suggestion: "give every list node one rdf:first and one rdf:rest, ending with rdf:nil",
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Message < diagnostics[j].Message
+	})
+	return diagnostics
+}
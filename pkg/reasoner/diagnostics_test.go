@@ -0,0 +1,60 @@
+package reasoner
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDiagnostics(t *testing.T) {
+	r := NewReasoner(WithConsistencyCheck())
+	err := r.LoadTurtleFrom("data.ttl", `
+@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:a ex:born "2024-13-01"^^xsd:date .
+ex:b ex:tags 'a' .
+ex:c a owl:Class , owl:ObjectProperty .
+ex:x owl:sameAs ex:y ; owl:differentFrom ex:y .
+_:all a owl:AllDifferent ; owl:members _:l .
+_:l rdf:first ex:x .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtleFrom failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	var got []string
+	for _, d := range r.Diagnostics() {
+		got = append(got, d.Severity.String()+" "+d.Code+" "+d.Location())
+	}
+	expected := []string{
+		"warning invalid-literal data.ttl:6",
+		"error unsupported-construct data.ttl:7",
+		"warning punning ",
+		"warning malformed-list ",
+		"error inconsistency ",
+	}
+	if !equalStrings(got, expected) {
+		t.Errorf("Diagnostics() = %q, want %q", got, expected)
+	}
+
+	// Inconsistencies are only checked on request
+	unchecked := r.Fork()
+	unchecked.checkConsistency = false
+	for _, d := range unchecked.Diagnostics() {
+		if d.Code == CodeInconsistency {
+			t.Errorf("Unexpected inconsistency without WithConsistencyCheck: %s", d)
+		}
+	}
+
+	d := Diagnostic{Severity: SeverityWarning, Code: CodeInvalidLiteral, Message: "bad", Line: 3, Suggestion: "fix it"}
+	if s := d.String(); s != "warning[invalid-literal] line 3: bad (fix it)" {
+		t.Errorf("String() = %q", s)
+	}
+	encoded, err := json.Marshal(d)
+	if err != nil || !strings.Contains(string(encoded), `"severity":"warning"`) || strings.Contains(string(encoded), "source") {
+		t.Errorf("json.Marshal = %s, %v", encoded, err)
+	}
+}
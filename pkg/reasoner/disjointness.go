@@ -0,0 +1,79 @@
+package reasoner
+
+// OWL vocabulary for disjointness and distinctness
+const (
+	OWLNothing            = "http://www.w3.org/2002/07/owl#Nothing"
+	OWLDifferentFrom      = "http://www.w3.org/2002/07/owl#differentFrom"
+	OWLDisjointWith       = "http://www.w3.org/2002/07/owl#disjointWith"
+	OWLAllDifferent       = "http://www.w3.org/2002/07/owl#AllDifferent"
+	OWLAllDisjointClasses = "http://www.w3.org/2002/07/owl#AllDisjointClasses"
+	OWLMembers            = "http://www.w3.org/2002/07/owl#members"
+	OWLDistinctMembers    = "http://www.w3.org/2002/07/owl#distinctMembers"
+)
+
+// listMemberDefinition defines member(X, L) for the Definition of rules
+// over rdf:List members
+const listMemberDefinition = `member(X, L) :- triple(L, rdf:first, X).
+member(X, L) :- triple(L, rdf:rest, R), member(X, R).`
+
+// AllDifferentExpansion expands owl:AllDifferent into pairwise
+// owl:differentFrom assertions between its members
+type AllDifferentExpansion struct{}
+
+func (r *AllDifferentExpansion) Name() string {
+	return "owl:AllDifferent-expansion"
+}
+
+func (r *AllDifferentExpansion) Definition() string {
+	return listMemberDefinition + `
+triple(A, owl:differentFrom, B) :- triple(D, rdf:type, owl:AllDifferent), triple(D, owl:members, L), member(A, L), member(B, L).  % where A != B
+triple(A, owl:differentFrom, B) :- triple(D, rdf:type, owl:AllDifferent), triple(D, owl:distinctMembers, L), member(A, L), member(B, L).  % where A != B`
+}
+
+func (r *AllDifferentExpansion) Apply(store *TripleStore) []Triple {
+	return expandPairwise(store, OWLAllDifferent, OWLDifferentFrom, OWLMembers, OWLDistinctMembers)
+}
+
+// AllDisjointClassesExpansion expands owl:AllDisjointClasses into pairwise
+// owl:disjointWith axioms between its members
+type AllDisjointClassesExpansion struct{}
+
+func (r *AllDisjointClassesExpansion) Name() string {
+	return "owl:AllDisjointClasses-expansion"
+}
+
+func (r *AllDisjointClassesExpansion) Definition() string {
+	return listMemberDefinition + `
+triple(A, owl:disjointWith, B) :- triple(D, rdf:type, owl:AllDisjointClasses), triple(D, owl:members, L), member(A, L), member(B, L).  % where A != B`
+}
+
+func (r *AllDisjointClassesExpansion) Apply(store *TripleStore) []Triple {
+	return expandPairwise(store, OWLAllDisjointClasses, OWLDisjointWith, OWLMembers)
+}
+
+// expandPairwise relates every two distinct members of the lists attached
+// through listPredicates to the instances of class. Malformed lists are
+// skipped.
+func expandPairwise(store *TripleStore, class, predicate string, listPredicates ...string) []Triple {
+	c := &deltaCollector{store: store}
+
+	for _, axiom := range store.FindByPredicateObject(RDFType, class) {
+		for _, listPredicate := range listPredicates {
+			for _, list := range store.FindBySubjectPredicate(axiom.Subject, listPredicate) {
+				members, err := store.ListMembers(list.Object)
+				if err != nil {
+					continue
+				}
+				for _, a := range members {
+					for _, b := range members {
+						if a != b {
+							c.add(Triple{Subject: a, Predicate: predicate, Object: b})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return c.inferred
+}
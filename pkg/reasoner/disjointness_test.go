@@ -0,0 +1,87 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+const disjointnessDocument = `
+@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+_:classes a owl:AllDisjointClasses ; owl:members _:c1 .
+_:c1 rdf:first ex:Municipality ; rdf:rest _:c2 .
+_:c2 rdf:first ex:Canton ; rdf:rest _:c3 .
+_:c3 rdf:first ex:Country ; rdf:rest rdf:nil .
+_:people a owl:AllDifferent ; owl:distinctMembers _:p1 .
+_:p1 rdf:first ex:alice ; rdf:rest _:p2 .
+_:p2 rdf:first ex:bob ; rdf:rest rdf:nil .
+ex:City rdfs:subClassOf ex:Municipality .
+ex:zurich a ex:City .
+ex:geneva a ex:City , ex:Canton .
+ex:alice owl:sameAs ex:bob .
+`
+
+func TestListMembers(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(disjointnessDocument + `
+_:cycle rdf:first ex:a ; rdf:rest _:cycle .
+_:broken rdf:first ex:a .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	store := r.GetStore()
+
+	members, err := store.ListMembers("_:c1")
+	if err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+	if strings.Join(members, " ") != "http://example.org/Municipality http://example.org/Canton http://example.org/Country" {
+		t.Errorf("Unexpected members %v", members)
+	}
+	if members, err := store.ListMembers(RDFNil); err != nil || len(members) != 0 {
+		t.Errorf("rdf:nil should be the empty list, got %v, %v", members, err)
+	}
+	if _, err := store.ListMembers("_:cycle"); err == nil {
+		t.Errorf("Expected an error for a cyclic list")
+	}
+	if _, err := store.ListMembers("_:broken"); err == nil {
+		t.Errorf("Expected an error for a list without rdf:rest")
+	}
+}
+
+func TestNaryDisjointnessAndConsistency(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(disjointnessDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+	store := r.GetStore()
+
+	ex := "http://example.org/"
+	for _, pair := range [][2]string{{"Municipality", "Canton"}, {"Canton", "Country"}, {"Country", "Municipality"}} {
+		if !store.Contains(Triple{Subject: ex + pair[0], Predicate: OWLDisjointWith, Object: ex + pair[1]}) {
+			t.Errorf("Expected %s owl:disjointWith %s", pair[0], pair[1])
+		}
+	}
+	if !store.Contains(Triple{Subject: ex + "bob", Predicate: OWLDifferentFrom, Object: ex + "alice"}) {
+		t.Errorf("Expected bob owl:differentFrom alice")
+	}
+	if store.Contains(Triple{Subject: ex + "alice", Predicate: OWLDifferentFrom, Object: ex + "alice"}) {
+		t.Errorf("Members must not be different from themselves")
+	}
+
+	var messages []string
+	for _, i := range store.CheckConsistency() {
+		messages = append(messages, i.Message)
+	}
+	expected := []string{
+		ex + "alice and " + ex + "bob are both the same and different",
+		ex + "geneva is an instance of the disjoint classes " + ex + "Canton and " + ex + "Municipality",
+	}
+	if !equalStrings(messages, expected) {
+		t.Errorf("CheckConsistency() = %v, want %v", messages, expected)
+	}
+}
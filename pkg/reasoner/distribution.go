@@ -0,0 +1,77 @@
+package reasoner
+
+import (
+	"crypto/md5" //nolint:gosec // Croissant file objects carry md5 checksums
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"sort"
+)
+
+// ErrUnsupportedHash is returned for a hash algorithm that is not supported
+var ErrUnsupportedHash = errors.New("unsupported hash algorithm")
+
+// hashAlgorithms maps the supported algorithms to their constructors
+var hashAlgorithms = map[string]func() hash.Hash{ //nolint:gochecknoglobals
+	"md5":    md5.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// HashAlgorithms returns the names of the supported hash algorithms, sorted
+func HashAlgorithms() []string {
+	names := make([]string, 0, len(hashAlgorithms))
+	for name := range hashAlgorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HashDistribution returns the hex-encoded SHA-256 hash of a file, as
+// recorded in the sha256 property of a Croissant file object
+func HashDistribution(path string) (string, error) {
+	hashes, err := HashDistributionWith(path, "sha256")
+	if err != nil {
+		return "", err
+	}
+	return hashes["sha256"], nil
+}
+
+// HashDistributionWith returns the hex-encoded hashes of a file with each of
+// the given algorithms, keyed by algorithm. The file is streamed once, so
+// files larger than memory can be hashed.
+func HashDistributionWith(path string, algorithms ...string) (map[string]string, error) {
+	hashers := make(map[string]hash.Hash, len(algorithms))
+	writers := make([]io.Writer, 0, len(algorithms))
+	for _, algorithm := range algorithms {
+		newHash, ok := hashAlgorithms[algorithm]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedHash, algorithm)
+		}
+		if _, ok := hashers[algorithm]; !ok {
+			hashers[algorithm] = newHash()
+			writers = append(writers, hashers[algorithm])
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open '%s': %w", path, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(io.MultiWriter(writers...), f); err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+
+	hashes := make(map[string]string, len(hashers))
+	for algorithm, h := range hashers {
+		hashes[algorithm] = hex.EncodeToString(h.Sum(nil))
+	}
+	return hashes, nil
+}
@@ -0,0 +1,39 @@
+package reasoner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashDistribution(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte("abc"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	sum, err := HashDistribution(path)
+	if err != nil {
+		t.Fatalf("HashDistribution failed: %v", err)
+	}
+	if sum != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+		t.Errorf("Unexpected SHA-256 hash %s", sum)
+	}
+
+	hashes, err := HashDistributionWith(path, "md5", "sha512", "md5")
+	if err != nil {
+		t.Fatalf("HashDistributionWith failed: %v", err)
+	}
+	if len(hashes) != 2 || hashes["md5"] != "900150983cd24fb0d6963f7d28e17f72" ||
+		hashes["sha512"] != "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f" {
+		t.Errorf("Unexpected hashes %v", hashes)
+	}
+
+	if _, err := HashDistributionWith(path, "blake3"); !errors.Is(err, ErrUnsupportedHash) {
+		t.Errorf("Expected ErrUnsupportedHash, got %v", err)
+	}
+	if _, err := HashDistribution(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,126 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// LiteralDuplicate is a set of literal values of the same subject and
+// predicate that differ only in case, whitespace or diacritics
+type LiteralDuplicate struct {
+	Subject   string
+	Predicate string
+	Values    []string // Literal terms in store order; the first is kept when merging
+}
+
+func (d LiteralDuplicate) String() string {
+	return fmt.Sprintf("%s %s has near-duplicate values %s", d.Subject, d.Predicate, strings.Join(d.Values, ", "))
+}
+
+// diacriticFolds maps lowercase Latin letters with diacritics to their base
+// letters
+//
+//nolint:gochecknoglobals
+var diacriticFolds = func() map[rune]string {
+	folds := make(map[rune]string)
+	for base, letters := range map[string]string{
+		"a": "àáâãäåāăą", "c": "çćĉċč", "d": "ďđ", "e": "èéêëēĕėęě",
+		"g": "ĝğġģ", "h": "ĥħ", "i": "ìíîïĩīĭįı", "j": "ĵ", "k": "ķ",
+		"l": "ĺļľŀł", "n": "ñńņňŉ", "o": "òóôõöøōŏő", "r": "ŕŗř",
+		"s": "śŝşš", "t": "ţťŧ", "u": "ùúûüũūŭůűų", "w": "ŵ", "y": "ýÿŷ",
+		"z": "źżž", "ss": "ß", "ae": "æ", "oe": "œ",
+	} {
+		for _, r := range letters {
+			folds[r] = base
+		}
+	}
+	return folds
+}()
+
+// FindDuplicateLiterals reports literal objects of the same subject and
+// predicate that are equal after lowercasing, collapsing whitespace and
+// removing diacritics, such as "Zürich", "zurich" and "Zurich ". Only
+// literals with the same datatype and language tag are compared. Results
+// are sorted by subject and predicate.
+func (ts *TripleStore) FindDuplicateLiterals() []LiteralDuplicate {
+	type group struct {
+		subject, predicate string
+		values             []string
+	}
+	var order []string
+	groups := make(map[string]*group)
+
+	for _, t := range ts.tripleList {
+		term := ParseTerm(t.Object)
+		if term.Kind != TermLiteral {
+			continue
+		}
+		key := t.Subject + "|" + t.Predicate + "|" + foldLiteral(term.Value) +
+			"@" + strings.ToLower(term.Language) + "^^" + term.Datatype
+		g, ok := groups[key]
+		if !ok {
+			g = &group{subject: t.Subject, predicate: t.Predicate}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.values = append(g.values, t.Object)
+	}
+
+	var duplicates []LiteralDuplicate
+	for _, key := range order {
+		if g := groups[key]; len(g.values) > 1 {
+			duplicates = append(duplicates, LiteralDuplicate{Subject: g.subject, Predicate: g.predicate, Values: g.values})
+		}
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		if duplicates[i].Subject != duplicates[j].Subject {
+			return duplicates[i].Subject < duplicates[j].Subject
+		}
+		return duplicates[i].Predicate < duplicates[j].Predicate
+	})
+	return duplicates
+}
+
+// MergeDuplicateLiterals removes all but the first value of every set of
+// near-duplicate literals found by FindDuplicateLiterals and returns the
+// number of triples removed. Merge before reasoning: triples already
+// inferred from the removed values are not retracted, so the next
+// reasoning run starts from scratch.
+func (r *Reasoner) MergeDuplicateLiterals() int {
+	if r.store.frozen {
+		return 0
+	}
+
+	remove := make(map[string]bool)
+	for _, d := range r.store.FindDuplicateLiterals() {
+		for _, v := range d.Values[1:] {
+			remove[tripleKey(Triple{Subject: d.Subject, Predicate: d.Predicate, Object: v})] = true
+		}
+	}
+	if len(remove) == 0 {
+		return 0
+	}
+
+	r.store.removeAll(remove)
+	r.closed = 0
+	return len(remove)
+}
+
+// foldLiteral lowercases a lexical form, removes diacritics and collapses
+// runs of whitespace
+func foldLiteral(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if base, ok := diacriticFolds[r]; ok {
+			sb.WriteString(base)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
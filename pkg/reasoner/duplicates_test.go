@@ -0,0 +1,55 @@
+package reasoner
+
+import (
+	"reflect"
+	"testing"
+)
+
+const duplicateLiterals = `@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:zurich rdfs:label "Zürich" , "zurich" , "Zurich  " , "Zürich"@de , "Genf" .
+ex:zurich ex:code "ZH" , "zh"^^<http://example.org/code> .
+ex:geneva rdfs:label "Genève" , "` + "Gene\u0300ve" + `" , "GENEVE" .
+ex:basel rdfs:label "Basel" .
+`
+
+func TestFindDuplicateLiterals(t *testing.T) {
+	store := NewTripleStore()
+	for _, tr := range loadTriples(t, duplicateLiterals) {
+		store.Add(tr)
+	}
+
+	expected := []LiteralDuplicate{
+		{Subject: "http://example.org/geneva", Predicate: RDFSLabel, Values: []string{`"Genève"`, "\"Gene\u0300ve\"", `"GENEVE"`}},
+		{Subject: "http://example.org/zurich", Predicate: RDFSLabel, Values: []string{`"Zürich"`, `"zurich"`, `"Zurich  "`}},
+	}
+	if got := store.FindDuplicateLiterals(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("FindDuplicateLiterals() =\n%v\nwant\n%v", got, expected)
+	}
+}
+
+func TestMergeDuplicateLiterals(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(duplicateLiterals); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+	size := r.GetStore().Size()
+
+	if removed := r.MergeDuplicateLiterals(); removed != 4 {
+		t.Errorf("Expected 4 triples removed, got %d", removed)
+	}
+	if got := r.GetStore().Size(); got != size-4 {
+		t.Errorf("Expected %d triples after merging, got %d", size-4, got)
+	}
+	labels := r.Query("http://example.org/zurich", RDFSLabel, "")
+	if len(labels) != 3 {
+		t.Errorf("Expected 3 labels left for ex:zurich, got %v", labels)
+	}
+	if len(r.GetStore().FindDuplicateLiterals()) != 0 {
+		t.Error("Expected no duplicates after merging")
+	}
+	if r.MergeDuplicateLiterals() != 0 {
+		t.Error("Expected merging again to remove nothing")
+	}
+}
@@ -0,0 +1,86 @@
+package reasoner
+
+import "strings"
+
+// TermEquivalence defines when two terms are the same for an application:
+// terms are compared by the key it returns, so it must return equal keys
+// for equivalent terms, such as identifiers differing only in case
+type TermEquivalence func(term string) string
+
+// SetTermEquivalence makes the store compare the objects of triples with a
+// predicate by the key equivalence returns, or exactly again if it is nil.
+// Contains, FindByPredicateObject, MatchBGP, and so the joins of rules,
+// then match equivalent objects, a triple equivalent to one in the store
+// is not added again, and owl:hasKey compares the values of the predicate
+// by key. FindByObject still compares objects exactly. Triples already in
+// the store are keyed again, keeping the first of equivalent triples.
+func (ts *TripleStore) SetTermEquivalence(predicate string, equivalence TermEquivalence) {
+	if ts.frozen {
+		return
+	}
+	if ts.equivalences == nil {
+		ts.equivalences = make(map[string]TermEquivalence)
+	}
+	if equivalence == nil {
+		delete(ts.equivalences, predicate)
+	} else {
+		ts.equivalences[predicate] = equivalence
+	}
+	if len(ts.tripleList) > 0 {
+		ts.removeAll(nil)
+	}
+}
+
+// WithTermEquivalence makes the reasoner's store compare the objects of a
+// predicate by the key equivalence returns; see SetTermEquivalence
+func WithTermEquivalence(predicate string, equivalence TermEquivalence) Option {
+	return func(r *Reasoner) {
+		r.store.SetTermEquivalence(predicate, equivalence)
+	}
+}
+
+// objectKey returns the key the object of a triple with predicate is
+// compared by
+func (ts *TripleStore) objectKey(predicate, object string) string {
+	if equivalence := ts.equivalences[predicate]; equivalence != nil {
+		return equivalence(object)
+	}
+	return object
+}
+
+// equivalentKey returns the key shared by the triples equivalent to t, if
+// its predicate has a term equivalence
+func (ts *TripleStore) equivalentKey(t Triple) (string, bool) {
+	equivalence := ts.equivalences[t.Predicate]
+	if equivalence == nil {
+		return "", false
+	}
+	return t.Subject + "|" + t.Predicate + "|" + termKey(equivalence(t.Object)), true
+}
+
+// CaseInsensitive is a TermEquivalence ignoring the case of IRIs and of
+// the lexical form of literals. Language tags and datatypes are kept, so
+// "ab"@de and "AB"@de are equivalent but "ab"@de and "ab"@en are not.
+func CaseInsensitive(term string) string {
+	t := ParseTerm(term)
+	if t.IsBlankNode() {
+		return term
+	}
+	t.Value = strings.ToLower(t.Value)
+	return t.String()
+}
+
+// NormalizedURN is a TermEquivalence comparing URNs as RFC 8141 does: the
+// "urn" scheme and the namespace identifier are case-insensitive, so
+// URN:ISBN:123 and urn:isbn:123 are equivalent. Other terms are compared
+// exactly.
+func NormalizedURN(term string) string {
+	if len(term) < 4 || !strings.EqualFold(term[:4], "urn:") {
+		return term
+	}
+	nid, nss, ok := strings.Cut(term[4:], ":")
+	if !ok {
+		return term
+	}
+	return "urn:" + strings.ToLower(nid) + ":" + nss
+}
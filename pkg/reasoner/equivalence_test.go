@@ -0,0 +1,138 @@
+package reasoner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTermEquivalence(t *testing.T) {
+	ex := "http://example.org/"
+	store := NewTripleStore()
+	store.Add(Triple{Subject: ex + "alice", Predicate: ex + "badge", Object: `"AB-12"`})
+	store.Add(Triple{Subject: ex + "alice", Predicate: ex + "badge", Object: `"ab-12"`})
+	store.Add(Triple{Subject: ex + "alice", Predicate: ex + "name", Object: `"Alice"`})
+	if store.Size() != 3 {
+		t.Fatalf("Expected exact comparison without an equivalence, got %d triples", store.Size())
+	}
+
+	// Setting an equivalence merges the triples already in the store
+	store.SetTermEquivalence(ex+"badge", CaseInsensitive)
+	if store.Size() != 2 {
+		t.Errorf("Expected equivalent triples to be merged, got %d triples", store.Size())
+	}
+	if store.Add(Triple{Subject: ex + "alice", Predicate: ex + "badge", Object: `"Ab-12"`}) {
+		t.Errorf("Expected a triple equivalent to a stored one not to be added")
+	}
+	if !store.Contains(Triple{Subject: ex + "alice", Predicate: ex + "badge", Object: `"aB-12"`}) {
+		t.Errorf("Expected Contains to match an equivalent object")
+	}
+	if store.Contains(Triple{Subject: ex + "alice", Predicate: ex + "name", Object: `"ALICE"`}) {
+		t.Errorf("Expected other predicates to be compared exactly")
+	}
+	if store.Contains(Triple{Subject: ex + "alice", Predicate: ex + "badge", Object: `"ab-12"@en`}) {
+		t.Errorf("Expected language tags to be kept")
+	}
+
+	// Joins match equivalent objects
+	store.Add(Triple{Subject: ex + "door", Predicate: ex + "badge", Object: `"ab-12"`})
+	bindings := store.MatchBGP([]TriplePattern{
+		{Subject: "?person", Predicate: ex + "name", Object: "?name"},
+		{Subject: "?person", Predicate: ex + "badge", Object: "?badge"},
+		{Subject: ex + "door", Predicate: ex + "badge", Object: "?badge"},
+	})
+	if len(bindings) != 1 || bindings[0]["name"] != `"Alice"` {
+		t.Errorf("Expected the join to match the badge, got %v", bindings)
+	}
+
+	frozen := store.Freeze()
+	if len(frozen.FindByPredicateObject(ex+"badge", `"AB-12"`)) != 2 ||
+		!frozen.Contains(Triple{Subject: ex + "door", Predicate: ex + "badge", Object: `"AB-12"`}) {
+		t.Errorf("Expected the frozen store to match equivalent objects")
+	}
+
+	// Removing the equivalence compares objects exactly again
+	store.SetTermEquivalence(ex+"badge", nil)
+	if store.Contains(Triple{Subject: ex + "door", Predicate: ex + "badge", Object: `"AB-12"`}) {
+		t.Errorf("Expected exact comparison after removing the equivalence")
+	}
+}
+
+func TestTermEquivalenceHasKey(t *testing.T) {
+	r := NewReasoner(WithTermEquivalence("http://example.org/id", NormalizedURN))
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Person owl:hasKey ( ex:id ) .
+ex:a a ex:Person ; ex:id <urn:ISBN:0451450523> .
+ex:b a ex:Person ; ex:id <URN:isbn:0451450523> .
+ex:c a ex:Person ; ex:id <urn:isbn:0451450524> .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	ex := "http://example.org/"
+	if !r.GetStore().Contains(Triple{Subject: ex + "a", Predicate: OWLSameAs, Object: ex + "b"}) {
+		t.Errorf("Expected individuals with equivalent URNs to be the same")
+	}
+	if r.GetStore().Contains(Triple{Subject: ex + "a", Predicate: OWLSameAs, Object: ex + "c"}) {
+		t.Errorf("Expected individuals with different URNs not to be the same")
+	}
+	if len(r.Query("", ex+"id", "urn:isbn:0451450523")) != 2 {
+		t.Errorf("Expected Query to match equivalent URNs")
+	}
+}
+
+func TestNormalizedURN(t *testing.T) {
+	tests := map[string]string{
+		"URN:ISBN:0451450523":   "urn:isbn:0451450523",
+		"urn:example:A/B":       "urn:example:A/B",
+		"urn:nonss":             "urn:nonss",
+		"http://example.org/ID": "http://example.org/ID",
+	}
+	for term, want := range tests {
+		if got := NormalizedURN(term); got != want {
+			t.Errorf("NormalizedURN(%q) = %q, want %q", term, got, want)
+		}
+	}
+}
+
+func TestTermEquivalenceCopies(t *testing.T) {
+	ex := "http://example.org/"
+	badge := func(object string) Triple {
+		return Triple{Subject: ex + "alice", Predicate: ex + "badge", Object: object}
+	}
+	store := NewTripleStore()
+	store.SetTermEquivalence(ex+"badge", CaseInsensitive)
+	store.EnableVersioning()
+
+	txn := store.Begin()
+	txn.Add(badge(`"ABC"`))
+	if err := txn.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if !store.Contains(badge(`"abc"`)) || store.Add(badge(`"abc"`)) {
+		t.Errorf("Expected the committed triple to match equivalent objects")
+	}
+
+	snapshot := store.AsOf(time.Now())
+	if !snapshot.Contains(badge(`"abc"`)) || snapshot.Add(badge(`"abc"`)) {
+		t.Errorf("Expected the snapshot to keep the equivalence")
+	}
+
+	txn = store.Begin()
+	txn.Remove(badge(`"ABC"`))
+	if err := txn.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if store.Size() != 0 || store.Contains(badge(`"abc"`)) {
+		t.Errorf("Expected the committed removal to remove equivalent objects, got %d triples", store.Size())
+	}
+
+	r := NewReasoner(WithTermEquivalence(ex+"badge", CaseInsensitive), WithPartitionParallel(2))
+	if err := r.PartitionSafety(); err == nil {
+		t.Errorf("Expected reasoning with term equivalences not to be partitioned")
+	}
+}
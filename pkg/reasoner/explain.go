@@ -0,0 +1,152 @@
+package reasoner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QueryPlan describes how MatchBGP evaluates a basic graph pattern: the
+// order in which its patterns are joined, the index each is looked up in,
+// and the estimated and actual number of rows at every step
+type QueryPlan struct {
+	Steps    []PlanStep    `json:"steps"`
+	Rows     int           `json:"rows"`     // Solutions of the whole pattern
+	Duration time.Duration `json:"duration"` // Time taken to evaluate it
+}
+
+// PlanStep is the join of one triple pattern with the solutions of the
+// patterns before it
+type PlanStep struct {
+	Pattern TriplePattern `json:"pattern"`
+	// Index is the index the pattern is looked up in: "subject",
+	// "predicate", "object", or "none" if every triple is scanned
+	Index string `json:"index"`
+	// Filter lists the positions checked on the triples read from the
+	// index, e.g. "predicate" when looking up by subject and predicate
+	Filter []string `json:"filter,omitempty"`
+	// Estimated is the number of solutions expected after the step,
+	// assuming each lookup returns as many triples as match the most
+	// selective position of the pattern
+	Estimated float64 `json:"estimated"`
+	Lookups   int     `json:"lookups"` // Index lookups, one per solution of the previous step
+	Scanned   int     `json:"scanned"` // Triples read from the index
+	Rows      int     `json:"rows"`    // Solutions after the step
+}
+
+// ExplainBGP evaluates a basic graph pattern like MatchBGP and returns its
+// plan with the actual row counts, to show why a query is slow: a step
+// scanning many more triples than it returns lacks a selective index, and
+// a large gap between estimated and actual rows points to skewed data.
+func (ts *TripleStore) ExplainBGP(patterns []TriplePattern) QueryPlan {
+	plan := &QueryPlan{}
+	start := time.Now()
+	plan.Rows = len(ts.matchBGP(patterns, plan))
+	plan.Duration = time.Since(start)
+	return *plan
+}
+
+// explainStep records the evaluation of a pattern, given the variables
+// bound before it and the number of solutions it extends
+func (ts *TripleStore) explainStep(pattern TriplePattern, bound map[string]bool, input []Binding, output int, estimated float64) PlanStep {
+	step := PlanStep{Pattern: pattern, Estimated: estimated, Lookups: len(input), Rows: output}
+	for _, b := range input {
+		s, p, o := b.resolve(pattern.Subject), b.resolve(pattern.Predicate), b.resolve(pattern.Object)
+		var scanned int
+		step.Index, step.Filter, scanned = ts.indexFor(s, p, o)
+		step.Scanned += scanned
+	}
+	if len(input) == 0 {
+		// Describe the lookup that would have been made
+		fixed := func(x string) string {
+			switch {
+			case x == "" || IsVariable(x) && !bound[x]:
+				return ""
+			case IsVariable(x):
+				return "<bound>"
+			default:
+				return x
+			}
+		}
+		step.Index, step.Filter, _ = ts.indexFor(fixed(pattern.Subject), fixed(pattern.Predicate), fixed(pattern.Object))
+	}
+	return step
+}
+
+// indexFor returns the index match uses for a lookup, the positions it
+// then filters on and the number of triples it reads. It mirrors match.
+func (ts *TripleStore) indexFor(s, p, o string) (string, []string, int) {
+	fixed := func(x string) bool { return x != "" && !IsVariable(x) }
+	switch {
+	case fixed(s) && fixed(p):
+		filter := []string{"predicate"}
+		if fixed(o) {
+			filter = append(filter, "object")
+		}
+		return "subject", filter, len(ts.bySubject[s])
+	case fixed(p) && fixed(o):
+		return "predicate", []string{"object"}, len(ts.byPredicate[p])
+	case fixed(s):
+		var filter []string
+		if fixed(o) {
+			filter = []string{"object"}
+		}
+		return "subject", filter, len(ts.bySubject[s])
+	case fixed(o):
+		return "object", nil, len(ts.byObject[o])
+	case fixed(p):
+		return "predicate", nil, len(ts.byPredicate[p])
+	default:
+		return "none", nil, len(ts.tripleList)
+	}
+}
+
+// estimateMatches estimates the number of triples a lookup of a pattern
+// returns as the count of its most selective position: for a term, the
+// number of triples with it in that position, and for a bound variable,
+// the average number of triples per distinct term there
+func (ts *TripleStore) estimateMatches(pattern TriplePattern, bound map[string]bool) float64 {
+	total := float64(len(ts.tripleList))
+	estimate := total
+	for _, position := range []struct {
+		term  string
+		index map[string][]int
+	}{
+		{pattern.Subject, ts.bySubject},
+		{pattern.Predicate, ts.byPredicate},
+		{pattern.Object, ts.byObject},
+	} {
+		switch {
+		case position.term == "" || IsVariable(position.term) && !bound[position.term]:
+		case IsVariable(position.term):
+			estimate = min(estimate, total/float64(max(len(position.index), 1)))
+		default:
+			estimate = min(estimate, float64(len(position.index[position.term])))
+		}
+	}
+	return estimate
+}
+
+// String formats the plan as a table with one row per step
+func (p QueryPlan) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-4s %-60s %-22s %10s %8s %10s %8s\n", "step", "pattern", "index", "estimated", "lookups", "scanned", "rows")
+	for i, step := range p.Steps {
+		index := step.Index
+		if len(step.Filter) > 0 {
+			index += " +" + strings.Join(step.Filter, "+")
+		}
+		pattern := fmt.Sprintf("%s %s %s", patternTerm(step.Pattern.Subject), patternTerm(step.Pattern.Predicate), patternTerm(step.Pattern.Object))
+		fmt.Fprintf(&sb, "%-4d %-60s %-22s %10.1f %8d %10d %8d\n", i+1, pattern, index, step.Estimated, step.Lookups, step.Scanned, step.Rows)
+	}
+	fmt.Fprintf(&sb, "%d rows in %s\n", p.Rows, p.Duration)
+	return sb.String()
+}
+
+// patternTerm formats a pattern position, "" matching anything
+func patternTerm(position string) string {
+	if position == "" {
+		return "*"
+	}
+	return position
+}
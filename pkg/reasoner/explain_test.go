@@ -0,0 +1,94 @@
+package reasoner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExplainBGP(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(bgpData); err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+	r.RunForwardReasoning()
+	store := r.GetStore()
+
+	ex := "http://example.org/"
+	patterns := []TriplePattern{
+		{Subject: "?p", Predicate: ex + "worksFor", Object: "?org"},
+		{Subject: "?org", Predicate: ex + "name", Object: "\"ACME\""},
+	}
+	plan := store.ExplainBGP(patterns)
+
+	if plan.Rows != len(store.MatchBGP(patterns)) || plan.Rows != 2 {
+		t.Fatalf("Expected 2 rows as with MatchBGP, got %d", plan.Rows)
+	}
+	if len(plan.Steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %+v", plan.Steps)
+	}
+
+	// The pattern with a fixed object is joined first
+	first, second := plan.Steps[0], plan.Steps[1]
+	if first.Pattern != patterns[1] || first.Index != "predicate" || strings.Join(first.Filter, ",") != "object" {
+		t.Errorf("Unexpected first step %+v", first)
+	}
+	if first.Lookups != 1 || first.Scanned != 1 || first.Rows != 1 || first.Estimated != 1 {
+		t.Errorf("Unexpected counts for the first step %+v", first)
+	}
+	if second.Pattern != patterns[0] || second.Index != "predicate" || strings.Join(second.Filter, ",") != "object" {
+		t.Errorf("Unexpected second step %+v", second)
+	}
+	if second.Lookups != 1 || second.Scanned != 3 || second.Rows != 2 {
+		t.Errorf("Unexpected counts for the second step %+v", second)
+	}
+	// The bound object is more selective than the 3 ex:worksFor triples
+	perObject := float64(len(store.tripleList)) / float64(len(store.byObject))
+	if second.Estimated != perObject {
+		t.Errorf("Expected an estimate of %v rows, got %v", perObject, second.Estimated)
+	}
+
+	text := plan.String()
+	for _, want := range []string{"step", "predicate +object", "2 rows in"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in the plan:\n%s", want, text)
+		}
+	}
+}
+
+func TestExplainBGPWithoutSolutions(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(bgpData); err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+
+	plan := r.GetStore().ExplainBGP([]TriplePattern{
+		{Subject: "http://example.org/nobody", Predicate: "?p", Object: "?o"},
+		{Subject: "?o", Predicate: "", Object: ""},
+	})
+	if plan.Rows != 0 || len(plan.Steps) != 2 {
+		t.Fatalf("Expected both steps and no rows, got %+v", plan)
+	}
+	if step := plan.Steps[1]; step.Lookups != 0 || step.Index != "subject" {
+		t.Errorf("Expected an unevaluated lookup by subject, got %+v", step)
+	}
+}
+
+func TestQueryBuilderExplain(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(bgpData); err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+	r.RunForwardReasoning()
+
+	plan, err := NewQuery().Type("ex:Person").Where("ex:knows", "?friend").Explain(r)
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if len(plan.Steps) != 2 || plan.Rows != 2 {
+		t.Errorf("Expected 2 steps and 2 rows, got %+v", plan)
+	}
+	if _, err := NewQuery().Explain(r); !errors.Is(err, ErrEmptyQuery) {
+		t.Errorf("Expected ErrEmptyQuery, got %v", err)
+	}
+}
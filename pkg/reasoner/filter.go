@@ -0,0 +1,24 @@
+package reasoner
+
+// TripleFilter selects triples beyond what a query pattern can express,
+// e.g. by comparing the value of a literal object
+type TripleFilter func(t Triple) bool
+
+// QueryFiltered returns the triples matching the pattern, as Query does,
+// that are accepted by all filters
+func (r *Reasoner) QueryFiltered(subject, predicate, object string, filters ...TripleFilter) []Triple {
+	var results []Triple
+	for _, t := range r.Query(subject, predicate, object) {
+		accepted := true
+		for _, filter := range filters {
+			if !filter(t) {
+				accepted = false
+				break
+			}
+		}
+		if accepted {
+			results = append(results, t)
+		}
+	}
+	return results
+}
@@ -0,0 +1,49 @@
+package reasoner
+
+// Fork returns an independent copy of the reasoner with the same rules and
+// options, triples, prefixes and diagnostics. If the reasoner has reasoned,
+// the copy knows its store to be closed, so data loaded into the copy is
+// reasoned over incrementally. Loading and reasoning over a TBox once and
+// forking it for each ABox thus saturates the TBox only once. The query
+// cache and subscriptions are not copied. Forks of one reasoner may be
+// used concurrently, as long as the reasoner itself is not modified.
+func (r *Reasoner) Fork() *Reasoner {
+	parser := NewTurtleParser()
+	parser.options = r.parser.options
+
+	f := &Reasoner{
+		store:            r.store.clone(),
+		rules:            r.rules,
+		parser:           parser,
+		tracer:           r.tracer,
+		scope:            r.scope,
+		progress:         r.progress,
+		literalMatching:  r.literalMatching,
+		provenance:       r.provenance,
+		iriNormalization: r.iriNormalization,
+		prefixes:         make(map[string]string, len(r.prefixes)),
+		loadDiagnostics:  append([]Diagnostic(nil), r.loadDiagnostics...),
+		runDiagnostics:   append([]Diagnostic(nil), r.runDiagnostics...),
+		constraints:      r.constraints,
+		checkConsistency: r.checkConsistency,
+		partitionWorkers: r.partitionWorkers,
+		closed:           r.closed,
+		graphReasoning:   r.graphReasoning,
+	}
+	for prefix, iri := range r.prefixes {
+		f.prefixes[prefix] = iri
+	}
+	if r.graphs != nil {
+		f.graphs = make(map[string][]string, len(r.graphs))
+		for key, graphs := range r.graphs {
+			f.graphs[key] = append([]string(nil), graphs...)
+		}
+	}
+	if r.budgets != nil {
+		f.budgets = make(map[string]RuleBudget, len(r.budgets))
+		for name, budget := range r.budgets {
+			f.budgets[name] = budget
+		}
+	}
+	return f
+}
@@ -0,0 +1,54 @@
+package reasoner
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestFork(t *testing.T) {
+	tbox := NewReasoner()
+	if err := tbox.LoadTurtle(partitionSchema); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	tbox.RunForwardReasoning()
+	schema := tbox.GetAllTriples()
+
+	datasets := []string{partitionData(3), partitionData(7), partitionData(12)}
+	forked := make([][]string, len(datasets))
+	var wg sync.WaitGroup
+	for i, data := range datasets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f := tbox.Fork()
+			if err := f.LoadTurtle(data); err != nil {
+				t.Errorf("LoadTurtle failed: %v", err)
+				return
+			}
+			f.RunForwardReasoning()
+			forked[i] = f.GetAllTriples()
+		}()
+	}
+	wg.Wait()
+
+	for i, data := range datasets {
+		full := NewReasoner()
+		for _, doc := range []string{partitionSchema, data} {
+			if err := full.LoadTurtle(doc); err != nil {
+				t.Fatalf("LoadTurtle failed: %v", err)
+			}
+		}
+		full.RunForwardReasoning()
+		if want := full.GetAllTriples(); !reflect.DeepEqual(forked[i], want) {
+			t.Errorf("Fork %d has %d triples, want %d", i, len(forked[i]), len(want))
+		}
+	}
+
+	if got := tbox.GetAllTriples(); !reflect.DeepEqual(got, schema) {
+		t.Errorf("Forks changed the original reasoner: %d triples, want %d", len(got), len(schema))
+	}
+	if got := tbox.Fork().Prefixes()["ex"]; got != "http://example.org/" {
+		t.Errorf("Expected the fork to keep the prefixes, got %q", got)
+	}
+}
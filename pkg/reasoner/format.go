@@ -0,0 +1,233 @@
+package reasoner
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Format identifies an RDF serialization
+type Format int
+
+const (
+	// FormatUnknown is returned when a document could not be identified
+	FormatUnknown Format = iota
+	// FormatTurtle is Turtle
+	FormatTurtle
+	// FormatNTriples is N-Triples, a subset of Turtle
+	FormatNTriples
+	// FormatRDFXML is RDF/XML
+	FormatRDFXML
+	// FormatJSONLD is JSON-LD
+	FormatJSONLD
+	// FormatTriG is TriG, Turtle with named graphs
+	FormatTriG
+	// FormatNQuads is N-Quads, N-Triples with named graphs
+	FormatNQuads
+	// FormatN3 is Notation3, Turtle with rules
+	FormatN3
+)
+
+//nolint:gochecknoglobals
+var formatNames = map[Format]string{
+	FormatUnknown:  "unknown",
+	FormatTurtle:   "turtle",
+	FormatNTriples: "ntriples",
+	FormatRDFXML:   "rdfxml",
+	FormatJSONLD:   "jsonld",
+	FormatTriG:     "trig",
+	FormatNQuads:   "nquads",
+	FormatN3:       "n3",
+}
+
+//nolint:gochecknoglobals
+var formatExtensions = map[string]Format{
+	".ttl":    FormatTurtle,
+	".turtle": FormatTurtle,
+	".n3":     FormatN3,
+	".nt":     FormatNTriples,
+	".rdf":    FormatRDFXML,
+	".owl":    FormatRDFXML,
+	".xml":    FormatRDFXML,
+	".jsonld": FormatJSONLD,
+	".json":   FormatJSONLD,
+	".trig":   FormatTriG,
+	".nq":     FormatNQuads,
+}
+
+// nTriplesLine matches a single N-Triples statement
+//
+//nolint:gochecknoglobals
+var nTriplesLine = regexp.MustCompile(`^(<[^>\s]*>|_:\S+)\s+<[^>\s]*>\s+(<[^>\s]*>|_:\S+|".*"(@[A-Za-z0-9-]+|\^\^<[^>\s]*>)?)\s*\.\s*(#.*)?$`)
+
+// nQuadsLine matches a single N-Quads statement, with or without a graph
+// label
+//
+//nolint:gochecknoglobals
+var nQuadsLine = regexp.MustCompile(`^(<[^>\s]*>|_:\S+)\s+<[^>\s]*>\s+(<[^>\s]*>|_:\S+|".*"(@[A-Za-z0-9-]+|\^\^<[^>\s]*>)?)(\s*<[^>\s]*>|\s+_:\S+)?\s*\.\s*(#.*)?$`)
+
+// String returns the name of the format
+func (f Format) String() string {
+	return formatNames[f]
+}
+
+// CanLoad reports whether the reasoner loads documents in the format
+func (f Format) CanLoad() bool {
+	return f != FormatUnknown
+}
+
+// ParseFormat parses a format name as returned by Format.String
+func ParseFormat(s string) (Format, error) {
+	for f, name := range formatNames {
+		if f != FormatUnknown && name == strings.ToLower(s) {
+			return f, nil
+		}
+	}
+	return FormatUnknown, fmt.Errorf("invalid format %q, must be 'turtle', 'ntriples', 'nquads', 'rdfxml', 'jsonld', 'trig' or 'n3'", s)
+}
+
+// FormatFromExtension returns the format conventionally used for a file
+// name's extension, or FormatUnknown
+func FormatFromExtension(filename string) Format {
+	return formatExtensions[strings.ToLower(filepath.Ext(filename))]
+}
+
+// DetectFormat identifies the serialization of a document from its content,
+// falling back to the file name's extension when the content is empty or
+// ambiguous. Content always wins over the extension, so an RDF/XML download
+// saved as data.txt, or Turtle saved as data.rdf, is identified correctly.
+// Since N3 extends Turtle, a .n3 file that looks like Turtle is N3.
+func DetectFormat(filename, content string) Format {
+	if f := sniffFormat(content); f != FormatUnknown {
+		if f == FormatTurtle && FormatFromExtension(filename) == FormatN3 {
+			return FormatN3
+		}
+		return f
+	}
+	return FormatFromExtension(filename)
+}
+
+// sniffFormat identifies a document from its first significant characters
+// and, for the Turtle family, from its statements
+func sniffFormat(content string) Format {
+	body := strings.TrimPrefix(content, "\ufeff")
+	head := skipCommentLines(body)
+
+	switch {
+	case head == "":
+		return FormatUnknown
+	case strings.HasPrefix(head, "<?xml"), strings.HasPrefix(head, "<rdf:RDF"), strings.HasPrefix(head, "<!DOCTYPE"):
+		return FormatRDFXML
+	case head[0] == '{' && isJSONObjectStart(head):
+		return FormatJSONLD
+	case head[0] == '[' && strings.HasPrefix(strings.TrimSpace(head[1:]), "{"):
+		return FormatJSONLD
+	}
+
+	if braces, implication := scanBraces(body); implication {
+		return FormatN3
+	} else if braces {
+		return FormatTriG
+	}
+	if isNTriples(body) {
+		return FormatNTriples
+	}
+	if isNQuads(body) {
+		return FormatNQuads
+	}
+	return FormatTurtle
+}
+
+// skipCommentLines drops leading blank lines and '#' comments
+func skipCommentLines(content string) string {
+	for {
+		content = strings.TrimLeft(content, " \t\r\n")
+		if !strings.HasPrefix(content, "#") {
+			return content
+		}
+		i := strings.IndexByte(content, '\n')
+		if i < 0 {
+			return ""
+		}
+		content = content[i+1:]
+	}
+}
+
+// isJSONObjectStart reports whether a document starting with '{' continues
+// like a JSON object, with a key or '}', rather than like an N3 formula or
+// TriG graph block
+func isJSONObjectStart(content string) bool {
+	rest := strings.TrimLeft(content[1:], " \t\r\n")
+	return rest == "" || rest[0] == '"' || rest[0] == '}'
+}
+
+// scanBraces reports whether a '{' occurs outside IRIs, literals and
+// comments, which only TriG and N3 allow, and whether an N3 implication
+// "=>" or "<=" does
+func scanBraces(content string) (braces, implication bool) {
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '{':
+			braces = true
+		case '=':
+			if strings.HasPrefix(content[i:], "=>") {
+				return braces, true
+			}
+		case '#':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+		case '<':
+			if strings.HasPrefix(content[i:], "<=") {
+				return braces, true
+			}
+			for i < len(content) && content[i] != '>' && content[i] != '\n' {
+				i++
+			}
+		case '"', '\'':
+			quote := content[i]
+			if long := strings.Repeat(string(quote), 3); strings.HasPrefix(content[i:], long) {
+				end := strings.Index(content[i+3:], long)
+				if end < 0 {
+					return braces, false
+				}
+				i += 3 + end + 2
+				continue
+			}
+			for i++; i < len(content) && content[i] != quote && content[i] != '\n'; i++ {
+				if content[i] == '\\' {
+					i++
+				}
+			}
+		}
+	}
+	return braces, false
+}
+
+// isNTriples reports whether every statement is a complete N-Triples line
+func isNTriples(content string) bool {
+	return everyLineMatches(content, nTriplesLine)
+}
+
+// isNQuads reports whether every statement is a complete N-Quads line
+func isNQuads(content string) bool {
+	return everyLineMatches(content, nQuadsLine)
+}
+
+// everyLineMatches reports whether there is at least one statement and
+// every line that is not blank or a comment matches statement
+func everyLineMatches(content string, statement *regexp.Regexp) bool {
+	statements := 0
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !statement.MatchString(line) {
+			return false
+		}
+		statements++
+	}
+	return statements > 0
+}
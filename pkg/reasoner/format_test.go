@@ -0,0 +1,50 @@
+package reasoner
+
+import "testing"
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		want     Format
+	}{
+		{"turtle with prefixes", "data.txt", "@prefix ex: <http://example.org/> .\nex:a ex:p ex:b .\n", FormatTurtle},
+		{"turtle with predicate list", "data", "<http://example.org/a> <http://example.org/p> 1 ;\n  <http://example.org/q> 2 .\n", FormatTurtle},
+		{"ntriples", "download", "# dump\n<http://example.org/a> <http://example.org/p> \"x\"@en .\n_:b <http://example.org/p> <http://example.org/a> .\n", FormatNTriples},
+		{"ntriples typed literal", "data.ttl", "<http://example.org/a> <http://example.org/p> \"1\"^^<http://www.w3.org/2001/XMLSchema#integer> .\n", FormatNTriples},
+		{"rdfxml declaration", "data.ttl", "<?xml version=\"1.0\"?>\n<rdf:RDF></rdf:RDF>\n", FormatRDFXML},
+		{"rdfxml without declaration", "data.txt", "<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\"/>", FormatRDFXML},
+		{"jsonld object", "data.rdf", "{\"@context\": {}}", FormatJSONLD},
+		{"jsonld array", "data", "[ {\"@id\": \"http://example.org/a\"} ]", FormatJSONLD},
+		{"nquads", "data.nt", "<http://example.org/a> <http://example.org/p> \"x\" <http://example.org/g> .\n<http://example.org/a> <http://example.org/p> _:b .\n", FormatNQuads},
+		{"trig", "data.ttl", "@prefix ex: <http://example.org/> .\nex:g { ex:a ex:p ex:b . }\n", FormatTriG},
+		{"brace in literal is not trig", "data", "@prefix ex: <http://example.org/> .\nex:a ex:p \"{\" , \"\"\"x\n{\"\"\" .\n", FormatTurtle},
+		{"trig default graph block", "data", "{ <http://example.org/a> <http://example.org/p> <http://example.org/b> . }\n", FormatTriG},
+		{"n3 rule", "rules", "{ ?x <http://example.org/p> ?y } => { ?y <http://example.org/p> ?x } .\n", FormatN3},
+		{"n3 reverse rule", "rules.ttl", "@prefix ex: <http://example.org/> .\n{ ?y ex:p ?x } <= { ?x ex:p ?y } .\n", FormatN3},
+		{"turtle in an n3 file", "data.n3", "@prefix ex: <http://example.org/> .\nex:a ex:p ex:b .\n", FormatN3},
+		{"empty falls back to extension", "data.nt", "", FormatNTriples},
+		{"empty without extension", "data", "  \n# only a comment\n", FormatUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectFormat(tt.filename, tt.content); got != tt.want {
+				t.Errorf("DetectFormat() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range []Format{FormatTurtle, FormatNTriples, FormatRDFXML, FormatJSONLD, FormatTriG, FormatNQuads, FormatN3} {
+		got, err := ParseFormat(f.String())
+		if err != nil || got != f {
+			t.Errorf("ParseFormat(%q) = %s, %v", f, got, err)
+		}
+	}
+	if _, err := ParseFormat("unknown"); err == nil {
+		t.Error("Expected an error for 'unknown'")
+	}
+}
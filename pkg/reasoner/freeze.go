@@ -0,0 +1,96 @@
+package reasoner
+
+import "errors"
+
+// ErrReadOnly is returned when a frozen store or read-only reasoner is
+// asked to change
+var ErrReadOnly = errors.New("store is read-only")
+
+// Freeze returns an immutable copy of the store, for serving a published
+// closure to any number of concurrent readers without locking. Its indexes
+// are built once with exact capacities, plus subject-predicate and
+// predicate-object indexes that answer FindBySubjectPredicate and
+// FindByPredicateObject without scanning. Add, AddFrom and Skolemize leave
+// a frozen store unchanged and committing a transaction on it fails with
+// ErrReadOnly. Sources are copied; the history and subscribers are not.
+func (ts *TripleStore) Freeze() *TripleStore {
+	f := &TripleStore{
+		triples:    make(map[string]bool, len(ts.tripleList)),
+		tripleList: append(make([]Triple, 0, len(ts.tripleList)), ts.tripleList...),
+		generation: ts.generation,
+		frozen:     true,
+	}
+	if ts.blobs != nil {
+		f.blobs = make(map[string]string, len(ts.blobs))
+		for key, term := range ts.blobs {
+			f.blobs[key] = term
+		}
+	}
+	if ts.equivalences != nil {
+		f.equivalences = make(map[string]TermEquivalence, len(ts.equivalences))
+		for predicate, equivalence := range ts.equivalences {
+			f.equivalences[predicate] = equivalence
+		}
+		f.equivalent = make(map[string]bool, len(ts.equivalent))
+		for key := range ts.equivalent {
+			f.equivalent[key] = true
+		}
+	}
+	for _, t := range f.tripleList {
+		f.triples[tripleKey(t)] = true
+	}
+	f.bySubject = frozenIndex(f.tripleList, func(t Triple) string { return t.Subject })
+	f.byPredicate = frozenIndex(f.tripleList, func(t Triple) string { return t.Predicate })
+	f.byObject = frozenIndex(f.tripleList, func(t Triple) string { return t.Object })
+	f.bySubjectPredicate = frozenIndex(f.tripleList, func(t Triple) string { return t.Subject + "|" + t.Predicate })
+	f.byPredicateObject = frozenIndex(f.tripleList, func(t Triple) string { return t.Predicate + "|" + termKey(f.objectKey(t.Predicate, t.Object)) })
+
+	if ts.sources != nil {
+		f.sources = make(map[string][]string, len(ts.sources))
+		for key, sources := range ts.sources {
+			f.sources[key] = append([]string(nil), sources...)
+		}
+	}
+	return f
+}
+
+// Frozen reports whether the store is immutable
+func (ts *TripleStore) Frozen() bool {
+	return ts.frozen
+}
+
+// frozenIndex maps the keys of triples to their positions, with every
+// slice allocated at its final size
+func frozenIndex(triples []Triple, key func(Triple) string) map[string][]int {
+	counts := make(map[string]int)
+	for _, t := range triples {
+		counts[key(t)]++
+	}
+	index := make(map[string][]int, len(counts))
+	for k, n := range counts {
+		index[k] = make([]int, 0, n)
+	}
+	for i, t := range triples {
+		k := key(t)
+		index[k] = append(index[k], i)
+	}
+	return index
+}
+
+// Freeze makes the reasoner read-only by replacing its store with a frozen
+// copy, as when serving a published closure. From then on loading
+// documents, dereferencing, streaming reasoning and committing
+// transactions fail with ErrReadOnly, and RunForwardReasoning,
+// MergeDuplicateLiterals and CoerceIRILiterals change nothing. Queries and
+// everything else that reads the store work as before; Fork returns a
+// writable copy.
+func (r *Reasoner) Freeze() {
+	if !r.store.frozen {
+		r.store = r.store.Freeze()
+	}
+}
+
+// ReadOnly reports whether the reasoner was frozen
+func (r *Reasoner) ReadOnly() bool {
+	return r.store.frozen
+}
@@ -0,0 +1,104 @@
+package reasoner
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestFreezeStore(t *testing.T) {
+	ex := "http://example.org/"
+	ts := NewTripleStore()
+	ts.AddFrom(Triple{Subject: ex + "bern", Predicate: RDFType, Object: ex + "City"}, "cities.ttl")
+	ts.Add(Triple{Subject: ex + "bern", Predicate: ex + "canton", Object: ex + "be"})
+	ts.Add(Triple{Subject: ex + "thun", Predicate: RDFType, Object: ex + "City"})
+	ts.Add(Triple{Subject: "_:b1", Predicate: ex + "near", Object: ex + "bern"})
+
+	frozen := ts.Freeze()
+	if !frozen.Frozen() || ts.Frozen() {
+		t.Fatalf("Expected only the copy to be frozen")
+	}
+	if frozen.Add(Triple{Subject: ex + "biel", Predicate: RDFType, Object: ex + "City"}) ||
+		frozen.AddFrom(Triple{Subject: ex + "biel", Predicate: RDFType, Object: ex + "City"}, "x.ttl") ||
+		frozen.Skolemize("") != 0 || frozen.Size() != 4 {
+		t.Errorf("Expected the frozen store to be unchanged, got %v", frozen.All())
+	}
+	txn := frozen.Begin()
+	txn.Add(Triple{Subject: ex + "biel", Predicate: RDFType, Object: ex + "City"})
+	if err := txn.Commit(); !errors.Is(err, ErrReadOnly) || frozen.Size() != 4 {
+		t.Errorf("Commit() = %v, want ErrReadOnly", err)
+	}
+
+	// The original store stays writable and independent
+	ts.Add(Triple{Subject: ex + "biel", Predicate: RDFType, Object: ex + "City"})
+	if frozen.Contains(Triple{Subject: ex + "biel", Predicate: RDFType, Object: ex + "City"}) {
+		t.Errorf("Expected the frozen copy not to see later changes")
+	}
+
+	if got := frozen.FindBySubjectPredicate(ex+"bern", RDFType); len(got) != 1 || got[0].Object != ex+"City" {
+		t.Errorf("FindBySubjectPredicate() = %v", got)
+	}
+	if got := frozen.FindByPredicateObject(RDFType, ex+"City"); len(got) != 2 {
+		t.Errorf("FindByPredicateObject() = %v", got)
+	}
+	if got := frozen.FindBySubjectPredicate(ex+"bern", ex+"missing"); got != nil {
+		t.Errorf("FindBySubjectPredicate() = %v, want nil", got)
+	}
+	if sources := frozen.SourceOf(Triple{Subject: ex + "bern", Predicate: RDFType, Object: ex + "City"}); len(sources) != 1 || sources[0] != "cities.ttl" {
+		t.Errorf("SourceOf() = %v", sources)
+	}
+}
+
+func TestFreezeReasoner(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(partitionSchema + partitionData(5)); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+	closure := r.GetAllTriples()
+
+	r.Freeze()
+	if !r.ReadOnly() {
+		t.Fatalf("Expected the reasoner to be read-only")
+	}
+	if err := r.LoadTurtle(partitionData(6)); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("LoadTurtle() = %v, want ErrReadOnly", err)
+	}
+	if err := r.LoadDocument("data.nt", "<http://example.org/a> <http://example.org/p> <http://example.org/b> .", FormatUnknown); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("LoadDocument() = %v, want ErrReadOnly", err)
+	}
+	if _, err := r.ReasonStream(func(Triple, string) error { return nil }); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("ReasonStream() = %v, want ErrReadOnly", err)
+	}
+	if _, err := r.Dereference("http://example.org/a"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Dereference() = %v, want ErrReadOnly", err)
+	}
+	if r.RunForwardReasoning() != 0 || r.MergeDuplicateLiterals() != 0 || r.CoerceIRILiterals() != 0 {
+		t.Errorf("Expected no changes to a read-only reasoner")
+	}
+	if got := r.GetAllTriples(); !equalStrings(got, closure) {
+		t.Errorf("Expected the closure to be unchanged")
+	}
+
+	// Concurrent readers need no locking
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, triple := range r.GetStore().All() {
+				if len(r.GetStore().FindBySubjectPredicate(triple.Subject, triple.Predicate)) == 0 {
+					t.Errorf("Expected %v to be found", triple)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Forks are writable
+	f := r.Fork()
+	if err := f.LoadTurtle(partitionData(6)); err != nil || f.ReadOnly() {
+		t.Errorf("Expected a writable fork, got %v", err)
+	}
+}
@@ -0,0 +1,353 @@
+package reasoner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GeoSPARQL vocabulary
+const (
+	GeoSPARQL      = "http://www.opengis.net/ont/geosparql#"
+	GeoWKTLiteral  = GeoSPARQL + "wktLiteral"
+	GeoAsWKT       = GeoSPARQL + "asWKT"
+	GeoHasGeometry = GeoSPARQL + "hasGeometry"
+)
+
+// GeometryKind distinguishes the geometries understood by the geo functions
+type GeometryKind int
+
+const (
+	// GeometryPoint is a single point
+	GeometryPoint GeometryKind = iota
+	// GeometryBox is an axis-aligned rectangle
+	GeometryBox
+	// GeometryPolygon is any other polygon, given by its exterior ring
+	GeometryPolygon
+)
+
+// Point is a position in the coordinate system of a WKT literal
+type Point struct {
+	X, Y float64
+}
+
+// Geometry is a point, an axis-aligned box or a polygon. Polygons are
+// compared by their exterior ring, so the geo functions are exact for
+// polygons without holes and treat holes as part of the polygon.
+type Geometry struct {
+	Kind     GeometryKind
+	Min, Max Point   // Corners of the box, or bounding box; equal for points
+	Ring     []Point // Exterior ring of a polygon, without the closing position
+}
+
+// NewPointGeometry returns a point geometry
+func NewPointGeometry(x, y float64) Geometry {
+	return Geometry{Kind: GeometryPoint, Min: Point{x, y}, Max: Point{x, y}}
+}
+
+// NewBoxGeometry returns the box spanned by two corners
+func NewBoxGeometry(x1, y1, x2, y2 float64) Geometry {
+	return Geometry{
+		Kind: GeometryBox,
+		Min:  Point{min(x1, x2), min(y1, y2)},
+		Max:  Point{max(x1, x2), max(y1, y2)},
+	}
+}
+
+// NewPolygonGeometry returns the polygon with the given exterior ring, which
+// may or may not repeat its first position at the end. Axis-aligned
+// rectangles are returned as boxes.
+func NewPolygonGeometry(ring []Point) Geometry {
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	g := Geometry{Kind: GeometryPolygon, Ring: append([]Point(nil), ring...)}
+	if len(ring) == 0 {
+		return g
+	}
+	g.Min, g.Max = ring[0], ring[0]
+	for _, p := range ring[1:] {
+		g.Min = Point{min(g.Min.X, p.X), min(g.Min.Y, p.Y)}
+		g.Max = Point{max(g.Max.X, p.X), max(g.Max.Y, p.Y)}
+	}
+	if isBox(ring, g.Min, g.Max) {
+		return NewBoxGeometry(g.Min.X, g.Min.Y, g.Max.X, g.Max.Y)
+	}
+	return g
+}
+
+// isBox reports whether a ring is the axis-aligned rectangle from min to max
+func isBox(ring []Point, min, max Point) bool {
+	if len(ring) != 4 {
+		return false
+	}
+	for i, p := range ring {
+		q := ring[(i+1)%len(ring)]
+		if (p.X != min.X && p.X != max.X) || (p.Y != min.Y && p.Y != max.Y) || (p.X != q.X && p.Y != q.Y) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the geometry as WKT
+func (g Geometry) String() string {
+	if g.Kind == GeometryPoint {
+		return fmt.Sprintf("POINT(%s %s)", formatCoordinate(g.Min.X), formatCoordinate(g.Min.Y))
+	}
+	corners := append(g.vertices(), g.vertices()[0])
+	coords := make([]string, len(corners))
+	for i, p := range corners {
+		coords[i] = formatCoordinate(p.X) + " " + formatCoordinate(p.Y)
+	}
+	return "POLYGON((" + strings.Join(coords, ", ") + "))"
+}
+
+// ParseWKT parses a POINT or POLYGON in Well-Known Text, optionally preceded
+// by a CRS IRI as allowed in geo:wktLiteral. Only the first two coordinates
+// of each position are used.
+func ParseWKT(wkt string) (Geometry, error) {
+	s := strings.TrimSpace(wkt)
+	if strings.HasPrefix(s, "<") {
+		end := strings.Index(s, ">")
+		if end < 0 {
+			return Geometry{}, fmt.Errorf("invalid WKT %q: unterminated CRS IRI", wkt)
+		}
+		s = strings.TrimSpace(s[end+1:])
+	}
+
+	open := strings.Index(s, "(")
+	if open < 0 || !strings.HasSuffix(s, ")") {
+		return Geometry{}, fmt.Errorf("invalid WKT %q: expected a geometry in parentheses", wkt)
+	}
+	kind := strings.ToUpper(strings.TrimSpace(s[:open]))
+	body := s[open+1 : len(s)-1]
+
+	switch kind {
+	case "POINT":
+		points, err := parseWKTPoints(body)
+		if err != nil {
+			return Geometry{}, fmt.Errorf("invalid WKT %q: %w", wkt, err)
+		}
+		if len(points) != 1 {
+			return Geometry{}, fmt.Errorf("invalid WKT %q: a point has exactly one position", wkt)
+		}
+		return NewPointGeometry(points[0].X, points[0].Y), nil
+	case "POLYGON":
+		// Only the exterior ring is kept
+		ring := strings.TrimSpace(body)
+		if !strings.HasPrefix(ring, "(") {
+			return Geometry{}, fmt.Errorf("invalid WKT %q: expected a ring in parentheses", wkt)
+		}
+		end := strings.Index(ring, ")")
+		if end < 0 {
+			return Geometry{}, fmt.Errorf("invalid WKT %q: unterminated ring", wkt)
+		}
+		points, err := parseWKTPoints(ring[1:end])
+		if err != nil {
+			return Geometry{}, fmt.Errorf("invalid WKT %q: %w", wkt, err)
+		}
+		if len(points) < 4 {
+			return Geometry{}, fmt.Errorf("invalid WKT %q: a polygon ring needs at least 4 positions", wkt)
+		}
+		return NewPolygonGeometry(points), nil
+	default:
+		return Geometry{}, fmt.Errorf("unsupported WKT geometry %q", kind)
+	}
+}
+
+// ParseGeometry parses the WKT lexical form of a literal term. Literals with
+// a datatype other than geo:wktLiteral are rejected.
+func ParseGeometry(term string) (Geometry, error) {
+	t := ParseTerm(term)
+	if !t.IsLiteral() {
+		return Geometry{}, fmt.Errorf("%s is not a literal", term)
+	}
+	if t.Datatype != "" && t.Datatype != GeoWKTLiteral && t.Datatype != XSDString {
+		return Geometry{}, fmt.Errorf("%s is not a WKT literal", term)
+	}
+	return ParseWKT(t.Value)
+}
+
+// SfWithin reports whether a lies entirely within b, boundary included
+func SfWithin(a, b Geometry) bool {
+	if a.Min.X < b.Min.X || a.Min.Y < b.Min.Y || a.Max.X > b.Max.X || a.Max.Y > b.Max.Y {
+		return false
+	}
+	if b.Kind != GeometryPolygon {
+		return true
+	}
+	// Within a polygon, every vertex of a must be inside it and no edge of
+	// a may cross its boundary
+	for _, p := range a.vertices() {
+		if !b.contains(p) {
+			return false
+		}
+	}
+	for _, e := range a.edges() {
+		for _, f := range b.edges() {
+			if segmentsCross(e[0], e[1], f[0], f[1]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SfIntersects reports whether a and b have at least one point in common
+func SfIntersects(a, b Geometry) bool {
+	if a.Min.X > b.Max.X || b.Min.X > a.Max.X || a.Min.Y > b.Max.Y || b.Min.Y > a.Max.Y {
+		return false
+	}
+	if a.Kind != GeometryPolygon && b.Kind != GeometryPolygon {
+		return true
+	}
+	// Either one lies within the other or their boundaries meet
+	if b.contains(a.vertices()[0]) || a.contains(b.vertices()[0]) {
+		return true
+	}
+	for _, e := range a.edges() {
+		for _, f := range b.edges() {
+			if segmentsTouch(e[0], e[1], f[0], f[1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// vertices returns the point, the corners of the box or the ring
+func (g Geometry) vertices() []Point {
+	switch g.Kind {
+	case GeometryPoint:
+		return []Point{g.Min}
+	case GeometryBox:
+		return []Point{g.Min, {g.Max.X, g.Min.Y}, g.Max, {g.Min.X, g.Max.Y}}
+	}
+	return g.Ring
+}
+
+// edges returns the segments of the boundary; a point has none
+func (g Geometry) edges() [][2]Point {
+	vertices := g.vertices()
+	if len(vertices) < 2 {
+		return nil
+	}
+	edges := make([][2]Point, len(vertices))
+	for i, p := range vertices {
+		edges[i] = [2]Point{p, vertices[(i+1)%len(vertices)]}
+	}
+	return edges
+}
+
+// contains reports whether p lies within the geometry, boundary included,
+// casting a ray from p across the exterior ring of polygons
+func (g Geometry) contains(p Point) bool {
+	if p.X < g.Min.X || p.Y < g.Min.Y || p.X > g.Max.X || p.Y > g.Max.Y {
+		return false
+	}
+	if g.Kind != GeometryPolygon {
+		return true
+	}
+	inside := false
+	for _, e := range g.edges() {
+		a, b := e[0], e[1]
+		if orientation(a, b, p) == 0 && onSegment(a, b, p) {
+			return true
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// orientation returns the sign of the turn from a to b to c: positive if
+// counterclockwise, negative if clockwise and 0 if collinear
+func orientation(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// onSegment reports whether p, collinear with a and b, lies between them
+func onSegment(a, b, p Point) bool {
+	return min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) && min(a.Y, b.Y) <= p.Y && p.Y <= max(a.Y, b.Y)
+}
+
+// segmentsCross reports whether the segments ab and cd cross at a point
+// inside both of them
+func segmentsCross(a, b, c, d Point) bool {
+	o1, o2 := orientation(a, b, c), orientation(a, b, d)
+	o3, o4 := orientation(c, d, a), orientation(c, d, b)
+	return o1*o2 < 0 && o3*o4 < 0
+}
+
+// segmentsTouch reports whether the segments ab and cd have a point in
+// common
+func segmentsTouch(a, b, c, d Point) bool {
+	if segmentsCross(a, b, c, d) {
+		return true
+	}
+	return (orientation(a, b, c) == 0 && onSegment(a, b, c)) ||
+		(orientation(a, b, d) == 0 && onSegment(a, b, d)) ||
+		(orientation(c, d, a) == 0 && onSegment(c, d, a)) ||
+		(orientation(c, d, b) == 0 && onSegment(c, d, b))
+}
+
+// ObjectWithin returns a filter matching triples whose object is a WKT
+// literal lying within region
+func ObjectWithin(region Geometry) TripleFilter {
+	return func(t Triple) bool {
+		g, err := ParseGeometry(t.Object)
+		return err == nil && SfWithin(g, region)
+	}
+}
+
+// ObjectIntersects returns a filter matching triples whose object is a WKT
+// literal intersecting region
+func ObjectIntersects(region Geometry) TripleFilter {
+	return func(t Triple) bool {
+		g, err := ParseGeometry(t.Object)
+		return err == nil && SfIntersects(g, region)
+	}
+}
+
+// parseWKTPoints parses a comma-separated list of positions
+func parseWKTPoints(s string) ([]Point, error) {
+	var points []Point
+	for _, position := range strings.Split(s, ",") {
+		fields := strings.Fields(position)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("position %q needs two coordinates", strings.TrimSpace(position))
+		}
+		x, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coordinate %q", fields[0])
+		}
+		y, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coordinate %q", fields[1])
+		}
+		points = append(points, Point{x, y})
+	}
+	return points, nil
+}
+
+// formatCoordinate formats a coordinate without trailing zeros
+func formatCoordinate(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// geoBuiltin adapts a geo predicate to a Datalog builtin over two WKT terms
+func geoBuiltin(predicate func(a, b Geometry) bool) DLBuiltin {
+	return func(args []string) bool {
+		if len(args) != 2 {
+			return false
+		}
+		a, err := ParseWKT(datalogLiteralValue(args[0]))
+		if err != nil {
+			return false
+		}
+		b, err := ParseWKT(datalogLiteralValue(args[1]))
+		return err == nil && predicate(a, b)
+	}
+}
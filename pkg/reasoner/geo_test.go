@@ -0,0 +1,155 @@
+package reasoner
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWKT(t *testing.T) {
+	tests := []struct {
+		wkt     string
+		want    Geometry
+		wantErr bool
+	}{
+		{"POINT(8.54 47.37)", NewPointGeometry(8.54, 47.37), false},
+		{"  point ( 1 2 3 ) ", NewPointGeometry(1, 2), false},
+		{"<http://www.opengis.net/def/crs/OGC/1.3/CRS84> POINT(1 2)", NewPointGeometry(1, 2), false},
+		{"POLYGON((0 0, 4 0, 4 3, 0 3, 0 0))", NewBoxGeometry(0, 0, 4, 3), false},
+		{"POLYGON((0 0, 4 1, 2 5, 0 0), (1 1, 2 1, 2 2, 1 1))", NewPolygonGeometry([]Point{{0, 0}, {4, 1}, {2, 5}}), false},
+		{"POINT(1)", Geometry{}, true},
+		{"POINT(a b)", Geometry{}, true},
+		{"POLYGON((0 0, 1 1))", Geometry{}, true},
+		{"LINESTRING(0 0, 1 1)", Geometry{}, true},
+		{"POINT EMPTY", Geometry{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseWKT(tt.wkt)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseWKT(%q) error = %v, wantErr %v", tt.wkt, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseWKT(%q) = %+v, want %+v", tt.wkt, got, tt.want)
+		}
+	}
+
+	for _, g := range []Geometry{NewBoxGeometry(0, 0, 4, 3), NewPolygonGeometry([]Point{{0, 0}, {4, 1}, {2, 5}})} {
+		if reparsed, err := ParseWKT(g.String()); err != nil || !reflect.DeepEqual(reparsed, g) {
+			t.Errorf("String() of %+v does not parse back: %q, %v", g, g.String(), err)
+		}
+	}
+}
+
+func TestGeoFunctions(t *testing.T) {
+	city := NewBoxGeometry(0, 0, 10, 10)
+	tests := []struct {
+		a          Geometry
+		within     bool
+		intersects bool
+	}{
+		{NewPointGeometry(5, 5), true, true},
+		{NewPointGeometry(10, 0), true, true},
+		{NewPointGeometry(11, 5), false, false},
+		{NewBoxGeometry(2, 2, 3, 3), true, true},
+		{NewBoxGeometry(8, 8, 12, 12), false, true},
+		{NewBoxGeometry(-1, -1, 11, 11), false, true},
+	}
+	for _, tt := range tests {
+		if got := SfWithin(tt.a, city); got != tt.within {
+			t.Errorf("SfWithin(%s) = %v, want %v", tt.a, got, tt.within)
+		}
+		if got := SfIntersects(tt.a, city); got != tt.intersects {
+			t.Errorf("SfIntersects(%s) = %v, want %v", tt.a, got, tt.intersects)
+		}
+	}
+
+	// Points in the bounding box of a concave polygon are not within it
+	district := NewPolygonGeometry([]Point{{0, 0}, {10, 0}, {10, 4}, {4, 4}, {4, 10}, {0, 10}})
+	tests = []struct {
+		a          Geometry
+		within     bool
+		intersects bool
+	}{
+		{NewPointGeometry(2, 8), true, true},
+		{NewPointGeometry(4, 7), true, true},
+		{NewPointGeometry(8, 8), false, false},
+		{NewBoxGeometry(1, 1, 3, 3), true, true},
+		{NewBoxGeometry(3, 3, 6, 6), false, true},
+		{NewBoxGeometry(6, 6, 9, 9), false, false},
+		{NewPolygonGeometry([]Point{{1, 1}, {9, 1}, {1, 9}}), false, true},
+		{NewPolygonGeometry([]Point{{1, 1}, {3, 1}, {1, 3}}), true, true},
+		{city, false, true},
+	}
+	for _, tt := range tests {
+		if got := SfWithin(tt.a, district); got != tt.within {
+			t.Errorf("SfWithin(%s, district) = %v, want %v", tt.a, got, tt.within)
+		}
+		if got := SfIntersects(tt.a, district); got != tt.intersects {
+			t.Errorf("SfIntersects(%s, district) = %v, want %v", tt.a, got, tt.intersects)
+		}
+	}
+	if !SfWithin(district, city) || SfWithin(city, district) {
+		t.Errorf("Expected the district within the city and not the reverse")
+	}
+}
+
+const geoDocument = `
+@prefix ex: <http://example.org/> .
+@prefix geo: <http://www.opengis.net/ont/geosparql#> .
+ex:townhall geo:asWKT "POINT(8.54 47.37)"^^geo:wktLiteral .
+ex:airport geo:asWKT "POINT(8.56 47.46)"^^geo:wktLiteral .
+ex:lake geo:asWKT "POLYGON((8.5 47.2, 8.8 47.2, 8.8 47.36, 8.5 47.36, 8.5 47.2))"^^geo:wktLiteral .
+ex:broken geo:asWKT "POINT(8.54)"^^geo:wktLiteral .
+`
+
+func TestQueryFilteredGeo(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(geoDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	oldTown := NewBoxGeometry(8.5, 47.35, 8.6, 47.4)
+	within := r.QueryFiltered("", GeoAsWKT, "", ObjectWithin(oldTown))
+	if len(within) != 1 || within[0].Subject != "http://example.org/townhall" {
+		t.Errorf("Expected only the townhall within the old town, got %v", within)
+	}
+
+	intersecting := r.QueryFiltered("", GeoAsWKT, "", ObjectIntersects(oldTown))
+	if len(intersecting) != 2 {
+		t.Errorf("Expected the townhall and the lake to intersect the old town, got %v", intersecting)
+	}
+}
+
+func TestDatalogGeoBuiltins(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(geoDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	facts := ConvertTriplesToDatalogWithOptions(r.GetAllTriples(), DatalogOptions{QuoteLiterals: true})
+
+	program := strings.Join(facts, "\n") + `
+InOldTown(X) :- sfWithin(G, "POLYGON((8.5 47.35, 8.6 47.35, 8.6 47.4, 8.5 47.4, 8.5 47.35))"), asWKT(X, G).
+`
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"?- InOldTown(townhall).", true},
+		{"?- InOldTown(airport).", false},
+		{"?- InOldTown(broken).", false},
+		{`?- sfIntersects("POINT(1 1)", "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))").`, true},
+		{`?- sfWithin("POINT(3 1)", "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))").`, false},
+	}
+	for _, tt := range tests {
+		result, err := DLQuery(program, tt.query)
+		if err != nil {
+			t.Errorf("DLQuery error for %s: %v", tt.query, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("DLQuery(%s) = %v, expected %v", tt.query, result, tt.expected)
+		}
+	}
+}
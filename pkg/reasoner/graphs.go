@@ -0,0 +1,167 @@
+package reasoner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// GraphReasoning selects how the named graphs of a dataset loaded from
+// N-Quads, TriG or JSON-LD are reasoned over
+type GraphReasoning int
+
+const (
+	// MergeGraphs reasons over the union of all graphs, as if they were
+	// one; inferred triples belong to the default graph
+	MergeGraphs GraphReasoning = iota
+	// PerGraph reasons over every named graph separately, together with
+	// the default graph, which typically holds the schema. Conclusions that
+	// need triples of two named graphs are not drawn, and inferred triples
+	// are labelled with the graph they were inferred in.
+	PerGraph
+)
+
+// String returns the name of the mode
+func (m GraphReasoning) String() string {
+	if m == PerGraph {
+		return "per-graph"
+	}
+	return "merge"
+}
+
+// ParseGraphReasoning parses "merge" or "per-graph"
+func ParseGraphReasoning(s string) (GraphReasoning, error) {
+	switch s {
+	case "merge":
+		return MergeGraphs, nil
+	case "per-graph":
+		return PerGraph, nil
+	default:
+		return MergeGraphs, fmt.Errorf("invalid graph reasoning %q, must be 'merge' or 'per-graph'", s)
+	}
+}
+
+// WithGraphReasoning sets whether named graphs are reasoned over together
+// or separately. Reasoning per graph is not partitioned, and the store
+// still holds the union of the graphs: a triple inferred in several graphs
+// is stored once, labelled with the first, and GraphsOf lists them all.
+func WithGraphReasoning(mode GraphReasoning) Option {
+	return func(r *Reasoner) {
+		r.graphReasoning = mode
+	}
+}
+
+// GraphsOf returns the graphs a stored triple was loaded or inferred in,
+// "" standing for the default graph. Besides the graph of the stored
+// triple, only graphs recorded while reasoning per graph are known.
+func (r *Reasoner) GraphsOf(t Triple) []string {
+	key := tripleKey(t)
+	if !r.store.triples[key] {
+		return nil
+	}
+	graph := ""
+	for _, idx := range r.store.bySubject[t.Subject] {
+		if stored := r.store.tripleList[idx]; tripleKey(stored) == key {
+			graph = stored.Graph
+			break
+		}
+	}
+	return append([]string{graph}, r.graphs[key]...)
+}
+
+// addGraphMember records that a triple already in the store also belongs
+// to the graph of t
+func (r *Reasoner) addGraphMember(t Triple) {
+	key := tripleKey(t)
+	for _, graph := range r.GraphsOf(t) {
+		if graph == t.Graph {
+			return
+		}
+	}
+	if r.graphs == nil {
+		r.graphs = make(map[string][]string)
+	}
+	r.graphs[key] = append(r.graphs[key], t.Graph)
+}
+
+// reasonPerGraph saturates the default graph, then each named graph in
+// order of their labels together with the saturated default graph, and
+// adds the inferred triples to the store labelled with their graph
+func (r *Reasoner) reasonPerGraph(ctx context.Context, emit func(Triple, string) error) (int, error) {
+	members := make(map[string][]Triple)
+	for _, t := range r.store.All() {
+		for _, graph := range r.GraphsOf(t) {
+			members[graph] = append(members[graph], Triple{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object})
+		}
+	}
+	var names []string
+	for graph := range members {
+		if graph != "" {
+			names = append(names, graph)
+		}
+	}
+	sort.Strings(names)
+
+	r.runDiagnostics = nil
+	inferred := 0
+	// merge adds the triples a graph's reasoner inferred after mark
+	merge := func(g *Reasoner, graph string, mark int, rules map[string]string) error {
+		r.runDiagnostics = append(r.runDiagnostics, g.runDiagnostics...)
+		for _, t := range g.store.tripleList[mark:] {
+			t.Graph = graph
+			if !r.store.Add(t) {
+				r.addGraphMember(t)
+				continue
+			}
+			inferred++
+			if emit != nil {
+				if err := emit(t, rules[tripleKey(t)]); err != nil {
+					return fmt.Errorf("rule %s: %w", rules[tripleKey(t)], err)
+				}
+			}
+		}
+		return nil
+	}
+
+	background := r.graphReasoner()
+	for _, t := range members[""] {
+		background.store.add(t)
+	}
+	mark := background.store.Size()
+	rules := make(map[string]string)
+	record := func(t Triple, rule string) error {
+		rules[tripleKey(t)] = rule
+		return nil
+	}
+	if _, err := background.reason(ctx, record); err != nil {
+		return inferred, err
+	}
+	if err := merge(background, "", mark, rules); err != nil {
+		return inferred, err
+	}
+
+	for _, graph := range names {
+		g := background.Fork()
+		for _, t := range members[graph] {
+			g.store.add(t)
+		}
+		mark := g.store.Size()
+		if _, err := g.reason(ctx, record); err != nil {
+			return inferred, err
+		}
+		if err := merge(g, graph, mark, rules); err != nil {
+			return inferred, err
+		}
+	}
+	return inferred, nil
+}
+
+// graphReasoner returns an empty reasoner with the rules and settings of r
+// that apply to reasoning, for reasoning over a single graph
+func (r *Reasoner) graphReasoner() *Reasoner {
+	g := NewReasonerWithRules(r.rules, WithTracer(r.tracer))
+	g.scope = r.scope
+	g.budgets = r.budgets
+	g.literalMatching = r.literalMatching
+	return g
+}
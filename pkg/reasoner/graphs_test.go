@@ -0,0 +1,84 @@
+package reasoner
+
+import "testing"
+
+const graphsDocument = `@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+
+ex:Municipality rdfs:subClassOf ex:Place .
+ex:partOf a owl:TransitiveProperty .
+
+ex:bfs {
+    ex:bern a ex:Municipality ;
+        ex:partOf ex:be .
+    ex:thun a ex:Municipality .
+}
+
+ex:osm {
+    ex:be ex:partOf ex:ch .
+    ex:thun a ex:Municipality .
+}
+`
+
+func TestGraphReasoning(t *testing.T) {
+	ex := "http://example.org/"
+	bernInCH := Triple{Subject: ex + "bern", Predicate: ex + "partOf", Object: ex + "ch"}
+
+	merged := NewReasoner()
+	if err := merged.LoadTriG(graphsDocument); err != nil {
+		t.Fatalf("LoadTriG failed: %v", err)
+	}
+	merged.RunForwardReasoning()
+	if !merged.GetStore().Contains(bernInCH) {
+		t.Errorf("Expected reasoning over the union of the graphs to join them")
+	}
+
+	r := NewReasoner(WithGraphReasoning(PerGraph))
+	if err := r.LoadTriG(graphsDocument); err != nil {
+		t.Fatalf("LoadTriG failed: %v", err)
+	}
+	inferred := r.RunForwardReasoning()
+	if r.GetStore().Contains(bernInCH) {
+		t.Errorf("Expected no conclusion from triples of two graphs")
+	}
+	place := r.Query(ex+"bern", RDFType, ex+"Place")
+	if len(place) != 1 || place[0].Graph != ex+"bfs" {
+		t.Errorf("Expected the inferred type in the graph of its premises, got %v", place)
+	}
+
+	// A triple asserted or inferred in two graphs belongs to both
+	thun := Triple{Subject: ex + "thun", Predicate: RDFType, Object: ex + "Place"}
+	if got := r.GraphsOf(thun); len(got) != 2 || got[0] != ex+"bfs" || got[1] != ex+"osm" {
+		t.Errorf("GraphsOf() = %v", got)
+	}
+	if got := r.GraphsOf(Triple{Subject: ex + "Municipality", Predicate: RDFSSubClassOf, Object: ex + "Place"}); len(got) != 1 || got[0] != "" {
+		t.Errorf("GraphsOf() = %v, want the default graph", got)
+	}
+
+	if again := r.RunForwardReasoning(); again != 0 || inferred == 0 {
+		t.Errorf("Expected a second run to infer nothing, got %d after %d", again, inferred)
+	}
+
+	var streamed []string
+	s := NewReasoner(WithGraphReasoning(PerGraph))
+	_ = s.LoadTriG(graphsDocument)
+	n, err := s.ReasonStream(func(t Triple, rule string) error {
+		streamed = append(streamed, rule)
+		return nil
+	})
+	if err != nil || n != inferred || len(streamed) != n || streamed[0] == "" {
+		t.Errorf("ReasonStream() = %d, %v with rules %v, want %d triples", n, err, streamed, inferred)
+	}
+}
+
+func TestParseGraphReasoning(t *testing.T) {
+	for _, mode := range []GraphReasoning{MergeGraphs, PerGraph} {
+		if parsed, err := ParseGraphReasoning(mode.String()); err != nil || parsed != mode {
+			t.Errorf("ParseGraphReasoning(%q) = %v, %v", mode, parsed, err)
+		}
+	}
+	if _, err := ParseGraphReasoning("union"); err == nil {
+		t.Errorf("Expected an error for an unknown mode")
+	}
+}
@@ -0,0 +1,79 @@
+package reasoner
+
+// OWLHasKey relates a class to the rdf:List of its key properties
+const OWLHasKey = "http://www.w3.org/2002/07/owl#hasKey"
+
+// HasKeyInference implements owl:hasKey: named instances of a class that
+// share a value for every key property are owl:sameAs. Literal values are
+// compared by value, so "01"^^xsd:integer matches "1"^^xsd:integer.
+// Blank nodes are ignored, as OWL keys only apply to named individuals.
+type HasKeyInference struct{}
+
+func (r *HasKeyInference) Name() string {
+	return "owl:hasKey"
+}
+
+func (r *HasKeyInference) Apply(store *TripleStore) []Triple {
+	c := &deltaCollector{store: store}
+
+	for _, axiom := range store.FindByPredicate(OWLHasKey) {
+		keys, err := store.ListMembers(axiom.Object)
+		if err != nil || len(keys) == 0 {
+			continue
+		}
+
+		// Group instances by the values of the first key property, then
+		// compare the remaining key properties within each group
+		groups := make(map[string][]string)
+		var order []string
+		for _, typed := range store.FindByPredicateObject(RDFType, axiom.Subject) {
+			x := typed.Subject
+			if !ParseTerm(x).IsIRI() {
+				continue
+			}
+			for _, v := range store.FindBySubjectPredicate(x, keys[0]) {
+				key := literalValueKey(store.objectKey(keys[0], v.Object))
+				if _, ok := groups[key]; !ok {
+					order = append(order, key)
+				}
+				groups[key] = append(groups[key], x)
+			}
+		}
+
+		for _, key := range order {
+			group := groups[key]
+			for i, x := range group {
+				for _, y := range group[i+1:] {
+					if x != y && shareKeyValues(store, x, y, keys[1:]) {
+						c.add(Triple{Subject: x, Predicate: OWLSameAs, Object: y})
+						c.add(Triple{Subject: y, Predicate: OWLSameAs, Object: x})
+					}
+				}
+			}
+		}
+	}
+
+	return c.inferred
+}
+
+// shareKeyValues reports whether x and y have a common value for each property
+func shareKeyValues(store *TripleStore, x, y string, properties []string) bool {
+	for _, p := range properties {
+		values := make(map[string]bool)
+		for _, v := range store.FindBySubjectPredicate(x, p) {
+			values[literalValueKey(store.objectKey(p, v.Object))] = true
+		}
+
+		shared := false
+		for _, v := range store.FindBySubjectPredicate(y, p) {
+			if values[literalValueKey(store.objectKey(p, v.Object))] {
+				shared = true
+				break
+			}
+		}
+		if !shared {
+			return false
+		}
+	}
+	return true
+}
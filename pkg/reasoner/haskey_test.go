@@ -0,0 +1,48 @@
+package reasoner
+
+import "testing"
+
+func TestHasKeyInference(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:Building owl:hasKey _:k1 .
+_:k1 rdf:first ex:street ; rdf:rest _:k2 .
+_:k2 rdf:first ex:number ; rdf:rest rdf:nil .
+ex:School rdfs:subClassOf ex:Building .
+
+ex:b1 a ex:Building ; ex:street ex:Bahnhofstrasse ; ex:number "1"^^xsd:integer .
+ex:b2 a ex:School ; ex:street ex:Bahnhofstrasse ; ex:number "01"^^xsd:integer .
+ex:b3 a ex:Building ; ex:street ex:Bahnhofstrasse ; ex:number "2"^^xsd:integer .
+ex:b4 a ex:Building ; ex:street ex:Bahnhofstrasse .
+ex:b5 ex:street ex:Bahnhofstrasse ; ex:number "1"^^xsd:integer .
+_:b6 a ex:Building ; ex:street ex:Bahnhofstrasse ; ex:number "1"^^xsd:integer .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	ex := "http://example.org/"
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{ex + "b1", ex + "b2", true},  // same key values, b2 typed through subClassOf
+		{ex + "b2", ex + "b1", true},  // symmetric
+		{ex + "b1", ex + "b3", false}, // different number
+		{ex + "b1", ex + "b4", false}, // missing number
+		{ex + "b1", ex + "b5", false}, // not a Building
+		{ex + "b1", "_:b6", false},    // blank nodes are not named individuals
+	}
+	for _, tt := range tests {
+		got := r.GetStore().Contains(Triple{Subject: tt.a, Predicate: OWLSameAs, Object: tt.b})
+		if got != tt.same {
+			t.Errorf("%s owl:sameAs %s = %v, want %v", tt.a, tt.b, got, tt.same)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+)
+
+// IntegrityProblem is an inconsistency between the triples of a store and
+// its indexes, found by CheckIntegrity
+type IntegrityProblem struct {
+	// Index names the structure at fault: "triples", "subject",
+	// "predicate", "object" or "sources"
+	Index   string
+	Message string
+}
+
+func (p IntegrityProblem) String() string {
+	return p.Index + " index: " + p.Message
+}
+
+// CheckIntegrity verifies that the store's indexes agree with its triples:
+// every triple is stored once and found in the subject, predicate and object
+// indexes, and every index entry refers to a stored triple with that term.
+// It returns the problems found, or nil if the store is consistent
+func (ts *TripleStore) CheckIntegrity() []IntegrityProblem {
+	var problems []IntegrityProblem
+	report := func(index, format string, args ...any) {
+		problems = append(problems, IntegrityProblem{Index: index, Message: fmt.Sprintf(format, args...)})
+	}
+
+	seen := make(map[string]int, len(ts.tripleList))
+	for i, t := range ts.tripleList {
+		key := tripleKey(t)
+		if first, ok := seen[key]; ok {
+			report("triples", "triple %d duplicates triple %d: %s", i, first, t)
+			continue
+		}
+		seen[key] = i
+		if !ts.triples[key] {
+			report("triples", "triple %d is missing from the membership set: %s", i, t)
+		}
+	}
+	for key := range ts.triples {
+		if _, ok := seen[key]; !ok {
+			report("triples", "membership set contains a triple that is not stored: %s", key)
+		}
+	}
+
+	indexes := []struct {
+		name  string
+		index map[string][]int
+		term  func(Triple) string
+	}{
+		{"subject", ts.bySubject, func(t Triple) string { return t.Subject }},
+		{"predicate", ts.byPredicate, func(t Triple) string { return t.Predicate }},
+		{"object", ts.byObject, func(t Triple) string { return t.Object }},
+	}
+	for _, idx := range indexes {
+		indexed := make([]bool, len(ts.tripleList))
+		terms := make([]string, 0, len(idx.index))
+		for term := range idx.index {
+			terms = append(terms, term)
+		}
+		sort.Strings(terms)
+		for _, term := range terms {
+			for _, id := range idx.index[term] {
+				switch {
+				case id < 0 || id >= len(ts.tripleList):
+					report(idx.name, "%s refers to triple %d, but only %d are stored", term, id, len(ts.tripleList))
+				case idx.term(ts.tripleList[id]) != term:
+					report(idx.name, "%s refers to triple %d, which is %s", term, id, ts.tripleList[id])
+				case indexed[id]:
+					report(idx.name, "%s refers to triple %d more than once", term, id)
+				default:
+					indexed[id] = true
+				}
+			}
+		}
+		for id, ok := range indexed {
+			if !ok {
+				report(idx.name, "triple %d is not indexed: %s", id, ts.tripleList[id])
+			}
+		}
+	}
+
+	for key := range ts.sources {
+		if !ts.triples[key] {
+			report("sources", "records the source of a triple that is not stored: %s", key)
+		}
+	}
+	return problems
+}
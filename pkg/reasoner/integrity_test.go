@@ -0,0 +1,57 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(manchesterData); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+	if problems := r.GetStore().CheckIntegrity(); problems != nil {
+		t.Fatalf("Expected a consistent store, got %v", problems)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func(ts *TripleStore)
+		index   string
+		message string
+	}{
+		{"dangling id", func(ts *TripleStore) {
+			ts.bySubject["http://example.org/alice"] = append(ts.bySubject["http://example.org/alice"], len(ts.tripleList))
+		}, "subject", "but only"},
+		{"unindexed triple", func(ts *TripleStore) {
+			delete(ts.byObject, ts.tripleList[0].Object)
+		}, "object", "is not indexed"},
+		{"wrong term", func(ts *TripleStore) {
+			ts.byPredicate["http://example.org/other"] = []int{0}
+		}, "predicate", "which is"},
+		{"missing membership", func(ts *TripleStore) {
+			delete(ts.triples, tripleKey(ts.tripleList[0]))
+		}, "triples", "missing from the membership set"},
+		{"duplicate triple", func(ts *TripleStore) {
+			ts.tripleList = append(ts.tripleList, ts.tripleList[0])
+		}, "triples", "duplicates triple 0"},
+	}
+	for _, tt := range tests {
+		store := NewTripleStore()
+		for _, triple := range r.GetStore().All() {
+			store.Add(triple)
+		}
+		tt.corrupt(store)
+		problems := store.CheckIntegrity()
+		found := false
+		for _, p := range problems {
+			if p.Index == tt.index && strings.Contains(p.Message, tt.message) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected a %s index problem containing %q, got %v", tt.name, tt.index, tt.message, problems)
+		}
+	}
+}
@@ -0,0 +1,826 @@
+package reasoner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// rdfJSON is the datatype of the values of terms typed @json
+const rdfJSON = RDF + "JSON"
+
+// jsonLDKeywords are the keywords of JSON-LD 1.1
+//
+//nolint:gochecknoglobals
+var jsonLDKeywords = map[string]bool{
+	"@base": true, "@container": true, "@context": true, "@direction": true,
+	"@graph": true, "@id": true, "@import": true, "@included": true,
+	"@index": true, "@json": true, "@language": true, "@list": true,
+	"@nest": true, "@none": true, "@prefix": true, "@propagate": true,
+	"@protected": true, "@reverse": true, "@set": true, "@type": true,
+	"@value": true, "@version": true, "@vocab": true,
+}
+
+// jsonLDKnownContexts are remote contexts that are not fetched but known to
+// declare a vocabulary, with the aliases id and type
+//
+//nolint:gochecknoglobals
+var jsonLDKnownContexts = map[string]string{
+	"http://schema.org":  "http://schema.org/",
+	"https://schema.org": "http://schema.org/",
+}
+
+// JSONLDParser parses JSON-LD, such as Croissant metadata, by expanding the
+// document and extracting its triples. Inline contexts are supported with
+// term definitions, @vocab, @base, @language, type coercion, the @list,
+// @set and @language containers, @reverse and property-scoped contexts.
+// Remote contexts are not fetched: the schema.org context is known, others
+// are reported and ignored. Named graphs are kept in Triple.Graph. Blank
+// nodes without an @id are labelled from a hash of the document, so that
+// documents loaded into the same store do not share them.
+type JSONLDParser struct {
+	// errors records values skipped during the last Parse
+	errors []ParseError
+
+	// warnings records suspicious but loaded triples of the last Parse
+	warnings []ParseError
+
+	// prefixes records the namespaces defined in the contexts of the last
+	// parsed document
+	prefixes map[string]string
+
+	// options limits the size of parsed documents
+	options ParserOptions
+
+	// base is the IRI of the document, against which relative IRIs are
+	// resolved unless @base overrides it
+	base string
+
+	lines      []int
+	triples    []Triple
+	graph      string
+	blankNodes int
+	blankLabel string
+	limitErr   error
+}
+
+// jsonObject is a JSON object with its keys in document order and the line
+// it starts on
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+	line   int
+}
+
+// jsonLDContext is the active context of a node
+type jsonLDContext struct {
+	base     string
+	vocab    string
+	language string
+	terms    map[string]*jsonLDTerm
+}
+
+// jsonLDTerm is a term definition; an empty id means the term is
+// explicitly not mapped
+type jsonLDTerm struct {
+	id          string
+	typ         string
+	container   string
+	language    string
+	hasLanguage bool
+	reverse     bool
+	context     any
+}
+
+// NewJSONLDParser creates a new JSON-LD parser
+func NewJSONLDParser() *JSONLDParser {
+	return &JSONLDParser{prefixes: make(map[string]string)}
+}
+
+// NewJSONLDParserWithOptions creates a JSON-LD parser that enforces limits.
+// Parse fails with an error wrapping ErrParserLimit as soon as a limit is
+// exceeded. MaxDepth limits the nesting of JSON objects and arrays.
+func NewJSONLDParserWithOptions(opts ParserOptions) *JSONLDParser {
+	p := NewJSONLDParser()
+	p.options = opts
+	return p
+}
+
+// Parse parses JSON-LD content and returns triples. Malformed JSON fails
+// the whole document; values that are valid JSON but cannot be converted
+// to RDF are skipped and reported by Errors. Keys that do not expand to an
+// IRI are dropped, as JSON-LD expansion does.
+func (p *JSONLDParser) Parse(content string) ([]Triple, error) {
+	p.errors = nil
+	p.warnings = nil
+	p.prefixes = make(map[string]string)
+	p.triples = nil
+	p.graph = ""
+	p.blankNodes = 0
+	p.blankLabel = hashString(content)[:8]
+	p.limitErr = nil
+
+	content = strings.TrimPrefix(content, "\ufeff")
+	if limit := p.options.MaxInputBytes; limit > 0 && len(content) > limit {
+		return nil, limitError("document is %d bytes, the limit is %d", len(content), limit)
+	}
+
+	p.lines = nil
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			p.lines = append(p.lines, i)
+		}
+	}
+
+	document, err := p.readDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	p.topLevel(document, &jsonLDContext{base: p.base, terms: make(map[string]*jsonLDTerm)})
+	if p.limitErr != nil {
+		return nil, p.limitErr
+	}
+
+	return p.triples, nil
+}
+
+// Errors returns the values skipped during the last call to Parse
+func (p *JSONLDParser) Errors() []ParseError {
+	return p.errors
+}
+
+// Warnings returns problems found in triples that were loaded anyway
+// during the last call to Parse, such as malformed date literals
+func (p *JSONLDParser) Warnings() []ParseError {
+	return p.warnings
+}
+
+// Prefixes returns the namespaces defined in the contexts of the last
+// parsed document: terms mapped to an IRI ending in '/' or '#', and the
+// vocabulary as the empty prefix
+func (p *JSONLDParser) Prefixes() map[string]string {
+	prefixes := make(map[string]string, len(p.prefixes))
+	for prefix, iri := range p.prefixes {
+		prefixes[prefix] = iri
+	}
+	return prefixes
+}
+
+// readDocument decodes the document into strings, json.Numbers, bools,
+// nil, []any and *jsonObject values
+func (p *JSONLDParser) readDocument(content string) (any, error) {
+	d := json.NewDecoder(strings.NewReader(content))
+	d.UseNumber()
+	document, err := p.readValue(d, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid JSON: unexpected content after the document on line %d", p.lineAt(d.InputOffset()))
+	}
+	return document, nil
+}
+
+// readValue decodes the next JSON value
+func (p *JSONLDParser) readValue(d *json.Decoder, depth int) (any, error) {
+	token, err := d.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	line := p.lineAt(d.InputOffset() - 1)
+	if limit := p.options.MaxDepth; limit > 0 && depth > limit {
+		return nil, fmt.Errorf("line %d: %w", line, limitError("JSON nested deeper than %d", limit))
+	}
+	if delim == '[' {
+		items := []any{}
+		for d.More() {
+			item, err := p.readValue(d, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		if _, err := d.Token(); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return items, nil
+	}
+
+	o := &jsonObject{values: make(map[string]any), line: line}
+	for d.More() {
+		token, err := d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		key, _ := token.(string)
+		value, err := p.readValue(d, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := o.values[key]; !ok {
+			o.keys = append(o.keys, key)
+		}
+		o.values[key] = value
+	}
+	if _, err := d.Token(); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return o, nil
+}
+
+// lineAt returns the 1-based line of an offset in the document
+func (p *JSONLDParser) lineAt(offset int64) int {
+	return sort.SearchInts(p.lines, int(offset)) + 1
+}
+
+// topLevel extracts the triples of the nodes of the document
+func (p *JSONLDParser) topLevel(value any, ctx *jsonLDContext) {
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			p.topLevel(item, ctx)
+		}
+	case *jsonObject:
+		// An object with only a context and a graph holds the nodes of
+		// the default graph
+		graph, hasGraph := v.values["@graph"]
+		_, hasContext := v.values["@context"]
+		if hasGraph && (len(v.keys) == 1 || len(v.keys) == 2 && hasContext) {
+			if c, ok := v.values["@context"]; ok {
+				ctx = p.processContext(ctx, c, v.line)
+			}
+			for _, item := range jsonLDItems(graph) {
+				p.topLevel(item, ctx)
+			}
+			return
+		}
+		p.node(v, ctx)
+	}
+}
+
+// processContext returns the active context updated with a local context
+func (p *JSONLDParser) processContext(active *jsonLDContext, value any, line int) *jsonLDContext {
+	switch v := value.(type) {
+	case nil:
+		return &jsonLDContext{base: p.base, terms: make(map[string]*jsonLDTerm)}
+	case []any:
+		for _, item := range v {
+			active = p.processContext(active, item, line)
+		}
+		return active
+	case string:
+		vocab, ok := jsonLDKnownContexts[strings.TrimSuffix(v, "/")]
+		if !ok {
+			p.errors = append(p.errors, ParseError{Line: line, Message: fmt.Sprintf("unsupported remote context %q, its terms are not defined", v)})
+			return active
+		}
+		ctx := active.clone()
+		ctx.vocab = vocab
+		ctx.terms["id"] = &jsonLDTerm{id: "@id"}
+		ctx.terms["type"] = &jsonLDTerm{id: "@type"}
+		return ctx
+	case *jsonObject:
+		ctx := active.clone()
+		if b, ok := v.values["@base"]; ok {
+			s, _ := b.(string)
+			if b == nil {
+				ctx.base = ""
+			} else {
+				ctx.base = resolveReference(ctx.base, s)
+			}
+		}
+		if vocab, ok := v.values["@vocab"]; ok {
+			s, _ := vocab.(string)
+			ctx.vocab = ""
+			if vocab != nil {
+				ctx.vocab = p.expandIRI(ctx, s, true, true)
+			}
+			if ctx.vocab != "" {
+				p.prefixes[""] = ctx.vocab
+			}
+		}
+		if language, ok := v.values["@language"]; ok {
+			ctx.language, _ = language.(string)
+		}
+		defined := make(map[string]bool)
+		for _, term := range v.keys {
+			if !strings.HasPrefix(term, "@") {
+				p.defineTerm(ctx, v, term, defined)
+			}
+		}
+		return ctx
+	default:
+		p.errors = append(p.errors, ParseError{Line: line, Message: "invalid @context, expected an object, an array or an IRI"})
+		return active
+	}
+}
+
+// clone returns a copy of the context that can be updated
+func (c *jsonLDContext) clone() *jsonLDContext {
+	clone := *c
+	clone.terms = make(map[string]*jsonLDTerm, len(c.terms))
+	for term, definition := range c.terms {
+		clone.terms[term] = definition
+	}
+	return &clone
+}
+
+// defineTerm adds the definition of a term of a local context, defining
+// first the terms its IRI depends on
+func (p *JSONLDParser) defineTerm(ctx *jsonLDContext, local *jsonObject, term string, defined map[string]bool) {
+	if done, ok := defined[term]; ok {
+		if !done {
+			p.errors = append(p.errors, ParseError{Line: local.line, Message: fmt.Sprintf("cyclic definition of term %q", term)})
+		}
+		return
+	}
+	defined[term] = false
+	defer func() { defined[term] = true }()
+
+	definition := &jsonLDTerm{}
+	var id any
+	hasID := false
+	switch v := local.values[term].(type) {
+	case nil:
+		ctx.terms[term] = definition
+		return
+	case string:
+		id, hasID = v, true
+	case *jsonObject:
+		id, hasID = v.values["@id"]
+		if reverse, ok := v.values["@reverse"].(string); ok {
+			id, hasID = reverse, true
+			definition.reverse = true
+		}
+		if typ, ok := v.values["@type"].(string); ok {
+			definition.typ = p.expandLocalIRI(ctx, local, typ, defined)
+		}
+		for _, container := range jsonLDItems(v.values["@container"]) {
+			if c, _ := container.(string); c == "@list" || c == "@set" || c == "@language" {
+				definition.container = c
+			}
+		}
+		if language, ok := v.values["@language"]; ok {
+			definition.language, _ = language.(string)
+			definition.hasLanguage = true
+		}
+		definition.context = v.values["@context"]
+	default:
+		p.errors = append(p.errors, ParseError{Line: local.line, Message: fmt.Sprintf("invalid definition of term %q", term)})
+		return
+	}
+
+	switch {
+	case hasID && id == nil:
+	case hasID:
+		s, ok := id.(string)
+		if !ok {
+			p.errors = append(p.errors, ParseError{Line: local.line, Message: fmt.Sprintf("invalid @id of term %q", term)})
+			return
+		}
+		definition.id = p.expandLocalIRI(ctx, local, s, defined)
+	case strings.Contains(term, ":"):
+		definition.id = p.expandLocalIRI(ctx, local, term, defined)
+	case ctx.vocab != "":
+		definition.id = ctx.vocab + term
+	default:
+		p.errors = append(p.errors, ParseError{Line: local.line, Message: fmt.Sprintf("term %q has no IRI and there is no @vocab", term)})
+		return
+	}
+	ctx.terms[term] = definition
+
+	if iri, ok := local.values[term].(string); ok && iri != "" && definition.id != "" && strings.ContainsAny(iri[len(iri)-1:], "/#") {
+		p.prefixes[term] = definition.id
+	}
+}
+
+// expandLocalIRI expands an IRI in a term definition, once the term or
+// prefix it uses is defined
+func (p *JSONLDParser) expandLocalIRI(ctx *jsonLDContext, local *jsonObject, value string, defined map[string]bool) string {
+	if _, ok := local.values[value]; ok && !strings.HasPrefix(value, "@") {
+		p.defineTerm(ctx, local, value, defined)
+	}
+	if prefix, _, ok := strings.Cut(value, ":"); ok {
+		if _, ok := local.values[prefix]; ok {
+			p.defineTerm(ctx, local, prefix, defined)
+		}
+	}
+	return p.expandIRI(ctx, value, true, false)
+}
+
+// expandIRI expands a keyword, term, compact IRI or relative IRI. Terms
+// and the vocabulary are used for properties, types and values typed
+// @vocab; relative IRIs are resolved against the base for node
+// identifiers. It returns "" for terms that are not mapped.
+func (p *JSONLDParser) expandIRI(ctx *jsonLDContext, value string, vocab, documentRelative bool) string {
+	if jsonLDKeywords[value] {
+		return value
+	}
+	if strings.HasPrefix(value, "@") {
+		return ""
+	}
+	if vocab {
+		if definition, ok := ctx.terms[value]; ok {
+			return definition.id
+		}
+	}
+	if prefix, suffix, ok := strings.Cut(value, ":"); ok {
+		if prefix == "_" || strings.HasPrefix(suffix, "//") {
+			return value
+		}
+		if definition, ok := ctx.terms[prefix]; ok && definition.id != "" {
+			return definition.id + suffix
+		}
+		return value
+	}
+	if vocab && ctx.vocab != "" {
+		return ctx.vocab + value
+	}
+	if documentRelative {
+		return resolveReference(ctx.base, value)
+	}
+	return value
+}
+
+// keyword returns the value of a keyword or one of its aliases
+func (p *JSONLDParser) keyword(ctx *jsonLDContext, o *jsonObject, keyword string) (any, bool) {
+	for _, key := range o.keys {
+		if key == keyword || p.expandIRI(ctx, key, true, false) == keyword {
+			return o.values[key], true
+		}
+	}
+	return nil, false
+}
+
+// node emits the triples of a node object and returns its subject
+func (p *JSONLDParser) node(o *jsonObject, ctx *jsonLDContext) string {
+	if c, ok := o.values["@context"]; ok {
+		ctx = p.processContext(ctx, c, o.line)
+	}
+
+	subject := ""
+	if id, ok := p.keyword(ctx, o, "@id"); ok {
+		if s, ok := id.(string); ok {
+			subject = p.expandIRI(ctx, s, false, true)
+		} else {
+			p.errors = append(p.errors, ParseError{Line: o.line, Message: "invalid @id, expected a string"})
+		}
+	}
+	if subject == "" {
+		subject = p.newBlankNode()
+	}
+	p.properties(subject, o, ctx)
+
+	if graph, ok := p.keyword(ctx, o, "@graph"); ok {
+		previous := p.graph
+		p.graph = subject
+		for _, item := range jsonLDItems(graph) {
+			if n, ok := item.(*jsonObject); ok {
+				p.node(n, ctx)
+			}
+		}
+		p.graph = previous
+	}
+	return subject
+}
+
+// properties emits the types and property values of a node object
+func (p *JSONLDParser) properties(subject string, o *jsonObject, ctx *jsonLDContext) {
+	for _, key := range o.keys {
+		value := o.values[key]
+		switch iri := p.expandIRI(ctx, key, true, false); iri {
+		case "@type":
+			for _, item := range jsonLDItems(value) {
+				s, ok := item.(string)
+				if !ok {
+					p.errors = append(p.errors, ParseError{Line: o.line, Message: "invalid @type, expected a string"})
+					continue
+				}
+				p.emit(Triple{Subject: subject, Predicate: RDFType, Object: p.expandIRI(ctx, s, true, true)}, o.line)
+			}
+		case "@reverse":
+			if r, ok := value.(*jsonObject); ok {
+				for _, property := range r.keys {
+					p.property(subject, property, p.expandIRI(ctx, property, true, false), r.values[property], ctx, r.line, true)
+				}
+			}
+		case "@nest":
+			for _, item := range jsonLDItems(value) {
+				if n, ok := item.(*jsonObject); ok {
+					p.properties(subject, n, ctx)
+				}
+			}
+		case "@included":
+			for _, item := range jsonLDItems(value) {
+				if n, ok := item.(*jsonObject); ok {
+					p.node(n, ctx)
+				}
+			}
+		case "":
+		default:
+			if !strings.HasPrefix(iri, "@") {
+				p.property(subject, key, iri, value, ctx, o.line, false)
+			}
+		}
+	}
+}
+
+// property emits the values of a property of a node
+func (p *JSONLDParser) property(subject, key, iri string, value any, ctx *jsonLDContext, line int, reverse bool) {
+	term := ctx.terms[key]
+	if term == nil {
+		term = &jsonLDTerm{}
+	}
+	if !strings.Contains(iri, ":") || strings.HasPrefix(iri, "_:") {
+		p.errors = append(p.errors, ParseError{Line: line, Message: fmt.Sprintf("property %q does not expand to an IRI", key)})
+		return
+	}
+	if term.context != nil {
+		ctx = p.processContext(ctx, term.context, line)
+	}
+
+	var objects []string
+	m, isMap := value.(*jsonObject)
+	switch {
+	case term.container == "@language" && isMap:
+		for _, language := range m.keys {
+			tag := language
+			if p.expandIRI(ctx, language, true, false) == "@none" {
+				tag = ""
+			}
+			for _, item := range jsonLDItems(m.values[language]) {
+				if s, ok := item.(string); ok {
+					objects = append(objects, p.literal(s, tag, ""))
+				}
+			}
+		}
+	case term.container == "@list" && term.typ != "@json":
+		if isMap {
+			if _, ok := p.keyword(ctx, m, "@list"); ok {
+				objects = p.objects(value, term, ctx, line)
+				break
+			}
+		}
+		objects = []string{p.list(jsonLDItems(value), term, ctx, line)}
+	default:
+		objects = p.objects(value, term, ctx, line)
+	}
+
+	for _, object := range objects {
+		switch {
+		case !reverse && !term.reverse:
+			p.emit(Triple{Subject: subject, Predicate: iri, Object: object}, line)
+		case strings.HasPrefix(object, `"`):
+			p.errors = append(p.errors, ParseError{Line: line, Message: fmt.Sprintf("reverse property %q has a literal value", key)})
+		default:
+			p.emit(Triple{Subject: object, Predicate: iri, Object: subject}, line)
+		}
+	}
+}
+
+// objects converts a value to RDF terms, emitting the triples of nested
+// nodes and lists
+func (p *JSONLDParser) objects(value any, term *jsonLDTerm, ctx *jsonLDContext, line int) []string {
+	if term.typ == "@json" && value != nil {
+		return []string{p.literal(canonicalJSON(value), "", rdfJSON)}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		var objects []string
+		for _, item := range v {
+			objects = append(objects, p.objects(item, term, ctx, line)...)
+		}
+		return objects
+	case string:
+		switch term.typ {
+		case "@id":
+			return []string{p.expandIRI(ctx, v, false, true)}
+		case "@vocab":
+			return []string{p.expandIRI(ctx, v, true, true)}
+		case "", "@none":
+			language := ctx.language
+			if term.hasLanguage {
+				language = term.language
+			}
+			return []string{p.literal(v, language, "")}
+		default:
+			return []string{p.literal(v, "", term.typ)}
+		}
+	case json.Number, bool:
+		lexical, datatype := jsonLDNative(v)
+		if term.typ != "" && !strings.HasPrefix(term.typ, "@") {
+			datatype = term.typ
+		}
+		return []string{p.literal(lexical, "", datatype)}
+	case *jsonObject:
+		if val, ok := p.keyword(ctx, v, "@value"); ok {
+			return p.value(v, val, ctx)
+		}
+		if list, ok := p.keyword(ctx, v, "@list"); ok {
+			return []string{p.list(jsonLDItems(list), term, ctx, v.line)}
+		}
+		if set, ok := p.keyword(ctx, v, "@set"); ok {
+			return p.objects(set, term, ctx, v.line)
+		}
+		return []string{p.node(v, ctx)}
+	}
+	return nil
+}
+
+// value converts a value object to a literal
+func (p *JSONLDParser) value(o *jsonObject, value any, ctx *jsonLDContext) []string {
+	datatype, language := "", ""
+	if t, ok := p.keyword(ctx, o, "@type"); ok {
+		s, _ := t.(string)
+		if s == "@json" {
+			return []string{p.literal(canonicalJSON(value), "", rdfJSON)}
+		}
+		datatype = p.expandIRI(ctx, s, true, true)
+	}
+	if l, ok := p.keyword(ctx, o, "@language"); ok {
+		language, _ = l.(string)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if datatype != "" {
+			language = ""
+		}
+		return []string{p.literal(v, language, datatype)}
+	case json.Number, bool:
+		lexical, native := jsonLDNative(v)
+		if datatype == "" {
+			datatype = native
+		}
+		return []string{p.literal(lexical, "", datatype)}
+	default:
+		p.errors = append(p.errors, ParseError{Line: o.line, Message: "invalid @value, expected a string, number or boolean"})
+		return nil
+	}
+}
+
+// list emits an rdf:List of values and returns its head
+func (p *JSONLDParser) list(items []any, term *jsonLDTerm, ctx *jsonLDContext, line int) string {
+	head := RDFNil
+	previous := ""
+	for _, item := range items {
+		for _, object := range p.objects(item, term, ctx, line) {
+			node := p.newBlankNode()
+			if previous == "" {
+				head = node
+			} else {
+				p.emit(Triple{Subject: previous, Predicate: RDFRest, Object: node}, line)
+			}
+			p.emit(Triple{Subject: node, Predicate: RDFFirst, Object: object}, line)
+			previous = node
+		}
+	}
+	if previous != "" {
+		p.emit(Triple{Subject: previous, Predicate: RDFRest, Object: RDFNil}, line)
+	}
+	return head
+}
+
+// literal returns a literal term with the escaping used for all stored
+// literals, and records an error if it exceeds MaxLiteralLength
+func (p *JSONLDParser) literal(value, lang, datatype string) string {
+	if limit := p.options.MaxLiteralLength; limit > 0 && len(value) > limit && p.limitErr == nil {
+		p.limitErr = limitError("literal longer than %d bytes", limit)
+	}
+	term := `"` + escapeLiteral(value) + `"`
+	switch {
+	case datatype != "":
+		return term + "^^<" + datatype + ">"
+	case lang != "":
+		return term + "@" + lang
+	default:
+		return term
+	}
+}
+
+// emit records a triple in the current graph and checks its literal.
+// Triples with a term that is not mapped are dropped.
+func (p *JSONLDParser) emit(t Triple, line int) {
+	if t.Subject == "" || t.Predicate == "" || t.Object == "" || p.limitErr != nil {
+		return
+	}
+	if err := validateTemporalLiteral(t.Object); err != nil {
+		p.warnings = append(p.warnings, ParseError{Line: line, Message: err.Error()})
+	}
+	if err := validateCustomLiteral(t.Object); err != nil {
+		p.warnings = append(p.warnings, ParseError{Line: line, Message: err.Error()})
+	}
+	t.Graph = p.graph
+	p.triples = append(p.triples, t)
+	if limit := p.options.MaxTriples; limit > 0 && len(p.triples) > limit {
+		p.limitErr = fmt.Errorf("line %d: %w", line, limitError("more than %d triples", limit))
+	}
+}
+
+// newBlankNode returns a blank node label unique within the document
+func (p *JSONLDParser) newBlankNode() string {
+	p.blankNodes++
+	return fmt.Sprintf("_:j%s_%d", p.blankLabel, p.blankNodes)
+}
+
+// jsonLDItems returns the items of an array, or a single value as one item
+func jsonLDItems(value any) []any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	default:
+		return []any{v}
+	}
+}
+
+// jsonLDNative returns the lexical form and datatype of a JSON number or
+// boolean: numbers without a fractional part are integers, others doubles
+// in canonical form
+func jsonLDNative(value any) (string, string) {
+	if b, ok := value.(bool); ok {
+		return strconv.FormatBool(b), XSD + "boolean"
+	}
+	n, _ := value.(json.Number)
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		return s, XSD + "integer"
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return s, XSD + "double"
+	}
+	if f == math.Trunc(f) && math.Abs(f) < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), XSD + "integer"
+	}
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(f, 'E', -1, 64), "E")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	e, _ := strconv.Atoi(exponent)
+	return mantissa + "E" + strconv.Itoa(e), XSD + "double"
+}
+
+// canonicalJSON serializes a JSON value compactly with sorted keys, the
+// lexical form of rdf:JSON literals
+func canonicalJSON(value any) string {
+	var buf bytes.Buffer
+	writeJSON(&buf, value)
+	return buf.String()
+}
+
+// writeJSON writes a decoded JSON value
+func writeJSON(buf *bytes.Buffer, value any) {
+	switch v := value.(type) {
+	case *jsonObject:
+		keys := append([]string(nil), v.keys...)
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSON(buf, key)
+			buf.WriteByte(':')
+			writeJSON(buf, v.values[key])
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSON(buf, item)
+		}
+		buf.WriteByte(']')
+	case string:
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+		_ = encoder.Encode(v)
+		buf.Truncate(buf.Len() - 1)
+	case json.Number:
+		buf.WriteString(v.String())
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	default:
+		buf.WriteString("null")
+	}
+}
@@ -0,0 +1,191 @@
+package reasoner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const croissantDocument = `{
+  "@context": {
+    "@language": "en",
+    "@vocab": "https://schema.org/",
+    "@base": "https://data.example.org/",
+    "cr": "http://mlcommons.org/croissant/",
+    "sc": "https://schema.org/",
+    "recordSet": "cr:recordSet",
+    "field": "cr:field",
+    "dataType": {"@id": "cr:dataType", "@type": "@vocab"},
+    "examples": {"@id": "cr:examples", "@type": "@json"},
+    "keywords": {"@container": "@list"},
+    "alternateName": {"@container": "@language"},
+    "isPartOf": {"@reverse": "hasPart"},
+    "ignored": null
+  },
+  "@type": "sc:Dataset",
+  "@id": "residents",
+  "name": "Residents",
+  "alternateName": {"de": "Einwohner", "@none": "Bewohner"},
+  "keywords": ["people", "census"],
+  "version": 2,
+  "isLiveDataset": true,
+  "size": 1.5,
+  "dateCreated": {"@value": "2024-01-01", "@type": "sc:Date"},
+  "ignored": "not loaded",
+  "http://example.org/note": {"@value": "Notiz", "@language": "de"},
+  "recordSet": [{
+    "@type": "cr:RecordSet",
+    "@id": "people",
+    "field": [
+      {"@type": "cr:Field", "@id": "people/email", "dataType": "sc:Text", "examples": {"b": [1, true], "a": "x"}},
+      {"@type": "cr:Field", "@id": "people/height", "dataType": "sc:Float"}
+    ]
+  }],
+  "isPartOf": {"@id": "catalog"}
+}`
+
+func TestJSONLDParser(t *testing.T) {
+	p := NewJSONLDParser()
+	triples, err := p.Parse(croissantDocument)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	store := NewTripleStore()
+	for _, tr := range triples {
+		store.Add(tr)
+	}
+
+	data, sc, cr := "https://data.example.org/", "https://schema.org/", "http://mlcommons.org/croissant/"
+	for _, want := range []Triple{
+		{Subject: data + "residents", Predicate: RDFType, Object: sc + "Dataset"},
+		{Subject: data + "residents", Predicate: sc + "name", Object: `"Residents"@en`},
+		{Subject: data + "residents", Predicate: sc + "alternateName", Object: `"Einwohner"@de`},
+		{Subject: data + "residents", Predicate: sc + "alternateName", Object: `"Bewohner"`},
+		{Subject: data + "residents", Predicate: sc + "version", Object: `"2"^^<` + XSD + `integer>`},
+		{Subject: data + "residents", Predicate: sc + "isLiveDataset", Object: `"true"^^<` + XSD + `boolean>`},
+		{Subject: data + "residents", Predicate: sc + "size", Object: `"1.5E0"^^<` + XSD + `double>`},
+		{Subject: data + "residents", Predicate: sc + "dateCreated", Object: `"2024-01-01"^^<` + sc + `Date>`},
+		{Subject: data + "residents", Predicate: "http://example.org/note", Object: `"Notiz"@de`},
+		{Subject: data + "residents", Predicate: cr + "recordSet", Object: data + "people"},
+		{Subject: data + "people", Predicate: RDFType, Object: cr + "RecordSet"},
+		{Subject: data + "people", Predicate: cr + "field", Object: data + "people/email"},
+		{Subject: data + "people/email", Predicate: cr + "dataType", Object: sc + "Text"},
+		{Subject: data + "people/email", Predicate: cr + "examples", Object: `"{\"a\":\"x\",\"b\":[1,true]}"^^<` + rdfJSON + `>`},
+		{Subject: data + "people/height", Predicate: cr + "dataType", Object: sc + "Float"},
+		{Subject: data + "catalog", Predicate: sc + "hasPart", Object: data + "residents"},
+	} {
+		if !store.Contains(want) {
+			t.Errorf("Expected %v", want)
+		}
+	}
+
+	keywords := store.FindBySubjectPredicate(data+"residents", sc+"keywords")
+	if len(keywords) != 1 {
+		t.Fatalf("Expected one list, got %v", keywords)
+	}
+	if members, err := store.ListMembers(keywords[0].Object); err != nil || len(members) != 2 || members[0] != `"people"@en` {
+		t.Errorf("ListMembers() = %v, %v", members, err)
+	}
+	if len(store.FindByPredicate(sc+"ignored")) != 0 {
+		t.Errorf("Expected a term mapped to null to be dropped")
+	}
+	if errs := p.Errors(); len(errs) != 0 {
+		t.Errorf("Unexpected errors: %v", errs)
+	}
+	if prefixes := p.Prefixes(); prefixes["cr"] != cr || prefixes[""] != sc || len(prefixes) != 3 {
+		t.Errorf("Unexpected prefixes: %v", prefixes)
+	}
+}
+
+func TestJSONLDParserGraphs(t *testing.T) {
+	p := NewJSONLDParser()
+	triples, err := p.Parse(`{
+  "@context": "https://schema.org",
+  "@graph": [
+    {"id": "http://example.org/bern", "type": "City", "name": "Bern"},
+    {"@id": "http://example.org/graphs/bfs", "@graph": {"@id": "http://example.org/bern", "population": 134000}},
+    {"@context": {"ex": "http://example.org/"}, "@id": "ex:zurich", "ex:near": {"name": "Uster"}},
+    {"@context": "https://example.org/context.jsonld", "@id": "http://example.org/basel", "unknown": "x"}
+  ]
+}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expected := []Triple{
+		{Subject: "http://example.org/bern", Predicate: RDFType, Object: "http://schema.org/City"},
+		{Subject: "http://example.org/bern", Predicate: "http://schema.org/name", Object: `"Bern"`},
+		{Subject: "http://example.org/bern", Predicate: "http://schema.org/population", Object: `"134000"^^<` + XSD + `integer>`, Graph: "http://example.org/graphs/bfs"},
+	}
+	if len(triples) != 6 {
+		t.Fatalf("Expected 6 triples, got %v", triples)
+	}
+	for i, want := range expected {
+		if triples[i] != want {
+			t.Errorf("Triple %d = %v, want %v", i, triples[i], want)
+		}
+	}
+	near := triples[4]
+	if near.Predicate != "http://example.org/near" || !isBlankNode(near.Object) || triples[3].Subject != near.Object {
+		t.Errorf("Expected a blank node for the nested node, got %v", triples[3:])
+	}
+
+	errs := p.Errors()
+	if len(errs) != 1 || errs[0].Line != 7 || !strings.Contains(errs[0].Message, "remote context") {
+		t.Errorf("Expected the remote context on line 7 to be reported, got %v", errs)
+	}
+}
+
+func TestJSONLDParserErrors(t *testing.T) {
+	if _, err := NewJSONLDParser().Parse(`{"@id": "http://example.org/a",`); err == nil {
+		t.Errorf("Expected an error for malformed JSON")
+	}
+	if _, err := NewJSONLDParser().Parse(`{} {}`); err == nil {
+		t.Errorf("Expected an error for content after the document")
+	}
+	_, err := NewJSONLDParserWithOptions(ParserOptions{MaxTriples: 5}).Parse(croissantDocument)
+	if !errors.Is(err, ErrParserLimit) {
+		t.Errorf("Expected a limit error, got %v", err)
+	}
+	_, err = NewJSONLDParserWithOptions(ParserOptions{MaxDepth: 2}).Parse(croissantDocument)
+	if !errors.Is(err, ErrParserLimit) {
+		t.Errorf("Expected a depth limit error, got %v", err)
+	}
+
+	p := NewJSONLDParser()
+	triples, err := p.Parse(`{
+  "@id": "http://example.org/a",
+  "name": "no vocabulary",
+  "@type": {"@id": "http://example.org/T"}
+}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	errs := p.Errors()
+	if len(triples) != 0 || len(errs) != 2 || !strings.Contains(errs[0].Message, `"name" does not expand to an IRI`) {
+		t.Errorf("Expected the property and type to be skipped, got %v and %v", triples, errs)
+	}
+}
+
+func TestLoadJSONLD(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(`
+@prefix cr: <http://mlcommons.org/croissant/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+cr:recordSet rdfs:range cr:RecordSet .
+cr:RecordSet rdfs:subClassOf <https://schema.org/Thing> .
+`); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	if err := r.LoadDocument("metadata.json", croissantDocument, FormatUnknown); err != nil {
+		t.Fatalf("LoadDocument failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	if !r.GetStore().Contains(Triple{Subject: "https://data.example.org/people", Predicate: RDFType, Object: "https://schema.org/Thing"}) {
+		t.Errorf("Expected reasoning over the Croissant metadata")
+	}
+	if r.Prefixes()["cr"] != "http://mlcommons.org/croissant/" {
+		t.Errorf("Expected the namespaces of the context to be kept as prefixes, got %v", r.Prefixes())
+	}
+}
@@ -0,0 +1,100 @@
+package reasoner
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// LabelFallback synthesizes an rdfs:label for every IRI used in the store
+// that has neither an rdfs:label nor a skos:prefLabel, derived from its
+// local name with LabelFromIRI. Labels are tagged with Language if it is
+// set, and plain literals otherwise. IRIs of the rdf, rdfs, owl, xsd and
+// skos vocabularies are left unlabelled. The rule is not one of the default
+// rules; add it to a rule set, or to the rules of a reasoner, to apply it.
+type LabelFallback struct {
+	Language string
+}
+
+func (r *LabelFallback) Name() string {
+	return "rdfs:label-fallback"
+}
+
+func (r *LabelFallback) Apply(store *TripleStore) []Triple {
+	c := &deltaCollector{store: store}
+
+	seen := make(map[string]bool)
+	consider := func(term string) {
+		if seen[term] {
+			return
+		}
+		seen[term] = true
+		if !ParseTerm(term).IsIRI() || isStandardVocabulary(term) ||
+			len(store.FindBySubjectPredicate(term, RDFSLabel)) > 0 ||
+			len(store.FindBySubjectPredicate(term, SKOSPrefLabel)) > 0 {
+			return
+		}
+		if label := LabelFromIRI(term); label != "" {
+			c.add(Triple{Subject: term, Predicate: RDFSLabel, Object: NewLangLiteral(label, r.Language).String()})
+		}
+	}
+	for _, t := range store.All() {
+		consider(t.Subject)
+		consider(t.Predicate)
+		consider(t.Object)
+	}
+
+	return c.inferred
+}
+
+// LabelFromIRI derives a human-readable label from the local name of an
+// IRI: percent-encoded characters are decoded, and words separated by
+// camelCase, underscores or hyphens are separated by spaces. The first word
+// keeps its case and later words are lowercased unless they are acronyms,
+// so "http://example.org/postalCode" and "http://example.org/postal_code"
+// are both labelled "postal code", and "NUTS_Region" becomes "NUTS region".
+// A trailing '/' or '#' is ignored; "" is returned if there is no local name.
+func LabelFromIRI(iri string) string {
+	name := localName(strings.TrimRight(iri, "/#"))
+	if decoded, err := url.PathUnescape(name); err == nil {
+		name = decoded
+	}
+	name = strings.NewReplacer("_", " ", "-", " ").Replace(name)
+
+	words := strings.Fields(splitIdentifier(name))
+	for i, word := range words {
+		if i > 0 && !isAcronym(word) {
+			words[i] = strings.ToLower(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// isAcronym reports whether a word has no lowercase letters and at least
+// two uppercase ones, such as "NUTS" or "BFS2"
+func isAcronym(word string) bool {
+	upper := 0
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+	return upper > 1
+}
+
+// isStandardVocabulary reports whether an IRI belongs to the rdf, rdfs,
+// owl, xsd or skos namespace
+func isStandardVocabulary(iri string) bool {
+	if strings.HasPrefix(iri, "http://www.w3.org/2004/02/skos/core#") {
+		return true
+	}
+	for _, namespace := range standardPrefixes {
+		if strings.HasPrefix(iri, namespace) {
+			return true
+		}
+	}
+	return false
+}
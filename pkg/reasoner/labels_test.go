@@ -0,0 +1,60 @@
+package reasoner
+
+import "testing"
+
+func TestLabelFromIRI(t *testing.T) {
+	for iri, want := range map[string]string{
+		"http://example.org/postalCode":          "postal code",
+		"http://example.org/postal_code":         "postal code",
+		"http://example.org/PostalCode":          "Postal code",
+		"http://example.org/onto#NUTS_Region":    "NUTS region",
+		"http://example.org/hasHTMLPage":         "has HTML page",
+		"http://example.org/municipality-351":    "municipality 351",
+		"http://example.org/cities/Z%C3%BCrich/": "Zürich",
+		"http://example.org/":                    "example.org",
+	} {
+		if got := LabelFromIRI(iri); got != want {
+			t.Errorf("LabelFromIRI(%q) = %q, want %q", iri, got, want)
+		}
+	}
+}
+
+func TestLabelFallback(t *testing.T) {
+	rule, ok := RuleByName("rdfs:label-fallback")
+	if !ok {
+		t.Fatalf("Expected the optional rule to be found by name")
+	}
+	rule.(*LabelFallback).Language = "en"
+
+	r := NewReasoner(WithRules(append(DefaultRules(), rule)))
+	if err := r.LoadTurtle(`@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix skos: <http://www.w3.org/2004/02/skos/core#> .
+ex:inCanton rdfs:range ex:Canton .
+ex:bern ex:inCanton ex:be ; rdfs:label "Bern"@de .
+ex:be skos:prefLabel "Bern"@de .
+_:b1 ex:inCanton ex:zurichCanton .`); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	ex := "http://example.org/"
+	labels := map[string]string{}
+	for _, triple := range r.Query("", RDFSLabel, "") {
+		labels[triple.Subject] = triple.Object
+	}
+	expected := map[string]string{
+		ex + "bern":         `"Bern"@de`,
+		ex + "inCanton":     `"in canton"@en`,
+		ex + "Canton":       `"Canton"@en`,
+		ex + "zurichCanton": `"zurich canton"@en`,
+	}
+	if len(labels) != len(expected) {
+		t.Errorf("Expected %d labels, got %v", len(expected), labels)
+	}
+	for subject, want := range expected {
+		if labels[subject] != want {
+			t.Errorf("Label of %s = %s, want %s", subject, labels[subject], want)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// LinkCandidate is a proposed owl:sameAs link between two resources
+type LinkCandidate struct {
+	Left       string   // Resource from the left store
+	Right      string   // Resource from the right store
+	Confidence float64  // Score between 0 and 1
+	Reasons    []string // Evidence for and against the link, for review
+}
+
+// Triple returns the owl:sameAs triple proposed by the candidate
+func (c LinkCandidate) Triple() Triple {
+	return Triple{Subject: c.Left, Predicate: OWLSameAs, Object: c.Right}
+}
+
+// LinkOptions configures ProposeSameAs
+type LinkOptions struct {
+	// LabelPredicates are compared for similarity (default: rdfs:label, skos:prefLabel)
+	LabelPredicates []string
+	// FunctionalProperties are treated as functional in addition to the
+	// properties declared owl:FunctionalProperty in either store
+	FunctionalProperties []string
+	// InverseFunctionalProperties are treated as inverse functional in
+	// addition to those declared owl:InverseFunctionalProperty in either store
+	InverseFunctionalProperties []string
+	// MinConfidence drops candidates scoring below it (default: 0.8)
+	MinConfidence float64
+}
+
+// ProposeSameAs proposes owl:sameAs links between resources of two stores,
+// ordered by decreasing confidence.
+//
+// The confidence starts from the best normalized Levenshtein similarity of
+// the resources' labels. Every functional property on which both resources
+// have the same value (compared by value) moves it halfway towards 1, every
+// functional property with different values halves it. A shared value of an
+// inverse functional property implies identity and scores 1. Only resources
+// sharing a label word or an inverse functional value are compared.
+func ProposeSameAs(left, right *TripleStore, opts LinkOptions) []LinkCandidate {
+	if len(opts.LabelPredicates) == 0 {
+		opts.LabelPredicates = []string{RDFSLabel, SKOSPrefLabel}
+	}
+	if opts.MinConfidence == 0 {
+		opts.MinConfidence = 0.8
+	}
+
+	functional := declaredProperties(OWLFunctionalProperty, opts.FunctionalProperties, left, right)
+	inverseFunctional := declaredProperties(OWLInverseFunctionalProperty, opts.InverseFunctionalProperties, left, right)
+
+	// Block right resources by label words and inverse functional values
+	blocks := make(map[string]map[string]bool)
+	block := func(key, resource string) {
+		if blocks[key] == nil {
+			blocks[key] = make(map[string]bool)
+		}
+		blocks[key][resource] = true
+	}
+	blockKeys := func(store *TripleStore, resource string) []string {
+		var keys []string
+		for _, label := range labelsOf(store, resource, opts.LabelPredicates) {
+			for _, word := range strings.Fields(label) {
+				keys = append(keys, "word:"+word)
+			}
+		}
+		for _, p := range inverseFunctional {
+			for _, t := range store.FindBySubjectPredicate(resource, p) {
+				keys = append(keys, "ifp:"+p+"|"+literalValueKey(t.Object))
+			}
+		}
+		return keys
+	}
+	for _, resource := range linkableResources(right, opts.LabelPredicates, inverseFunctional) {
+		for _, key := range blockKeys(right, resource) {
+			block(key, resource)
+		}
+	}
+
+	var candidates []LinkCandidate
+	for _, l := range linkableResources(left, opts.LabelPredicates, inverseFunctional) {
+		compared := make(map[string]bool)
+		for _, key := range blockKeys(left, l) {
+			for r := range blocks[key] {
+				if compared[r] || r == l {
+					continue
+				}
+				compared[r] = true
+				if left.Contains(Triple{Subject: l, Predicate: OWLSameAs, Object: r}) ||
+					right.Contains(Triple{Subject: r, Predicate: OWLSameAs, Object: l}) {
+					continue
+				}
+
+				c := scoreLink(left, right, l, r, opts.LabelPredicates, functional, inverseFunctional)
+				if c.Confidence >= opts.MinConfidence {
+					candidates = append(candidates, c)
+				}
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		if a.Left != b.Left {
+			return a.Left < b.Left
+		}
+		return a.Right < b.Right
+	})
+	return candidates
+}
+
+// scoreLink computes the confidence that two resources are the same
+func scoreLink(left, right *TripleStore, l, r string, labelPredicates, functional, inverseFunctional []string) LinkCandidate {
+	c := LinkCandidate{Left: l, Right: r}
+
+	for _, p := range inverseFunctional {
+		for _, lt := range left.FindBySubjectPredicate(l, p) {
+			for _, rt := range right.FindBySubjectPredicate(r, p) {
+				if LiteralsEqual(lt.Object, rt.Object, ValueMatching) {
+					c.Confidence = 1
+					c.Reasons = append(c.Reasons, fmt.Sprintf("same inverse functional %s %s", p, lt.Object))
+					return c
+				}
+			}
+		}
+	}
+
+	bestLeft, bestRight := "", ""
+	for _, a := range labelsOf(left, l, labelPredicates) {
+		for _, b := range labelsOf(right, r, labelPredicates) {
+			if sim := labelSimilarity(a, b); sim > c.Confidence || bestLeft == "" {
+				c.Confidence, bestLeft, bestRight = sim, a, b
+			}
+		}
+	}
+	if bestLeft != "" {
+		c.Reasons = append(c.Reasons, fmt.Sprintf("label similarity %.2f (%q ~ %q)", c.Confidence, bestLeft, bestRight))
+	}
+
+	for _, p := range functional {
+		lv := left.FindBySubjectPredicate(l, p)
+		rv := right.FindBySubjectPredicate(r, p)
+		if len(lv) == 0 || len(rv) == 0 {
+			continue
+		}
+		if LiteralsEqual(lv[0].Object, rv[0].Object, ValueMatching) {
+			c.Confidence += (1 - c.Confidence) / 2
+			c.Reasons = append(c.Reasons, fmt.Sprintf("same functional %s %s", p, lv[0].Object))
+		} else {
+			c.Confidence /= 2
+			c.Reasons = append(c.Reasons, fmt.Sprintf("conflicting functional %s: %s vs %s", p, lv[0].Object, rv[0].Object))
+		}
+	}
+
+	return c
+}
+
+// declaredProperties returns the properties typed with class in either
+// store, together with extra, sorted and without duplicates
+func declaredProperties(class string, extra []string, stores ...*TripleStore) []string {
+	seen := make(map[string]bool)
+	for _, p := range extra {
+		seen[p] = true
+	}
+	for _, store := range stores {
+		for _, t := range store.FindByPredicateObject(RDFType, class) {
+			seen[t.Subject] = true
+		}
+	}
+
+	props := make([]string, 0, len(seen))
+	for p := range seen {
+		props = append(props, p)
+	}
+	sort.Strings(props)
+	return props
+}
+
+// linkableResources returns the subjects that have a label or an inverse
+// functional property value, sorted
+func linkableResources(store *TripleStore, labelPredicates, inverseFunctional []string) []string {
+	seen := make(map[string]bool)
+	for _, p := range append(append([]string{}, labelPredicates...), inverseFunctional...) {
+		for _, t := range store.FindByPredicate(p) {
+			seen[t.Subject] = true
+		}
+	}
+
+	resources := make([]string, 0, len(seen))
+	for r := range seen {
+		resources = append(resources, r)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// labelsOf returns the normalized labels of a resource
+func labelsOf(store *TripleStore, resource string, labelPredicates []string) []string {
+	var labels []string
+	for _, p := range labelPredicates {
+		for _, t := range store.FindBySubjectPredicate(resource, p) {
+			if term := t.ObjectTerm(); term.IsLiteral() {
+				labels = append(labels, normalizeLabel(term.Value))
+			}
+		}
+	}
+	return labels
+}
+
+// normalizeLabel lowercases a label and collapses punctuation and spaces
+func normalizeLabel(label string) string {
+	fields := strings.FieldsFunc(strings.ToLower(label), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// labelSimilarity returns 1 minus the Levenshtein distance of two labels
+// divided by the length of the longer one
+func labelSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the edit distance between two rune slices
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,93 @@
+package reasoner
+
+import "testing"
+
+func loadStore(t *testing.T, content string) *TripleStore {
+	t.Helper()
+	r := NewReasoner()
+	if err := r.LoadTurtle(content); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	return r.GetStore()
+}
+
+func TestProposeSameAs(t *testing.T) {
+	left := loadStore(t, `
+@prefix p: <http://a.example/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+p:born a owl:FunctionalProperty .
+p:email a owl:InverseFunctionalProperty .
+p:ada rdfs:label "Ada Lovelace" ; p:born "1815"^^xsd:integer .
+p:alan rdfs:label "Alan Turing" ; p:born "1912"^^xsd:integer .
+p:grace rdfs:label "G. Hopper" ; p:email "grace@navy.mil" .
+p:charles rdfs:label "Charles Babbage" .
+`)
+	right := loadStore(t, `
+@prefix q: <http://b.example/> .
+@prefix p: <http://a.example/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix skos: <http://www.w3.org/2004/02/skos/core#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+q:lovelace skos:prefLabel "ada  lovelace." ; p:born "01815"^^xsd:integer .
+q:turing rdfs:label "Alan Turin" ; p:born "1913"^^xsd:integer .
+q:hopper rdfs:label "Rear Admiral Hopper" ; p:email "grace@navy.mil" .
+q:babbage rdfs:label "Charles Dickens" .
+`)
+
+	candidates := ProposeSameAs(left, right, LinkOptions{})
+
+	got := make(map[string]float64)
+	for _, c := range candidates {
+		got[c.Left+" "+c.Right] = c.Confidence
+		if len(c.Reasons) == 0 {
+			t.Errorf("Candidate %s %s has no reasons", c.Left, c.Right)
+		}
+	}
+
+	if c := got["http://a.example/ada http://b.example/lovelace"]; c != 1 {
+		t.Errorf("Expected confidence 1 for matching label and birth year, got %v", c)
+	}
+	if c := got["http://a.example/grace http://b.example/hopper"]; c != 1 {
+		t.Errorf("Expected confidence 1 for shared inverse functional value, got %v", c)
+	}
+	if _, ok := got["http://a.example/alan http://b.example/turing"]; ok {
+		t.Errorf("Conflicting functional value should drop the candidate below the threshold")
+	}
+	if _, ok := got["http://a.example/charles http://b.example/babbage"]; ok {
+		t.Errorf("Dissimilar labels should not be proposed")
+	}
+	if len(candidates) != 2 {
+		t.Errorf("Expected 2 candidates, got %+v", candidates)
+	}
+	if len(candidates) > 0 && candidates[0].Triple().Predicate != OWLSameAs {
+		t.Errorf("Candidate triple should use owl:sameAs, got %s", candidates[0].Triple())
+	}
+
+	// Lowering the threshold keeps the conflicting pair with a reduced score
+	candidates = ProposeSameAs(left, right, LinkOptions{MinConfidence: 0.01})
+	for _, c := range candidates {
+		if c.Left == "http://a.example/alan" && c.Confidence >= 0.5 {
+			t.Errorf("Conflicting functional value should halve the confidence, got %v", c.Confidence)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
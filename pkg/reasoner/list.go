@@ -0,0 +1,37 @@
+package reasoner
+
+import "fmt"
+
+// RDF collection vocabulary
+const (
+	RDFFirst = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	RDFRest  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	RDFNil   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+)
+
+// ListMembers returns the members of the rdf:List starting at head, in
+// order. It fails if a node lacks rdf:first or rdf:rest, has several of
+// them, or if the list is cyclic.
+func (ts *TripleStore) ListMembers(head string) ([]string, error) {
+	var members []string
+	visited := make(map[string]bool)
+
+	for node := head; node != RDFNil; {
+		if visited[node] {
+			return nil, fmt.Errorf("list %s is cyclic at %s", head, node)
+		}
+		visited[node] = true
+
+		first := ts.FindBySubjectPredicate(node, RDFFirst)
+		rest := ts.FindBySubjectPredicate(node, RDFRest)
+		if len(first) != 1 || len(rest) != 1 {
+			return nil, fmt.Errorf("list %s is malformed at %s: %d rdf:first and %d rdf:rest values",
+				head, node, len(first), len(rest))
+		}
+
+		members = append(members, first[0].Object)
+		node = rest[0].Object
+	}
+
+	return members, nil
+}
@@ -0,0 +1,162 @@
+package reasoner
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XSD is the XML Schema datatypes namespace
+const XSD = "http://www.w3.org/2001/XMLSchema#"
+
+// XML Schema datatypes with value-based comparison
+const (
+	XSDString   = XSD + "string" // Datatype of plain literals in RDF 1.1
+	XSDBoolean  = XSD + "boolean"
+	XSDDecimal  = XSD + "decimal"
+	XSDInteger  = XSD + "integer"
+	XSDDouble   = XSD + "double"
+	XSDFloat    = XSD + "float"
+	XSDDateTime = XSD + "dateTime"
+)
+
+// xsdNumericTypes lists the numeric datatypes compared by value
+var xsdNumericTypes = map[string]bool{ //nolint:gochecknoglobals
+	XSDDecimal: true, XSDInteger: true, XSDDouble: true, XSDFloat: true,
+	XSD + "long": true, XSD + "int": true, XSD + "short": true, XSD + "byte": true,
+	XSD + "nonNegativeInteger": true, XSD + "positiveInteger": true,
+	XSD + "nonPositiveInteger": true, XSD + "negativeInteger": true,
+	XSD + "unsignedLong": true, XSD + "unsignedInt": true,
+	XSD + "unsignedShort": true, XSD + "unsignedByte": true,
+}
+
+// LiteralMatching selects how literals are compared when matching patterns
+type LiteralMatching int
+
+const (
+	// LexicalMatching compares literals by their exact lexical form,
+	// datatype and language tag
+	LexicalMatching LiteralMatching = iota
+	// ValueMatching compares literals by value after parsing their
+	// datatype: "01"^^xsd:integer equals "1.0"^^xsd:decimal, "true" equals
+	// "1" for xsd:boolean, date-times are compared in UTC, plain literals
+	// equal xsd:string literals and language tags are case-insensitive.
+	// Datatypes registered with RegisterDatatype use their compare function.
+	ValueMatching
+)
+
+// String returns the name of the matching mode
+func (m LiteralMatching) String() string {
+	if m == ValueMatching {
+		return "value"
+	}
+	return "lexical"
+}
+
+// ParseLiteralMatching parses "lexical" or "value"
+func ParseLiteralMatching(s string) (LiteralMatching, error) {
+	switch s {
+	case "lexical":
+		return LexicalMatching, nil
+	case "value":
+		return ValueMatching, nil
+	default:
+		return LexicalMatching, fmt.Errorf("invalid literal matching %q, must be 'lexical' or 'value'", s)
+	}
+}
+
+// WithLiteralMatching sets how Query compares literal objects
+func WithLiteralMatching(mode LiteralMatching) Option {
+	return func(r *Reasoner) {
+		r.literalMatching = mode
+	}
+}
+
+// LiteralsEqual reports whether two terms are equal under the given mode.
+// Terms that are not literals are always compared exactly.
+func LiteralsEqual(a, b string, mode LiteralMatching) bool {
+	if a == b {
+		return true
+	}
+	if mode != ValueMatching {
+		return false
+	}
+	if equal, ok := customEqual(a, b); ok {
+		return equal
+	}
+	return literalValueKey(a) == literalValueKey(b)
+}
+
+// CanonicalLiteral rewrites a literal with a value-comparable datatype into
+// its canonical lexical form, e.g. "007"^^xsd:integer into "7"^^xsd:integer.
+// Other terms are returned unchanged.
+func CanonicalLiteral(term string) string {
+	t := ParseTerm(term)
+	if !t.IsLiteral() {
+		return term
+	}
+	if t.Datatype == XSDString {
+		t.Datatype = ""
+	}
+	if _, lexical, ok := canonicalValue(t); ok {
+		t.Value = lexical
+	}
+	if t.Language != "" {
+		t.Language = strings.ToLower(t.Language)
+	}
+	return t.String()
+}
+
+// literalValueKey returns a key that is equal for literals with the same
+// value. Keys of literals start with a NUL byte so they cannot collide with
+// the encoding of other terms.
+func literalValueKey(term string) string {
+	t := ParseTerm(term)
+	if !t.IsLiteral() {
+		return term
+	}
+	if family, lexical, ok := canonicalValue(t); ok {
+		return "\x00" + family + ":" + lexical
+	}
+	if t.Language != "" {
+		return "\x00lang:" + strings.ToLower(t.Language) + ":" + t.Value
+	}
+	if t.Datatype == "" || t.Datatype == XSDString {
+		return "\x00string:" + t.Value
+	}
+	return term
+}
+
+// canonicalValue parses the lexical form of a numeric, boolean or dateTime
+// literal and returns its value family and canonical lexical form
+func canonicalValue(t Term) (family, lexical string, ok bool) {
+	value := strings.TrimSpace(t.Value)
+
+	switch {
+	case xsdNumericTypes[t.Datatype]:
+		rat, ok := new(big.Rat).SetString(value)
+		if !ok {
+			return "", "", false
+		}
+		if rat.IsInt() {
+			return "numeric", rat.Num().String(), true
+		}
+		f, _ := rat.Float64()
+		return "numeric", strconv.FormatFloat(f, 'f', -1, 64), true
+	case t.Datatype == XSDBoolean:
+		switch value {
+		case "true", "1":
+			return "boolean", "true", true
+		case "false", "0":
+			return "boolean", "false", true
+		}
+	case t.Datatype == XSDDateTime:
+		if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return "dateTime", parsed.UTC().Format(time.RFC3339Nano), true
+		}
+	}
+
+	return "", "", false
+}
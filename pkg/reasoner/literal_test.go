@@ -0,0 +1,76 @@
+package reasoner
+
+import (
+	"testing"
+)
+
+func TestLiteralsEqual(t *testing.T) {
+	typed := func(lexical, datatype string) string {
+		return NewLiteral(lexical, datatype).String()
+	}
+
+	tests := []struct {
+		a, b    string
+		lexical bool
+		value   bool
+	}{
+		{`"Alice"`, `"Alice"`, true, true},
+		{`"Alice"`, typed("Alice", XSDString), false, true},
+		{`"Alice"@en`, `"Alice"@EN`, false, true},
+		{`"Alice"@en`, `"Alice"`, false, false},
+		{typed("01", XSDInteger), typed("1", XSDInteger), false, true},
+		{typed("1", XSDInteger), typed("1.0", XSDDecimal), false, true},
+		{typed("1.5", XSDDouble), typed("1.50", XSDDecimal), false, true},
+		{typed("1", XSDInteger), typed("2", XSDInteger), false, false},
+		{typed("true", XSDBoolean), typed("1", XSDBoolean), false, true},
+		{typed("2024-01-01T12:00:00+02:00", XSDDateTime), typed("2024-01-01T10:00:00Z", XSDDateTime), false, true},
+		{typed("1", XSDInteger), `"1"`, false, false},
+		{"http://example.org/a", "http://example.org/a", true, true},
+	}
+
+	for _, tt := range tests {
+		if got := LiteralsEqual(tt.a, tt.b, LexicalMatching); got != tt.lexical {
+			t.Errorf("LiteralsEqual(%s, %s, lexical) = %v, expected %v", tt.a, tt.b, got, tt.lexical)
+		}
+		if got := LiteralsEqual(tt.a, tt.b, ValueMatching); got != tt.value {
+			t.Errorf("LiteralsEqual(%s, %s, value) = %v, expected %v", tt.a, tt.b, got, tt.value)
+		}
+	}
+}
+
+func TestQueryLiteralMatching(t *testing.T) {
+	content := `
+@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:alice ex:age "042"^^xsd:integer .
+ex:bob ex:age "42"^^xsd:int .
+ex:carol ex:age "43"^^xsd:integer .
+`
+	pattern := NewLiteral("42", XSDInteger).String()
+
+	for _, tt := range []struct {
+		mode     LiteralMatching
+		expected int
+	}{
+		{LexicalMatching, 0},
+		{ValueMatching, 2},
+	} {
+		r := NewReasoner(WithLiteralMatching(tt.mode))
+		if err := r.LoadTurtle(content); err != nil {
+			t.Fatalf("LoadTurtle failed: %v", err)
+		}
+		if got := len(r.Query("", "http://example.org/age", pattern)); got != tt.expected {
+			t.Errorf("%s matching: expected %d results, got %d", tt.mode, tt.expected, got)
+		}
+		if got := len(r.Query("", "", pattern)); got != tt.expected {
+			t.Errorf("%s matching by object only: expected %d results, got %d", tt.mode, tt.expected, got)
+		}
+	}
+
+	facts := ConvertTriplesToDatalogWithOptions([]string{
+		`<http://example.org/alice> <http://example.org/age> "042"^^<` + XSDInteger + `> .`,
+	}, DatalogOptions{LiteralMatching: ValueMatching})
+	if len(facts) != 1 || facts[0] != "age(alice, _42)." {
+		t.Errorf("Unexpected Datalog facts: %v", facts)
+	}
+}
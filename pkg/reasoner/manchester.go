@@ -0,0 +1,392 @@
+package reasoner
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ClassExpressionKind is the kind of a ClassExpression
+type ClassExpressionKind int
+
+// Kinds of class expressions
+const (
+	ClassName            ClassExpressionKind = iota // a named class
+	ObjectIntersectionOf                            // A and B
+	ObjectUnionOf                                   // A or B
+	ObjectSomeValuesFrom                            // p some C
+	ObjectHasValue                                  // p value v
+	ObjectOneOf                                     // {a, b}
+)
+
+// ClassExpression is a class expression parsed by ParseClassExpression
+type ClassExpression struct {
+	Kind ClassExpressionKind
+	// IRI is the class of a ClassName or the property of a restriction
+	IRI string
+	// Value is the term of an ObjectHasValue
+	Value string
+	// Operands are the operands of an intersection or union, or the single
+	// filler of an ObjectSomeValuesFrom
+	Operands []ClassExpression
+	// Individuals are the members of an ObjectOneOf
+	Individuals []string
+}
+
+// unsupportedManchesterKeywords are the Manchester syntax keywords whose
+// instances cannot be retrieved from the closure, since they depend on
+// what is not stated
+//
+//nolint:gochecknoglobals
+var unsupportedManchesterKeywords = map[string]bool{
+	"not": true, "only": true, "min": true, "max": true, "exactly": true, "inverse": true, "self": true,
+}
+
+// ParseClassExpression parses a class expression in Manchester syntax, such
+// as "Person and worksFor some GovernmentAgency". Expressions are built from
+// class names, "and" (or "that"), "or", "p some C", "p value v", "{a, b}"
+// and parentheses. Names are prefixed names, full IRIs in angle brackets or,
+// if the empty prefix is declared, bare names; the standard rdf, rdfs, owl
+// and xsd prefixes are known. Values are names or literals such as "Bern",
+// "3"^^xsd:integer or 3. Negation, universal and cardinality restrictions
+// are not supported, since their instances cannot be told from the data
+// under the open-world assumption.
+func ParseClassExpression(expr string, prefixes map[string]string) (ClassExpression, error) {
+	tokens, err := tokenizeManchester(expr)
+	if err != nil {
+		return ClassExpression{}, fmt.Errorf("invalid class expression %q: %w", expr, err)
+	}
+	p := &manchesterParser{tokens: tokens, prefixes: withStandardPrefixes(prefixes)}
+	ce, err := p.parseUnion()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	if err != nil {
+		return ClassExpression{}, fmt.Errorf("invalid class expression %q: %w", expr, err)
+	}
+	return ce, nil
+}
+
+// InstancesOfExpression returns the sorted individuals that are instances
+// of a class expression in Manchester syntax, as parsed by
+// ParseClassExpression with the prefixes of the loaded documents. Instances
+// are retrieved from the store as it is, so inferred memberships are only
+// found after RunForwardReasoning.
+func (r *Reasoner) InstancesOfExpression(expr string) ([]string, error) {
+	ce, err := ParseClassExpression(expr, r.Prefixes())
+	if err != nil {
+		return nil, err
+	}
+	return r.store.InstancesOf(ce), nil
+}
+
+// InstancesOf returns the sorted individuals of the store that are
+// instances of a class expression
+func (ts *TripleStore) InstancesOf(ce ClassExpression) []string {
+	members := ce.instances(ts)
+	result := make([]string, 0, len(members))
+	for m := range members {
+		result = append(result, m)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// instances returns the set of instances of the expression
+func (ce ClassExpression) instances(ts *TripleStore) map[string]bool {
+	members := make(map[string]bool)
+	switch ce.Kind {
+	case ClassName:
+		if ce.IRI == OWLThing {
+			return individuals(ts)
+		}
+		for _, t := range ts.FindByPredicateObject(RDFType, ce.IRI) {
+			members[t.Subject] = true
+		}
+	case ObjectIntersectionOf:
+		for i, operand := range ce.Operands {
+			set := operand.instances(ts)
+			if i == 0 {
+				members = set
+				continue
+			}
+			for m := range members {
+				if !set[m] {
+					delete(members, m)
+				}
+			}
+		}
+	case ObjectUnionOf:
+		for _, operand := range ce.Operands {
+			for m := range operand.instances(ts) {
+				members[m] = true
+			}
+		}
+	case ObjectSomeValuesFrom:
+		filler := ce.Operands[0]
+		anything := filler.Kind == ClassName && filler.IRI == OWLThing
+		var fillers map[string]bool
+		if !anything {
+			fillers = filler.instances(ts)
+		}
+		for _, t := range ts.FindByPredicate(ce.IRI) {
+			if anything || fillers[t.Object] {
+				members[t.Subject] = true
+			}
+		}
+	case ObjectHasValue:
+		for _, t := range ts.FindByPredicateObject(ce.IRI, ce.Value) {
+			members[t.Subject] = true
+		}
+	case ObjectOneOf:
+		for _, individual := range ce.Individuals {
+			members[individual] = true
+		}
+	}
+	return members
+}
+
+// individuals returns the subjects and the IRI or blank node objects of
+// the triples that do not belong to the TBox, other than the classes of
+// rdf:type assertions
+func individuals(ts *TripleStore) map[string]bool {
+	members := make(map[string]bool)
+	for _, t := range ts.All() {
+		if isSchemaTriple(t) {
+			continue
+		}
+		members[t.Subject] = true
+		if t.Predicate != RDFType && !ParseTerm(t.Object).IsLiteral() {
+			members[t.Object] = true
+		}
+	}
+	return members
+}
+
+// manchesterParser is a recursive descent parser over Manchester tokens
+type manchesterParser struct {
+	tokens   []string
+	pos      int
+	prefixes map[string]string
+}
+
+// peek returns the next token in lowercase, or "" at the end
+func (p *manchesterParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return strings.ToLower(p.tokens[p.pos])
+}
+
+// next consumes and returns the next token, or "" at the end
+func (p *manchesterParser) next() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	p.pos++
+	return p.tokens[p.pos-1]
+}
+
+// parseUnion parses operands separated by "or"
+func (p *manchesterParser) parseUnion() (ClassExpression, error) {
+	return p.parseNary(ObjectUnionOf, p.parseIntersection, "or")
+}
+
+// parseIntersection parses operands separated by "and" or "that"
+func (p *manchesterParser) parseIntersection() (ClassExpression, error) {
+	return p.parseNary(ObjectIntersectionOf, p.parseRestriction, "and", "that")
+}
+
+// parseNary parses one or more operands separated by the given keywords
+func (p *manchesterParser) parseNary(kind ClassExpressionKind, operand func() (ClassExpression, error), keywords ...string) (ClassExpression, error) {
+	first, err := operand()
+	if err != nil {
+		return ClassExpression{}, err
+	}
+	operands := []ClassExpression{first}
+	for slices.Contains(keywords, p.peek()) {
+		p.next()
+		o, err := operand()
+		if err != nil {
+			return ClassExpression{}, err
+		}
+		operands = append(operands, o)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return ClassExpression{Kind: kind, Operands: operands}, nil
+}
+
+// parseRestriction parses "p some C", "p value v" or a primary expression
+func (p *manchesterParser) parseRestriction() (ClassExpression, error) {
+	switch token := p.peek(); {
+	case token == "":
+		return ClassExpression{}, fmt.Errorf("unexpected end of expression")
+	case token == "(":
+		p.next()
+		ce, err := p.parseUnion()
+		if err != nil {
+			return ClassExpression{}, err
+		}
+		if p.next() != ")" {
+			return ClassExpression{}, fmt.Errorf("missing ')'")
+		}
+		return ce, nil
+	case token == "{":
+		return p.parseOneOf()
+	case unsupportedManchesterKeywords[token]:
+		return ClassExpression{}, fmt.Errorf("%q is not supported", token)
+	case isManchesterPunctuation(token) || isManchesterKeyword(token):
+		return ClassExpression{}, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+
+	iri, err := p.name(p.next())
+	if err != nil {
+		return ClassExpression{}, err
+	}
+	switch keyword := p.peek(); {
+	case keyword == "some":
+		p.next()
+		filler, err := p.parseRestriction()
+		if err != nil {
+			return ClassExpression{}, err
+		}
+		return ClassExpression{Kind: ObjectSomeValuesFrom, IRI: iri, Operands: []ClassExpression{filler}}, nil
+	case keyword == "value":
+		p.next()
+		value, err := p.value(p.next())
+		if err != nil {
+			return ClassExpression{}, err
+		}
+		return ClassExpression{Kind: ObjectHasValue, IRI: iri, Value: value}, nil
+	case unsupportedManchesterKeywords[keyword]:
+		return ClassExpression{}, fmt.Errorf("%q is not supported", keyword)
+	}
+	return ClassExpression{Kind: ClassName, IRI: iri}, nil
+}
+
+// parseOneOf parses an enumeration of individuals such as "{a, b}"
+func (p *manchesterParser) parseOneOf() (ClassExpression, error) {
+	p.next()
+	ce := ClassExpression{Kind: ObjectOneOf}
+	for {
+		token := p.next()
+		if token == "}" && len(ce.Individuals) == 0 {
+			return ce, nil
+		}
+		individual, err := p.name(token)
+		if err != nil {
+			return ClassExpression{}, err
+		}
+		ce.Individuals = append(ce.Individuals, individual)
+		switch p.next() {
+		case ",":
+		case "}":
+			return ce, nil
+		default:
+			return ClassExpression{}, fmt.Errorf("missing '}'")
+		}
+	}
+}
+
+// name resolves a class, property or individual name to an IRI
+func (p *manchesterParser) name(token string) (string, error) {
+	switch {
+	case token == "":
+		return "", fmt.Errorf("unexpected end of expression")
+	case isManchesterPunctuation(token) || strings.HasPrefix(token, `"`):
+		return "", fmt.Errorf("expected a name, found %q", token)
+	case strings.HasPrefix(token, "<"):
+		return ExpandPrefixedName(token, p.prefixes), nil
+	case !strings.Contains(token, ":"):
+		ns, ok := p.prefixes[""]
+		if !ok {
+			return "", fmt.Errorf("%q has no prefix and the empty prefix is not declared", token)
+		}
+		return ns + token, nil
+	}
+	iri := ExpandPrefixedName(token, p.prefixes)
+	if iri == token {
+		return "", fmt.Errorf("undefined prefix in %q", token)
+	}
+	return iri, nil
+}
+
+// value resolves the value of a hasValue restriction to a term
+func (p *manchesterParser) value(token string) (string, error) {
+	switch {
+	case strings.HasPrefix(token, `"`):
+		return conditionTerm(token, p.prefixes), nil
+	case token != "" && strings.Trim(token, "0123456789") == "":
+		return NewLiteral(token, XSDInteger).String(), nil
+	case token != "" && strings.Count(token, ".") == 1 && strings.Trim(token, "0123456789.") == "":
+		return NewLiteral(token, XSDDecimal).String(), nil
+	}
+	return p.name(token)
+}
+
+// isManchesterPunctuation reports whether a token is a bracket or comma
+func isManchesterPunctuation(token string) bool {
+	return token == "(" || token == ")" || token == "{" || token == "}" || token == ","
+}
+
+// isManchesterKeyword reports whether a token is a supported keyword
+func isManchesterKeyword(token string) bool {
+	return token == "and" || token == "that" || token == "or" || token == "some" || token == "value"
+}
+
+// tokenizeManchester splits an expression into names, keywords, literals
+// and punctuation
+func tokenizeManchester(expr string) ([]string, error) {
+	var tokens []string
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isManchesterPunctuation(string(r)):
+			tokens = append(tokens, string(r))
+			i++
+		case r == '<':
+			end := i + 1
+			for end < len(runes) && runes[end] != '>' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated IRI")
+			}
+			tokens = append(tokens, string(runes[i:end+1]))
+			i = end + 1
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated literal")
+			}
+			// Include a language tag or datatype
+			end++
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !isManchesterPunctuation(string(runes[end])) {
+				end++
+			}
+			tokens = append(tokens, string(runes[i:end]))
+			i = end
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !isManchesterPunctuation(string(runes[end])) {
+				end++
+			}
+			tokens = append(tokens, string(runes[i:end]))
+			i = end
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,83 @@
+package reasoner
+
+import (
+	"reflect"
+	"testing"
+)
+
+const manchesterData = `@prefix : <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+:Ministry rdfs:subClassOf :GovernmentAgency .
+:Employee rdfs:subClassOf :Person .
+:alice a :Employee ; :worksFor :finance ; :canton "Bern" .
+:bob a :Person ; :worksFor :acme ; :age "42"^^xsd:integer .
+:carol a :Person .
+:finance a :Ministry .
+:acme a :Company .
+`
+
+func TestInstancesOfExpression(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(manchesterData); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	ex := "http://example.org/"
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{"Person", []string{ex + "alice", ex + "bob", ex + "carol"}},
+		{"Person and worksFor some GovernmentAgency", []string{ex + "alice"}},
+		{"Person THAT worksFor some (Company or Ministry)", []string{ex + "alice", ex + "bob"}},
+		{"worksFor some owl:Thing", []string{ex + "alice", ex + "bob"}},
+		{"<http://example.org/Company> or Ministry", []string{ex + "acme", ex + "finance"}},
+		{`canton value "Bern"`, []string{ex + "alice"}},
+		{"age value 42", []string{ex + "bob"}},
+		{`age value "42"^^xsd:integer`, []string{ex + "bob"}},
+		{"worksFor value acme", []string{ex + "bob"}},
+		{"{alice, carol} and Employee", []string{ex + "alice"}},
+		{"worksFor some (worksFor some owl:Thing)", []string{}},
+	}
+	for _, tt := range tests {
+		got, err := r.InstancesOfExpression(tt.expr)
+		if err != nil {
+			t.Errorf("InstancesOfExpression(%q) failed: %v", tt.expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("InstancesOfExpression(%q) = %v, want %v", tt.expr, got, tt.expected)
+		}
+	}
+
+	things, err := r.InstancesOfExpression("owl:Thing")
+	if err != nil {
+		t.Fatalf("InstancesOfExpression failed: %v", err)
+	}
+	if len(things) != 5 {
+		t.Errorf("Expected 5 individuals, got %v", things)
+	}
+}
+
+func TestParseClassExpressionErrors(t *testing.T) {
+	prefixes := map[string]string{"ex": "http://example.org/"}
+	for _, expr := range []string{
+		"",
+		"ex:Person and",
+		"ex:Person and not ex:Robot",
+		"ex:worksFor only ex:Agency",
+		"ex:hasChild min 2 ex:Person",
+		"(ex:Person",
+		"{ex:alice, ex:bob",
+		"Person",
+		"foo:Person",
+		`ex:name value "unterminated`,
+		"ex:Person ex:Agency",
+	} {
+		if _, err := ParseClassExpression(expr, prefixes); err == nil {
+			t.Errorf("Expected an error for %q", expr)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package reasoner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// rdfsLiteral is the class of all literals
+const rdfsLiteral = "http://www.w3.org/2000/01/rdf-schema#Literal"
+
+// ErrNoFields is returned when Croissant metadata describes no fields
+var ErrNoFields = errors.New("metadata has no record set fields")
+
+// Column describes a column of a tabular dataset to be mapped to RDF
+type Column struct {
+	Name string
+	// DataType is the column's type as an IRI or prefixed name, e.g. the
+	// Croissant dataType "sc:Integer" or "xsd:date"; it may be empty
+	DataType string
+}
+
+// MappingCandidate is a proposed property for the values of a column
+type MappingCandidate struct {
+	Column     string   `json:"column"`     // Name of the column
+	Property   string   `json:"property"`   // IRI of the proposed property
+	Confidence float64  `json:"confidence"` // Score between 0 and 1
+	Reasons    []string `json:"reasons"`    // Evidence for and against the mapping, for review
+}
+
+// MappingOptions configures SuggestColumnMappings
+type MappingOptions struct {
+	// MinConfidence drops candidates scoring below it (default: 0.6)
+	MinConfidence float64
+	// MaxCandidates limits the candidates per column (default: 3)
+	MaxCandidates int
+}
+
+// SuggestColumnMappings proposes properties of a TBox for the columns of a
+// dataset, ordered by column and then by decreasing confidence.
+//
+// Candidates are the properties declared rdf:Property, owl:DatatypeProperty
+// or owl:ObjectProperty, or given a domain or range. The confidence starts
+// from the best normalized Levenshtein similarity of the column name and the
+// property's local name or labels, with camelCase, snake_case and kebab-case
+// names split into words. A column type compatible with the property's range
+// closes half the distance to 1, scaled by that similarity so that types
+// refine name matches rather than create them; an incompatible type halves
+// it.
+func SuggestColumnMappings(tbox *TripleStore, columns []Column, opts MappingOptions) []MappingCandidate {
+	if opts.MinConfidence == 0 {
+		opts.MinConfidence = 0.6
+	}
+	if opts.MaxCandidates == 0 {
+		opts.MaxCandidates = 3
+	}
+	properties := mappableProperties(tbox)
+
+	var result []MappingCandidate
+	for _, column := range columns {
+		name := normalizeLabel(splitIdentifier(column.Name))
+		columnKind := valueKind(column.DataType)
+
+		var candidates []MappingCandidate
+		for _, property := range properties {
+			c := scoreMapping(tbox, column, name, columnKind, property)
+			if c.Confidence >= opts.MinConfidence {
+				candidates = append(candidates, c)
+			}
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].Confidence != candidates[j].Confidence {
+				return candidates[i].Confidence > candidates[j].Confidence
+			}
+			return candidates[i].Property < candidates[j].Property
+		})
+		if len(candidates) > opts.MaxCandidates {
+			candidates = candidates[:opts.MaxCandidates]
+		}
+		result = append(result, candidates...)
+	}
+	return result
+}
+
+// scoreMapping computes the confidence that a column holds values of a
+// property
+func scoreMapping(tbox *TripleStore, column Column, name, columnKind, property string) MappingCandidate {
+	c := MappingCandidate{Column: column.Name, Property: property}
+
+	names := append([]string{normalizeLabel(splitIdentifier(localName(property)))},
+		labelsOf(tbox, property, []string{RDFSLabel, SKOSPrefLabel})...)
+	best := ""
+	for _, n := range names {
+		if sim := labelSimilarity(name, n); sim > c.Confidence {
+			c.Confidence, best = sim, n
+		}
+	}
+	if c.Confidence == 0 {
+		return c
+	}
+	c.Reasons = append(c.Reasons, fmt.Sprintf("name similarity %.2f with %q", c.Confidence, best))
+
+	propertyKind := rangeKind(tbox, property)
+	switch {
+	case columnKind == "" || propertyKind == "":
+	case columnKind == propertyKind:
+		c.Confidence += (1 - c.Confidence) * c.Confidence / 2
+		c.Reasons = append(c.Reasons, fmt.Sprintf("%s column matches the range", columnKind))
+	default:
+		c.Confidence /= 2
+		c.Reasons = append(c.Reasons, fmt.Sprintf("%s column conflicts with a %s range", columnKind, propertyKind))
+	}
+	return c
+}
+
+// mappableProperties returns the properties declared in the TBox or given a
+// domain or range, sorted
+func mappableProperties(tbox *TripleStore) []string {
+	seen := make(map[string]bool)
+	for _, p := range []string{RDFSDomain, RDFSRange} {
+		for _, t := range tbox.FindByPredicate(p) {
+			seen[t.Subject] = true
+		}
+	}
+	for _, class := range []string{RDFProperty, OWLDatatypeProperty, OWLObjectProperty} {
+		for _, t := range tbox.FindByPredicateObject(RDFType, class) {
+			seen[t.Subject] = true
+		}
+	}
+
+	props := make([]string, 0, len(seen))
+	for p := range seen {
+		if ParseTerm(p).IsIRI() {
+			props = append(props, p)
+		}
+	}
+	sort.Strings(props)
+	return props
+}
+
+// rangeKind returns the kind of values of a property, from its range or its
+// declaration as an object property, or "" if unknown
+func rangeKind(tbox *TripleStore, property string) string {
+	for _, t := range tbox.FindBySubjectPredicate(property, RDFSRange) {
+		if kind := valueKind(t.Object); kind != "" {
+			return kind
+		}
+		if t.Object != rdfsLiteral && !strings.HasPrefix(t.Object, XSD) {
+			return "iri"
+		}
+	}
+	if tbox.Contains(Triple{Subject: property, Predicate: RDFType, Object: OWLObjectProperty}) {
+		return "iri"
+	}
+	return ""
+}
+
+// valueKind classifies a datatype IRI or prefixed name, such as xsd:int or
+// Croissant's sc:Integer, as "number", "text", "date", "boolean" or "iri",
+// or "" if unknown
+func valueKind(datatype string) string {
+	local := localName(datatype)
+	if i := strings.LastIndex(local, ":"); i >= 0 {
+		local = local[i+1:]
+	}
+	switch strings.ToLower(local) {
+	case "integer", "int", "long", "short", "byte", "decimal", "float", "double", "number",
+		"nonnegativeinteger", "positiveinteger", "nonpositiveinteger", "negativeinteger",
+		"unsignedint", "unsignedlong", "unsignedshort", "unsignedbyte",
+		"int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return "number"
+	case "string", "text", "normalizedstring", "token", "langstring", "language":
+		return "text"
+	case "date", "datetime", "datetimestamp", "time", "gyear", "gyearmonth":
+		return "date"
+	case "boolean", "bool":
+		return "boolean"
+	case "anyuri", "url":
+		return "iri"
+	default:
+		return ""
+	}
+}
+
+// localName returns the part of an IRI after its last '#' or '/'
+func localName(iri string) string {
+	return iri[strings.LastIndexAny(iri, "#/")+1:]
+}
+
+// splitIdentifier separates the words of a camelCase identifier with spaces
+func splitIdentifier(s string) string {
+	var sb strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) ||
+			i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+			sb.WriteRune(' ')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// croissantMetadata is the part of a Croissant JSON-LD document describing
+// the fields of its record sets
+type croissantMetadata struct {
+	RecordSet croissantList[croissantRecordSet] `json:"recordSet"`
+}
+
+type croissantRecordSet struct {
+	Field croissantList[croissantField] `json:"field"`
+}
+
+type croissantField struct {
+	Name     string                `json:"name"`
+	DataType croissantList[string] `json:"dataType"`
+}
+
+// croissantList decodes a JSON-LD value that is either a single value or an
+// array of values
+type croissantList[T any] []T
+
+func (l *croissantList[T]) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, (*[]T)(l))
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*l = croissantList[T]{v}
+	return nil
+}
+
+// ParseCroissantFields returns the fields of the record sets of Croissant
+// JSON-LD metadata as columns, with the first dataType of each field
+func ParseCroissantFields(data []byte) ([]Column, error) {
+	var metadata croissantMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("invalid Croissant metadata: %w", err)
+	}
+	var columns []Column
+	for _, recordSet := range metadata.RecordSet {
+		for _, field := range recordSet.Field {
+			column := Column{Name: field.Name}
+			if len(field.DataType) > 0 {
+				column.DataType = field.DataType[0]
+			}
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, ErrNoFields
+	}
+	return columns, nil
+}
+
+// Format returns the candidate as a line, with the property compacted using
+// prefixes and the reasons for its confidence
+func (c MappingCandidate) Format(prefixes map[string]string) string {
+	w := newTurtleWriter(withStandardPrefixes(prefixes))
+	return fmt.Sprintf("%s -> %s (%.2f): %s\n", c.Column, w.term(c.Property), c.Confidence, strings.Join(c.Reasons, "; "))
+}
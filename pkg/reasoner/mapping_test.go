@@ -0,0 +1,99 @@
+package reasoner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const mappingTBox = `@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:birthDate a owl:DatatypeProperty ; rdfs:range xsd:date .
+ex:birthPlace a owl:ObjectProperty .
+ex:population rdfs:range xsd:integer .
+ex:municipalityName a rdf:Property ; rdfs:label "name of the municipality" ; rdfs:range xsd:string .
+ex:populationDensity rdfs:range xsd:decimal .
+ex:Municipality a owl:Class .
+`
+
+func TestSuggestColumnMappings(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(mappingTBox); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	ex := "http://example.org/"
+
+	columns := []Column{
+		{Name: "birth_date", DataType: "sc:Date"},
+		{Name: "Population", DataType: "sc:Integer"},
+		{Name: "municipality-name", DataType: "https://schema.org/Text"},
+		{Name: "birth_place", DataType: "sc:Integer"},
+		{Name: "comment"},
+	}
+	candidates := SuggestColumnMappings(r.GetStore(), columns, MappingOptions{})
+
+	var got [][2]string
+	for _, c := range candidates {
+		got = append(got, [2]string{c.Column, c.Property})
+	}
+	want := [][2]string{
+		{"birth_date", ex + "birthDate"},
+		{"Population", ex + "population"},
+		// A partial name match with a compatible range
+		{"Population", ex + "populationDensity"},
+		{"municipality-name", ex + "municipalityName"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("SuggestColumnMappings() = %v, want %v", got, want)
+	}
+	if candidates[0].Confidence != 1 || len(candidates[0].Reasons) != 2 {
+		t.Errorf("Expected an exact match with a compatible range, got %+v", candidates[0])
+	}
+	line := `birth_date -> ex:birthDate (1.00): name similarity 1.00 with "birth date"; date column matches the range` + "\n"
+	if got := candidates[0].Format(r.Prefixes()); got != line {
+		t.Errorf("Format() = %q, want %q", got, line)
+	}
+
+	// birth_place matches by name but an integer column conflicts with an
+	// object property
+	candidates = SuggestColumnMappings(r.GetStore(), columns[3:4], MappingOptions{MinConfidence: 0.1})
+	if len(candidates) == 0 || candidates[0].Property != ex+"birthPlace" || candidates[0].Confidence != 0.5 {
+		t.Errorf("Expected a halved candidate for birth_place, got %+v", candidates)
+	}
+	candidates = SuggestColumnMappings(r.GetStore(), []Column{{Name: "birthPlace"}}, MappingOptions{MaxCandidates: 1})
+	if len(candidates) != 1 || candidates[0].Confidence != 1 {
+		t.Errorf("Expected a single untyped candidate, got %+v", candidates)
+	}
+}
+
+func TestParseCroissantFields(t *testing.T) {
+	metadata := `{
+  "@context": {"sc": "https://schema.org/", "cr": "http://mlcommons.org/croissant/"},
+  "@type": "sc:Dataset",
+  "recordSet": [{
+    "@type": "cr:RecordSet",
+    "field": [
+      {"@type": "cr:Field", "name": "birth_date", "dataType": "sc:Date"},
+      {"@type": "cr:Field", "name": "population", "dataType": ["sc:Integer", "sc:Number"]}
+    ]
+  }]
+}`
+	columns, err := ParseCroissantFields([]byte(metadata))
+	if err != nil {
+		t.Fatalf("ParseCroissantFields failed: %v", err)
+	}
+	want := []Column{{Name: "birth_date", DataType: "sc:Date"}, {Name: "population", DataType: "sc:Integer"}}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("ParseCroissantFields() = %v, want %v", columns, want)
+	}
+
+	if _, err := ParseCroissantFields([]byte(`{"recordSet": {"field": []}}`)); !errors.Is(err, ErrNoFields) {
+		t.Errorf("Expected ErrNoFields, got %v", err)
+	}
+	if _, err := ParseCroissantFields([]byte(`not json`)); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
@@ -0,0 +1,221 @@
+package reasoner
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoSubject is returned by Marshal for structs without a subject field
+var ErrNoSubject = errors.New(`no field tagged rdf:"@id" with a value`)
+
+//nolint:gochecknoglobals
+var timeType = reflect.TypeOf(time.Time{})
+
+// rdfField is a struct field mapped to a predicate by its rdf tag
+type rdfField struct {
+	index     int
+	predicate string // Predicate IRI, or "@id" for the subject
+	iri       bool   // Write string values as IRIs rather than literals
+}
+
+// rdfFields returns the tagged fields of a struct type. Fields are tagged
+// with the predicate IRI, optionally followed by ",iri", or with "@id" for
+// the field holding the subject IRI; untagged fields and "-" are ignored.
+func rdfFields(t reflect.Type) []rdfField {
+	var fields []rdfField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("rdf")
+		if !ok || tag == "-" || !f.IsExported() {
+			continue
+		}
+		predicate, option, _ := strings.Cut(tag, ",")
+		fields = append(fields, rdfField{index: i, predicate: predicate, iri: option == "iri"})
+	}
+	return fields
+}
+
+// Unmarshal fills the struct pointed to by v with the values of subject in
+// the store, similar to json.Unmarshal. Each field tagged `rdf:"<predicate
+// IRI>"` receives the objects of that predicate: slice fields all of them in
+// store order, other fields the first. A field tagged `rdf:"@id"` receives
+// the subject itself. Supported field types are string (receiving an IRI or
+// a literal's lexical form), bool, integers, floats, time.Time and slices of
+// these. Fields without values keep their zero value.
+func Unmarshal(store *TripleStore, subject string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unmarshal target must be a non-nil pointer to a struct, got %T", v)
+	}
+	rv = rv.Elem()
+
+	for _, f := range rdfFields(rv.Type()) {
+		field := rv.Field(f.index)
+		name := rv.Type().Field(f.index).Name
+
+		if f.predicate == "@id" {
+			if field.Kind() != reflect.String {
+				return fmt.Errorf("field %s tagged @id must be a string", name)
+			}
+			field.SetString(subject)
+			continue
+		}
+
+		objects := store.FindBySubjectPredicate(subject, f.predicate)
+		if len(objects) == 0 {
+			continue
+		}
+
+		if field.Kind() == reflect.Slice {
+			values := reflect.MakeSlice(field.Type(), len(objects), len(objects))
+			for i, t := range objects {
+				if err := setFieldValue(values.Index(i), t.Object); err != nil {
+					return fmt.Errorf("field %s: %w", name, err)
+				}
+			}
+			field.Set(values)
+			continue
+		}
+		if err := setFieldValue(field, objects[0].Object); err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setFieldValue converts an object term to the type of a field
+func setFieldValue(field reflect.Value, object string) error {
+	term := ParseTerm(object)
+	lexical := strings.TrimSpace(term.Value)
+
+	if field.Type() == timeType {
+		parsed, err := ParseDateTime(object)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	//nolint:exhaustive
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(term.Value)
+	case reflect.Bool:
+		switch lexical {
+		case "true", "1":
+			field.SetBool(true)
+		case "false", "0":
+			field.SetBool(false)
+		default:
+			return fmt.Errorf("invalid boolean %s", object)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimPrefix(lexical, "+"), 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %s", object)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimPrefix(lexical, "+"), 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %s", object)
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(lexical, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %s", object)
+		}
+		field.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// Marshal returns the triples describing the struct pointed to by v, the
+// inverse of Unmarshal. The subject is taken from the field tagged
+// `rdf:"@id"`. Strings become plain literals, or IRIs if the tag ends in
+// ",iri"; bools, integers, floats and time.Time become xsd:boolean,
+// xsd:integer, xsd:double and xsd:dateTime literals. Zero-valued scalar
+// fields and empty slices are omitted.
+func Marshal(v any) ([]Triple, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("marshal source must be a struct or a pointer to one, got %T", v)
+	}
+
+	fields := rdfFields(rv.Type())
+	subject := ""
+	for _, f := range fields {
+		if f.predicate == "@id" && rv.Field(f.index).Kind() == reflect.String {
+			subject = rv.Field(f.index).String()
+		}
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("cannot marshal %T: %w", v, ErrNoSubject)
+	}
+
+	var triples []Triple
+	for _, f := range fields {
+		if f.predicate == "@id" {
+			continue
+		}
+		field := rv.Field(f.index)
+		name := rv.Type().Field(f.index).Name
+
+		values := []reflect.Value{field}
+		if field.Kind() == reflect.Slice {
+			values = values[:0]
+			for i := range field.Len() {
+				values = append(values, field.Index(i))
+			}
+		} else if field.IsZero() {
+			continue
+		}
+
+		for _, value := range values {
+			object, err := fieldTerm(value, f.iri)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			triples = append(triples, Triple{Subject: subject, Predicate: f.predicate, Object: object})
+		}
+	}
+	return triples, nil
+}
+
+// fieldTerm converts a field value to an object term
+func fieldTerm(value reflect.Value, iri bool) (string, error) {
+	if value.Type() == timeType {
+		instant, _ := value.Interface().(time.Time)
+		return NewLiteral(instant.Format(time.RFC3339Nano), XSDDateTime).String(), nil
+	}
+
+	//nolint:exhaustive
+	switch value.Kind() {
+	case reflect.String:
+		if iri {
+			return NewIRI(value.String()).String(), nil
+		}
+		return NewLiteral(value.String(), "").String(), nil
+	case reflect.Bool:
+		return NewLiteral(strconv.FormatBool(value.Bool()), XSDBoolean).String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewLiteral(strconv.FormatInt(value.Int(), 10), XSDInteger).String(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NewLiteral(strconv.FormatUint(value.Uint(), 10), XSDInteger).String(), nil
+	case reflect.Float32, reflect.Float64:
+		return NewLiteral(strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()), XSDDouble).String(), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %s", value.Type())
+	}
+}
@@ -0,0 +1,94 @@
+package reasoner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testPerson struct {
+	ID       string    `rdf:"@id"`
+	Name     string    `rdf:"http://example.org/name"`
+	Age      int       `rdf:"http://example.org/age"`
+	Height   float64   `rdf:"http://example.org/height"`
+	Active   bool      `rdf:"http://example.org/active"`
+	Born     time.Time `rdf:"http://example.org/born"`
+	Knows    []string  `rdf:"http://example.org/knows,iri"`
+	Nickname string    `rdf:"-"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	store := NewTripleStore()
+	for _, tr := range loadTriples(t, `@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:alice ex:name "Alice" ;
+    ex:age "42"^^xsd:integer ;
+    ex:height "1.7"^^xsd:decimal ;
+    ex:active "true"^^xsd:boolean ;
+    ex:born "1990-05-17"^^xsd:date ;
+    ex:knows ex:bob , ex:carol .
+`) {
+		store.Add(tr)
+	}
+
+	var got testPerson
+	if err := Unmarshal(store, "http://example.org/alice", &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	expected := testPerson{
+		ID:     "http://example.org/alice",
+		Name:   "Alice",
+		Age:    42,
+		Height: 1.7,
+		Active: true,
+		Born:   time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+		Knows:  []string{"http://example.org/bob", "http://example.org/carol"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unmarshal() =\n%+v\nwant\n%+v", got, expected)
+	}
+
+	store.Add(Triple{Subject: "http://example.org/bob", Predicate: "http://example.org/age", Object: `"old"`})
+	if err := Unmarshal(store, "http://example.org/bob", &got); err == nil {
+		t.Error("Expected an error for an invalid integer")
+	}
+	if err := Unmarshal(store, "http://example.org/alice", got); err == nil {
+		t.Error("Expected an error for a non-pointer target")
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	person := testPerson{
+		ID:       "http://example.org/alice",
+		Name:     "Alice \"Al\"",
+		Age:      42,
+		Born:     time.Date(1990, 5, 17, 10, 0, 0, 0, time.UTC),
+		Knows:    []string{"http://example.org/bob"},
+		Nickname: "ignored",
+	}
+	triples, err := Marshal(&person)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if len(triples) != 4 {
+		t.Errorf("Expected 4 triples for the non-zero fields, got %v", triples)
+	}
+
+	store := NewTripleStore()
+	for _, tr := range triples {
+		store.Add(tr)
+	}
+	var got testPerson
+	if err := Unmarshal(store, person.ID, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	person.Nickname = ""
+	if !reflect.DeepEqual(got, person) {
+		t.Errorf("Round trip changed the struct:\n%+v\nwant\n%+v", got, person)
+	}
+
+	if _, err := Marshal(testPerson{Name: "anonymous"}); !errors.Is(err, ErrNoSubject) {
+		t.Errorf("Expected ErrNoSubject, got %v", err)
+	}
+}
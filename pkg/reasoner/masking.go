@@ -0,0 +1,102 @@
+package reasoner
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// RedactedValue replaces the values of redacted predicates in output
+const RedactedValue = `"[redacted]"`
+
+// OutputMasking hides payloads and sensitive values when writing triples,
+// so that dumps of a closure can be shared for debugging. It only changes
+// the triples written, never the store.
+type OutputMasking struct {
+	// MaxLiteralLength truncates the lexical forms of literals longer than
+	// this many characters, noting how many were cut (0 = no limit). The
+	// language tag or datatype is kept.
+	MaxLiteralLength int
+	// RedactPredicates are the IRIs of predicates whose values, literals
+	// or resources alike, are redacted wherever they occur, so that they do
+	// not leak through inferred triples such as those of a super-property.
+	// Objects equal to a redacted value are replaced with RedactedValue and
+	// triples with a redacted value as subject, such as those of an inverse
+	// property, are left out.
+	RedactPredicates []string
+}
+
+// Enabled reports whether the masking changes any triple
+func (m OutputMasking) Enabled() bool {
+	return m.MaxLiteralLength > 0 || len(m.RedactPredicates) > 0
+}
+
+// Apply returns the triples with redacted values and truncated literals
+func (m OutputMasking) Apply(triples []Triple) []Triple {
+	return m.mask(triples, m.redactedValues(triples))
+}
+
+// ApplyInStore is like Apply for a part of the triples of a store, such as
+// the inferred ones, also redacting the values that only the rest of the
+// store gives the redacted predicates
+func (m OutputMasking) ApplyInStore(triples []Triple, store *TripleStore) []Triple {
+	values := m.redactedValues(triples)
+	for _, p := range m.RedactPredicates {
+		for _, t := range store.FindByPredicate(p) {
+			values[t.Object] = true
+		}
+	}
+	return m.mask(triples, values)
+}
+
+// redactedValues returns the values of the redacted predicates in triples
+func (m OutputMasking) redactedValues(triples []Triple) map[string]bool {
+	redact := make(map[string]bool, len(m.RedactPredicates))
+	for _, p := range m.RedactPredicates {
+		redact[p] = true
+	}
+	values := make(map[string]bool)
+	for _, t := range triples {
+		if redact[t.Predicate] {
+			values[t.Object] = true
+		}
+	}
+	return values
+}
+
+// mask redacts the values and truncates the literals of triples
+func (m OutputMasking) mask(triples []Triple, values map[string]bool) []Triple {
+	masked := make([]Triple, 0, len(triples))
+	for _, t := range triples {
+		switch {
+		case values[t.Subject]:
+			continue
+		case values[t.Object]:
+			t.Object = RedactedValue
+		case m.MaxLiteralLength > 0:
+			t.Object = truncateLiteral(t.Object, m.MaxLiteralLength)
+		}
+		masked = append(masked, t)
+	}
+	return masked
+}
+
+// truncateLiteral shortens the lexical form of a literal term to at most
+// limit characters; other terms are returned unchanged
+func truncateLiteral(term string, limit int) string {
+	if len(term) <= limit || term[0] != '"' {
+		return term
+	}
+	literal := ParseTerm(term)
+	length := utf8.RuneCountInString(literal.Value)
+	if length <= limit {
+		return term
+	}
+
+	cut := 0
+	for i := 0; i < limit; i++ {
+		_, size := utf8.DecodeRuneInString(literal.Value[cut:])
+		cut += size
+	}
+	literal.Value = fmt.Sprintf("%s… [%d more characters]", literal.Value[:cut], length-limit)
+	return literal.String()
+}
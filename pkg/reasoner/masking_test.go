@@ -0,0 +1,90 @@
+package reasoner
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOutputMasking(t *testing.T) {
+	ex := "http://example.org/"
+	triples := []Triple{
+		{Subject: ex + "doc", Predicate: ex + "content", Object: `"` + strings.Repeat("A", 100) + `"^^<http://www.w3.org/2001/XMLSchema#base64Binary>`},
+		{Subject: ex + "doc", Predicate: ex + "title", Object: `"Über"@de`},
+		{Subject: ex + "alice", Predicate: ex + "email", Object: `"alice@example.org"`},
+		{Subject: ex + "alice", Predicate: ex + "account", Object: ex + "accounts/42"},
+		{Subject: ex + "alice", Predicate: RDFType, Object: ex + "Person"},
+	}
+	original := append([]Triple(nil), triples...)
+
+	m := OutputMasking{MaxLiteralLength: 4, RedactPredicates: []string{ex + "email", ex + "account"}}
+	if !m.Enabled() || (OutputMasking{}).Enabled() {
+		t.Errorf("Enabled() must report whether masking changes triples")
+	}
+	masked := m.Apply(triples)
+
+	expected := []string{
+		`"AAAA… [96 more characters]"^^<http://www.w3.org/2001/XMLSchema#base64Binary>`,
+		`"Über"@de`,
+		RedactedValue,
+		RedactedValue,
+		ex + "Person",
+	}
+	for i, want := range expected {
+		if masked[i].Object != want {
+			t.Errorf("Object %d = %s, want %s", i, masked[i].Object, want)
+		}
+		if masked[i].Subject != triples[i].Subject || masked[i].Predicate != triples[i].Predicate {
+			t.Errorf("Triple %d changed its subject or predicate: %v", i, masked[i])
+		}
+	}
+	for i := range triples {
+		if triples[i] != original[i] {
+			t.Errorf("Apply must not modify its input, triple %d is now %v", i, triples[i])
+		}
+	}
+}
+
+func TestOutputMaskingInferred(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:ssn rdfs:subPropertyOf ex:identifier .
+ex:account owl:inverseOf ex:holder .
+ex:bob ex:ssn "123-45-6789" ; ex:account <http://example.org/accounts/42> ; ex:name "Bob" .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	result := r.RunForwardReasoningWithDetails()
+
+	ex := "http://example.org/"
+	m := OutputMasking{RedactPredicates: []string{ex + "ssn", ex + "account"}}
+	masked := m.Apply(r.GetStore().All())
+	redacted := 0
+	for _, tr := range masked {
+		for _, term := range []string{tr.Subject, tr.Object} {
+			if term == `"123-45-6789"` || term == ex+"accounts/42" {
+				t.Errorf("Redacted value leaked through %v", tr)
+			}
+		}
+		if tr.Object == RedactedValue {
+			redacted++
+		}
+	}
+	// ex:ssn, ex:identifier and ex:account
+	if redacted != 3 {
+		t.Errorf("Expected 3 redacted triples, got %d", redacted)
+	}
+	if !slices.Contains(masked, Triple{Subject: ex + "bob", Predicate: ex + "name", Object: `"Bob"`}) {
+		t.Errorf("Expected other triples to be kept")
+	}
+
+	// The inferred triples alone are redacted with the values of the store
+	inferred := m.ApplyInStore(r.GetStore().All()[result.OriginalCount:], r.GetStore())
+	if !slices.Contains(inferred, Triple{Subject: ex + "bob", Predicate: ex + "identifier", Object: RedactedValue}) {
+		t.Errorf("Expected the inferred identifier to be redacted, got %v", inferred)
+	}
+}
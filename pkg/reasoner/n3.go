@@ -0,0 +1,265 @@
+package reasoner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// N3Parser parses the subset of Notation3 that extends Turtle with rules:
+//
+//	{ ?x ex:hasChild ?y } => { ?y ex:hasParent ?x } .
+//
+// A rule's premise and conclusion are formulae, "{ }" blocks of triples
+// whose terms may be ?variables; "<=" writes the conclusion first. Blank
+// nodes of a premise match any term, and "=" abbreviates owl:sameAs. Other
+// N3 constructs, such as formulae used as terms, paths, @forAll and
+// @forSome, are reported as errors and the statement is skipped.
+type N3Parser struct {
+	TurtleParser
+}
+
+// NewN3Parser creates a new Notation3 parser
+func NewN3Parser() *N3Parser {
+	p := &N3Parser{*NewTurtleParser()}
+	p.n3 = true
+	return p
+}
+
+// NewN3ParserWithOptions creates a Notation3 parser that enforces limits
+func NewN3ParserWithOptions(opts ParserOptions) *N3Parser {
+	p := NewN3Parser()
+	p.options = opts
+	return p
+}
+
+// Rules returns the rules of the last parsed document, in order. They are
+// named "n3:" followed by the line they start on.
+func (p *N3Parser) Rules() []Rule {
+	rules := make([]Rule, len(p.rules))
+	for i, rule := range p.rules {
+		rules[i] = rule
+	}
+	return rules
+}
+
+// parseN3Statement parses a rule at the current position, reporting
+// whether there was one and it was consumed. N3 directives are reported.
+func (p *TurtleParser) parseN3Statement() (bool, error) {
+	for _, directive := range []string{"@forAll", "@forSome", "@keywords"} {
+		if p.lookingAt(directive) {
+			return false, fmt.Errorf("unsupported N3 directive %s", directive)
+		}
+	}
+	if p.input[p.pos] != '{' {
+		return false, nil
+	}
+
+	start := p.pos
+	premise, err := p.parseFormula()
+	if err != nil {
+		return false, err
+	}
+	p.skipWhitespaceAndComments()
+	reverse := p.lookingAt("<=")
+	if !reverse && !p.lookingAt("=>") {
+		return false, fmt.Errorf("expected '=>' or '<=' after a formula; formulae are only supported in rules")
+	}
+	p.pos += 2
+	p.skipWhitespaceAndComments()
+	conclusion, err := p.parseFormula()
+	if err != nil {
+		return false, err
+	}
+	if reverse {
+		premise, conclusion = conclusion, premise
+	}
+
+	p.skipWhitespaceAndComments()
+	if p.pos < len(p.input) {
+		if p.input[p.pos] != '.' {
+			return false, fmt.Errorf("expected '.' after the rule")
+		}
+		p.pos++
+	}
+
+	rule, err := newN3Rule(fmt.Sprintf("n3:%d", p.lineAt(start)), premise, conclusion, p.prefixes)
+	if err != nil {
+		return true, err
+	}
+	p.rules = append(p.rules, rule)
+	return true, nil
+}
+
+// parseFormula parses the triples of a formula "{ ... }"
+func (p *TurtleParser) parseFormula() ([]Triple, error) {
+	if p.pos >= len(p.input) || p.input[p.pos] != '{' {
+		return nil, fmt.Errorf("expected '{' to start a formula")
+	}
+	p.pos++
+	p.inFormula = true
+
+	var triples []Triple
+	for {
+		p.skipWhitespaceAndComments()
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("formula is not closed with '}'")
+		}
+		if p.input[p.pos] == '}' {
+			p.pos++
+			p.inFormula = false
+			return triples, nil
+		}
+		statement, err := p.parseTriples()
+		if err != nil {
+			return nil, err
+		}
+		triples = append(triples, statement...)
+	}
+}
+
+// parseVariable parses a ?variable, which may only occur in formulae
+func (p *TurtleParser) parseVariable() (string, error) {
+	start := p.pos
+	p.pos++ // skip '?'
+	for p.pos < len(p.input) && (isAlphaNum(rune(p.input[p.pos])) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+
+	name := p.input[start:p.pos]
+	switch {
+	case len(name) == 1:
+		return "", fmt.Errorf("expected a variable name after '?'")
+	case !p.inFormula:
+		return "", fmt.Errorf("variable %s outside a rule", name)
+	}
+	return name, nil
+}
+
+// parseN3Predicate parses the predicates N3 adds to Turtle, reporting
+// whether there was one
+func (p *TurtleParser) parseN3Predicate() (string, bool, error) {
+	switch {
+	case p.lookingAt("=>"), p.lookingAt("<="):
+		p.pos += 2
+		return "", true, fmt.Errorf("unsupported implication between terms other than formulae")
+	case p.input[p.pos] == '=':
+		p.pos++
+		return OWLSameAs, true, nil
+	case p.input[p.pos] == '?':
+		variable, err := p.parseVariable()
+		return variable, true, err
+	}
+	return "", false, nil
+}
+
+// checkN3Path fails if the term just parsed is followed by an N3 path
+// operator, as in ex:bern!ex:canton
+func (p *TurtleParser) checkN3Path() error {
+	if !p.n3 || p.pos >= len(p.input) {
+		return nil
+	}
+	if ch := p.input[p.pos]; ch == '!' || ch == '^' && !p.lookingAt("^^") {
+		return fmt.Errorf("unsupported path '%c'", ch)
+	}
+	return nil
+}
+
+// skipToNextN3Statement skips past the '.' ending the current statement,
+// including the formulae of a rule
+func (p *TurtleParser) skipToNextN3Statement() {
+	depth := 0
+	if p.inFormula {
+		depth = 1
+		p.inFormula = false
+	}
+	for ; p.pos < len(p.input); p.pos++ {
+		switch p.input[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '.':
+			if depth <= 0 {
+				p.pos++
+				return
+			}
+		}
+	}
+}
+
+// newN3Rule returns the rule inferring the triples of a conclusion from
+// those of a premise. Blank nodes of the premise are variables; in the
+// conclusion they would stand for new resources, which rules cannot create.
+// IRIs are abbreviated with prefixes in the definition of the rule.
+func newN3Rule(name string, premise, conclusion []Triple, prefixes map[string]string) (*TripleRule, error) {
+	if len(premise) == 0 {
+		return nil, fmt.Errorf("unsupported rule with an empty premise")
+	}
+
+	r := &TripleRule{name: name}
+	bound := make(map[string]bool)
+	for _, t := range premise {
+		pattern := TriplePattern{Subject: n3Variable(t.Subject), Predicate: n3Variable(t.Predicate), Object: n3Variable(t.Object)}
+		for _, v := range []string{pattern.Subject, pattern.Predicate, pattern.Object} {
+			bound[v] = true
+		}
+		r.body = append(r.body, pattern)
+	}
+	for _, t := range conclusion {
+		for _, v := range []string{t.Subject, t.Predicate, t.Object} {
+			switch {
+			case strings.HasPrefix(v, "_:"):
+				return nil, fmt.Errorf("unsupported blank node %s in the conclusion of a rule", v)
+			case IsVariable(v) && !bound[v]:
+				return nil, fmt.Errorf("variable %s of the conclusion does not occur in the premise", v)
+			}
+		}
+		r.head = append(r.head, TriplePattern{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object})
+	}
+
+	// The definition is a Datalog clause per triple of the conclusion
+	known := withStandardPrefixes(prefixes)
+	body := make([]string, len(r.body))
+	for i, pattern := range r.body {
+		body[i] = datalogTriplePattern(pattern, known)
+	}
+	clauses := make([]string, len(r.head))
+	for i, head := range r.head {
+		clauses[i] = datalogTriplePattern(head, known) + " :- " + strings.Join(body, ", ") + "."
+	}
+	r.definition = strings.Join(clauses, "\n")
+	return r, nil
+}
+
+// n3Variable turns a blank node of a premise into a variable
+func n3Variable(term string) string {
+	if label, ok := strings.CutPrefix(term, "_:"); ok {
+		return "?_" + label
+	}
+	return term
+}
+
+// datalogTriplePattern renders a triple pattern as a triple/3 atom, with
+// IRIs abbreviated to prefixed names with the longest matching prefix, or
+// in angle brackets
+func datalogTriplePattern(pattern TriplePattern, prefixes map[string]string) string {
+	terms := []string{pattern.Subject, pattern.Predicate, pattern.Object}
+	for i, term := range terms {
+		if IsVariable(term) || !ParseTerm(term).IsIRI() {
+			continue
+		}
+		best, bestNS := "", ""
+		for prefix, ns := range prefixes {
+			local, ok := strings.CutPrefix(term, ns)
+			if ok && !strings.ContainsAny(local, ":/#") && (len(ns) > len(bestNS) || len(ns) == len(bestNS) && prefix < best) {
+				best, bestNS = prefix, ns
+			}
+		}
+		if bestNS != "" {
+			terms[i] = best + ":" + term[len(bestNS):]
+		} else {
+			terms[i] = "<" + term + ">"
+		}
+	}
+	return "triple(" + strings.Join(terms, ", ") + ")"
+}
@@ -0,0 +1,137 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+const n3Document = `@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+
+ex:carol ex:hasChild ex:bob .
+ex:bern = ex:berne .
+
+{ ?x ex:hasChild ?y } => { ?y ex:hasParent ?x ; a ex:Child } .
+
+{ ?p a ex:Parent } <= {
+    ?p ex:hasChild _:c .
+} .
+`
+
+func TestN3Parser(t *testing.T) {
+	p := NewN3Parser()
+	triples, err := p.Parse(n3Document)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(p.Errors()) > 0 {
+		t.Fatalf("Unexpected errors: %v", p.Errors())
+	}
+
+	ex := "http://example.org/"
+	expected := []Triple{
+		{Subject: ex + "carol", Predicate: ex + "hasChild", Object: ex + "bob"},
+		{Subject: ex + "bern", Predicate: OWLSameAs, Object: ex + "berne"},
+	}
+	if len(triples) != len(expected) {
+		t.Fatalf("Expected %d triples, got %v", len(expected), triples)
+	}
+	for i, want := range expected {
+		if triples[i] != want {
+			t.Errorf("Triple %d = %v, want %v", i, triples[i], want)
+		}
+	}
+
+	rules := p.Rules()
+	if len(rules) != 2 || rules[0].Name() != "n3:7" || rules[1].Name() != "n3:9" {
+		t.Fatalf("Rules() = %v", rules)
+	}
+	definition := rules[0].(DefinedRule).Definition()
+	if want := "triple(?y, ex:hasParent, ?x) :- triple(?x, ex:hasChild, ?y).\ntriple(?y, rdf:type, ex:Child)"; !strings.HasPrefix(definition, want) {
+		t.Errorf("Definition() = %q, want it to start with %q", definition, want)
+	}
+	// The definition is a valid Datalog program
+	if program, err := ParseDatalog(definition); err != nil || len(program.Rules) != 2 {
+		t.Errorf("ParseDatalog(%q) = %v, %v", definition, program, err)
+	}
+}
+
+func TestN3ParserUnsupported(t *testing.T) {
+	p := NewN3Parser()
+	triples, err := p.Parse(`@prefix ex: <http://example.org/> .
+@forAll ex:x .
+ex:alice ex:says { ex:sky ex:is ex:blue } .
+ex:a ex:p ?x .
+{ ?x ex:p ?y } => { ?y ex:q _:new } .
+{ ?x ex:p ?y } => { ?z ex:q ?x } .
+{ ?x ex:p ?y . ex:a ex:b ex:c } .
+ex:bern!ex:canton ex:p ex:b .
+ex:a ex:b ex:c .
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(triples) != 1 || triples[0].Object != "http://example.org/c" {
+		t.Errorf("Expected only the last triple, got %v", triples)
+	}
+	if len(p.Rules()) != 0 {
+		t.Errorf("Expected no rules, got %v", p.Rules())
+	}
+
+	expected := []string{
+		"line 2: unsupported N3 directive @forAll",
+		"line 3: unsupported formula '{ }' as a term",
+		"line 4: variable ?x outside a rule",
+		"line 5: unsupported blank node _:new in the conclusion of a rule",
+		"line 6: variable ?z of the conclusion does not occur in the premise",
+		"line 7: expected '=>' or '<=' after a formula; formulae are only supported in rules",
+		"line 8: unsupported path '!'",
+	}
+	errs := p.Errors()
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %v", len(expected), errs)
+	}
+	for i, want := range expected {
+		if errs[i].Error() != want {
+			t.Errorf("Error %d = %q, want %q", i, errs[i].Error(), want)
+		}
+	}
+}
+
+func TestLoadN3(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadDocument("family.n3", n3Document, FormatUnknown); err != nil {
+		t.Fatalf("LoadDocument failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	ex := "http://example.org/"
+	for _, want := range []Triple{
+		{Subject: ex + "bob", Predicate: ex + "hasParent", Object: ex + "carol"},
+		{Subject: ex + "bob", Predicate: RDFType, Object: ex + "Child"},
+		{Subject: ex + "carol", Predicate: RDFType, Object: ex + "Parent"},
+		{Subject: ex + "berne", Predicate: OWLSameAs, Object: ex + "bern"},
+	} {
+		if !r.GetStore().Contains(want) {
+			t.Errorf("Expected %v to be inferred", want)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, d := range DescribeRules(r.rules) {
+		names[d.Name] = d.Origin == RuleOriginCustom
+	}
+	if !names["family.n3:7"] || !names["family.n3:9"] {
+		t.Errorf("Expected the rules to be named after the file, got %v", names)
+	}
+
+	// Rules are only added to the reasoner that loaded them
+	shared := append(make([]Rule, 0, 32), DefaultRules()...)
+	a, b := NewReasonerWithRules(shared), NewReasonerWithRules(shared)
+	if err := a.LoadN3(n3Document); err != nil {
+		t.Fatalf("LoadN3 failed: %v", err)
+	}
+	if len(a.rules) != len(shared)+2 || len(b.rules) != len(shared) || a.rules[len(shared)].Name() != "n3:7" {
+		t.Errorf("Expected the rules to be added to one reasoner, got %d and %d", len(a.rules), len(b.rules))
+	}
+}
@@ -0,0 +1,112 @@
+package reasoner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IRINormalization selects rewrites applied to the IRIs of loaded triples,
+// so that documents referring to the same resources inconsistently can be
+// joined. The zero value leaves IRIs unchanged.
+type IRINormalization struct {
+	// TrimTrailingSlash removes a trailing "/" from the path and an empty
+	// fragment, so http://example.org/a/ and http://example.org/a# become
+	// http://example.org/a
+	TrimTrailingSlash bool
+	// LowercaseHost lowercases the scheme and host, which are
+	// case-insensitive
+	LowercaseHost bool
+	// HTTPSAsHTTP rewrites https IRIs to http, treating both schemes as
+	// the same resource
+	HTTPSAsHTTP bool
+}
+
+// ParseIRINormalization builds a policy from a list of rewrite names:
+// "trailing-slash", "host", "scheme" or "all"
+func ParseIRINormalization(names []string) (IRINormalization, error) {
+	var n IRINormalization
+	for _, name := range names {
+		switch strings.TrimSpace(name) {
+		case "trailing-slash":
+			n.TrimTrailingSlash = true
+		case "host":
+			n.LowercaseHost = true
+		case "scheme":
+			n.HTTPSAsHTTP = true
+		case "all":
+			n = IRINormalization{TrimTrailingSlash: true, LowercaseHost: true, HTTPSAsHTTP: true}
+		default:
+			return IRINormalization{}, fmt.Errorf("invalid IRI normalization %q, must be 'trailing-slash', 'host', 'scheme' or 'all'", name)
+		}
+	}
+	return n, nil
+}
+
+// WithIRINormalization rewrites the IRIs of every triple loaded by
+// LoadTurtle and its variants according to policy. Query terms are not
+// rewritten; pass them through policy.Normalize first.
+func WithIRINormalization(policy IRINormalization) Option {
+	return func(r *Reasoner) {
+		r.iriNormalization = policy
+	}
+}
+
+// Normalize returns iri rewritten according to the policy. Terms that are
+// not http or https IRIs, such as blank nodes and URNs, are returned
+// unchanged.
+func (n IRINormalization) Normalize(iri string) string {
+	schemeEnd := strings.Index(iri, "://")
+	if schemeEnd < 0 {
+		return iri
+	}
+	scheme := iri[:schemeEnd]
+	if !strings.EqualFold(scheme, "http") && !strings.EqualFold(scheme, "https") {
+		return iri
+	}
+
+	rest := iri[schemeEnd+3:]
+	authority, path := rest, ""
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		authority, path = rest[:i], rest[i:]
+	}
+
+	if n.LowercaseHost {
+		scheme = strings.ToLower(scheme)
+		authority = lowercaseHost(authority)
+	}
+	if n.HTTPSAsHTTP && strings.EqualFold(scheme, "https") {
+		scheme = "http"
+	}
+	if n.TrimTrailingSlash {
+		path = strings.TrimSuffix(path, "#")
+		// The root path is kept, as http://example.org equals http://example.org/
+		if len(path) > 1 && !strings.ContainsAny(path, "?#") {
+			path = strings.TrimSuffix(path, "/")
+		}
+	}
+	return scheme + "://" + authority + path
+}
+
+// enabled reports whether the policy rewrites any IRIs
+func (n IRINormalization) enabled() bool {
+	return n != IRINormalization{}
+}
+
+// triple returns t with its IRIs normalized
+func (n IRINormalization) triple(t Triple) Triple {
+	t.Subject = n.Normalize(t.Subject)
+	t.Predicate = n.Normalize(t.Predicate)
+	if !ParseTerm(t.Object).IsLiteral() {
+		t.Object = n.Normalize(t.Object)
+	}
+	return t
+}
+
+// lowercaseHost lowercases the host of an authority, keeping user
+// information, which is case-sensitive
+func lowercaseHost(authority string) string {
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		return authority[:i+1] + strings.ToLower(authority[i+1:])
+	}
+	return strings.ToLower(authority)
+}
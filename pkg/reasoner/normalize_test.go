@@ -0,0 +1,70 @@
+package reasoner
+
+import "testing"
+
+func TestIRINormalization(t *testing.T) {
+	all := IRINormalization{TrimTrailingSlash: true, LowercaseHost: true, HTTPSAsHTTP: true}
+
+	tests := []struct {
+		policy IRINormalization
+		iri    string
+		want   string
+	}{
+		{IRINormalization{}, "https://Example.org/a/", "https://Example.org/a/"},
+		{IRINormalization{TrimTrailingSlash: true}, "http://example.org/a/", "http://example.org/a"},
+		{IRINormalization{TrimTrailingSlash: true}, "http://example.org/a#", "http://example.org/a"},
+		{IRINormalization{TrimTrailingSlash: true}, "http://example.org/a/#", "http://example.org/a"},
+		{IRINormalization{TrimTrailingSlash: true}, "http://example.org/", "http://example.org/"},
+		{IRINormalization{TrimTrailingSlash: true}, "http://example.org/a#b/", "http://example.org/a#b/"},
+		{IRINormalization{TrimTrailingSlash: true}, "http://example.org/a/?q=1", "http://example.org/a/?q=1"},
+		{IRINormalization{LowercaseHost: true}, "HTTP://User@Example.ORG/Path", "http://User@example.org/Path"},
+		{IRINormalization{HTTPSAsHTTP: true}, "https://example.org/a", "http://example.org/a"},
+		{all, "HTTPS://Example.org/Building/42/", "http://example.org/Building/42"},
+		{all, "urn:example:A/", "urn:example:A/"},
+		{all, "_:b0", "_:b0"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.policy.Normalize(tt.iri); got != tt.want {
+			t.Errorf("%+v.Normalize(%q) = %q, want %q", tt.policy, tt.iri, got, tt.want)
+		}
+	}
+}
+
+func TestWithIRINormalization(t *testing.T) {
+	r := NewReasoner(WithIRINormalization(IRINormalization{TrimTrailingSlash: true, LowercaseHost: true, HTTPSAsHTTP: true}))
+	err := r.LoadTurtle(`@prefix ex: <https://Example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.org/Building> rdfs:subClassOf ex:Structure .
+<https://example.org/b42/> a <http://example.org/Building/> ; ex:label "https://Example.org/" .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+	r.RunForwardReasoning()
+
+	types := r.GetInferredTypes("http://example.org/b42")
+	if len(types) != 2 || types[0] != "http://example.org/Building" || types[1] != "http://example.org/Structure" {
+		t.Errorf("GetInferredTypes() = %v, want Building and Structure", types)
+	}
+	if got := r.Query("http://example.org/b42", "http://example.org/label", ""); len(got) != 1 || got[0].Object != `"https://Example.org/"` {
+		t.Errorf("literal objects were rewritten: %v", got)
+	}
+	if ns := r.Prefixes()["ex"]; ns != "http://example.org/" {
+		t.Errorf("Prefixes()[ex] = %q, want http://example.org/", ns)
+	}
+}
+
+func TestParseIRINormalization(t *testing.T) {
+	got, err := ParseIRINormalization([]string{"host", "scheme"})
+	if err != nil || got != (IRINormalization{LowercaseHost: true, HTTPSAsHTTP: true}) {
+		t.Errorf("ParseIRINormalization(host, scheme) = %+v, %v", got, err)
+	}
+	got, err = ParseIRINormalization([]string{"all"})
+	if err != nil || got != (IRINormalization{TrimTrailingSlash: true, LowercaseHost: true, HTTPSAsHTTP: true}) {
+		t.Errorf("ParseIRINormalization(all) = %+v, %v", got, err)
+	}
+	if _, err := ParseIRINormalization([]string{"fragment"}); err == nil {
+		t.Error("ParseIRINormalization(fragment) succeeded, want error")
+	}
+}
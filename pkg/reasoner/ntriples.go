@@ -0,0 +1,359 @@
+package reasoner
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// NTriplesParser parses N-Triples, the line-based format the reasoner
+// writes. Unlike TurtleParser it follows the N-Triples grammar strictly:
+// IRIs must be absolute, escapes are decoded in IRIs and literals, and a
+// line that is not a single complete triple is skipped as a whole.
+type NTriplesParser struct {
+	// progress, if set, is called with the bytes consumed and triples parsed
+	progress func(done, total, triples int)
+
+	// errors records lines skipped during the last Parse
+	errors []ParseError
+
+	// warnings records suspicious but loaded triples of the last Parse
+	warnings []ParseError
+
+	// options limits the size of parsed documents; MaxDepth does not apply
+	options ParserOptions
+
+	// quads accepts a graph label after the object, as in N-Quads
+	quads bool
+}
+
+// NewNTriplesParser creates a new N-Triples parser
+func NewNTriplesParser() *NTriplesParser {
+	return &NTriplesParser{}
+}
+
+// NewNTriplesParserWithOptions creates an N-Triples parser that enforces
+// limits. Parse fails with an error wrapping ErrParserLimit as soon as a
+// limit is exceeded.
+func NewNTriplesParserWithOptions(opts ParserOptions) *NTriplesParser {
+	p := NewNTriplesParser()
+	p.options = opts
+	return p
+}
+
+// NQuadsParser parses N-Quads: N-Triples lines with an optional graph
+// label, an IRI or blank node, after the object. The label is kept in
+// Triple.Graph.
+type NQuadsParser struct {
+	NTriplesParser
+}
+
+// NewNQuadsParser creates a new N-Quads parser
+func NewNQuadsParser() *NQuadsParser {
+	return &NQuadsParser{NTriplesParser{quads: true}}
+}
+
+// NewNQuadsParserWithOptions creates an N-Quads parser that enforces limits
+func NewNQuadsParserWithOptions(opts ParserOptions) *NQuadsParser {
+	p := NewNQuadsParser()
+	p.options = opts
+	return p
+}
+
+// Parse parses N-Triples content and returns triples. Lines that are not
+// valid N-Triples are skipped and reported by Errors.
+func (p *NTriplesParser) Parse(content string) ([]Triple, error) {
+	p.errors = nil
+	p.warnings = nil
+
+	if limit := p.options.MaxInputBytes; limit > 0 && len(content) > limit {
+		return nil, limitError("document is %d bytes, the limit is %d", len(content), limit)
+	}
+	content = strings.TrimPrefix(content, "\ufeff")
+
+	var triples []Triple
+	nextReport := progressInterval
+	line, pos := 0, 0
+	for pos < len(content) {
+		if p.progress != nil && pos >= nextReport {
+			p.progress(pos, len(content), len(triples))
+			nextReport = pos + progressInterval
+		}
+
+		end := strings.IndexAny(content[pos:], "\r\n")
+		if end < 0 {
+			end = len(content)
+		} else {
+			end += pos
+		}
+		text := content[pos:end]
+		line++
+		pos = end + 1
+		if strings.HasPrefix(content[end:], "\r\n") {
+			pos++
+		}
+
+		t, ok, err := p.parseLine(text)
+		if errors.Is(err, ErrParserLimit) {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if err != nil {
+			p.errors = append(p.errors, ParseError{Line: line, Message: err.Error()})
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := validateTemporalLiteral(t.Object); err != nil {
+			p.warnings = append(p.warnings, ParseError{Line: line, Message: err.Error()})
+		}
+		if err := validateCustomLiteral(t.Object); err != nil {
+			p.warnings = append(p.warnings, ParseError{Line: line, Message: err.Error()})
+		}
+		triples = append(triples, t)
+		if limit := p.options.MaxTriples; limit > 0 && len(triples) > limit {
+			return nil, limitError("more than %d triples", limit)
+		}
+	}
+
+	if p.progress != nil {
+		p.progress(len(content), len(content), len(triples))
+	}
+
+	return triples, nil
+}
+
+// Errors returns the lines skipped during the last call to Parse
+func (p *NTriplesParser) Errors() []ParseError {
+	return p.errors
+}
+
+// Warnings returns problems found in triples that were loaded anyway
+// during the last call to Parse, such as malformed date literals
+func (p *NTriplesParser) Warnings() []ParseError {
+	return p.warnings
+}
+
+// parseLine parses a line, reporting false for blank and comment lines
+func (p *NTriplesParser) parseLine(text string) (Triple, bool, error) {
+	s := &ntScanner{input: text}
+	s.skipSpace()
+	if s.done() {
+		return Triple{}, false, nil
+	}
+
+	subject, err := s.subject()
+	if err != nil {
+		return Triple{}, false, fmt.Errorf("invalid subject: %w", err)
+	}
+	s.skipSpace()
+	predicate, err := s.iri()
+	if err != nil {
+		return Triple{}, false, fmt.Errorf("invalid predicate: %w", err)
+	}
+	s.skipSpace()
+	object, err := p.object(s)
+	if err != nil {
+		return Triple{}, false, fmt.Errorf("invalid object: %w", err)
+	}
+	s.skipSpace()
+	var graph string
+	if p.quads && !s.done() && s.input[s.pos] != '.' {
+		if graph, err = s.subject(); err != nil {
+			return Triple{}, false, fmt.Errorf("invalid graph label: %w", err)
+		}
+		s.skipSpace()
+	}
+	if s.done() || s.input[s.pos] != '.' {
+		return Triple{}, false, errors.New("expected '.' after the object")
+	}
+	s.pos++
+	s.skipSpace()
+	if !s.done() {
+		return Triple{}, false, fmt.Errorf("unexpected %q after the triple", s.input[s.pos:])
+	}
+
+	return Triple{Subject: subject, Predicate: predicate, Object: object, Graph: graph}, true, nil
+}
+
+// object parses an IRI, blank node or literal
+func (p *NTriplesParser) object(s *ntScanner) (string, error) {
+	switch {
+	case s.done():
+		return "", errors.New("missing object")
+	case s.input[s.pos] == '"':
+		return s.literal(p.options.MaxLiteralLength)
+	default:
+		return s.subject()
+	}
+}
+
+// ntScanner reads the terms of a single N-Triples line
+type ntScanner struct {
+	input string
+	pos   int
+}
+
+// done reports whether the rest of the line is empty or a comment
+func (s *ntScanner) done() bool {
+	return s.pos >= len(s.input) || s.input[s.pos] == '#'
+}
+
+// skipSpace skips spaces and tabs
+func (s *ntScanner) skipSpace() {
+	for s.pos < len(s.input) && (s.input[s.pos] == ' ' || s.input[s.pos] == '\t') {
+		s.pos++
+	}
+}
+
+// subject parses an IRI or blank node
+func (s *ntScanner) subject() (string, error) {
+	if strings.HasPrefix(s.input[s.pos:], "_:") {
+		return s.blankNode()
+	}
+	return s.iri()
+}
+
+// iri parses an absolute IRIREF, decoding UCHAR escapes
+func (s *ntScanner) iri() (string, error) {
+	if s.pos >= len(s.input) || s.input[s.pos] != '<' {
+		return "", errors.New("expected '<'")
+	}
+	s.pos++
+
+	var sb strings.Builder
+	for s.pos < len(s.input) && s.input[s.pos] != '>' {
+		c := s.input[s.pos]
+		switch {
+		case c == '\\':
+			r, err := s.uchar()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteRune(r)
+			continue
+		case c <= ' ' || strings.IndexByte(`<"{}|^`+"`", c) >= 0:
+			return "", fmt.Errorf("character %q is not allowed in an IRI", c)
+		}
+		sb.WriteByte(c)
+		s.pos++
+	}
+	if s.pos >= len(s.input) {
+		return "", errors.New("unterminated IRI")
+	}
+	s.pos++ // skip '>'
+
+	iri := sb.String()
+	if isRelativeIRI(iri) {
+		return "", fmt.Errorf("relative IRI <%s>", iri)
+	}
+	return iri, nil
+}
+
+// uchar decodes a \uXXXX or \UXXXXXXXX escape
+func (s *ntScanner) uchar() (rune, error) {
+	size := 0
+	if s.pos+1 < len(s.input) {
+		switch s.input[s.pos+1] {
+		case 'u':
+			size = 4
+		case 'U':
+			size = 8
+		}
+	}
+	if size == 0 || s.pos+2+size > len(s.input) {
+		return 0, fmt.Errorf("invalid escape sequence at column %d", s.pos+1)
+	}
+	code, err := strconv.ParseUint(s.input[s.pos+2:s.pos+2+size], 16, 32)
+	if err != nil || code > unicode.MaxRune {
+		return 0, fmt.Errorf("invalid escape sequence %s", s.input[s.pos:s.pos+2+size])
+	}
+	s.pos += 2 + size
+	return rune(code), nil
+}
+
+// blankNode parses a blank node label
+func (s *ntScanner) blankNode() (string, error) {
+	start := s.pos
+	s.pos += 2 // skip "_:"
+	for s.pos < len(s.input) && (isNameChar(rune(s.input[s.pos])) || s.input[s.pos] >= 0x80) {
+		s.pos++
+	}
+	// A label may contain but not end with '.', which ends the triple
+	for s.pos > start+2 && s.input[s.pos-1] == '.' {
+		s.pos--
+	}
+	if s.pos == start+2 {
+		return "", errors.New("empty blank node label")
+	}
+	return s.input[start:s.pos], nil
+}
+
+// literal parses a quoted literal with an optional language tag or
+// datatype, returning it with the escaping used for all stored literals
+func (s *ntScanner) literal(maxLength int) (string, error) {
+	s.pos++ // skip opening quote
+	start := s.pos
+	for s.pos < len(s.input) && s.input[s.pos] != '"' {
+		if maxLength > 0 && s.pos-start > maxLength {
+			return "", limitError("literal longer than %d bytes", maxLength)
+		}
+		if s.input[s.pos] != '\\' {
+			s.pos++
+			continue
+		}
+		if s.pos+1 < len(s.input) && strings.IndexByte(`tbnrf"'\`, s.input[s.pos+1]) >= 0 {
+			s.pos += 2
+			continue
+		}
+		if _, err := s.uchar(); err != nil {
+			return "", err
+		}
+	}
+	if s.pos >= len(s.input) {
+		return "", errors.New("unterminated literal")
+	}
+	raw := s.input[start:s.pos]
+	s.pos++ // skip closing quote
+
+	literal := `"` + escapeLiteral(unescapeLiteral(raw)) + `"`
+	switch {
+	case strings.HasPrefix(s.input[s.pos:], "^^"):
+		s.pos += 2
+		datatype, err := s.iri()
+		if err != nil {
+			return "", fmt.Errorf("invalid datatype: %w", err)
+		}
+		return literal + "^^<" + datatype + ">", nil
+	case strings.HasPrefix(s.input[s.pos:], "@"):
+		s.pos++
+		tagStart := s.pos
+		for s.pos < len(s.input) && (isAlphaNum(rune(s.input[s.pos])) || s.input[s.pos] == '-') {
+			s.pos++
+		}
+		tag := s.input[tagStart:s.pos]
+		if !isLanguageTag(tag) {
+			return "", fmt.Errorf("invalid language tag %q", tag)
+		}
+		return literal + "@" + tag, nil
+	}
+	return literal, nil
+}
+
+// isLanguageTag reports whether s matches the N-Triples LANGTAG production
+// without its '@': letters, followed by subtags of letters and digits
+func isLanguageTag(s string) bool {
+	for i, subtag := range strings.Split(s, "-") {
+		if subtag == "" {
+			return false
+		}
+		for _, r := range subtag {
+			if r > unicode.MaxASCII || !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+				return false
+			}
+		}
+	}
+	return true
+}
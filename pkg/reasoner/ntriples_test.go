@@ -0,0 +1,161 @@
+package reasoner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNTriplesParser(t *testing.T) {
+	p := NewNTriplesParser()
+	triples, err := p.Parse("# municipalities\r\n" +
+		"<http://example.org/bern> <http://example.org/name> \"Bern\"@de-CH .\r\n" +
+		"<http://example.org/z\\u00FCrich> <http://example.org/name> \"Z\\u00fcrich\\n\\\"ZH\\\"\" . # comment\n" +
+		"_:b1 <http://example.org/population> \"134000\"^^<http://www.w3.org/2001/XMLSchema#integer> .\n" +
+		"\n" +
+		"<http://example.org/bern>\t<http://example.org/near>\t_:b1.\n" +
+		"ex:bern <http://example.org/name> \"Bern\" .\n" +
+		"<bern> <http://example.org/name> \"Bern\" .\n" +
+		"<http://example.org/bern> <http://example.org/name> \"Bern\"\n" +
+		"<http://example.org/bern> <http://example.org/name> \"B\\qrn\" .\n" +
+		"<http://example.org/bern> <http://example.org/name> \"Bern\"@1de .\n" +
+		"<http://example.org/bern> <http://example.org/name> \"Bern\" . <http://example.org/x>\n")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expected := []Triple{
+		{Subject: "http://example.org/bern", Predicate: "http://example.org/name", Object: `"Bern"@de-CH`},
+		{Subject: "http://example.org/zürich", Predicate: "http://example.org/name", Object: `"Zürich\n\"ZH\""`},
+		{Subject: "_:b1", Predicate: "http://example.org/population", Object: `"134000"^^<http://www.w3.org/2001/XMLSchema#integer>`},
+		{Subject: "http://example.org/bern", Predicate: "http://example.org/near", Object: "_:b1"},
+	}
+	if len(triples) != len(expected) {
+		t.Fatalf("Expected %d triples, got %v", len(expected), triples)
+	}
+	for i := range expected {
+		if triples[i] != expected[i] {
+			t.Errorf("Triple %d = %v, want %v", i, triples[i], expected[i])
+		}
+	}
+
+	expectedErrors := []struct {
+		line    int
+		message string
+	}{
+		{7, "invalid subject"},
+		{8, "relative IRI"},
+		{9, "expected '.'"},
+		{10, "invalid escape"},
+		{11, "invalid language tag"},
+		{12, "after the triple"},
+	}
+	errs := p.Errors()
+	if len(errs) != len(expectedErrors) {
+		t.Fatalf("Expected %d errors, got %v", len(expectedErrors), errs)
+	}
+	for i, tt := range expectedErrors {
+		if errs[i].Line != tt.line || !strings.Contains(errs[i].Message, tt.message) {
+			t.Errorf("Error %d = %v, expected line %d mentioning %q", i, errs[i], tt.line, tt.message)
+		}
+	}
+}
+
+func TestNTriplesParserLimits(t *testing.T) {
+	p := NewNTriplesParserWithOptions(ParserOptions{MaxTriples: 1})
+	_, err := p.Parse("<http://example.org/a> <http://example.org/p> <http://example.org/b> .\n" +
+		"<http://example.org/b> <http://example.org/p> <http://example.org/c> .\n")
+	if !errors.Is(err, ErrParserLimit) {
+		t.Errorf("Expected a limit error, got %v", err)
+	}
+}
+
+func TestLoadNTriplesOutput(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:City rdfs:subClassOf ex:Place .
+ex:bern a ex:City ; rdfs:label "Bern \"BE\""@de, "Berne"@fr .
+ex:bern ex:founded "1191"^^<http://www.w3.org/2001/XMLSchema#gYear> .
+`); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+	output := r.GetAllTriples()
+
+	// The output of a run loads back unchanged as input to another
+	reloaded := NewReasoner(WithProvenance())
+	if err := reloaded.LoadNTriplesFrom("output.nt", strings.Join(output, "\n")); err != nil {
+		t.Fatalf("LoadNTriplesFrom failed: %v", err)
+	}
+	if got := reloaded.GetAllTriples(); !equalStrings(got, output) {
+		t.Errorf("Reloaded triples differ:\n got %v\nwant %v", got, output)
+	}
+	bern := Triple{Subject: "http://example.org/bern", Predicate: RDFType, Object: "http://example.org/Place"}
+	if sources := reloaded.GetStore().SourceOf(bern); len(sources) != 1 || sources[0] != "output.nt" {
+		t.Errorf("SourceOf() = %v, want [output.nt]", sources)
+	}
+	if diagnostics := reloaded.Diagnostics(); len(diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+}
+
+func TestNQuadsParser(t *testing.T) {
+	p := NewNQuadsParser()
+	triples, err := p.Parse(`<http://example.org/bern> <http://example.org/name> "Bern" <http://example.org/graphs/bfs> .
+<http://example.org/bern> <http://example.org/canton> <http://example.org/be> _:g1 .
+<http://example.org/bern> <http://example.org/mayor> "Alec" .
+<http://example.org/bern> <http://example.org/name> "Berne" "graph" .
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	graphs := []string{"http://example.org/graphs/bfs", "_:g1", ""}
+	if len(triples) != len(graphs) {
+		t.Fatalf("Expected %d quads, got %v", len(graphs), triples)
+	}
+	for i, graph := range graphs {
+		if triples[i].Graph != graph {
+			t.Errorf("Graph of quad %d = %q, want %q", i, triples[i].Graph, graph)
+		}
+	}
+	if errs := p.Errors(); len(errs) != 1 || errs[0].Line != 4 || !strings.Contains(errs[0].Message, "invalid graph label") {
+		t.Errorf("Expected an invalid graph label on line 4, got %v", errs)
+	}
+
+	// Graph labels are only accepted by the N-Quads parser
+	if _, err := NewNTriplesParser().Parse(`<http://example.org/a> <http://example.org/p> <http://example.org/b> <http://example.org/g> .`); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+}
+
+func TestLoadNQuads(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadNQuads(`<http://example.org/City> <http://www.w3.org/2000/01/rdf-schema#subClassOf> <http://example.org/Place> <http://example.org/schema> .
+<http://example.org/bern> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.org/City> <http://example.org/data> .
+<http://example.org/bern> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.org/City> <http://example.org/copy> .
+`)
+	if err != nil {
+		t.Fatalf("LoadNQuads failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	// Reasoning is over the union of the graphs
+	bern := Triple{Subject: "http://example.org/bern", Predicate: RDFType, Object: "http://example.org/Place"}
+	if !r.GetStore().Contains(bern) {
+		t.Errorf("Expected %v to be inferred across graphs", bern)
+	}
+	graphs := make(map[string]string)
+	for _, tr := range r.GetStore().All() {
+		if tr.Predicate == RDFType {
+			graphs[tr.Object] = tr.Graph
+		}
+	}
+	if graphs["http://example.org/City"] != "http://example.org/data" || graphs["http://example.org/Place"] != "" {
+		t.Errorf("Expected the first graph of asserted triples and the default graph of inferred ones, got %v", graphs)
+	}
+	if r.GetStore().Size() != 3 {
+		t.Errorf("Expected a triple in two graphs to be stored once, got %d triples", r.GetStore().Size())
+	}
+}
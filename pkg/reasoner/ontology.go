@@ -0,0 +1,157 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode"
+)
+
+// OWL ontology header vocabulary
+const (
+	OWLOntology     = "http://www.w3.org/2002/07/owl#Ontology"
+	OWLVersionIRI   = "http://www.w3.org/2002/07/owl#versionIRI"
+	OWLPriorVersion = "http://www.w3.org/2002/07/owl#priorVersion"
+	OWLImports      = "http://www.w3.org/2002/07/owl#imports"
+	OWLVersionInfo  = "http://www.w3.org/2002/07/owl#versionInfo"
+)
+
+// OntologyHeader is the owl:Ontology declaration of a document
+type OntologyHeader struct {
+	IRI           string
+	VersionIRI    string
+	VersionInfo   string   // Lexical form of owl:versionInfo
+	PriorVersions []string // Values of owl:priorVersion, sorted
+	Imports       []string // Values of owl:imports, sorted
+}
+
+// OntologyHeaders returns the headers of all owl:Ontology resources in the
+// store, sorted by IRI
+func (ts *TripleStore) OntologyHeaders() []OntologyHeader {
+	var headers []OntologyHeader
+	for _, t := range ts.FindByPredicateObject(RDFType, OWLOntology) {
+		h := OntologyHeader{IRI: t.Subject}
+		if v := ts.FindBySubjectPredicate(t.Subject, OWLVersionIRI); len(v) > 0 {
+			h.VersionIRI = v[0].Object
+		}
+		if v := ts.FindBySubjectPredicate(t.Subject, OWLVersionInfo); len(v) > 0 {
+			h.VersionInfo = v[0].ObjectTerm().Value
+		}
+		for _, v := range ts.FindBySubjectPredicate(t.Subject, OWLPriorVersion) {
+			h.PriorVersions = append(h.PriorVersions, v.Object)
+		}
+		for _, v := range ts.FindBySubjectPredicate(t.Subject, OWLImports) {
+			h.Imports = append(h.Imports, v.Object)
+		}
+		sort.Strings(h.PriorVersions)
+		sort.Strings(h.Imports)
+		headers = append(headers, h)
+	}
+
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].IRI < headers[j].IRI
+	})
+	return headers
+}
+
+// OntologyHeader returns the header of the only ontology in the store
+func (ts *TripleStore) OntologyHeader() (OntologyHeader, error) {
+	headers := ts.OntologyHeaders()
+	switch len(headers) {
+	case 0:
+		return OntologyHeader{}, fmt.Errorf("no owl:Ontology declared")
+	case 1:
+		return headers[0], nil
+	default:
+		return OntologyHeader{}, fmt.Errorf("%d owl:Ontology resources declared, expected one", len(headers))
+	}
+}
+
+// CheckVersionOrder verifies that newer is a later version of the same
+// ontology as older: either newer lists the versionIRI of older as
+// owl:priorVersion, or its versionIRI is greater when comparing the numbers
+// it contains, as in .../1.2.0 < .../1.10.0.
+func CheckVersionOrder(older, newer OntologyHeader) error {
+	if older.IRI != newer.IRI {
+		return fmt.Errorf("different ontologies: %s and %s", older.IRI, newer.IRI)
+	}
+	if older.VersionIRI == "" || newer.VersionIRI == "" {
+		return fmt.Errorf("both versions of %s need an owl:versionIRI", older.IRI)
+	}
+	for _, prior := range newer.PriorVersions {
+		if prior == older.VersionIRI {
+			return nil
+		}
+	}
+	if compareVersionIRIs(newer.VersionIRI, older.VersionIRI) <= 0 {
+		return fmt.Errorf("versionIRI %s is not newer than %s", newer.VersionIRI, older.VersionIRI)
+	}
+	return nil
+}
+
+// DiffOntologies returns the axioms added and removed between two versions
+// of an ontology, ignoring the ontology header. Blank nodes are compared as
+// in CompareGraphs.
+func DiffOntologies(older, newer []Triple) GraphDiff {
+	return CompareGraphs(withoutOntologyHeader(older), withoutOntologyHeader(newer))
+}
+
+// withoutOntologyHeader drops the triples describing owl:Ontology resources
+func withoutOntologyHeader(triples []Triple) []Triple {
+	ontologies := make(map[string]bool)
+	for _, t := range triples {
+		if t.Predicate == RDFType && t.Object == OWLOntology {
+			ontologies[t.Subject] = true
+		}
+	}
+
+	axioms := make([]Triple, 0, len(triples))
+	for _, t := range triples {
+		if !ontologies[t.Subject] {
+			axioms = append(axioms, t)
+		}
+	}
+	return axioms
+}
+
+// compareVersionIRIs compares two IRIs segment by segment, comparing runs
+// of digits numerically and everything else lexically
+func compareVersionIRIs(a, b string) int {
+	sa, sb := versionSegments(a), versionSegments(b)
+	for i := 0; i < len(sa) && i < len(sb); i++ {
+		na, errA := strconv.ParseUint(sa[i], 10, 64)
+		nb, errB := strconv.ParseUint(sb[i], 10, 64)
+		switch {
+		case errA == nil && errB == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+			return 1
+		case (errA != nil || errB != nil) && sa[i] != sb[i]:
+			if sa[i] < sb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(sa) - len(sb)
+}
+
+// versionSegments splits a string into alternating runs of digits and non-digits
+func versionSegments(s string) []string {
+	var segments []string
+	start := 0
+	prevDigit := false
+	for i, r := range s {
+		digit := unicode.IsDigit(r)
+		if i > 0 && digit != prevDigit {
+			segments = append(segments, s[start:i])
+			start = i
+		}
+		prevDigit = digit
+	}
+	if start < len(s) {
+		segments = append(segments, s[start:])
+	}
+	return segments
+}
@@ -0,0 +1,96 @@
+package reasoner
+
+import "testing"
+
+func loadTriples(t *testing.T, content string) []Triple {
+	t.Helper()
+	triples, err := NewTurtleParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return triples
+}
+
+const ontologyV1 = `
+@prefix ex: <http://example.org/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.org/onto> a owl:Ontology ;
+    owl:versionIRI <http://example.org/onto/1.9.0> ;
+    owl:imports <http://example.org/base> .
+ex:Car rdfs:subClassOf ex:Vehicle .
+ex:Bike rdfs:subClassOf ex:Vehicle .
+`
+
+const ontologyV2 = `
+@prefix ex: <http://example.org/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.org/onto> a owl:Ontology ;
+    owl:versionIRI <http://example.org/onto/1.10.0> ;
+    owl:versionInfo "1.10.0" ;
+    owl:imports <http://example.org/base> , <http://example.org/geo> .
+ex:Car rdfs:subClassOf ex:Vehicle .
+ex:Truck rdfs:subClassOf ex:Vehicle .
+`
+
+func TestOntologyHeader(t *testing.T) {
+	store := NewTripleStore()
+	for _, triple := range loadTriples(t, ontologyV2) {
+		store.Add(triple)
+	}
+
+	h, err := store.OntologyHeader()
+	if err != nil {
+		t.Fatalf("OntologyHeader failed: %v", err)
+	}
+	if h.IRI != "http://example.org/onto" || h.VersionIRI != "http://example.org/onto/1.10.0" || h.VersionInfo != "1.10.0" {
+		t.Errorf("Unexpected header %+v", h)
+	}
+	if !equalStrings(h.Imports, []string{"http://example.org/base", "http://example.org/geo"}) {
+		t.Errorf("Unexpected imports %v", h.Imports)
+	}
+
+	if _, err := NewTripleStore().OntologyHeader(); err == nil {
+		t.Errorf("Expected an error for a store without ontology")
+	}
+}
+
+func TestCheckVersionOrder(t *testing.T) {
+	v1 := OntologyHeader{IRI: "http://example.org/onto", VersionIRI: "http://example.org/onto/1.9.0"}
+	v2 := OntologyHeader{IRI: "http://example.org/onto", VersionIRI: "http://example.org/onto/1.10.0"}
+	renamed := OntologyHeader{IRI: "http://example.org/onto", VersionIRI: "http://example.org/onto/latest",
+		PriorVersions: []string{v2.VersionIRI}}
+
+	tests := []struct {
+		name         string
+		older, newer OntologyHeader
+		wantErr      bool
+	}{
+		{"numeric order", v1, v2, false},
+		{"reversed", v2, v1, true},
+		{"same version", v1, v1, true},
+		{"priorVersion", v2, renamed, false},
+		{"different ontology", v1, OntologyHeader{IRI: "http://example.org/other", VersionIRI: v2.VersionIRI}, true},
+		{"missing versionIRI", v1, OntologyHeader{IRI: v1.IRI}, true},
+	}
+	for _, tt := range tests {
+		if err := CheckVersionOrder(tt.older, tt.newer); (err != nil) != tt.wantErr {
+			t.Errorf("%s: CheckVersionOrder error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDiffOntologies(t *testing.T) {
+	diff := DiffOntologies(loadTriples(t, ontologyV1), loadTriples(t, ontologyV2))
+
+	if diff.Common != 1 || len(diff.OnlyInFirst) != 1 || len(diff.OnlyInSecond) != 1 {
+		t.Fatalf("Expected 1 unchanged, 1 removed and 1 added axiom, got %+v", diff)
+	}
+	if diff.OnlyInFirst[0].Subject != "http://example.org/onto#Bike" {
+		t.Errorf("Expected the Bike axiom to be removed, got %v", diff.OnlyInFirst)
+	}
+	if diff.OnlyInSecond[0].Subject != "http://example.org/onto#Truck" {
+		t.Errorf("Expected the Truck axiom to be added, got %v", diff.OnlyInSecond)
+	}
+}
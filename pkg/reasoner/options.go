@@ -0,0 +1,14 @@
+package reasoner
+
+// Option configures a Reasoner at construction time
+type Option func(*Reasoner)
+
+// WithTracer instruments loading, reasoning and querying with spans
+// created by the given tracer
+func WithTracer(tracer Tracer) Option {
+	return func(r *Reasoner) {
+		if tracer != nil {
+			r.tracer = tracer
+		}
+	}
+}
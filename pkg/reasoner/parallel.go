@@ -0,0 +1,203 @@
+package reasoner
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WithPartitionParallel materializes the ABox in up to workers partitions
+// by subject, in parallel, when that gives the same closure as reasoning
+// over the whole store: every rule must join at most one ABox triple with
+// the saturated TBox, as the RDFS typing rules do. Otherwise reasoning
+// falls back to a single partition; PartitionSafety reports why. The store
+// holds the same triples, with the same sources, as after sequential
+// reasoning, and their order does not depend on workers.
+func WithPartitionParallel(workers int) Option {
+	return func(r *Reasoner) {
+		r.partitionWorkers = workers
+	}
+}
+
+// tboxPredicates are the predicates of TBox triples besides the
+// schemaPredicates; every partition receives these triples
+//
+//nolint:gochecknoglobals
+var tboxPredicates = map[string]bool{
+	OWLHasKey: true, OWLDisjointWith: true, OWLMembers: true, OWLDistinctMembers: true,
+	RDFFirst: true, RDFRest: true,
+}
+
+// isSchemaTriple reports whether a triple belongs to the TBox: it has a
+// schema or list predicate or declares a class, property or axiom with an RDF, RDFS
+// or OWL type other than owl:NamedIndividual and owl:Thing
+func isSchemaTriple(t Triple) bool {
+	if schemaPredicates[t.Predicate] || tboxPredicates[t.Predicate] {
+		return true
+	}
+	if t.Predicate != RDFType || t.Object == OWLNamedIndividual || t.Object == OWLThing {
+		return false
+	}
+	for _, prefix := range []string{"rdf", "rdfs", "owl"} {
+		if strings.HasPrefix(t.Object, standardPrefixes[prefix]) {
+			return true
+		}
+	}
+	return false
+}
+
+// PartitionSafety returns nil if the reasoner's rules can materialize the
+// ABox partition by partition with the store's current TBox, or an error
+// explaining why not
+func (r *Reasoner) PartitionSafety() error {
+	switch {
+	case r.scope != nil:
+		return fmt.Errorf("scoped reasoning is not partitioned")
+	case len(r.budgets) > 0:
+		return fmt.Errorf("rule budgets apply to the whole run and are not partitioned")
+	case r.graphReasoning == PerGraph:
+		return fmt.Errorf("reasoning per graph is not partitioned")
+	case len(r.store.equivalences) > 0:
+		return fmt.Errorf("term equivalences apply to the whole store and are not partitioned")
+	}
+	return partitionSafety(r.rules, r.store)
+}
+
+// partitionSafety checks that every rule joins at most one ABox triple.
+// Some rules only join several when the data uses the features they
+// implement.
+func partitionSafety(rules []Rule, store *TripleStore) error {
+	for _, rule := range rules {
+		switch rule.(type) {
+		case *SubClassTransitivity, *TypeInheritance, *DomainInference, *RangeInference,
+			*SubPropertyTransitivity, *SubPropertyInheritance, *EquivalentClassSymmetry,
+			*EquivalentClassTransitivity, *SameAsSymmetry, *InversePropertyInference,
+			*SymmetricPropertyInference, *AllDifferentExpansion, *AllDisjointClassesExpansion:
+		case *TransitivePropertyInference:
+			if len(store.FindByPredicateObject(RDFType, OWLTransitiveProperty)) > 0 {
+				return fmt.Errorf("rule %s joins ABox triples of the declared transitive properties", rule.Name())
+			}
+		case *SameAsTransitivity:
+			if len(store.FindByPredicate(OWLSameAs)) > 0 {
+				return fmt.Errorf("rule %s joins owl:sameAs triples", rule.Name())
+			}
+		case *HasKeyInference:
+			if len(store.FindByPredicate(OWLHasKey)) > 0 {
+				return fmt.Errorf("rule %s compares individuals of the classes with owl:hasKey", rule.Name())
+			}
+		default:
+			return fmt.Errorf("rule %s is not known to join at most one ABox triple", rule.Name())
+		}
+	}
+	return nil
+}
+
+// reasonPartitioned saturates the TBox, then materializes the ABox in
+// partitions by subject in parallel and merges the results in the order
+// of mergePartitions. It returns false without changing the store if
+// partitioning is not safe.
+func (r *Reasoner) reasonPartitioned(ctx context.Context) (int, bool) {
+	if r.PartitionSafety() != nil {
+		return 0, false
+	}
+
+	tbox := NewReasonerWithRules(r.rules, WithTracer(r.tracer))
+	var abox []Triple
+	for _, t := range r.store.All() {
+		if isSchemaTriple(t) {
+			tbox.store.add(t)
+		} else {
+			abox = append(abox, t)
+		}
+	}
+	if _, err := tbox.reason(ctx, nil); err != nil {
+		return 0, false
+	}
+	// Saturation may have introduced triples that make partitioning unsafe
+	if partitionSafety(r.rules, tbox.store) != nil {
+		return 0, false
+	}
+
+	partitions := make([][]Triple, r.partitionWorkers)
+	for _, t := range abox {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(t.Subject))
+		i := h.Sum32() % uint32(len(partitions))
+		partitions[i] = append(partitions[i], t)
+	}
+
+	results := make([]*TripleStore, len(partitions))
+	var wg sync.WaitGroup
+	for i, partition := range partitions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := NewReasonerWithRules(r.rules)
+			for _, t := range tbox.store.tripleList {
+				p.store.add(t)
+			}
+			for _, t := range partition {
+				p.store.add(t)
+			}
+			_, _ = p.reason(ctx, nil)
+			results[i] = p.store
+		}()
+	}
+	wg.Wait()
+
+	inferred := 0
+	schemaInferred := false
+	for _, t := range mergePartitions(tbox.store, results) {
+		if r.store.Add(t) {
+			inferred++
+			schemaInferred = schemaInferred || isSchemaTriple(t)
+		}
+	}
+
+	// A partition inferred a TBox triple, which the other partitions did not
+	// see; finish with a full run
+	if schemaInferred {
+		more, _ := r.reason(ctx, nil)
+		return inferred + more, true
+	}
+	r.runDiagnostics = nil
+	r.closed = r.store.Size()
+	return inferred, true
+}
+
+// mergePartitions returns the triples to add to the store after
+// partitioned reasoning, in an order that does not depend on the number of
+// partitions or on which finished first: the saturated TBox in the order
+// its triples were derived, followed by the triples of all partitions
+// sorted by subject, predicate and object. Adding them in this order gives
+// the same store, and the same order of triples, on every machine. Only
+// asserted triples have sources, and they are already in the store, so the
+// provenance of a triple does not depend on the partitioning either.
+func mergePartitions(tbox *TripleStore, partitions []*TripleStore) []Triple {
+	merged := append([]Triple(nil), tbox.tripleList...)
+	seen := make(map[string]bool)
+	var abox []Triple
+	for _, p := range partitions {
+		for _, t := range p.tripleList {
+			key := tripleKey(t)
+			if !seen[key] && !tbox.triples[key] {
+				seen[key] = true
+				abox = append(abox, t)
+			}
+		}
+	}
+	sort.Slice(abox, func(i, j int) bool {
+		a, b := abox[i], abox[j]
+		if a.Subject != b.Subject {
+			return a.Subject < b.Subject
+		}
+		if a.Predicate != b.Predicate {
+			return a.Predicate < b.Predicate
+		}
+		return a.Object < b.Object
+	})
+	return append(merged, abox...)
+}
@@ -0,0 +1,158 @@
+package reasoner
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const partitionSchema = `@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Town rdfs:subClassOf ex:Municipality .
+ex:Municipality rdfs:subClassOf ex:Place .
+ex:inCanton rdfs:domain ex:Municipality ; rdfs:range ex:Canton .
+ex:inCanton rdfs:subPropertyOf ex:locatedIn .
+ex:contains owl:inverseOf ex:locatedIn .
+`
+
+// partitionData returns n towns in a few cantons
+func partitionData(n int) string {
+	var sb strings.Builder
+	sb.WriteString("@prefix ex: <http://example.org/> .\n")
+	for i := range n {
+		fmt.Fprintf(&sb, "ex:town%d a ex:Town ; ex:inCanton ex:canton%d .\n", i, i%5)
+	}
+	return sb.String()
+}
+
+func TestPartitionParallel(t *testing.T) {
+	closure := func(opts ...Option) []string {
+		r := NewReasoner(opts...)
+		for _, doc := range []string{partitionSchema, partitionData(50)} {
+			if err := r.LoadTurtle(doc); err != nil {
+				t.Fatalf("LoadTurtle failed: %v", err)
+			}
+		}
+		if err := r.PartitionSafety(); err != nil {
+			t.Fatalf("Expected partitioning to be safe, got %v", err)
+		}
+		r.RunForwardReasoning()
+		return r.GetAllTriples()
+	}
+
+	sequential := closure()
+	parallel := closure(WithPartitionParallel(4))
+	if !reflect.DeepEqual(parallel, sequential) {
+		t.Errorf("Partitioned closure has %d triples, want %d", len(parallel), len(sequential))
+	}
+}
+
+func TestPartitionParallelDeterministic(t *testing.T) {
+	run := func(opts ...Option) *Reasoner {
+		r := NewReasoner(append(opts, WithProvenance())...)
+		if err := r.LoadTurtleFrom("schema.ttl", partitionSchema); err != nil {
+			t.Fatalf("LoadTurtleFrom failed: %v", err)
+		}
+		if err := r.LoadTurtleFrom("towns.ttl", partitionData(40)); err != nil {
+			t.Fatalf("LoadTurtleFrom failed: %v", err)
+		}
+		// Asserted again in a second document, and also inferred
+		if err := r.LoadTurtleFrom("extra.ttl", "@prefix ex: <http://example.org/> .\nex:town1 a ex:Place .\n"); err != nil {
+			t.Fatalf("LoadTurtleFrom failed: %v", err)
+		}
+		r.RunForwardReasoning()
+		return r
+	}
+
+	sequential := run()
+	reference := run(WithPartitionParallel(2)).GetStore().All()
+	for _, workers := range []int{2, 3, 7, 16} {
+		parallel := run(WithPartitionParallel(workers))
+		if got := parallel.GetStore().All(); !reflect.DeepEqual(got, reference) {
+			t.Errorf("Store order with %d workers differs from the order with 2", workers)
+		}
+		if !reflect.DeepEqual(parallel.GetAllTriples(), sequential.GetAllTriples()) {
+			t.Errorf("Closure with %d workers differs from the sequential closure", workers)
+		}
+		for _, tr := range sequential.GetStore().All() {
+			if got, want := parallel.GetStore().SourceOf(tr), sequential.GetStore().SourceOf(tr); !reflect.DeepEqual(got, want) {
+				t.Errorf("SourceOf(%v) with %d workers = %v, want %v", tr, workers, got, want)
+			}
+		}
+	}
+}
+
+func TestPartitionParallelIncremental(t *testing.T) {
+	r := NewReasoner(WithPartitionParallel(3))
+	if err := r.LoadTurtle(partitionSchema); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+	if err := r.LoadTurtle(partitionData(10)); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	if len(r.Query("http://example.org/canton2", "http://example.org/contains", "http://example.org/town7")) != 1 {
+		t.Error("Expected the inverse of ex:locatedIn to be inferred across partitions")
+	}
+	if got := r.GetInferredTypes("http://example.org/town3"); len(got) != 3 {
+		t.Errorf("Expected 3 types for ex:town3, got %v", got)
+	}
+}
+
+func TestPartitionSafety(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		rules []Rule
+		want  string
+	}{
+		{
+			name: "transitive property",
+			data: `@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:partOf a owl:TransitiveProperty .`,
+			want: "transitive properties",
+		},
+		{
+			name: "sameAs",
+			data: `@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:a owl:sameAs ex:b .`,
+			want: "owl:sameAs",
+		},
+		{
+			name:  "custom rule",
+			rules: []Rule{&SubClassTransitivity{}, &testRule{}},
+			want:  "not known",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			if rules == nil {
+				rules = DefaultRules()
+			}
+			r := NewReasonerWithRules(rules, WithPartitionParallel(2))
+			if err := r.LoadTurtle(tt.data); err != nil {
+				t.Fatalf("LoadTurtle failed: %v", err)
+			}
+			err := r.PartitionSafety()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Expected an error containing %q, got %v", tt.want, err)
+			}
+			// Reasoning falls back to a single partition
+			r.RunForwardReasoning()
+		})
+	}
+}
+
+// testRule is a rule the partitioning does not know
+type testRule struct{}
+
+func (r *testRule) Name() string { return "test-rule" }
+
+func (r *testRule) Apply(*TripleStore) []Triple { return nil }
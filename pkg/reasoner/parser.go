@@ -1,8 +1,10 @@
 package reasoner
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -13,6 +15,56 @@ type TurtleParser struct {
 	base     string
 	input    string
 	pos      int
+
+	// lines holds the offsets of the newlines of the input
+	lines []int
+
+	// progress, if set, is called with the bytes consumed and triples parsed
+	progress func(done, total, triples int)
+
+	// errors records statements skipped during the last Parse
+	errors []ParseError
+
+	// warnings records suspicious but loaded statements of the last Parse
+	warnings []ParseError
+
+	// options limits the size of parsed documents
+	options ParserOptions
+
+	// notes records problems of the last Parse that are only reported by
+	// ValidateRDF
+	notes []Diagnostic
+
+	// trig accepts graph blocks, as in TriG; graph labels the triples of
+	// the open block, which starts at graphStart, or -1 outside blocks
+	trig       bool
+	graph      string
+	graphStart int
+
+	// n3 accepts Notation3 rules; inFormula is set while the triples of a
+	// formula are parsed, and rules holds the rules of the last Parse
+	n3        bool
+	inFormula bool
+	rules     []*TripleRule
+
+	// nested collects the triples of the blank node property lists and
+	// collections of the statement being parsed, depth is their current
+	// nesting, and blankNodes numbers their blank nodes, labelled with
+	// blankLabel
+	nested     []Triple
+	depth      int
+	blankNodes int
+	blankLabel string
+}
+
+// ParseError describes a statement that was skipped because it could not be parsed
+type ParseError struct {
+	Line    int    // 1-based line where the statement starts
+	Message string // What went wrong
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
 }
 
 // NewTurtleParser creates a new Turtle parser
@@ -30,6 +82,19 @@ func (p *TurtleParser) Parse(content string) ([]Triple, error) {
 	p.base = ""
 	p.input = content
 	p.pos = 0
+	p.errors = nil
+	p.warnings = nil
+	p.notes = nil
+	p.graph = ""
+	p.graphStart = -1
+	p.inFormula = false
+	p.rules = nil
+	p.blankNodes = 0
+	p.blankLabel = hashString(content)[:8]
+
+	if limit := p.options.MaxInputBytes; limit > 0 && len(content) > limit {
+		return nil, limitError("document is %d bytes, the limit is %d", len(content), limit)
+	}
 
 	var triples []Triple
 
@@ -37,7 +102,20 @@ func (p *TurtleParser) Parse(content string) ([]Triple, error) {
 	p.input = strings.TrimPrefix(p.input, "\ufeff")
 	p.input = strings.ReplaceAll(p.input, "\r\n", "\n")
 
+	p.lines = nil
+	for i := 0; i < len(p.input); i++ {
+		if p.input[i] == '\n' {
+			p.lines = append(p.lines, i)
+		}
+	}
+
+	nextReport := progressInterval
 	for p.pos < len(p.input) {
+		if p.progress != nil && p.pos >= nextReport {
+			p.progress(p.pos, len(p.input), len(triples))
+			nextReport = p.pos + progressInterval
+		}
+
 		p.skipWhitespaceAndComments()
 		if p.pos >= len(p.input) {
 			break
@@ -60,18 +138,112 @@ func (p *TurtleParser) Parse(content string) ([]Triple, error) {
 		}
 
 		// Parse triple(s)
+		start := p.pos
+		if p.trig {
+			ok, err := p.parseGraphBoundary()
+			if err != nil {
+				p.errors = append(p.errors, ParseError{Line: p.lineAt(start), Message: err.Error()})
+				if !ok {
+					p.skipToNextStatement()
+				}
+				continue
+			}
+			if ok {
+				continue
+			}
+		}
+		if p.n3 {
+			ok, err := p.parseN3Statement()
+			if err != nil {
+				p.errors = append(p.errors, ParseError{Line: p.lineAt(start), Message: err.Error()})
+				if !ok {
+					p.skipToNextStatement()
+				}
+				continue
+			}
+			if ok {
+				continue
+			}
+		}
 		newTriples, err := p.parseTriples()
+		if errors.Is(err, ErrParserLimit) {
+			return nil, fmt.Errorf("line %d: %w", p.lineAt(start), err)
+		}
 		if err != nil {
+			p.errors = append(p.errors, ParseError{Line: p.lineAt(start), Message: err.Error()})
 			// Try to skip to next statement on error
 			p.skipToNextStatement()
 			continue
 		}
+		for i, t := range newTriples {
+			newTriples[i].Graph = p.graph
+			if err := validateTemporalLiteral(t.Object); err != nil {
+				p.warnings = append(p.warnings, ParseError{Line: p.lineAt(start), Message: err.Error()})
+			}
+			if err := validateCustomLiteral(t.Object); err != nil {
+				p.warnings = append(p.warnings, ParseError{Line: p.lineAt(start), Message: err.Error()})
+			}
+			p.checkDatatype(t.Object, start)
+		}
 		triples = append(triples, newTriples...)
+		if limit := p.options.MaxTriples; limit > 0 && len(triples) > limit {
+			return nil, limitError("more than %d triples", limit)
+		}
+	}
+
+	if p.graphStart >= 0 {
+		p.errors = append(p.errors, ParseError{Line: p.lineAt(p.graphStart), Message: "graph block is not closed with '}'"})
+	}
+
+	if p.progress != nil {
+		p.progress(len(p.input), len(p.input), len(triples))
 	}
 
 	return triples, nil
 }
 
+// Errors returns the statements skipped during the last call to Parse
+func (p *TurtleParser) Errors() []ParseError {
+	return p.errors
+}
+
+// Warnings returns problems found in statements that were loaded anyway
+// during the last call to Parse, such as malformed date literals
+func (p *TurtleParser) Warnings() []ParseError {
+	return p.warnings
+}
+
+// Prefixes returns the prefixes declared in the last parsed document
+func (p *TurtleParser) Prefixes() map[string]string {
+	prefixes := make(map[string]string, len(p.prefixes))
+	for prefix, iri := range p.prefixes {
+		prefixes[prefix] = iri
+	}
+	return prefixes
+}
+
+// lineAt returns the 1-based line number of a position in the input
+func (p *TurtleParser) lineAt(pos int) int {
+	return sort.SearchInts(p.lines, pos) + 1
+}
+
+// unsupportedConstruct names the Turtle construct at the current position
+// if the parser cannot handle it, or returns ""
+func (p *TurtleParser) unsupportedConstruct() string {
+	if p.pos >= len(p.input) {
+		return ""
+	}
+	switch ch := p.input[p.pos]; {
+	case p.n3 && ch == '{':
+		return "formula '{ }' as a term"
+	case p.lookingAt("<<"):
+		return "quoted triple '<< >>'"
+	case ch == '\'':
+		return "single-quoted literal"
+	}
+	return ""
+}
+
 func (p *TurtleParser) skipWhitespaceAndComments() {
 	for p.pos < len(p.input) {
 		ch := p.input[p.pos]
@@ -135,7 +307,7 @@ func (p *TurtleParser) parsePrefix() error {
 
 	// Store prefix (without the colon)
 	prefix := strings.TrimSuffix(prefixName, ":")
-	p.prefixes[prefix] = iri
+	p.prefixes[prefix] = p.resolveIRI(iri)
 
 	return nil
 }
@@ -211,23 +383,42 @@ func (p *TurtleParser) parseIRI() (string, error) {
 }
 
 func (p *TurtleParser) parseTriples() ([]Triple, error) {
-	var triples []Triple
+	p.nested = nil
+	p.depth = 0
 
 	// Parse subject
 	subject, err := p.parseSubject()
 	if err != nil {
 		return nil, err
 	}
+	if err := p.checkN3Path(); err != nil {
+		return nil, err
+	}
+
+	triples, err := p.parsePredicateObjectList(subject, false)
+	if err != nil {
+		return nil, err
+	}
+	return append(triples, p.nested...), nil
+}
+
+// parsePredicateObjectList parses the predicates and objects of a subject,
+// up to the '.' ending the statement, or up to the ']' ending a blank node
+// property list if inList is set, which is left to the caller
+func (p *TurtleParser) parsePredicateObjectList(subject string, inList bool) ([]Triple, error) {
+	var triples []Triple
 
-	// Parse predicate-object list
 	for {
 		p.skipWhitespaceAndComments()
 		if p.pos >= len(p.input) {
 			break
 		}
 
-		// Check for end of statement
-		if p.input[p.pos] == '.' {
+		// Check for end of statement or property list
+		if inList && p.input[p.pos] == ']' {
+			break
+		}
+		if !inList && p.input[p.pos] == '.' {
 			p.pos++
 			break
 		}
@@ -246,6 +437,9 @@ func (p *TurtleParser) parseTriples() ([]Triple, error) {
 			if err != nil {
 				return nil, err
 			}
+			if err := p.checkN3Path(); err != nil {
+				return nil, err
+			}
 
 			triples = append(triples, Triple{
 				Subject:   subject,
@@ -276,14 +470,21 @@ func (p *TurtleParser) parseTriples() ([]Triple, error) {
 			p.pos++
 			p.skipWhitespaceAndComments()
 			// Check if followed by '.' (empty predicate-object after semicolon)
-			if p.pos < len(p.input) && p.input[p.pos] == '.' {
+			if !inList && p.pos < len(p.input) && p.input[p.pos] == '.' {
 				p.pos++
 				break
 			}
+			// or by the end of a property list, graph block or formula
+			if inList && p.pos < len(p.input) && p.input[p.pos] == ']' {
+				break
+			}
+			if (p.trig || p.inFormula) && p.pos < len(p.input) && p.input[p.pos] == '}' {
+				break
+			}
 			continue
 		}
 
-		if p.input[p.pos] == '.' {
+		if !inList && p.input[p.pos] == '.' {
 			p.pos++
 			break
 		}
@@ -294,6 +495,89 @@ func (p *TurtleParser) parseTriples() ([]Triple, error) {
 	return triples, nil
 }
 
+// parseBlankNodePropertyList parses a blank node property list such as
+// [ a ex:Person ; ex:name "Bob" ], returning a fresh blank node and adding
+// its triples to the nested triples of the statement
+func (p *TurtleParser) parseBlankNodePropertyList() (string, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if err := p.checkDepth(); err != nil {
+		return "", err
+	}
+
+	p.pos++ // skip '['
+	node := p.newBlankNode()
+	triples, err := p.parsePredicateObjectList(node, true)
+	if err != nil {
+		return "", err
+	}
+	p.skipWhitespaceAndComments()
+	if p.pos >= len(p.input) || p.input[p.pos] != ']' {
+		return "", fmt.Errorf("blank node property list is not closed with ']'")
+	}
+	p.pos++
+	p.nested = append(p.nested, triples...)
+	return node, nil
+}
+
+// parseCollection parses a collection such as ( ex:a ex:b ), returning the
+// head of an rdf:List of its items, or rdf:nil if it is empty, and adding
+// the triples of the list to the nested triples of the statement
+func (p *TurtleParser) parseCollection() (string, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if err := p.checkDepth(); err != nil {
+		return "", err
+	}
+
+	p.pos++ // skip '('
+	head, previous := RDFNil, ""
+	var triples []Triple
+	for {
+		p.skipWhitespaceAndComments()
+		if p.pos >= len(p.input) || p.input[p.pos] == '.' && !p.lookingAtNumber() {
+			return "", fmt.Errorf("collection is not closed with ')'")
+		}
+		if p.input[p.pos] == ')' {
+			p.pos++
+			break
+		}
+
+		node := p.newBlankNode()
+		item, err := p.parseObject()
+		if err != nil {
+			return "", err
+		}
+		if previous == "" {
+			head = node
+		} else {
+			triples = append(triples, Triple{Subject: previous, Predicate: RDFRest, Object: node})
+		}
+		triples = append(triples, Triple{Subject: node, Predicate: RDFFirst, Object: item})
+		previous = node
+	}
+	if previous != "" {
+		triples = append(triples, Triple{Subject: previous, Predicate: RDFRest, Object: RDFNil})
+	}
+	p.nested = append(p.nested, triples...)
+	return head, nil
+}
+
+// checkDepth fails if blank node property lists and collections are nested
+// deeper than MaxDepth
+func (p *TurtleParser) checkDepth() error {
+	if limit := p.options.MaxDepth; limit > 0 && p.depth > limit {
+		return limitError("blank node property lists and collections nested more than %d deep", limit)
+	}
+	return nil
+}
+
+// newBlankNode returns a blank node label unique within the document
+func (p *TurtleParser) newBlankNode() string {
+	p.blankNodes++
+	return fmt.Sprintf("_:t%s_%d", p.blankLabel, p.blankNodes)
+}
+
 func (p *TurtleParser) parseSubject() (string, error) {
 	p.skipWhitespaceAndComments()
 
@@ -301,6 +585,15 @@ func (p *TurtleParser) parseSubject() (string, error) {
 		return "", fmt.Errorf("unexpected end of input")
 	}
 
+	if construct := p.unsupportedConstruct(); construct != "" {
+		return "", fmt.Errorf("unsupported %s", construct)
+	}
+
+	// Variable
+	if p.n3 && p.input[p.pos] == '?' {
+		return p.parseVariable()
+	}
+
 	// IRI
 	if p.input[p.pos] == '<' {
 		iri, err := p.parseIRI()
@@ -314,6 +607,12 @@ func (p *TurtleParser) parseSubject() (string, error) {
 	if p.lookingAt("_:") {
 		return p.parseBlankNode()
 	}
+	if p.input[p.pos] == '[' {
+		return p.parseBlankNodePropertyList()
+	}
+	if p.input[p.pos] == '(' {
+		return p.parseCollection()
+	}
 
 	// Prefixed name
 	return p.parsePrefixedName()
@@ -326,6 +625,12 @@ func (p *TurtleParser) parsePredicate() (string, error) {
 		return "", fmt.Errorf("unexpected end of input")
 	}
 
+	if p.n3 {
+		if predicate, ok, err := p.parseN3Predicate(); ok {
+			return predicate, err
+		}
+	}
+
 	// 'a' keyword for rdf:type
 	if p.pos+1 <= len(p.input) && p.input[p.pos] == 'a' {
 		// Check it's standalone 'a' not part of another token
@@ -355,6 +660,15 @@ func (p *TurtleParser) parseObject() (string, error) {
 		return "", fmt.Errorf("unexpected end of input")
 	}
 
+	if construct := p.unsupportedConstruct(); construct != "" {
+		return "", fmt.Errorf("unsupported %s", construct)
+	}
+
+	// Variable
+	if p.n3 && p.input[p.pos] == '?' {
+		return p.parseVariable()
+	}
+
 	// IRI
 	if p.input[p.pos] == '<' {
 		iri, err := p.parseIRI()
@@ -368,11 +682,24 @@ func (p *TurtleParser) parseObject() (string, error) {
 	if p.lookingAt("_:") {
 		return p.parseBlankNode()
 	}
+	if p.input[p.pos] == '[' {
+		return p.parseBlankNodePropertyList()
+	}
+	if p.input[p.pos] == '(' {
+		return p.parseCollection()
+	}
 
 	// Literal
 	if p.input[p.pos] == '"' {
 		return p.parseLiteral()
 	}
+	if p.lookingAtNumber() {
+		return p.parseNumericLiteral()
+	}
+	if value := p.lookingAtBoolean(); value != "" {
+		p.pos += len(value)
+		return NewLiteral(value, XSDBoolean).String(), nil
+	}
 
 	// Prefixed name
 	return p.parsePrefixedName()
@@ -424,49 +751,58 @@ func (p *TurtleParser) parsePrefixedName() (string, error) {
 		return base + local, nil
 	}
 
+	p.note(p.lineAt(start), CodeUndefinedPrefix, "prefix %q is not declared", prefix)
 	return prefix + ":" + local, nil
 }
 
 func (p *TurtleParser) parseLiteral() (string, error) {
 	var sb strings.Builder
 
-	// Check for triple-quoted string
+	// Read the lexical form, keeping escape sequences for unescapeLiteral
+	var raw strings.Builder
 	if p.lookingAt(`"""`) {
+		// Triple-quoted string
 		p.pos += 3
-		sb.WriteString(`"`)
-
-		for p.pos < len(p.input) {
-			if p.lookingAt(`"""`) {
-				p.pos += 3
-				sb.WriteString(`"`)
-				break
+		for p.pos < len(p.input) && !p.lookingAt(`"""`) {
+			if err := p.checkLiteralLength(raw.Len()); err != nil {
+				return "", err
 			}
-			sb.WriteByte(p.input[p.pos])
+			if p.input[p.pos] == '\\' && p.pos+1 < len(p.input) {
+				raw.WriteString(p.input[p.pos : p.pos+2])
+				p.pos += 2
+				continue
+			}
+			raw.WriteByte(p.input[p.pos])
 			p.pos++
 		}
+		if p.pos < len(p.input) {
+			p.pos += 3 // skip closing quotes
+		}
 	} else {
 		// Single-quoted string
 		p.pos++ // skip opening quote
-		sb.WriteString(`"`)
-
 		for p.pos < len(p.input) && p.input[p.pos] != '"' {
+			if err := p.checkLiteralLength(raw.Len()); err != nil {
+				return "", err
+			}
 			if p.input[p.pos] == '\\' && p.pos+1 < len(p.input) {
-				sb.WriteByte(p.input[p.pos])
-				p.pos++
-				sb.WriteByte(p.input[p.pos])
-				p.pos++
+				raw.WriteString(p.input[p.pos : p.pos+2])
+				p.pos += 2
 				continue
 			}
-			sb.WriteByte(p.input[p.pos])
+			raw.WriteByte(p.input[p.pos])
 			p.pos++
 		}
-
 		if p.pos < len(p.input) {
 			p.pos++ // skip closing quote
 		}
-		sb.WriteString(`"`)
 	}
 
+	// Store every literal with the same, N-Triples style escaping
+	sb.WriteString(`"`)
+	sb.WriteString(escapeLiteral(unescapeLiteral(raw.String())))
+	sb.WriteString(`"`)
+
 	// Check for language tag or datatype
 	if p.pos < len(p.input) && p.input[p.pos] == '@' {
 		// Language tag
@@ -499,15 +835,96 @@ func (p *TurtleParser) parseLiteral() (string, error) {
 	return sb.String(), nil
 }
 
+// lookingAtNumber reports whether an unquoted numeric literal starts at the
+// current position
+func (p *TurtleParser) lookingAtNumber() bool {
+	i := p.pos
+	if i < len(p.input) && (p.input[i] == '+' || p.input[i] == '-') {
+		i++
+	}
+	if i < len(p.input) && p.input[i] == '.' {
+		i++
+	}
+	return i < len(p.input) && isDigit(p.input[i])
+}
+
+// parseNumericLiteral parses an unquoted integer, decimal or double, such
+// as 42, -3.14 or 1.0e6, as a literal of that datatype
+func (p *TurtleParser) parseNumericLiteral() (string, error) {
+	start := p.pos
+	digits := func() int {
+		n := 0
+		for ; p.pos < len(p.input) && isDigit(p.input[p.pos]); n++ {
+			p.pos++
+		}
+		return n
+	}
+
+	if p.input[p.pos] == '+' || p.input[p.pos] == '-' {
+		p.pos++
+	}
+	integer := digits()
+	datatype := XSDInteger
+	// A '.' is the end of the statement unless a fraction or exponent follows
+	if p.pos+1 < len(p.input) && p.input[p.pos] == '.' &&
+		(isDigit(p.input[p.pos+1]) || integer > 0 && (p.input[p.pos+1] == 'e' || p.input[p.pos+1] == 'E')) {
+		p.pos++
+		digits()
+		datatype = XSDDecimal
+	}
+	if p.pos < len(p.input) && (p.input[p.pos] == 'e' || p.input[p.pos] == 'E') {
+		p.pos++
+		if p.pos < len(p.input) && (p.input[p.pos] == '+' || p.input[p.pos] == '-') {
+			p.pos++
+		}
+		if digits() == 0 {
+			return "", fmt.Errorf("expected digits in the exponent of %s", p.input[start:p.pos])
+		}
+		datatype = XSDDouble
+	}
+	return NewLiteral(p.input[start:p.pos], datatype).String(), nil
+}
+
+// lookingAtBoolean returns "true" or "false" if an unquoted boolean literal
+// starts at the current position, or ""
+func (p *TurtleParser) lookingAtBoolean() string {
+	for _, value := range []string{"true", "false"} {
+		end := p.pos + len(value)
+		if p.lookingAt(value) && (end >= len(p.input) || p.input[end] == '.' || !isNameChar(rune(p.input[end])) && p.input[end] != ':') {
+			return value
+		}
+	}
+	return ""
+}
+
+// checkLiteralLength fails once a literal being read exceeds MaxLiteralLength
+func (p *TurtleParser) checkLiteralLength(length int) error {
+	if limit := p.options.MaxLiteralLength; limit > 0 && length > limit {
+		return limitError("literal longer than %d bytes", limit)
+	}
+	return nil
+}
+
 func (p *TurtleParser) resolveIRI(iri string) string {
 	if p.base != "" && !strings.Contains(iri, "://") && !strings.HasPrefix(iri, "#") {
 		return p.base + iri
 	}
+	if p.base == "" && isRelativeIRI(iri) {
+		p.note(p.lineAt(p.pos), CodeRelativeIRI, "relative IRI <%s> without a base", iri)
+	}
 	return iri
 }
 
 func (p *TurtleParser) skipToNextStatement() {
+	if p.n3 {
+		p.skipToNextN3Statement()
+		return
+	}
 	for p.pos < len(p.input) && p.input[p.pos] != '.' {
+		// The end of a graph block also ends its last statement
+		if p.trig && p.input[p.pos] == '}' && p.graphStart >= 0 {
+			return
+		}
 		p.pos++
 	}
 	if p.pos < len(p.input) {
@@ -519,6 +936,10 @@ func isNameChar(r rune) bool {
 	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.'
 }
 
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
 func isAlphaNum(r rune) bool {
 	return unicode.IsLetter(r) || unicode.IsDigit(r)
 }
@@ -0,0 +1,45 @@
+package reasoner
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrParserLimit is returned, wrapped, when a document exceeds a limit set
+// in ParserOptions
+var ErrParserLimit = errors.New("parser limit exceeded")
+
+// ParserOptions limits the resources a single document may consume, to
+// protect services that parse untrusted input. Zero values mean no limit.
+type ParserOptions struct {
+	// MaxInputBytes limits the size of the document
+	MaxInputBytes int
+	// MaxTriples limits the number of triples produced
+	MaxTriples int
+	// MaxLiteralLength limits the length of a literal's lexical form in bytes
+	MaxLiteralLength int
+	// MaxDepth limits how deeply blank node property lists '[ ]' and
+	// collections '( )' may be nested
+	MaxDepth int
+}
+
+// NewTurtleParserWithOptions creates a Turtle parser that enforces limits.
+// Parse fails with an error wrapping ErrParserLimit as soon as a limit is
+// exceeded, rather than skipping the offending statement.
+func NewTurtleParserWithOptions(opts ParserOptions) *TurtleParser {
+	p := NewTurtleParser()
+	p.options = opts
+	return p
+}
+
+// WithParserOptions makes the reasoner parse documents with limits
+func WithParserOptions(opts ParserOptions) Option {
+	return func(r *Reasoner) {
+		r.parser.options = opts
+	}
+}
+
+// limitError reports that a limit was exceeded
+func limitError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrParserLimit, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,83 @@
+package reasoner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParserOptionsLimits(t *testing.T) {
+	content := `@prefix ex: <http://example.org/> .
+ex:a ex:p ex:b .
+ex:b ex:p ex:c .
+ex:c ex:label "a fairly long label" .
+`
+
+	tests := []struct {
+		name      string
+		opts      ParserOptions
+		wantLimit bool
+	}{
+		{"no limits", ParserOptions{}, false},
+		{"generous limits", ParserOptions{MaxInputBytes: 1000, MaxTriples: 3, MaxLiteralLength: 100}, false},
+		{"input too large", ParserOptions{MaxInputBytes: 10}, true},
+		{"too many triples", ParserOptions{MaxTriples: 2}, true},
+		{"literal too long", ParserOptions{MaxLiteralLength: 5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			triples, err := NewTurtleParserWithOptions(tt.opts).Parse(content)
+			if tt.wantLimit {
+				if !errors.Is(err, ErrParserLimit) {
+					t.Fatalf("expected ErrParserLimit, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(triples) != 3 {
+				t.Errorf("expected 3 triples, got %d", len(triples))
+			}
+		})
+	}
+}
+
+func TestParserOptionsLongTripleQuotedLiteral(t *testing.T) {
+	content := `<http://example.org/a> <http://example.org/p> """` + strings.Repeat("x", 64) + `""" .`
+
+	_, err := NewTurtleParserWithOptions(ParserOptions{MaxLiteralLength: 32}).Parse(content)
+	if !errors.Is(err, ErrParserLimit) {
+		t.Fatalf("expected ErrParserLimit, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "line 1:") {
+		t.Errorf("expected error to carry the line, got %q", err)
+	}
+}
+
+func TestParserOptionsMaxDepth(t *testing.T) {
+	content := `@prefix ex: <http://example.org/> .
+ex:a ex:p [ ex:q [ ex:r ex:b ] ] .
+`
+
+	if _, err := NewTurtleParserWithOptions(ParserOptions{MaxDepth: 2}).Parse(content); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := NewTurtleParserWithOptions(ParserOptions{MaxDepth: 1}).Parse(content)
+	if !errors.Is(err, ErrParserLimit) || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Fatalf("expected ErrParserLimit on line 2, got %v", err)
+	}
+}
+
+func TestWithParserOptions(t *testing.T) {
+	r := NewReasoner(WithParserOptions(ParserOptions{MaxTriples: 1}))
+
+	err := r.LoadTurtle(`@prefix ex: <http://example.org/> .
+ex:a ex:p ex:b .
+ex:b ex:p ex:c .
+`)
+	if !errors.Is(err, ErrParserLimit) {
+		t.Fatalf("expected ErrParserLimit, got %v", err)
+	}
+}
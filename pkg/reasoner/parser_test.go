@@ -0,0 +1,207 @@
+package reasoner
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParserErrors(t *testing.T) {
+	p := NewTurtleParser()
+	triples, err := p.Parse(`@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:alice foaf:name "Alice" .
+ex:alice foaf:age '42' .
+ex:alice foaf:knows [ foaf:name "Bob" ] .
+ex:list ex:items ( ex:a ex:b ) .
+ex:alice ex:active << ex:a ex:b ex:c >> .
+ex:bob foaf:name "Bob" .
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(triples) != 9 {
+		t.Errorf("Expected 9 triples, got %d: %v", len(triples), triples)
+	}
+
+	expected := []struct {
+		line      int
+		construct string
+	}{
+		{4, "single-quoted literal"},
+		{7, "quoted triple"},
+	}
+	errs := p.Errors()
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %v", len(expected), errs)
+	}
+	for i, tt := range expected {
+		if errs[i].Line != tt.line || !strings.Contains(errs[i].Message, tt.construct) {
+			t.Errorf("Error %d = %v, expected line %d mentioning %q", i, errs[i], tt.line, tt.construct)
+		}
+	}
+
+	prefixes := p.Prefixes()
+	if len(prefixes) != 2 || prefixes["foaf"] != "http://xmlns.com/foaf/0.1/" {
+		t.Errorf("Unexpected prefixes: %v", prefixes)
+	}
+}
+
+func TestParserBlankNodePropertyLists(t *testing.T) {
+	p := NewTurtleParser()
+	triples, err := p.Parse(`@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Parent owl:equivalentClass [ a owl:Restriction ;
+    owl:onProperty ex:hasChild ;
+    owl:someValuesFrom [ a owl:Class ; ] ] .
+[ ex:name "Bob" ] ex:knows [], ex:alice .
+[ ex:name "Eve" ] .
+ex:carol ex:knows [ ex:name "Bob" ] ; ex:name "Carol" .
+ex:dave ex:knows [ ex:name "Dan" .
+ex:erin ex:name "Erin" .
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Blank nodes are numbered in the order their lists start
+	ex, owl := "http://example.org/", "http://www.w3.org/2002/07/owl#"
+	b := func(n int) string {
+		return fmt.Sprintf("_:t%s_%d", p.blankLabel, n)
+	}
+	expected := []Triple{
+		{Subject: ex + "Parent", Predicate: OWLEquivalentClass, Object: b(1)},
+		{Subject: b(2), Predicate: RDFType, Object: owl + "Class"},
+		{Subject: b(1), Predicate: RDFType, Object: owl + "Restriction"},
+		{Subject: b(1), Predicate: owl + "onProperty", Object: ex + "hasChild"},
+		{Subject: b(1), Predicate: owl + "someValuesFrom", Object: b(2)},
+		{Subject: b(3), Predicate: ex + "knows", Object: b(4)},
+		{Subject: b(3), Predicate: ex + "knows", Object: ex + "alice"},
+		{Subject: b(3), Predicate: ex + "name", Object: `"Bob"`},
+		{Subject: b(5), Predicate: ex + "name", Object: `"Eve"`},
+		{Subject: ex + "carol", Predicate: ex + "knows", Object: b(6)},
+		{Subject: ex + "carol", Predicate: ex + "name", Object: `"Carol"`},
+		{Subject: b(6), Predicate: ex + "name", Object: `"Bob"`},
+		{Subject: ex + "erin", Predicate: ex + "name", Object: `"Erin"`},
+	}
+	if len(triples) != len(expected) {
+		t.Fatalf("Expected %d triples, got %v", len(expected), triples)
+	}
+	for i, want := range expected {
+		if triples[i] != want {
+			t.Errorf("Triple %d = %v, want %v", i, triples[i], want)
+		}
+	}
+
+	errs := p.Errors()
+	if len(errs) != 1 || errs[0].Line != 9 {
+		t.Errorf("Expected an error for the unclosed list on line 9, got %v", errs)
+	}
+
+	// Labels differ between documents, so that they can be loaded together
+	other := NewTurtleParser()
+	if again, _ := other.Parse("[ <http://example.org/p> <http://example.org/o> ] ."); again[0].Subject == b(1) {
+		t.Errorf("Expected a different blank node than %s in another document", b(1))
+	}
+}
+
+func TestParserCollections(t *testing.T) {
+	p := NewTurtleParser()
+	triples, err := p.Parse(`@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Person owl:equivalentClass [ owl:unionOf ( ex:Adult ex:Child ) ] .
+ex:empty ex:items () .
+( "a" ( ex:b ) [ ex:name "c" ] ) ex:size "3" .
+ex:open ex:items ( ex:a .
+ex:erin ex:name "Erin" .
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	store := NewTripleStore()
+	for _, triple := range triples {
+		store.Add(triple)
+	}
+
+	ex := "http://example.org/"
+	union := store.FindBySubjectPredicate(store.FindBySubjectPredicate(ex+"Person", OWLEquivalentClass)[0].Object, "http://www.w3.org/2002/07/owl#unionOf")
+	if members, err := store.ListMembers(union[0].Object); err != nil || !equalStrings(members, []string{ex + "Adult", ex + "Child"}) {
+		t.Errorf("ListMembers(unionOf) = %v, %v", members, err)
+	}
+	if !store.Contains(Triple{Subject: ex + "empty", Predicate: ex + "items", Object: RDFNil}) {
+		t.Errorf("Expected () to be rdf:nil")
+	}
+
+	subject := store.FindByPredicateObject(ex+"size", `"3"`)[0].Subject
+	members, err := store.ListMembers(subject)
+	if err != nil || len(members) != 3 || members[0] != `"a"` {
+		t.Fatalf("ListMembers(subject) = %v, %v", members, err)
+	}
+	if nested, err := store.ListMembers(members[1]); err != nil || !equalStrings(nested, []string{ex + "b"}) {
+		t.Errorf("ListMembers(nested) = %v, %v", nested, err)
+	}
+	if !store.Contains(Triple{Subject: members[2], Predicate: ex + "name", Object: `"c"`}) {
+		t.Errorf("Expected the property list item %s to be described", members[2])
+	}
+
+	errs := p.Errors()
+	if len(errs) != 1 || errs[0].Line != 6 || !store.Contains(Triple{Subject: ex + "erin", Predicate: ex + "name", Object: `"Erin"`}) {
+		t.Errorf("Expected an error for the unclosed collection on line 6, got %v", errs)
+	}
+}
+
+func TestParserLiteralShorthand(t *testing.T) {
+	p := NewTurtleParser()
+	triples, err := p.Parse(`@prefix ex: <http://example.org/> .
+ex:a ex:p 42, -7, +0012, 3.14, -.5, 1.0e6, 2E-3, 4.e1, true, false ;
+    ex:q ( 1 2.5 ) ; ex:r 42.
+ex:b ex:p true.
+ex:c ex:p truely:x .
+ex:d ex:p 1e .
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var objects []string
+	for _, triple := range triples {
+		if triple.Predicate != RDFRest && !strings.HasPrefix(triple.Object, "_:") {
+			objects = append(objects, triple.Object)
+		}
+	}
+	literal := func(lexical, datatype string) string {
+		return NewLiteral(lexical, datatype).String()
+	}
+	expected := []string{
+		literal("42", XSDInteger), literal("-7", XSDInteger), literal("+0012", XSDInteger),
+		literal("3.14", XSDDecimal), literal("-.5", XSDDecimal),
+		literal("1.0e6", XSDDouble), literal("2E-3", XSDDouble), literal("4.e1", XSDDouble),
+		literal("true", XSDBoolean), literal("false", XSDBoolean),
+		literal("42", XSDInteger), literal("1", XSDInteger), literal("2.5", XSDDecimal),
+		literal("true", XSDBoolean),
+		"truely:x",
+	}
+	if !equalStrings(objects, expected) {
+		t.Errorf("Objects = %v, want %v", objects, expected)
+	}
+
+	errs := p.Errors()
+	if len(errs) != 1 || errs[0].Line != 6 || !strings.Contains(errs[0].Message, "exponent") {
+		t.Errorf("Expected an error for the exponent on line 6, got %v", errs)
+	}
+}
+
+func BenchmarkTurtleParse(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString("@prefix ex: <http://example.org/> .\n")
+	for i := 0; i < 100000; i++ {
+		fmt.Fprintf(&sb, "ex:s%d ex:value \"%d\"^^ex:unknown .\n", i, i)
+	}
+	content := sb.String()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewTurtleParser().Parse(content); err != nil {
+			b.Fatalf("Parse failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package reasoner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PartitionFunc assigns a triple to a named partition. An empty key selects
+// the default partition.
+type PartitionFunc func(t Triple) string
+
+// Partition describes one file written by WritePartitioned
+type Partition struct {
+	Key     string // Partition key returned by the PartitionFunc
+	Path    string // Path of the N-Triples file
+	Triples int    // Number of triples in the file
+}
+
+// DefaultPartition is the file name used for triples with an empty key
+const DefaultPartition = "default"
+
+// PartitionByNamespace partitions triples by the namespace of their subject,
+// i.e. the IRI up to and including the last '#' or '/'
+func PartitionByNamespace(t Triple) string {
+	return namespaceOf(t.Subject)
+}
+
+// PartitionByClass returns a PartitionFunc that partitions triples by the
+// most specific class of their subject in store, so that all triples about
+// the instances of a class end up in the same file. Subjects with several
+// unrelated classes use the first in lexical order; untyped subjects go to
+// the default partition.
+func PartitionByClass(store *TripleStore) PartitionFunc {
+	classOf := make(map[string]string)
+
+	return func(t Triple) string {
+		if class, ok := classOf[t.Subject]; ok {
+			return class
+		}
+
+		types := store.FindBySubjectPredicate(t.Subject, RDFType)
+		isType := make(map[string]bool, len(types))
+		for _, tt := range types {
+			isType[tt.Object] = true
+		}
+
+		// A class is most specific if none of its strict subclasses is also a type
+		var candidates []string
+		for class := range isType {
+			specific := true
+			for _, sub := range store.FindByPredicateObject(RDFSSubClassOf, class) {
+				if sub.Subject != class && isType[sub.Subject] &&
+					!store.Contains(Triple{Subject: class, Predicate: RDFSSubClassOf, Object: sub.Subject}) {
+					specific = false
+					break
+				}
+			}
+			if specific {
+				candidates = append(candidates, class)
+			}
+		}
+		sort.Strings(candidates)
+
+		class := ""
+		if len(candidates) > 0 {
+			class = candidates[0]
+		}
+		classOf[t.Subject] = class
+		return class
+	}
+}
+
+// WritePartitioned writes all triples in the store to N-Triples files in dir,
+// one file per partition key returned by keyFunc. File names are derived
+// from the keys. Returns the written partitions ordered by key. dir must
+// not exist or be empty, so that it holds no partitions of earlier runs.
+func (r *Reasoner) WritePartitioned(dir string, keyFunc PartitionFunc) ([]Partition, error) {
+	groups := make(map[string][]string)
+	for _, t := range r.store.All() {
+		key := keyFunc(t)
+		groups[key] = append(groups[key], t.String())
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("output directory %q is not empty", dir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	used := make(map[string]bool, len(keys))
+	partitions := make([]Partition, 0, len(keys))
+	for _, key := range keys {
+		name := partitionFileName(key)
+		if used[name] {
+			name += "_" + hashString(key)[:8]
+		}
+		used[name] = true
+
+		lines := groups[key]
+		sort.Strings(lines)
+
+		path := filepath.Join(dir, name+".nt")
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write partition %q: %w", key, err)
+		}
+		partitions = append(partitions, Partition{Key: key, Path: path, Triples: len(lines)})
+	}
+
+	return partitions, nil
+}
+
+// namespaceOf returns the namespace part of an IRI, or "" for other terms
+func namespaceOf(term string) string {
+	if !ParseTerm(term).IsIRI() {
+		return ""
+	}
+	if i := strings.LastIndexAny(term, "#/"); i >= 0 {
+		return term[:i+1]
+	}
+	return ""
+}
+
+// partitionFileName turns a partition key into a safe file name
+func partitionFileName(key string) string {
+	if i := strings.Index(key, "://"); i >= 0 {
+		key = key[i+3:]
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if isAlphaNum(r) || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, key)
+	name = strings.Trim(name, "_.")
+
+	if name == "" {
+		return DefaultPartition
+	}
+	return name
+}
@@ -0,0 +1,71 @@
+package reasoner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWritePartitioned(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix org: <http://example.com/org#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:Employee rdfs:subClassOf ex:Person .
+ex:alice a ex:Employee ; ex:name "Alice" .
+ex:bob a ex:Person .
+org:acme ex:name "ACME" .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	dir := t.TempDir()
+	partitions, err := r.WritePartitioned(dir, PartitionByClass(r.GetStore()))
+	if err != nil {
+		t.Fatalf("WritePartitioned failed: %v", err)
+	}
+
+	counts := make(map[string]int)
+	total := 0
+	for _, p := range partitions {
+		counts[p.Key] = p.Triples
+		total += p.Triples
+		if _, err := os.Stat(p.Path); err != nil {
+			t.Errorf("Partition file %s missing: %v", p.Path, err)
+		}
+	}
+	if total != r.GetStore().Size() {
+		t.Errorf("Expected %d triples across partitions, got %d", r.GetStore().Size(), total)
+	}
+	// alice: type Employee, type Person, name
+	if counts["http://example.org/Employee"] != 3 {
+		t.Errorf("Expected 3 triples in the Employee partition, got %v", counts)
+	}
+	if counts["http://example.org/Person"] != 1 {
+		t.Errorf("Expected 1 triple in the Person partition, got %v", counts)
+	}
+
+	// Partitions of earlier runs are not mixed with new ones
+	if _, err := r.WritePartitioned(dir, PartitionByNamespace); err == nil {
+		t.Errorf("Expected an error for a non-empty output directory")
+	}
+
+	partitions, err = r.WritePartitioned(filepath.Join(t.TempDir(), "closure"), PartitionByNamespace)
+	if err != nil {
+		t.Fatalf("WritePartitioned failed: %v", err)
+	}
+	if len(partitions) != 2 {
+		t.Fatalf("Expected 2 namespace partitions, got %v", partitions)
+	}
+	content, err := os.ReadFile(partitions[0].Path)
+	if err != nil {
+		t.Fatalf("Failed to read partition: %v", err)
+	}
+	if !strings.HasSuffix(partitions[0].Path, "example.com_org.nt") || !strings.Contains(string(content), `"ACME"`) {
+		t.Errorf("Unexpected partition %s:\n%s", partitions[0].Path, content)
+	}
+}
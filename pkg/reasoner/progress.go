@@ -0,0 +1,32 @@
+package reasoner
+
+// ProgressStage identifies the operation a ProgressEvent reports on
+type ProgressStage int
+
+const (
+	// ProgressParsing reports bytes and triples parsed by LoadTurtle
+	ProgressParsing ProgressStage = iota
+	// ProgressReasoning reports rounds and triples inferred by RunForwardReasoning
+	ProgressReasoning
+)
+
+// ProgressEvent reports the progress of a long-running operation
+type ProgressEvent struct {
+	Stage   ProgressStage
+	Done    int    // Bytes parsed, or the current reasoning round
+	Total   int    // Total bytes to parse; 0 when unknown, as for reasoning
+	Triples int    // Triples parsed or inferred so far
+	Rule    string // Rule that was just applied, while reasoning
+}
+
+// progressInterval is the number of input bytes between parsing events
+const progressInterval = 64 * 1024
+
+// WithProgress calls fn periodically while parsing input and after every
+// rule application during reasoning. fn is called synchronously and should
+// return quickly.
+func WithProgress(fn func(ProgressEvent)) Option {
+	return func(r *Reasoner) {
+		r.progress = fn
+	}
+}
@@ -0,0 +1,61 @@
+package reasoner
+
+// WithProvenance makes the reasoner record the source of every triple loaded
+// with LoadTurtleFrom, retrievable with TripleStore.SourceOf. Diagnostics
+// about inconsistencies then name the documents the conflicting triples
+// came from.
+func WithProvenance() Option {
+	return func(r *Reasoner) {
+		r.provenance = true
+	}
+}
+
+// AddFrom is like Add but records source, typically a file name, as a
+// document the triple was asserted in. A triple asserted in several
+// documents keeps all of them.
+func (ts *TripleStore) AddFrom(t Triple, source string) bool {
+	if ts.frozen {
+		return false
+	}
+	added := ts.Add(t)
+
+	key := tripleKey(t)
+	for _, s := range ts.sources[key] {
+		if s == source {
+			return added
+		}
+	}
+	if ts.sources == nil {
+		ts.sources = make(map[string][]string)
+	}
+	ts.sources[key] = append(ts.sources[key], source)
+	return added
+}
+
+// SourceOf returns the documents a triple was asserted in, in load order.
+// It returns nil for inferred triples and triples added without a source.
+func (ts *TripleStore) SourceOf(t Triple) []string {
+	sources := ts.sources[tripleKey(t)]
+	if len(sources) == 0 {
+		return nil
+	}
+	result := make([]string, len(sources))
+	copy(result, sources)
+	return result
+}
+
+// sourcesOf returns the distinct documents any of the triples were
+// asserted in, in order of first occurrence
+func (ts *TripleStore) sourcesOf(triples []Triple) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, t := range triples {
+		for _, s := range ts.sources[tripleKey(t)] {
+			if !seen[s] {
+				seen[s] = true
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
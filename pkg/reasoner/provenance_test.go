@@ -0,0 +1,75 @@
+package reasoner
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSourceOf(t *testing.T) {
+	r := NewReasoner(WithProvenance(), WithConsistencyCheck())
+
+	schema := `@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Cat owl:disjointWith ex:Dog .
+`
+	cats := `@prefix ex: <http://example.org/> .
+ex:tom a ex:Cat .
+ex:rex a ex:Cat .
+`
+	dogs := `@prefix ex: <http://example.org/> .
+ex:rex a ex:Dog .
+ex:tom a ex:Cat .
+`
+	for _, in := range []struct{ source, content string }{
+		{"schema.ttl", schema}, {"cats.ttl", cats}, {"dogs.ttl", dogs},
+	} {
+		if err := r.LoadTurtleFrom(in.source, in.content); err != nil {
+			t.Fatalf("LoadTurtleFrom(%s) failed: %v", in.source, err)
+		}
+	}
+	r.RunForwardReasoning()
+
+	tests := []struct {
+		triple Triple
+		want   []string
+	}{
+		{Triple{Subject: "http://example.org/rex", Predicate: RDFType, Object: "http://example.org/Dog"}, []string{"dogs.ttl"}},
+		{Triple{Subject: "http://example.org/tom", Predicate: RDFType, Object: "http://example.org/Cat"}, []string{"cats.ttl", "dogs.ttl"}},
+		{Triple{Subject: "http://example.org/Dog", Predicate: OWLDisjointWith, Object: "http://example.org/Cat"}, nil},
+		{Triple{Subject: "http://example.org/nobody", Predicate: RDFType, Object: "http://example.org/Cat"}, nil},
+	}
+	for _, tt := range tests {
+		if got := r.GetStore().SourceOf(tt.triple); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SourceOf(%v) = %v, want %v", tt.triple, got, tt.want)
+		}
+	}
+
+	var found bool
+	for _, d := range r.Diagnostics() {
+		if d.Code != CodeInconsistency {
+			continue
+		}
+		found = true
+		sources := strings.Split(d.Source, ", ")
+		sort.Strings(sources)
+		if want := []string{"cats.ttl", "dogs.ttl", "schema.ttl"}; !reflect.DeepEqual(sources, want) {
+			t.Errorf("Expected the inconsistency to name %v, got %q", want, d.Source)
+		}
+	}
+	if !found {
+		t.Error("Expected an inconsistency for ex:rex")
+	}
+}
+
+func TestSourceOfWithoutProvenance(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtleFrom("data.ttl", `<http://example.org/a> <http://example.org/p> <http://example.org/b> .`); err != nil {
+		t.Fatalf("LoadTurtleFrom failed: %v", err)
+	}
+	triple := Triple{Subject: "http://example.org/a", Predicate: "http://example.org/p", Object: "http://example.org/b"}
+	if got := r.GetStore().SourceOf(triple); got != nil {
+		t.Errorf("Expected no sources without WithProvenance, got %v", got)
+	}
+}
@@ -0,0 +1,165 @@
+package reasoner
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrEmptyQuery is returned when running a query without patterns
+var ErrEmptyQuery = errors.New("query has no patterns")
+
+// QueryBuilder builds a basic graph pattern query, as an alternative to
+// writing query strings:
+//
+//	q := reasoner.NewQuery().Type("ex:Person").Where("ex:worksFor", "?org").Select("?org")
+//	bindings, err := q.Run(r)
+//
+// Patterns are about the current subject, "?s" until changed with Subject.
+// Terms may be IRIs, prefixed names, quoted literals such as "42"^^xsd:int,
+// or variables starting with "?". Prefixed names are expanded when the
+// query is run, with the reasoner's prefixes, the standard rdf, rdfs, owl
+// and xsd prefixes and those added with Prefix.
+type QueryBuilder struct {
+	subject  string
+	patterns []TriplePattern
+	selected []string
+	prefixes map[string]string
+	distinct bool
+	limit    int
+}
+
+// NewQuery starts a query about the subject "?s"
+func NewQuery() *QueryBuilder {
+	return &QueryBuilder{subject: "?s", prefixes: make(map[string]string)}
+}
+
+// Prefix declares a prefix for the terms of the query
+func (q *QueryBuilder) Prefix(prefix, namespace string) *QueryBuilder {
+	q.prefixes[prefix] = namespace
+	return q
+}
+
+// Subject makes subject, a term or variable, the subject of the following
+// patterns
+func (q *QueryBuilder) Subject(subject string) *QueryBuilder {
+	q.subject = subject
+	return q
+}
+
+// Type requires the current subject to have rdf:type class
+func (q *QueryBuilder) Type(class string) *QueryBuilder {
+	return q.Where(RDFType, class)
+}
+
+// Where requires the current subject to have object as a value of predicate
+func (q *QueryBuilder) Where(predicate, object string) *QueryBuilder {
+	return q.Triple(q.subject, predicate, object)
+}
+
+// Triple adds a pattern with an explicit subject, leaving the current
+// subject unchanged
+func (q *QueryBuilder) Triple(subject, predicate, object string) *QueryBuilder {
+	q.patterns = append(q.patterns, TriplePattern{Subject: subject, Predicate: predicate, Object: object})
+	return q
+}
+
+// Select restricts the results to the given variables. Without Select,
+// results bind every variable of the patterns.
+func (q *QueryBuilder) Select(variables ...string) *QueryBuilder {
+	q.selected = append(q.selected, variables...)
+	return q
+}
+
+// Distinct removes duplicate results
+func (q *QueryBuilder) Distinct() *QueryBuilder {
+	q.distinct = true
+	return q
+}
+
+// Limit returns at most n results; zero means no limit
+func (q *QueryBuilder) Limit(n int) *QueryBuilder {
+	q.limit = n
+	return q
+}
+
+// Patterns returns the triple patterns of the query with prefixed names
+// expanded, as passed to TripleStore.MatchBGP
+func (q *QueryBuilder) Patterns(prefixes map[string]string) []TriplePattern {
+	known := withStandardPrefixes(prefixes)
+	for prefix, ns := range q.prefixes {
+		known[prefix] = ns
+	}
+	patterns := make([]TriplePattern, len(q.patterns))
+	for i, p := range q.patterns {
+		patterns[i] = TriplePattern{
+			Subject:   conditionTerm(p.Subject, known),
+			Predicate: conditionTerm(p.Predicate, known),
+			Object:    conditionTerm(p.Object, known),
+		}
+	}
+	return patterns
+}
+
+// Run evaluates the query against the reasoner's store and returns the
+// bindings of the selected variables, sorted for stable output
+func (q *QueryBuilder) Run(r *Reasoner) ([]Binding, error) {
+	if len(q.patterns) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	patterns := q.Patterns(r.Prefixes())
+
+	variables := make(map[string]bool)
+	for _, p := range patterns {
+		for _, position := range []string{p.Subject, p.Predicate, p.Object} {
+			if IsVariable(position) {
+				variables[position[1:]] = true
+			}
+		}
+	}
+	selected := make([]string, len(q.selected))
+	for i, v := range q.selected {
+		name := strings.TrimPrefix(v, "?")
+		if !variables[name] {
+			return nil, fmt.Errorf("selected variable ?%s does not occur in the query", name)
+		}
+		selected[i] = name
+	}
+
+	results := r.store.MatchBGP(patterns)
+	if len(selected) > 0 {
+		for i, b := range results {
+			projected := make(Binding, len(selected))
+			for _, name := range selected {
+				projected[name] = b[name]
+			}
+			results[i] = projected
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].String() < results[j].String() })
+	if q.distinct {
+		var unique []Binding
+		for _, b := range results {
+			if len(unique) == 0 || b.String() != unique[len(unique)-1].String() {
+				unique = append(unique, b)
+			}
+		}
+		results = unique
+	}
+	if q.limit > 0 && len(results) > q.limit {
+		results = results[:q.limit]
+	}
+	return results, nil
+}
+
+// Explain evaluates the patterns of the query and returns their plan, as
+// with TripleStore.ExplainBGP. Selection, Distinct and Limit are applied
+// after the patterns are matched and are not part of the plan.
+func (q *QueryBuilder) Explain(r *Reasoner) (QueryPlan, error) {
+	if len(q.patterns) == 0 {
+		return QueryPlan{}, ErrEmptyQuery
+	}
+	return r.store.ExplainBGP(q.Patterns(r.Prefixes())), nil
+}
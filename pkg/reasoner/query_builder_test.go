@@ -0,0 +1,65 @@
+package reasoner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(bgpData); err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+	r.RunForwardReasoning()
+
+	tests := []struct {
+		name  string
+		query *QueryBuilder
+		want  []string
+	}{
+		{
+			"select",
+			NewQuery().Type("ex:Person").Where("ex:worksFor", "?org").Select("?org"),
+			[]string{"?org=ex:acme", "?org=ex:acme", "?org=ex:globex"},
+		},
+		{
+			"distinct",
+			NewQuery().Type("ex:Person").Where("ex:worksFor", "?org").Select("?org").Distinct(),
+			[]string{"?org=ex:acme", "?org=ex:globex"},
+		},
+		{
+			"subject and literal",
+			NewQuery().Where("ex:worksFor", "?org").Subject("?org").Where("ex:name", `"ACME"`).Select("s"),
+			[]string{"?s=ex:alice", "?s=ex:carol"},
+		},
+		{
+			"all variables and limit",
+			NewQuery().Prefix("e", "http://example.org/").Type("e:Employee").Where("e:knows", "?friend").Limit(1),
+			[]string{"?friend=ex:bob ?s=ex:alice"},
+		},
+		{
+			"constant subject",
+			NewQuery().Subject("<http://example.org/bob>").Where("rdf:type", "?class"),
+			[]string{"?class=ex:Person"},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.query.Run(r)
+		if err != nil {
+			t.Errorf("%s: Run() error = %v", tt.name, err)
+			continue
+		}
+		if strings.Join(bindingStrings(got), "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: Run() = %q, want %q", tt.name, bindingStrings(got), tt.want)
+		}
+	}
+
+	if _, err := NewQuery().Run(r); !errors.Is(err, ErrEmptyQuery) {
+		t.Errorf("Run() of an empty query error = %v, want ErrEmptyQuery", err)
+	}
+	if _, err := NewQuery().Type("ex:Person").Select("?org").Run(r); err == nil {
+		t.Error("Run() selecting an unknown variable succeeded, want error")
+	}
+}
@@ -0,0 +1,373 @@
+package reasoner
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// rdfXMLLiteral is the datatype of rdf:parseType="Literal" values
+const rdfXMLLiteral = RDF + "XMLLiteral"
+
+// xmlNamespace is the namespace of the xml:lang and xml:base attributes
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// RDFXMLParser parses RDF/XML, the format many published ontologies are
+// only distributed in. It supports node and property elements, typed node
+// elements, property attributes, rdf:about, rdf:ID, rdf:nodeID,
+// rdf:resource, rdf:datatype, rdf:li, xml:lang, xml:base and the
+// parseTypes Resource, Literal and Collection. Blank nodes without an
+// rdf:nodeID are labelled from a hash of the document, so that documents
+// loaded into the same store do not share them.
+type RDFXMLParser struct {
+	// errors records elements skipped during the last Parse
+	errors []ParseError
+
+	// warnings records suspicious but loaded triples of the last Parse
+	warnings []ParseError
+
+	// prefixes records the namespaces declared in the last parsed document
+	prefixes map[string]string
+
+	// options limits the size of parsed documents
+	options ParserOptions
+
+	triples    []Triple
+	blankNodes int
+	blankLabel string
+	limitErr   error
+}
+
+// xmlElement is an element of the document with the language and base in
+// scope
+type xmlElement struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     string
+	inner    string // Raw content, for rdf:parseType="Literal"
+	line     int
+	start    int64
+	lang     string
+	base     string
+}
+
+// NewRDFXMLParser creates a new RDF/XML parser
+func NewRDFXMLParser() *RDFXMLParser {
+	return &RDFXMLParser{prefixes: make(map[string]string)}
+}
+
+// NewRDFXMLParserWithOptions creates an RDF/XML parser that enforces
+// limits. Parse fails with an error wrapping ErrParserLimit as soon as a
+// limit is exceeded.
+func NewRDFXMLParserWithOptions(opts ParserOptions) *RDFXMLParser {
+	p := NewRDFXMLParser()
+	p.options = opts
+	return p
+}
+
+// Parse parses RDF/XML content and returns triples. Malformed XML fails
+// the whole document; elements that are well-formed XML but not valid
+// RDF/XML are skipped and reported by Errors.
+func (p *RDFXMLParser) Parse(content string) ([]Triple, error) {
+	p.errors = nil
+	p.warnings = nil
+	p.prefixes = make(map[string]string)
+	p.triples = nil
+	p.blankNodes = 0
+	p.blankLabel = hashString(content)[:8]
+	p.limitErr = nil
+
+	if limit := p.options.MaxInputBytes; limit > 0 && len(content) > limit {
+		return nil, limitError("document is %d bytes, the limit is %d", len(content), limit)
+	}
+
+	document, err := p.readTree(content)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := document.children
+	if len(nodes) == 1 && nodes[0].name == (xml.Name{Space: RDF, Local: "RDF"}) {
+		nodes = nodes[0].children
+	}
+	for _, n := range nodes {
+		p.nodeElement(n)
+		if p.limitErr != nil {
+			return nil, fmt.Errorf("line %d: %w", n.line, p.limitErr)
+		}
+	}
+
+	return p.triples, nil
+}
+
+// Errors returns the elements skipped during the last call to Parse
+func (p *RDFXMLParser) Errors() []ParseError {
+	return p.errors
+}
+
+// Warnings returns problems found in triples that were loaded anyway
+// during the last call to Parse, such as malformed date literals
+func (p *RDFXMLParser) Warnings() []ParseError {
+	return p.warnings
+}
+
+// Prefixes returns the namespaces declared in the last parsed document
+func (p *RDFXMLParser) Prefixes() map[string]string {
+	prefixes := make(map[string]string, len(p.prefixes))
+	for prefix, iri := range p.prefixes {
+		prefixes[prefix] = iri
+	}
+	return prefixes
+}
+
+// readTree reads the elements of the document, resolving the language and
+// base of each
+func (p *RDFXMLParser) readTree(content string) (*xmlElement, error) {
+	d := xml.NewDecoder(strings.NewReader(content))
+	document := &xmlElement{}
+	stack := []*xmlElement{document}
+	previous := d.InputOffset()
+
+	for {
+		token, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %w", err)
+		}
+
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			line, _ := d.InputPos()
+			e := &xmlElement{name: t.Name, line: line, start: d.InputOffset(), lang: parent.lang, base: parent.base}
+			for _, a := range t.Attr {
+				switch {
+				case a.Name.Space == "xmlns":
+					p.prefixes[a.Name.Local] = a.Value
+				case a.Name.Space == "" && a.Name.Local == "xmlns":
+					p.prefixes[""] = a.Value
+				case a.Name.Space == xmlNamespace && a.Name.Local == "lang":
+					e.lang = a.Value
+				case a.Name.Space == xmlNamespace && a.Name.Local == "base":
+					e.base = resolveReference(parent.base, a.Value)
+				case a.Name.Space != xmlNamespace:
+					e.attrs = append(e.attrs, a)
+				}
+			}
+			if limit := p.options.MaxDepth; limit > 0 && len(stack) > limit+1 {
+				return nil, fmt.Errorf("line %d: %w", line, limitError("elements nested deeper than %d", limit))
+			}
+			parent.children = append(parent.children, e)
+			stack = append(stack, e)
+		case xml.EndElement:
+			parent.inner = content[parent.start:previous]
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			parent.text += string(t)
+		}
+		previous = d.InputOffset()
+	}
+	return document, nil
+}
+
+// nodeElement emits the triples of a node element and returns its subject
+func (p *RDFXMLParser) nodeElement(e *xmlElement) string {
+	subject := ""
+	var properties []xml.Attr
+	for _, a := range e.attrs {
+		switch a.Name {
+		case xml.Name{Space: RDF, Local: "about"}:
+			subject = resolveReference(e.base, a.Value)
+		case xml.Name{Space: RDF, Local: "ID"}:
+			subject = resolveReference(e.base, "#"+a.Value)
+		case xml.Name{Space: RDF, Local: "nodeID"}:
+			subject = "_:" + a.Value
+		default:
+			properties = append(properties, a)
+		}
+	}
+	if subject == "" {
+		subject = p.newBlankNode()
+	}
+
+	if e.name != (xml.Name{Space: RDF, Local: "Description"}) {
+		p.emit(Triple{Subject: subject, Predicate: RDFType, Object: e.name.Space + e.name.Local}, e.line)
+	}
+	p.propertyAttributes(subject, properties, e)
+
+	li := 0
+	for _, child := range e.children {
+		p.propertyElement(subject, child, &li)
+	}
+	return subject
+}
+
+// propertyAttributes emits the triples of property attributes, whose values
+// are literals except for rdf:type
+func (p *RDFXMLParser) propertyAttributes(subject string, attrs []xml.Attr, e *xmlElement) {
+	for _, a := range attrs {
+		if a.Name.Space == "" {
+			p.errors = append(p.errors, ParseError{Line: e.line, Message: fmt.Sprintf("attribute %q has no namespace", a.Name.Local)})
+			continue
+		}
+		predicate := a.Name.Space + a.Name.Local
+		if predicate == RDFType {
+			p.emit(Triple{Subject: subject, Predicate: predicate, Object: resolveReference(e.base, a.Value)}, e.line)
+			continue
+		}
+		p.emit(Triple{Subject: subject, Predicate: predicate, Object: p.literal(a.Value, e.lang, "")}, e.line)
+	}
+}
+
+// propertyElement emits the triples of a property element of subject.
+// li counts the rdf:li elements of the subject so far.
+func (p *RDFXMLParser) propertyElement(subject string, e *xmlElement, li *int) {
+	predicate := e.name.Space + e.name.Local
+	if predicate == RDF+"li" {
+		*li++
+		predicate = RDF + "_" + strconv.Itoa(*li)
+	}
+
+	var resource, parseType, datatype, reifier string
+	hasResource := false
+	var properties []xml.Attr
+	for _, a := range e.attrs {
+		switch a.Name {
+		case xml.Name{Space: RDF, Local: "resource"}:
+			resource, hasResource = resolveReference(e.base, a.Value), true
+		case xml.Name{Space: RDF, Local: "nodeID"}:
+			resource, hasResource = "_:"+a.Value, true
+		case xml.Name{Space: RDF, Local: "parseType"}:
+			parseType = a.Value
+		case xml.Name{Space: RDF, Local: "datatype"}:
+			datatype = resolveReference(e.base, a.Value)
+		case xml.Name{Space: RDF, Local: "ID"}:
+			reifier = resolveReference(e.base, "#"+a.Value)
+		default:
+			properties = append(properties, a)
+		}
+	}
+
+	var object string
+	switch {
+	case parseType == "Resource":
+		object = p.newBlankNode()
+		p.emit(Triple{Subject: subject, Predicate: predicate, Object: object}, e.line)
+		nested := 0
+		for _, child := range e.children {
+			p.propertyElement(object, child, &nested)
+		}
+	case parseType == "Collection":
+		object = p.collection(e)
+		p.emit(Triple{Subject: subject, Predicate: predicate, Object: object}, e.line)
+	case parseType != "":
+		// Any other parseType is treated as Literal
+		object = p.literal(e.inner, "", rdfXMLLiteral)
+		p.emit(Triple{Subject: subject, Predicate: predicate, Object: object}, e.line)
+	case len(e.children) > 1:
+		p.errors = append(p.errors, ParseError{Line: e.line, Message: fmt.Sprintf("property element <%s> has more than one node element", predicate)})
+		return
+	case len(e.children) == 1:
+		object = p.nodeElement(e.children[0])
+		p.emit(Triple{Subject: subject, Predicate: predicate, Object: object}, e.line)
+	case hasResource || len(properties) > 0:
+		// An empty property element describing its object with attributes
+		object = resource
+		if !hasResource {
+			object = p.newBlankNode()
+		}
+		p.emit(Triple{Subject: subject, Predicate: predicate, Object: object}, e.line)
+		p.propertyAttributes(object, properties, e)
+	default:
+		object = p.literal(e.text, e.lang, datatype)
+		p.emit(Triple{Subject: subject, Predicate: predicate, Object: object}, e.line)
+	}
+
+	if reifier != "" {
+		p.emit(Triple{Subject: reifier, Predicate: RDFType, Object: RDFStatement}, e.line)
+		p.emit(Triple{Subject: reifier, Predicate: RDFSubject, Object: subject}, e.line)
+		p.emit(Triple{Subject: reifier, Predicate: RDFPredicate, Object: predicate}, e.line)
+		p.emit(Triple{Subject: reifier, Predicate: RDFObject, Object: object}, e.line)
+	}
+}
+
+// collection emits an rdf:List of the node elements of a property element
+// and returns its head
+func (p *RDFXMLParser) collection(e *xmlElement) string {
+	head := RDFNil
+	previous := ""
+	for _, child := range e.children {
+		node := p.newBlankNode()
+		if previous == "" {
+			head = node
+		} else {
+			p.emit(Triple{Subject: previous, Predicate: RDFRest, Object: node}, child.line)
+		}
+		p.emit(Triple{Subject: node, Predicate: RDFFirst, Object: p.nodeElement(child)}, child.line)
+		previous = node
+	}
+	if previous != "" {
+		p.emit(Triple{Subject: previous, Predicate: RDFRest, Object: RDFNil}, e.line)
+	}
+	return head
+}
+
+// literal returns a literal term with the escaping used for all stored
+// literals, and records an error if it exceeds MaxLiteralLength
+func (p *RDFXMLParser) literal(value, lang, datatype string) string {
+	if limit := p.options.MaxLiteralLength; limit > 0 && len(value) > limit && p.limitErr == nil {
+		p.limitErr = limitError("literal longer than %d bytes", limit)
+	}
+	term := `"` + escapeLiteral(value) + `"`
+	switch {
+	case datatype != "":
+		return term + "^^<" + datatype + ">"
+	case lang != "":
+		return term + "@" + lang
+	default:
+		return term
+	}
+}
+
+// emit records a triple and checks its literal
+func (p *RDFXMLParser) emit(t Triple, line int) {
+	if err := validateTemporalLiteral(t.Object); err != nil {
+		p.warnings = append(p.warnings, ParseError{Line: line, Message: err.Error()})
+	}
+	if err := validateCustomLiteral(t.Object); err != nil {
+		p.warnings = append(p.warnings, ParseError{Line: line, Message: err.Error()})
+	}
+	p.triples = append(p.triples, t)
+	if limit := p.options.MaxTriples; limit > 0 && len(p.triples) > limit && p.limitErr == nil {
+		p.limitErr = limitError("more than %d triples", limit)
+	}
+}
+
+// newBlankNode returns a blank node label unique within the document
+func (p *RDFXMLParser) newBlankNode() string {
+	p.blankNodes++
+	return fmt.Sprintf("_:x%s_%d", p.blankLabel, p.blankNodes)
+}
+
+// resolveReference resolves a reference against a base IRI, if any
+func resolveReference(base, ref string) string {
+	if base == "" {
+		return ref
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return b.ResolveReference(r).String()
+}
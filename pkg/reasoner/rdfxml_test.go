@@ -0,0 +1,138 @@
+package reasoner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const rdfXMLDocument = `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+         xmlns:rdfs="http://www.w3.org/2000/01/rdf-schema#"
+         xmlns:owl="http://www.w3.org/2002/07/owl#"
+         xmlns:ex="http://example.org/"
+         xml:base="http://example.org/onto">
+  <owl:Class rdf:ID="Municipality">
+    <rdfs:subClassOf rdf:resource="#Place"/>
+    <rdfs:label xml:lang="de">Gemeinde</rdfs:label>
+  </owl:Class>
+  <rdf:Description rdf:about="http://example.org/bern" ex:code="351">
+    <rdf:type rdf:resource="http://example.org/onto#Municipality"/>
+    <ex:population rdf:datatype="http://www.w3.org/2001/XMLSchema#integer">134000</ex:population>
+    <ex:canton>
+      <ex:Canton rdf:about="http://example.org/be" ex:code="BE"/>
+    </ex:canton>
+    <ex:mayor rdf:parseType="Resource">
+      <ex:name>Alec</ex:name>
+    </ex:mayor>
+    <ex:neighbours rdf:parseType="Collection">
+      <rdf:Description rdf:about="http://example.org/koeniz"/>
+      <rdf:Description rdf:about="http://example.org/ostermundigen"/>
+    </ex:neighbours>
+    <ex:motto rdf:parseType="Literal"><b>Bärn</b></ex:motto>
+    <ex:twin rdf:nodeID="t1" rdf:ID="twinning"/>
+    <ex:address ex:street="Bundesplatz 3"/>
+  </rdf:Description>
+  <rdf:Bag rdf:about="http://example.org/bag">
+    <rdf:li>a</rdf:li>
+    <rdf:li>b</rdf:li>
+  </rdf:Bag>
+  <rdf:Description rdf:about="http://example.org/broken">
+    <ex:p><ex:A/><ex:B/></ex:p>
+  </rdf:Description>
+</rdf:RDF>
+`
+
+func TestRDFXMLParser(t *testing.T) {
+	p := NewRDFXMLParser()
+	triples, err := p.Parse(rdfXMLDocument)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	store := NewTripleStore()
+	for _, tr := range triples {
+		store.Add(tr)
+	}
+
+	ex, onto := "http://example.org/", "http://example.org/onto#"
+	for _, want := range []Triple{
+		{Subject: onto + "Municipality", Predicate: RDFType, Object: OWLClass},
+		{Subject: onto + "Municipality", Predicate: RDFSSubClassOf, Object: onto + "Place"},
+		{Subject: onto + "Municipality", Predicate: RDFSLabel, Object: `"Gemeinde"@de`},
+		{Subject: ex + "bern", Predicate: ex + "code", Object: `"351"`},
+		{Subject: ex + "bern", Predicate: RDFType, Object: onto + "Municipality"},
+		{Subject: ex + "bern", Predicate: ex + "population", Object: `"134000"^^<http://www.w3.org/2001/XMLSchema#integer>`},
+		{Subject: ex + "bern", Predicate: ex + "canton", Object: ex + "be"},
+		{Subject: ex + "be", Predicate: RDFType, Object: ex + "Canton"},
+		{Subject: ex + "be", Predicate: ex + "code", Object: `"BE"`},
+		{Subject: ex + "bern", Predicate: ex + "motto", Object: `"<b>Bärn</b>"^^<` + rdfXMLLiteral + `>`},
+		{Subject: ex + "bern", Predicate: ex + "twin", Object: "_:t1"},
+		{Subject: onto + "twinning", Predicate: RDFSubject, Object: ex + "bern"},
+		{Subject: onto + "twinning", Predicate: RDFObject, Object: "_:t1"},
+		{Subject: ex + "bag", Predicate: RDF + "_1", Object: `"a"`},
+		{Subject: ex + "bag", Predicate: RDF + "_2", Object: `"b"`},
+	} {
+		if !store.Contains(want) {
+			t.Errorf("Expected %v", want)
+		}
+	}
+
+	mayor := store.FindBySubjectPredicate(ex+"bern", ex+"mayor")
+	if len(mayor) != 1 || !isBlankNode(mayor[0].Object) || !store.Contains(Triple{Subject: mayor[0].Object, Predicate: ex + "name", Object: `"Alec"`}) {
+		t.Errorf("Expected a blank node for rdf:parseType=\"Resource\", got %v", mayor)
+	}
+	address := store.FindBySubjectPredicate(ex+"bern", ex+"address")
+	if len(address) != 1 || !store.Contains(Triple{Subject: address[0].Object, Predicate: ex + "street", Object: `"Bundesplatz 3"`}) {
+		t.Errorf("Expected a blank node described by property attributes, got %v", address)
+	}
+	neighbours := store.FindBySubjectPredicate(ex+"bern", ex+"neighbours")
+	if len(neighbours) != 1 {
+		t.Fatalf("Expected one list, got %v", neighbours)
+	}
+	if members, err := store.ListMembers(neighbours[0].Object); err != nil || len(members) != 2 || members[1] != ex+"ostermundigen" {
+		t.Errorf("ListMembers() = %v, %v", members, err)
+	}
+
+	if errs := p.Errors(); len(errs) != 1 || !strings.Contains(errs[0].Message, "more than one node element") {
+		t.Errorf("Expected the property element with two nodes to be skipped, got %v", errs)
+	}
+	if prefixes := p.Prefixes(); prefixes["ex"] != ex || prefixes["owl"] != "http://www.w3.org/2002/07/owl#" {
+		t.Errorf("Unexpected prefixes: %v", prefixes)
+	}
+}
+
+func TestRDFXMLParserErrors(t *testing.T) {
+	if _, err := NewRDFXMLParser().Parse(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description>`); err == nil {
+		t.Errorf("Expected an error for malformed XML")
+	}
+	_, err := NewRDFXMLParserWithOptions(ParserOptions{MaxTriples: 5}).Parse(rdfXMLDocument)
+	if !errors.Is(err, ErrParserLimit) {
+		t.Errorf("Expected a limit error, got %v", err)
+	}
+
+	// Blank nodes of different documents are distinct
+	doc := `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/"><rdf:Description ex:n="%s"/></rdf:RDF>`
+	a, _ := NewRDFXMLParser().Parse(strings.Replace(doc, "%s", "a", 1))
+	b, _ := NewRDFXMLParser().Parse(strings.Replace(doc, "%s", "b", 1))
+	if len(a) != 1 || len(b) != 1 || a[0].Subject == b[0].Subject {
+		t.Errorf("Expected distinct blank nodes, got %v and %v", a, b)
+	}
+}
+
+func TestLoadRDFXML(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadDocument("onto.rdf", rdfXMLDocument, FormatUnknown); err != nil {
+		t.Fatalf("LoadDocument failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	if !r.GetStore().Contains(Triple{Subject: "http://example.org/bern", Predicate: RDFType, Object: "http://example.org/onto#Place"}) {
+		t.Errorf("Expected reasoning over the RDF/XML ontology")
+	}
+	if r.Prefixes()["rdfs"] != "http://www.w3.org/2000/01/rdf-schema#" {
+		t.Errorf("Expected the namespaces to be kept as prefixes, got %v", r.Prefixes())
+	}
+	if err := r.LoadDocument("notes.txt", "", FormatUnknown); err == nil {
+		t.Errorf("Expected a document of unknown format to be rejected")
+	}
+}
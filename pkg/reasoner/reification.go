@@ -0,0 +1,241 @@
+package reasoner
+
+import (
+	"sort"
+	"strings"
+)
+
+// RDF reification vocabulary
+const (
+	RDFStatement           = "http://www.w3.org/1999/02/22-rdf-syntax-ns#Statement"
+	RDFSubject             = "http://www.w3.org/1999/02/22-rdf-syntax-ns#subject"
+	RDFPredicate           = "http://www.w3.org/1999/02/22-rdf-syntax-ns#predicate"
+	RDFObject              = "http://www.w3.org/1999/02/22-rdf-syntax-ns#object"
+	RDFSingletonPropertyOf = "http://www.w3.org/1999/02/22-rdf-syntax-ns#singletonPropertyOf"
+)
+
+// AnnotationStyle is a convention for making statements about statements
+type AnnotationStyle int
+
+const (
+	// PlainTriples drops statement annotations and keeps only the statements
+	// that were asserted; statements that were only quoted are dropped too
+	PlainTriples AnnotationStyle = iota
+	// StandardReification uses rdf:Statement with rdf:subject/predicate/object
+	StandardReification
+	// RDFStar uses quoted triple terms: << s p o >> ex:source ex:census .
+	RDFStar
+	// SingletonProperty uses a statement-specific property:
+	// s p_1 o . p_1 rdf:singletonPropertyOf p .
+	SingletonProperty
+)
+
+// Annotation is a statement together with the triples describing it
+type Annotation struct {
+	Statement Triple          // The annotated statement
+	Style     AnnotationStyle // The style the annotation was written in
+	Node      string          // The reifier, quoted triple term or singleton property
+	Asserted  bool            // Whether the statement itself is asserted
+	Triples   []Triple        // Annotation triples, with Node as subject
+}
+
+// QuotedTriple formats a triple as an RDF-star quoted triple term
+func QuotedTriple(t Triple) string {
+	return "<< " + strings.TrimSuffix(t.String(), " .") + " >>"
+}
+
+// ParseQuotedTriple parses an RDF-star quoted triple term
+func ParseQuotedTriple(term string) (Triple, bool) {
+	if !strings.HasPrefix(term, "<<") || !strings.HasSuffix(term, ">>") {
+		return Triple{}, false
+	}
+
+	inner := strings.TrimSpace(term[2 : len(term)-2])
+	parts := parseNTripleParts(inner)
+	if len(parts) != 3 {
+		return Triple{}, false
+	}
+
+	unbracket := func(s string) string {
+		if strings.HasPrefix(s, "<") && strings.HasSuffix(s, ">") {
+			return s[1 : len(s)-1]
+		}
+		return s
+	}
+
+	return Triple{Subject: unbracket(parts[0]), Predicate: unbracket(parts[1]), Object: unbracket(parts[2])}, true
+}
+
+// ExtractAnnotations finds statement annotations written in any supported
+// style and returns them together with the remaining, unrelated triples
+func ExtractAnnotations(triples []Triple) ([]Annotation, []Triple) {
+	asserted := make(map[string]bool, len(triples))
+	bySubject := make(map[string][]Triple)
+	for _, t := range triples {
+		asserted[tripleKey(t)] = true
+		bySubject[t.Subject] = append(bySubject[t.Subject], t)
+	}
+
+	consumed := make(map[string]bool)
+	var annotations []Annotation
+
+	// Standard reification: nodes with rdf:subject, rdf:predicate and rdf:object
+	for node, ts := range bySubject {
+		var stmt Triple
+		found := 0
+		for _, t := range ts {
+			switch t.Predicate {
+			case RDFSubject:
+				stmt.Subject = t.Object
+				found++
+			case RDFPredicate:
+				stmt.Predicate = t.Object
+				found++
+			case RDFObject:
+				stmt.Object = t.Object
+				found++
+			}
+		}
+		if found != 3 {
+			continue
+		}
+
+		ann := Annotation{Statement: stmt, Style: StandardReification, Node: node, Asserted: asserted[tripleKey(stmt)]}
+		for _, t := range ts {
+			consumed[tripleKey(t)] = true
+			isStructural := t.Predicate == RDFSubject || t.Predicate == RDFPredicate || t.Predicate == RDFObject ||
+				(t.Predicate == RDFType && t.Object == RDFStatement)
+			if !isStructural {
+				ann.Triples = append(ann.Triples, t)
+			}
+		}
+		annotations = append(annotations, ann)
+	}
+
+	// RDF-star: quoted triple terms used as subjects
+	for node, ts := range bySubject {
+		stmt, ok := ParseQuotedTriple(node)
+		if !ok {
+			continue
+		}
+		ann := Annotation{Statement: stmt, Style: RDFStar, Node: node, Asserted: asserted[tripleKey(stmt)]}
+		for _, t := range ts {
+			consumed[tripleKey(t)] = true
+			ann.Triples = append(ann.Triples, t)
+		}
+		annotations = append(annotations, ann)
+	}
+
+	// Singleton properties: s sp o . sp rdf:singletonPropertyOf p .
+	singletons := make(map[string]string)
+	for _, t := range triples {
+		if t.Predicate == RDFSingletonPropertyOf {
+			singletons[t.Subject] = t.Object
+		}
+	}
+	for _, t := range triples {
+		generic, ok := singletons[t.Predicate]
+		if !ok {
+			continue
+		}
+		stmt := Triple{Subject: t.Subject, Predicate: generic, Object: t.Object}
+		ann := Annotation{Statement: stmt, Style: SingletonProperty, Node: t.Predicate, Asserted: true}
+		consumed[tripleKey(t)] = true
+		for _, at := range bySubject[t.Predicate] {
+			consumed[tripleKey(at)] = true
+			if at.Predicate != RDFSingletonPropertyOf {
+				ann.Triples = append(ann.Triples, at)
+			}
+		}
+		annotations = append(annotations, ann)
+	}
+
+	var rest []Triple
+	for _, t := range triples {
+		if !consumed[tripleKey(t)] {
+			rest = append(rest, t)
+		}
+	}
+
+	sort.Slice(annotations, func(i, j int) bool {
+		return annotations[i].Node < annotations[j].Node
+	})
+
+	return annotations, rest
+}
+
+// NormalizeAnnotations rewrites all statement annotations found in triples,
+// whatever their original style, into the target style. Triples that are not
+// part of an annotation are returned unchanged.
+func NormalizeAnnotations(triples []Triple, target AnnotationStyle) []Triple {
+	annotations, rest := ExtractAnnotations(triples)
+
+	seen := make(map[string]bool)
+	result := make([]Triple, 0, len(triples))
+	add := func(t Triple) {
+		if key := tripleKey(t); !seen[key] {
+			seen[key] = true
+			result = append(result, t)
+		}
+	}
+
+	for _, t := range rest {
+		add(t)
+	}
+	for _, ann := range annotations {
+		for _, t := range renderAnnotation(ann, target) {
+			add(t)
+		}
+	}
+
+	return result
+}
+
+// renderAnnotation writes an annotation in the given style, keeping the
+// original annotation node when the style does not change
+func renderAnnotation(ann Annotation, style AnnotationStyle) []Triple {
+	stmt := ann.Statement
+	node := ann.Node
+	suffix := hashString(stmt.String())[:12]
+	var result []Triple
+
+	switch style {
+	case PlainTriples:
+		if !ann.Asserted {
+			return nil
+		}
+		return []Triple{stmt}
+	case StandardReification:
+		if ann.Style != StandardReification {
+			node = "_:stmt" + suffix
+		}
+		if ann.Asserted {
+			result = append(result, stmt)
+		}
+		result = append(result,
+			Triple{Subject: node, Predicate: RDFType, Object: RDFStatement},
+			Triple{Subject: node, Predicate: RDFSubject, Object: stmt.Subject},
+			Triple{Subject: node, Predicate: RDFPredicate, Object: stmt.Predicate},
+			Triple{Subject: node, Predicate: RDFObject, Object: stmt.Object},
+		)
+	case RDFStar:
+		node = QuotedTriple(stmt)
+		if ann.Asserted {
+			result = append(result, stmt)
+		}
+	case SingletonProperty:
+		if ann.Style != SingletonProperty {
+			node = stmt.Predicate + "_" + suffix
+		}
+		result = append(result,
+			Triple{Subject: stmt.Subject, Predicate: node, Object: stmt.Object},
+			Triple{Subject: node, Predicate: RDFSingletonPropertyOf, Object: stmt.Predicate},
+		)
+	}
+
+	for _, t := range ann.Triples {
+		result = append(result, Triple{Subject: node, Predicate: t.Predicate, Object: t.Object})
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package reasoner
+
+import (
+	"testing"
+)
+
+func TestNormalizeAnnotations(t *testing.T) {
+	const ex = "http://example.org/"
+	stmt := Triple{Subject: ex + "alice", Predicate: ex + "worksFor", Object: ex + "acme"}
+
+	reified := []Triple{
+		stmt,
+		{Subject: "_:r1", Predicate: RDFType, Object: RDFStatement},
+		{Subject: "_:r1", Predicate: RDFSubject, Object: stmt.Subject},
+		{Subject: "_:r1", Predicate: RDFPredicate, Object: stmt.Predicate},
+		{Subject: "_:r1", Predicate: RDFObject, Object: stmt.Object},
+		{Subject: "_:r1", Predicate: ex + "source", Object: ex + "registry"},
+		{Subject: ex + "acme", Predicate: RDFType, Object: ex + "Company"},
+	}
+
+	star := NormalizeAnnotations(reified, RDFStar)
+	quoted := QuotedTriple(stmt)
+	if !containsTriple(star, Triple{Subject: quoted, Predicate: ex + "source", Object: ex + "registry"}) {
+		t.Errorf("Expected quoted triple annotation in %v", star)
+	}
+	if !containsTriple(star, stmt) {
+		t.Errorf("Expected asserted statement to be kept in %v", star)
+	}
+
+	singleton := NormalizeAnnotations(star, SingletonProperty)
+	annotations, _ := ExtractAnnotations(singleton)
+	if len(annotations) != 1 || annotations[0].Statement != stmt || len(annotations[0].Triples) != 1 {
+		t.Errorf("Unexpected singleton property annotations: %+v", annotations)
+	}
+
+	plain := NormalizeAnnotations(singleton, PlainTriples)
+	if len(plain) != 2 || !containsTriple(plain, stmt) {
+		t.Errorf("Expected statement and unrelated triple only, got %v", plain)
+	}
+
+	// Statements that are only quoted are not asserted by dropping the
+	// annotation
+	quotedOnly := []Triple{{Subject: quoted, Predicate: ex + "source", Object: ex + "rumor"}}
+	if plain := NormalizeAnnotations(quotedOnly, PlainTriples); len(plain) != 0 {
+		t.Errorf("Expected no triples for a quoted statement, got %v", plain)
+	}
+}
+
+func TestParseQuotedTriple(t *testing.T) {
+	stmt := Triple{Subject: "http://example.org/a", Predicate: "http://example.org/name", Object: `"A b"@en`}
+	parsed, ok := ParseQuotedTriple(QuotedTriple(stmt))
+	if !ok || parsed != stmt {
+		t.Errorf("ParseQuotedTriple round trip failed: got %+v", parsed)
+	}
+}
+
+func containsTriple(triples []Triple, want Triple) bool {
+	for _, t := range triples {
+		if t == want {
+			return true
+		}
+	}
+	return false
+}
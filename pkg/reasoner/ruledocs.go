@@ -0,0 +1,182 @@
+package reasoner
+
+import "sort"
+
+// Origins of the rules of a rule set
+const (
+	RuleOriginBuiltIn = "built-in"
+	RuleOriginCustom  = "custom"
+)
+
+// ruleDoc explains a default rule in prose, with an example inference
+type ruleDoc struct {
+	description string
+	example     string
+}
+
+// ruleDocs documents the default rules by name
+var ruleDocs = map[string]ruleDoc{ //nolint:gochecknoglobals
+	"rdfs:subClassOf-transitivity": {
+		"A subclass of a subclass is a subclass",
+		"ex:Cat rdfs:subClassOf ex:Mammal . ex:Mammal rdfs:subClassOf ex:Animal . => ex:Cat rdfs:subClassOf ex:Animal .",
+	},
+	"rdf:type-inheritance": {
+		"Instances of a class are instances of its superclasses",
+		"ex:tom rdf:type ex:Cat . ex:Cat rdfs:subClassOf ex:Animal . => ex:tom rdf:type ex:Animal .",
+	},
+	"rdfs:domain-inference": {
+		"Subjects of a property are instances of its domain",
+		"ex:owns rdfs:domain ex:Person . ex:alice ex:owns ex:tom . => ex:alice rdf:type ex:Person .",
+	},
+	"rdfs:range-inference": {
+		"Resource values of a property are instances of its range; literals are not typed",
+		"ex:owns rdfs:range ex:Animal . ex:alice ex:owns ex:tom . => ex:tom rdf:type ex:Animal .",
+	},
+	"rdfs:subPropertyOf-transitivity": {
+		"A subproperty of a subproperty is a subproperty",
+		"ex:hasMother rdfs:subPropertyOf ex:hasParent . ex:hasParent rdfs:subPropertyOf ex:hasAncestor . => ex:hasMother rdfs:subPropertyOf ex:hasAncestor .",
+	},
+	"rdfs:subPropertyOf-inheritance": {
+		"Values of a property are values of its superproperties",
+		"ex:hasMother rdfs:subPropertyOf ex:hasParent . ex:bob ex:hasMother ex:carol . => ex:bob ex:hasParent ex:carol .",
+	},
+	"owl:equivalentClass-symmetry": {
+		"Class equivalence holds in both directions",
+		"ex:Human owl:equivalentClass ex:Person . => ex:Person owl:equivalentClass ex:Human .",
+	},
+	"owl:equivalentClass-transitivity": {
+		"Classes equivalent to the same class are equivalent",
+		"ex:Human owl:equivalentClass ex:Person . ex:Person owl:equivalentClass ex:Individual . => ex:Human owl:equivalentClass ex:Individual .",
+	},
+	"owl:sameAs-symmetry": {
+		"Identity holds in both directions",
+		"ex:zurich owl:sameAs ex:zuerich . => ex:zuerich owl:sameAs ex:zurich .",
+	},
+	"owl:sameAs-transitivity": {
+		"Resources identical to the same resource are identical",
+		"ex:zurich owl:sameAs ex:zuerich . ex:zuerich owl:sameAs ex:zh . => ex:zurich owl:sameAs ex:zh .",
+	},
+	"owl:inverseOf-inference": {
+		"Values of a property are values of its inverse in the opposite direction",
+		"ex:hasChild owl:inverseOf ex:hasParent . ex:carol ex:hasChild ex:bob . => ex:bob ex:hasParent ex:carol .",
+	},
+	"owl:TransitiveProperty-inference": {
+		"Values of a transitive property chain",
+		"ex:partOf rdf:type owl:TransitiveProperty . ex:bern ex:partOf ex:be . ex:be ex:partOf ex:ch . => ex:bern ex:partOf ex:ch .",
+	},
+	"owl:SymmetricProperty-inference": {
+		"Values of a symmetric property hold in both directions",
+		"ex:borders rdf:type owl:SymmetricProperty . ex:be ex:borders ex:fr . => ex:fr ex:borders ex:be .",
+	},
+	"owl:AllDifferent-expansion": {
+		"The members of an owl:AllDifferent axiom are pairwise different",
+		"_:d rdf:type owl:AllDifferent ; owl:members (ex:a ex:b) . => ex:a owl:differentFrom ex:b . ex:b owl:differentFrom ex:a .",
+	},
+	"owl:AllDisjointClasses-expansion": {
+		"The members of an owl:AllDisjointClasses axiom are pairwise disjoint",
+		"_:d rdf:type owl:AllDisjointClasses ; owl:members (ex:Cat ex:Dog) . => ex:Cat owl:disjointWith ex:Dog . ex:Dog owl:disjointWith ex:Cat .",
+	},
+	"owl:hasKey": {
+		"Named instances of a class with the same values for all of its key properties are the same; literals are compared by value",
+		"ex:Canton owl:hasKey (ex:code) . ex:a rdf:type ex:Canton ; ex:code \"BE\" . ex:b rdf:type ex:Canton ; ex:code \"BE\" . => ex:a owl:sameAs ex:b .",
+	},
+	"rdfs:label-fallback": {
+		"Resources without an rdfs:label or skos:prefLabel are labelled with their local name split into words",
+		"ex:bern ex:postalCode \"3000\" . => ex:bern rdfs:label \"bern\" . ex:postalCode rdfs:label \"postal code\" .",
+	},
+}
+
+// RuleDescription describes a rule of a rule set: where it comes from,
+// whether it is applied, and what it infers
+type RuleDescription struct {
+	Name        string `json:"name"`
+	Origin      string `json:"origin"` // RuleOriginBuiltIn or RuleOriginCustom
+	Active      bool   `json:"active"`
+	Description string `json:"description,omitempty"`
+	Definition  string `json:"definition,omitempty"` // Datalog clauses, if the rule implements DefinedRule
+	Example     string `json:"example,omitempty"`    // Triples and what the rule infers from them
+}
+
+// DescribeRules describes the effective semantics of a rule set: its rules
+// in order, followed by the default rules it leaves out, which are inactive
+func DescribeRules(rules []Rule) []RuleDescription {
+	descriptions := make([]RuleDescription, 0, len(rules))
+	active := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		active[rule.Name()] = true
+		descriptions = append(descriptions, describeRule(rule, true))
+	}
+	for _, rule := range DefaultRules() {
+		if !active[rule.Name()] {
+			descriptions = append(descriptions, describeRule(rule, false))
+		}
+	}
+	return descriptions
+}
+
+// describeRule describes a single rule
+func describeRule(rule Rule, active bool) RuleDescription {
+	d := RuleDescription{
+		Name:       rule.Name(),
+		Origin:     RuleOriginCustom,
+		Active:     active,
+		Definition: ruleDefinition(rule),
+	}
+	if _, ok := RuleByName(rule.Name()); ok {
+		d.Origin = RuleOriginBuiltIn
+		d.Description = ruleDocs[d.Name].description
+		d.Example = ruleDocs[d.Name].example
+	}
+	return d
+}
+
+// ruleDefinition returns the Datalog definition of a rule, or ""
+func ruleDefinition(rule Rule) string {
+	if defined, ok := rule.(DefinedRule); ok {
+		return defined.Definition()
+	}
+	return ""
+}
+
+// RuleChange is a difference between two rule sets
+type RuleChange struct {
+	Name   string `json:"name"`
+	Change string `json:"change"`           // "added", "removed" or "changed"
+	Before string `json:"before,omitempty"` // Definition in the first set
+	After  string `json:"after,omitempty"`  // Definition in the second set
+}
+
+// DiffRules compares two rule sets by rule name, reporting rules only in
+// to as added, rules only in from as removed, and rules in both with
+// different definitions as changed. The order of rules is ignored, as it
+// does not change the closure. Changes are sorted by name.
+func DiffRules(from, to []Rule) []RuleChange {
+	before := make(map[string]string, len(from))
+	for _, rule := range from {
+		before[rule.Name()] = ruleDefinition(rule)
+	}
+	after := make(map[string]string, len(to))
+	for _, rule := range to {
+		after[rule.Name()] = ruleDefinition(rule)
+	}
+
+	var changes []RuleChange
+	for name, definition := range before {
+		if _, ok := after[name]; !ok {
+			changes = append(changes, RuleChange{Name: name, Change: "removed", Before: definition})
+		}
+	}
+	for name, definition := range after {
+		previous, ok := before[name]
+		switch {
+		case !ok:
+			changes = append(changes, RuleChange{Name: name, Change: "added", After: definition})
+		case previous != definition:
+			changes = append(changes, RuleChange{Name: name, Change: "changed", Before: previous, After: definition})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
@@ -0,0 +1,64 @@
+package reasoner
+
+import (
+	"testing"
+)
+
+func TestDescribeRules(t *testing.T) {
+	for _, d := range DescribeRules(DefaultRules()) {
+		if !d.Active || d.Origin != RuleOriginBuiltIn {
+			t.Errorf("Expected %s to be an active built-in rule, got %+v", d.Name, d)
+		}
+		if d.Description == "" || d.Example == "" {
+			t.Errorf("Rule %s is not documented", d.Name)
+		}
+	}
+
+	custom, err := ParseTripleRule("in-canton", "triple(X, ex:inCanton, C) :- triple(X, ex:inDistrict, D), triple(D, ex:inCanton, C).",
+		map[string]string{"ex": "http://example.org/"})
+	if err != nil {
+		t.Fatalf("ParseTripleRule failed: %v", err)
+	}
+	descriptions := DescribeRules([]Rule{&TypeInheritance{}, custom})
+	if len(descriptions) != len(DefaultRules())+1 {
+		t.Fatalf("Expected the two rules and the %d disabled default rules, got %d", len(DefaultRules())-1, len(descriptions))
+	}
+	if d := descriptions[0]; d.Name != "rdf:type-inheritance" || !d.Active {
+		t.Errorf("Unexpected first rule %+v", d)
+	}
+	if d := descriptions[1]; d.Name != "in-canton" || d.Origin != RuleOriginCustom || !d.Active || d.Definition == "" {
+		t.Errorf("Unexpected custom rule %+v", d)
+	}
+	for _, d := range descriptions[2:] {
+		if d.Active || d.Origin != RuleOriginBuiltIn || d.Name == "rdf:type-inheritance" {
+			t.Errorf("Expected a disabled built-in rule, got %+v", d)
+		}
+	}
+}
+
+func TestDiffRules(t *testing.T) {
+	prefixes := map[string]string{"ex": "http://example.org/"}
+	v1, _ := ParseTripleRule("in-canton", "triple(X, ex:inCanton, C) :- triple(X, ex:inDistrict, D), triple(D, ex:inCanton, C).", prefixes)
+	v2, _ := ParseTripleRule("in-canton", "triple(X, ex:inCanton, C) :- triple(X, ex:inCommune, D), triple(D, ex:inCanton, C).", prefixes)
+
+	changes := DiffRules(
+		[]Rule{&SubClassTransitivity{}, &DomainInference{}, v1},
+		[]Rule{&RangeInference{}, v2, &SubClassTransitivity{}},
+	)
+	expected := []RuleChange{
+		{Name: "in-canton", Change: "changed", Before: v1.Definition(), After: v2.Definition()},
+		{Name: "rdfs:domain-inference", Change: "removed", Before: (&DomainInference{}).Definition()},
+		{Name: "rdfs:range-inference", Change: "added", After: (&RangeInference{}).Definition()},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("DiffRules() = %+v, want %+v", changes, expected)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Change %d = %+v, want %+v", i, changes[i], expected[i])
+		}
+	}
+	if changes := DiffRules(DefaultRules(), DefaultRules()); len(changes) != 0 {
+		t.Errorf("Expected no changes between identical rule sets, got %+v", changes)
+	}
+}
@@ -10,10 +10,15 @@ const (
 	OWLClass          = "http://www.w3.org/2002/07/owl#Class"
 	OWLThing          = "http://www.w3.org/2002/07/owl#Thing"
 	OWLEquivalentClass = "http://www.w3.org/2002/07/owl#equivalentClass"
+	OWLEquivalentProperty = "http://www.w3.org/2002/07/owl#equivalentProperty"
 	OWLSameAs         = "http://www.w3.org/2002/07/owl#sameAs"
 	OWLInverseOf      = "http://www.w3.org/2002/07/owl#inverseOf"
 	OWLTransitiveProperty = "http://www.w3.org/2002/07/owl#TransitiveProperty"
 	OWLSymmetricProperty  = "http://www.w3.org/2002/07/owl#SymmetricProperty"
+	OWLFunctionalProperty        = "http://www.w3.org/2002/07/owl#FunctionalProperty"
+	OWLInverseFunctionalProperty = "http://www.w3.org/2002/07/owl#InverseFunctionalProperty"
+	RDFSLabel         = "http://www.w3.org/2000/01/rdf-schema#label"
+	SKOSPrefLabel     = "http://www.w3.org/2004/02/skos/core#prefLabel"
 )
 
 // Rule represents a forward reasoning rule
@@ -24,6 +29,14 @@ type Rule interface {
 	Apply(store *TripleStore) []Triple
 }
 
+// DefinedRule is implemented by rules that can state their semantics as
+// Datalog clauses over triple(Subject, Predicate, Object)
+type DefinedRule interface {
+	Rule
+	// Definition returns the Datalog clauses equivalent to the rule
+	Definition() string
+}
+
 // SubClassTransitivity implements rdfs:subClassOf transitivity
 // If A rdfs:subClassOf B and B rdfs:subClassOf C, then A rdfs:subClassOf C
 type SubClassTransitivity struct{}
@@ -32,6 +45,10 @@ func (r *SubClassTransitivity) Name() string {
 	return "rdfs:subClassOf-transitivity"
 }
 
+func (r *SubClassTransitivity) Definition() string {
+	return `triple(A, rdfs:subClassOf, C) :- triple(A, rdfs:subClassOf, B), triple(B, rdfs:subClassOf, C).  % where A != C`
+}
+
 func (r *SubClassTransitivity) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -64,6 +81,10 @@ func (r *TypeInheritance) Name() string {
 	return "rdf:type-inheritance"
 }
 
+func (r *TypeInheritance) Definition() string {
+	return `triple(X, rdf:type, B) :- triple(X, rdf:type, A), triple(A, rdfs:subClassOf, B).`
+}
+
 func (r *TypeInheritance) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -96,28 +117,24 @@ func (r *DomainInference) Name() string {
 	return "rdfs:domain-inference"
 }
 
-func (r *DomainInference) Apply(store *TripleStore) []Triple {
-	var inferred []Triple
-
-	domainTriples := store.FindByPredicate(RDFSDomain)
+func (r *DomainInference) Definition() string {
+	return `triple(X, rdf:type, C) :- triple(P, rdfs:domain, C), triple(X, P, Y).`
+}
 
-	for _, dt := range domainTriples {
-		// dt: P rdfs:domain C
-		p := dt.Subject
-		c := dt.Object
+func (r *DomainInference) Apply(store *TripleStore) []Triple {
+	c := &deltaCollector{store: store}
 
-		// Find all: X P Y
+	// Join each property's triples once with all of its domains
+	domains := newPropertyIndex(store, RDFSDomain)
+	for _, p := range domains.properties {
 		for _, t := range store.FindByPredicate(p) {
-			x := t.Subject
-			// Infer: X rdf:type C
-			newTriple := Triple{Subject: x, Predicate: RDFType, Object: c}
-			if !store.Contains(newTriple) {
-				inferred = append(inferred, newTriple)
+			for _, class := range domains.objects[p] {
+				c.add(Triple{Subject: t.Subject, Predicate: RDFType, Object: class})
 			}
 		}
 	}
 
-	return inferred
+	return c.inferred
 }
 
 // RangeInference implements rdfs:range inference
@@ -128,32 +145,28 @@ func (r *RangeInference) Name() string {
 	return "rdfs:range-inference"
 }
 
-func (r *RangeInference) Apply(store *TripleStore) []Triple {
-	var inferred []Triple
-
-	rangeTriples := store.FindByPredicate(RDFSRange)
+func (r *RangeInference) Definition() string {
+	return `triple(Y, rdf:type, C) :- triple(P, rdfs:range, C), triple(X, P, Y).  % where Y is not a literal`
+}
 
-	for _, rt := range rangeTriples {
-		// rt: P rdfs:range C
-		p := rt.Subject
-		c := rt.Object
+func (r *RangeInference) Apply(store *TripleStore) []Triple {
+	c := &deltaCollector{store: store}
 
-		// Find all: X P Y
+	// Join each property's triples once with all of its ranges
+	ranges := newPropertyIndex(store, RDFSRange)
+	for _, p := range ranges.properties {
 		for _, t := range store.FindByPredicate(p) {
-			y := t.Object
-			// Skip literals
-			if len(y) > 0 && y[0] == '"' {
+			// Skip literals, which cannot be typed
+			if t.ObjectTerm().IsLiteral() {
 				continue
 			}
-			// Infer: Y rdf:type C
-			newTriple := Triple{Subject: y, Predicate: RDFType, Object: c}
-			if !store.Contains(newTriple) {
-				inferred = append(inferred, newTriple)
+			for _, class := range ranges.objects[p] {
+				c.add(Triple{Subject: t.Object, Predicate: RDFType, Object: class})
 			}
 		}
 	}
 
-	return inferred
+	return c.inferred
 }
 
 // SubPropertyTransitivity implements rdfs:subPropertyOf transitivity
@@ -164,6 +177,10 @@ func (r *SubPropertyTransitivity) Name() string {
 	return "rdfs:subPropertyOf-transitivity"
 }
 
+func (r *SubPropertyTransitivity) Definition() string {
+	return `triple(P1, rdfs:subPropertyOf, P3) :- triple(P1, rdfs:subPropertyOf, P2), triple(P2, rdfs:subPropertyOf, P3).  % where P1 != P3`
+}
+
 func (r *SubPropertyTransitivity) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -193,24 +210,24 @@ func (r *SubPropertyInheritance) Name() string {
 	return "rdfs:subPropertyOf-inheritance"
 }
 
-func (r *SubPropertyInheritance) Apply(store *TripleStore) []Triple {
-	var inferred []Triple
-
-	subPropTriples := store.FindByPredicate(RDFSSubPropertyOf)
+func (r *SubPropertyInheritance) Definition() string {
+	return `triple(X, P2, Y) :- triple(P1, rdfs:subPropertyOf, P2), triple(X, P1, Y).`
+}
 
-	for _, sp := range subPropTriples {
-		p1 := sp.Subject
-		p2 := sp.Object
+func (r *SubPropertyInheritance) Apply(store *TripleStore) []Triple {
+	c := &deltaCollector{store: store}
 
+	// Join each property's triples once with all of its superproperties
+	supers := newPropertyIndex(store, RDFSSubPropertyOf)
+	for _, p1 := range supers.properties {
 		for _, t := range store.FindByPredicate(p1) {
-			newTriple := Triple{Subject: t.Subject, Predicate: p2, Object: t.Object}
-			if !store.Contains(newTriple) {
-				inferred = append(inferred, newTriple)
+			for _, p2 := range supers.objects[p1] {
+				c.add(Triple{Subject: t.Subject, Predicate: p2, Object: t.Object})
 			}
 		}
 	}
 
-	return inferred
+	return c.inferred
 }
 
 // EquivalentClassSymmetry implements owl:equivalentClass symmetry
@@ -221,6 +238,10 @@ func (r *EquivalentClassSymmetry) Name() string {
 	return "owl:equivalentClass-symmetry"
 }
 
+func (r *EquivalentClassSymmetry) Definition() string {
+	return `triple(B, owl:equivalentClass, A) :- triple(A, owl:equivalentClass, B).`
+}
+
 func (r *EquivalentClassSymmetry) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -228,7 +249,7 @@ func (r *EquivalentClassSymmetry) Apply(store *TripleStore) []Triple {
 
 	for _, t := range eqTriples {
 		newTriple := Triple{Subject: t.Object, Predicate: OWLEquivalentClass, Object: t.Subject}
-		if !store.Contains(newTriple) {
+		if !store.Contains(newTriple) && newTriple.IsWellFormed() {
 			inferred = append(inferred, newTriple)
 		}
 	}
@@ -244,6 +265,10 @@ func (r *EquivalentClassTransitivity) Name() string {
 	return "owl:equivalentClass-transitivity"
 }
 
+func (r *EquivalentClassTransitivity) Definition() string {
+	return `triple(A, owl:equivalentClass, C) :- triple(A, owl:equivalentClass, B), triple(B, owl:equivalentClass, C).  % where A != C`
+}
+
 func (r *EquivalentClassTransitivity) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -272,6 +297,10 @@ func (r *SameAsSymmetry) Name() string {
 	return "owl:sameAs-symmetry"
 }
 
+func (r *SameAsSymmetry) Definition() string {
+	return `triple(B, owl:sameAs, A) :- triple(A, owl:sameAs, B).`
+}
+
 func (r *SameAsSymmetry) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -279,7 +308,7 @@ func (r *SameAsSymmetry) Apply(store *TripleStore) []Triple {
 
 	for _, t := range sameAsTriples {
 		newTriple := Triple{Subject: t.Object, Predicate: OWLSameAs, Object: t.Subject}
-		if !store.Contains(newTriple) {
+		if !store.Contains(newTriple) && newTriple.IsWellFormed() {
 			inferred = append(inferred, newTriple)
 		}
 	}
@@ -294,6 +323,10 @@ func (r *SameAsTransitivity) Name() string {
 	return "owl:sameAs-transitivity"
 }
 
+func (r *SameAsTransitivity) Definition() string {
+	return `triple(A, owl:sameAs, C) :- triple(A, owl:sameAs, B), triple(B, owl:sameAs, C).  % where A != C`
+}
+
 func (r *SameAsTransitivity) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -323,6 +356,11 @@ func (r *InversePropertyInference) Name() string {
 	return "owl:inverseOf-inference"
 }
 
+func (r *InversePropertyInference) Definition() string {
+	return `triple(Y, P2, X) :- triple(P1, owl:inverseOf, P2), triple(X, P1, Y).
+triple(Y, P1, X) :- triple(P1, owl:inverseOf, P2), triple(X, P2, Y).`
+}
+
 func (r *InversePropertyInference) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -335,7 +373,7 @@ func (r *InversePropertyInference) Apply(store *TripleStore) []Triple {
 		// For X P1 Y, infer Y P2 X
 		for _, t := range store.FindByPredicate(p1) {
 			newTriple := Triple{Subject: t.Object, Predicate: p2, Object: t.Subject}
-			if !store.Contains(newTriple) {
+			if !store.Contains(newTriple) && newTriple.IsWellFormed() {
 				inferred = append(inferred, newTriple)
 			}
 		}
@@ -343,7 +381,7 @@ func (r *InversePropertyInference) Apply(store *TripleStore) []Triple {
 		// For X P2 Y, infer Y P1 X
 		for _, t := range store.FindByPredicate(p2) {
 			newTriple := Triple{Subject: t.Object, Predicate: p1, Object: t.Subject}
-			if !store.Contains(newTriple) {
+			if !store.Contains(newTriple) && newTriple.IsWellFormed() {
 				inferred = append(inferred, newTriple)
 			}
 		}
@@ -360,6 +398,10 @@ func (r *TransitivePropertyInference) Name() string {
 	return "owl:TransitiveProperty-inference"
 }
 
+func (r *TransitivePropertyInference) Definition() string {
+	return `triple(X, P, Z) :- triple(P, rdf:type, owl:TransitiveProperty), triple(X, P, Y), triple(Y, P, Z).  % where X != Z`
+}
+
 func (r *TransitivePropertyInference) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -397,6 +439,10 @@ func (r *SymmetricPropertyInference) Name() string {
 	return "owl:SymmetricProperty-inference"
 }
 
+func (r *SymmetricPropertyInference) Definition() string {
+	return `triple(Y, P, X) :- triple(P, rdf:type, owl:SymmetricProperty), triple(X, P, Y).`
+}
+
 func (r *SymmetricPropertyInference) Apply(store *TripleStore) []Triple {
 	var inferred []Triple
 
@@ -409,7 +455,7 @@ func (r *SymmetricPropertyInference) Apply(store *TripleStore) []Triple {
 	for prop := range symmetricProps {
 		for _, t := range store.FindByPredicate(prop) {
 			newTriple := Triple{Subject: t.Object, Predicate: prop, Object: t.Subject}
-			if !store.Contains(newTriple) {
+			if !store.Contains(newTriple) && newTriple.IsWellFormed() {
 				inferred = append(inferred, newTriple)
 			}
 		}
@@ -434,5 +480,16 @@ func DefaultRules() []Rule {
 		&InversePropertyInference{},
 		&TransitivePropertyInference{},
 		&SymmetricPropertyInference{},
+		&AllDifferentExpansion{},
+		&AllDisjointClassesExpansion{},
+		&HasKeyInference{},
+	}
+}
+
+// OptionalRules returns the built-in rules that are not applied by default.
+// Rule sets can list them by name.
+func OptionalRules() []Rule {
+	return []Rule{
+		&LabelFallback{},
 	}
 }
\ No newline at end of file
@@ -0,0 +1,274 @@
+package reasoner
+
+// DeltaRule is implemented by rules that support semi-naive evaluation.
+//
+// ApplyDelta must return every conclusion whose premises include at least
+// one triple from delta; the remaining premises may come from anywhere in
+// the store. Rules that do not implement DeltaRule are re-applied to the
+// whole store in every round.
+type DeltaRule interface {
+	Rule
+	// ApplyDelta applies the rule to combinations involving the delta triples
+	ApplyDelta(store *TripleStore, delta []Triple) []Triple
+}
+
+// deltaCollector accumulates well-formed conclusions that are not yet in
+// the store, each once
+type deltaCollector struct {
+	store    *TripleStore
+	inferred []Triple
+	seen     map[string]bool
+}
+
+func (c *deltaCollector) add(t Triple) {
+	key := tripleKey(t)
+	if c.seen[key] || c.store.Contains(t) || !t.IsWellFormed() {
+		return
+	}
+	if c.seen == nil {
+		c.seen = make(map[string]bool)
+	}
+	c.seen[key] = true
+	c.inferred = append(c.inferred, t)
+}
+
+// propertyIndex maps the subjects of a schema predicate to its objects, such
+// as properties to their rdfs:domain classes. Rules build it once per
+// application, so that joining many triples with the schema costs a map
+// lookup per triple instead of an index scan.
+type propertyIndex struct {
+	properties []string // Subjects in store order
+	objects    map[string][]string
+}
+
+// newPropertyIndex indexes the triples of a schema predicate by subject
+func newPropertyIndex(store *TripleStore, predicate string) propertyIndex {
+	index := propertyIndex{objects: make(map[string][]string)}
+	for _, t := range store.FindByPredicate(predicate) {
+		if _, ok := index.objects[t.Subject]; !ok {
+			index.properties = append(index.properties, t.Subject)
+		}
+		index.objects[t.Subject] = append(index.objects[t.Subject], t.Object)
+	}
+	return index
+}
+
+// applyTransitiveDelta joins delta triples of a transitive predicate with
+// the store in both directions: A p B (new) with B p C, and Z p A with A p B (new)
+func applyTransitiveDelta(store *TripleStore, delta []Triple, predicate string, allowReflexive bool) []Triple {
+	c := &deltaCollector{store: store}
+
+	for _, d := range delta {
+		if d.Predicate != predicate {
+			continue
+		}
+		for _, next := range store.FindBySubjectPredicate(d.Object, predicate) {
+			if allowReflexive || d.Subject != next.Object {
+				c.add(Triple{Subject: d.Subject, Predicate: predicate, Object: next.Object})
+			}
+		}
+		for _, prev := range store.FindByPredicateObject(predicate, d.Subject) {
+			if allowReflexive || prev.Subject != d.Object {
+				c.add(Triple{Subject: prev.Subject, Predicate: predicate, Object: d.Object})
+			}
+		}
+	}
+
+	return c.inferred
+}
+
+// applySymmetricDelta mirrors delta triples of a symmetric predicate
+func applySymmetricDelta(store *TripleStore, delta []Triple, predicate string) []Triple {
+	c := &deltaCollector{store: store}
+	for _, d := range delta {
+		if d.Predicate == predicate {
+			c.add(Triple{Subject: d.Object, Predicate: predicate, Object: d.Subject})
+		}
+	}
+	return c.inferred
+}
+
+func (r *SubClassTransitivity) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	return applyTransitiveDelta(store, delta, RDFSSubClassOf, false)
+}
+
+func (r *TypeInheritance) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	c := &deltaCollector{store: store}
+
+	for _, d := range delta {
+		switch d.Predicate {
+		case RDFType:
+			// X rdf:type A (new), A subClassOf B
+			for _, sc := range store.FindBySubjectPredicate(d.Object, RDFSSubClassOf) {
+				c.add(Triple{Subject: d.Subject, Predicate: RDFType, Object: sc.Object})
+			}
+		case RDFSSubClassOf:
+			// A subClassOf B (new), X rdf:type A
+			for _, t := range store.FindByPredicateObject(RDFType, d.Subject) {
+				c.add(Triple{Subject: t.Subject, Predicate: RDFType, Object: d.Object})
+			}
+		}
+	}
+
+	return c.inferred
+}
+
+func (r *DomainInference) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	c := &deltaCollector{store: store}
+	domains := newPropertyIndex(store, RDFSDomain)
+	if len(domains.properties) == 0 {
+		return nil
+	}
+
+	for _, d := range delta {
+		if d.Predicate == RDFSDomain {
+			// P rdfs:domain C (new), X P Y
+			for _, t := range store.FindByPredicate(d.Subject) {
+				c.add(Triple{Subject: t.Subject, Predicate: RDFType, Object: d.Object})
+			}
+		}
+		// X P Y (new), P rdfs:domain C
+		for _, class := range domains.objects[d.Predicate] {
+			c.add(Triple{Subject: d.Subject, Predicate: RDFType, Object: class})
+		}
+	}
+
+	return c.inferred
+}
+
+func (r *RangeInference) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	c := &deltaCollector{store: store}
+	ranges := newPropertyIndex(store, RDFSRange)
+	if len(ranges.properties) == 0 {
+		return nil
+	}
+
+	for _, d := range delta {
+		if d.Predicate == RDFSRange {
+			// P rdfs:range C (new), X P Y
+			for _, t := range store.FindByPredicate(d.Subject) {
+				if !t.ObjectTerm().IsLiteral() {
+					c.add(Triple{Subject: t.Object, Predicate: RDFType, Object: d.Object})
+				}
+			}
+		}
+		// X P Y (new), P rdfs:range C
+		if d.ObjectTerm().IsLiteral() {
+			continue
+		}
+		for _, class := range ranges.objects[d.Predicate] {
+			c.add(Triple{Subject: d.Object, Predicate: RDFType, Object: class})
+		}
+	}
+
+	return c.inferred
+}
+
+func (r *SubPropertyTransitivity) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	return applyTransitiveDelta(store, delta, RDFSSubPropertyOf, false)
+}
+
+func (r *SubPropertyInheritance) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	c := &deltaCollector{store: store}
+	supers := newPropertyIndex(store, RDFSSubPropertyOf)
+	if len(supers.properties) == 0 {
+		return nil
+	}
+
+	for _, d := range delta {
+		if d.Predicate == RDFSSubPropertyOf {
+			// P1 subPropertyOf P2 (new), X P1 Y
+			for _, t := range store.FindByPredicate(d.Subject) {
+				c.add(Triple{Subject: t.Subject, Predicate: d.Object, Object: t.Object})
+			}
+		}
+		// X P1 Y (new), P1 subPropertyOf P2
+		for _, p2 := range supers.objects[d.Predicate] {
+			c.add(Triple{Subject: d.Subject, Predicate: p2, Object: d.Object})
+		}
+	}
+
+	return c.inferred
+}
+
+func (r *EquivalentClassSymmetry) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	return applySymmetricDelta(store, delta, OWLEquivalentClass)
+}
+
+func (r *EquivalentClassTransitivity) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	return applyTransitiveDelta(store, delta, OWLEquivalentClass, false)
+}
+
+func (r *SameAsSymmetry) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	return applySymmetricDelta(store, delta, OWLSameAs)
+}
+
+func (r *SameAsTransitivity) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	return applyTransitiveDelta(store, delta, OWLSameAs, false)
+}
+
+func (r *InversePropertyInference) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	c := &deltaCollector{store: store}
+
+	for _, d := range delta {
+		if d.Predicate == OWLInverseOf {
+			// P1 owl:inverseOf P2 (new): mirror all P1 and P2 assertions
+			for _, t := range store.FindByPredicate(d.Subject) {
+				c.add(Triple{Subject: t.Object, Predicate: d.Object, Object: t.Subject})
+			}
+			for _, t := range store.FindByPredicate(d.Object) {
+				c.add(Triple{Subject: t.Object, Predicate: d.Subject, Object: t.Subject})
+			}
+		}
+		// X P Y (new) where P is either side of an inverseOf axiom
+		for _, inv := range store.FindBySubjectPredicate(d.Predicate, OWLInverseOf) {
+			c.add(Triple{Subject: d.Object, Predicate: inv.Object, Object: d.Subject})
+		}
+		for _, inv := range store.FindByPredicateObject(OWLInverseOf, d.Predicate) {
+			c.add(Triple{Subject: d.Object, Predicate: inv.Subject, Object: d.Subject})
+		}
+	}
+
+	return c.inferred
+}
+
+func (r *TransitivePropertyInference) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	c := &deltaCollector{store: store}
+
+	for _, d := range delta {
+		if d.Predicate == RDFType && d.Object == OWLTransitiveProperty {
+			// P becomes transitive: close all existing P assertions
+			prop := d.Subject
+			for _, t1 := range store.FindByPredicate(prop) {
+				for _, t2 := range store.FindBySubjectPredicate(t1.Object, prop) {
+					if t1.Subject != t2.Object {
+						c.add(Triple{Subject: t1.Subject, Predicate: prop, Object: t2.Object})
+					}
+				}
+			}
+		}
+		if store.Contains(Triple{Subject: d.Predicate, Predicate: RDFType, Object: OWLTransitiveProperty}) {
+			c.inferred = append(c.inferred, applyTransitiveDelta(store, []Triple{d}, d.Predicate, false)...)
+		}
+	}
+
+	return c.inferred
+}
+
+func (r *SymmetricPropertyInference) ApplyDelta(store *TripleStore, delta []Triple) []Triple {
+	c := &deltaCollector{store: store}
+
+	for _, d := range delta {
+		if d.Predicate == RDFType && d.Object == OWLSymmetricProperty {
+			// P becomes symmetric: mirror all existing P assertions
+			for _, t := range store.FindByPredicate(d.Subject) {
+				c.add(Triple{Subject: t.Object, Predicate: d.Subject, Object: t.Subject})
+			}
+		}
+		if store.Contains(Triple{Subject: d.Predicate, Predicate: RDFType, Object: OWLSymmetricProperty}) {
+			c.add(Triple{Subject: d.Object, Predicate: d.Predicate, Object: d.Subject})
+		}
+	}
+
+	return c.inferred
+}
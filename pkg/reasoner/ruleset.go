@@ -0,0 +1,213 @@
+package reasoner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExportRules renders a rule set as a Datalog program over
+// triple(Subject, Predicate, Object), so the effective inference semantics
+// can be reviewed or evaluated with DLQuery against triple facts.
+// Rules that do not implement DefinedRule are listed as comments.
+func ExportRules(rules []Rule) string {
+	var sb strings.Builder
+
+	sb.WriteString("% goreasoner rule set\n")
+	sb.WriteString("% Prefixes: rdf, rdfs and owl denote the standard W3C namespaces.\n")
+	sb.WriteString(fmt.Sprintf("%% Rules: %d\n", len(rules)))
+
+	for _, rule := range rules {
+		sb.WriteString("\n% ")
+		sb.WriteString(rule.Name())
+		sb.WriteString("\n")
+
+		if defined, ok := rule.(DefinedRule); ok {
+			sb.WriteString(defined.Definition())
+			sb.WriteString("\n")
+		} else {
+			sb.WriteString("% (definition not available for this rule)\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// WithRules replaces the default rules of a reasoner created with NewReasoner
+func WithRules(rules []Rule) Option {
+	return func(r *Reasoner) {
+		r.rules = rules
+	}
+}
+
+// RuleByName returns the default or optional rule with the given name
+func RuleByName(name string) (Rule, bool) {
+	for _, rule := range append(DefaultRules(), OptionalRules()...) {
+		if rule.Name() == name {
+			return rule, true
+		}
+	}
+	return nil, false
+}
+
+// RuleSetDefinition defines a reusable rule set, typically read from a
+// configuration file so that inference is reproducible across teams
+type RuleSetDefinition struct {
+	// Rules lists default and optional rules by name; "default" stands for
+	// all default rules
+	Rules []string
+	// Custom lists rules written as Datalog clauses over triple/3
+	Custom []CustomRuleDefinition
+	// Prefixes declares the prefixes used in custom rules, in addition to
+	// the standard rdf, rdfs, owl and xsd prefixes
+	Prefixes map[string]string
+}
+
+// CustomRuleDefinition names a rule written as a Datalog clause, such as
+// "triple(X, ex:inCanton, C) :- triple(X, ex:inDistrict, D), triple(D, ex:inCanton, C)."
+type CustomRuleDefinition struct {
+	Name string
+	Rule string
+}
+
+// Build returns the rules of the set: the named default rules in the
+// order listed, followed by the custom rules
+func (d RuleSetDefinition) Build() ([]Rule, error) {
+	var rules []Rule
+	seen := make(map[string]bool)
+	add := func(rule Rule) error {
+		if seen[rule.Name()] {
+			return fmt.Errorf("rule %q is listed twice", rule.Name())
+		}
+		seen[rule.Name()] = true
+		rules = append(rules, rule)
+		return nil
+	}
+
+	for _, name := range d.Rules {
+		if name == "default" {
+			for _, rule := range DefaultRules() {
+				if err := add(rule); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		rule, ok := RuleByName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		if err := add(rule); err != nil {
+			return nil, err
+		}
+	}
+	for _, custom := range d.Custom {
+		rule, err := ParseTripleRule(custom.Name, custom.Rule, d.Prefixes)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(rule); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// TripleRule is a custom rule defined by a Datalog clause over triple/3.
+// Variables are written as in Datalog programs, X or ?x; constants are
+// prefixed names, <IRIs> or quoted literals.
+type TripleRule struct {
+	name       string
+	definition string
+	head       []TriplePattern
+	body       []TriplePattern
+}
+
+// ParseTripleRule parses a clause such as
+// "triple(X, rdf:type, ex:Parent) :- triple(X, ex:hasChild, Y)" into a
+// rule, expanding prefixed names with prefixes and the standard rdf, rdfs,
+// owl and xsd prefixes. Only triple atoms are allowed, and every variable
+// of the head must occur in the body.
+func ParseTripleRule(name, clause string, prefixes map[string]string) (*TripleRule, error) {
+	if name == "" {
+		return nil, fmt.Errorf("custom rule %q has no name", clause)
+	}
+	program, err := ParseDatalog(clause)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule %s: %w", name, err)
+	}
+	if len(program.Rules) != 1 || len(program.Facts) != 0 {
+		return nil, fmt.Errorf("invalid rule %s: expected a single clause with a body", name)
+	}
+
+	known := withStandardPrefixes(prefixes)
+	pattern := func(a DLAtom) (TriplePattern, error) {
+		if a.Predicate != "triple" || len(a.Terms) != 3 {
+			return TriplePattern{}, fmt.Errorf("invalid rule %s: %s is not a triple(Subject, Predicate, Object) atom", name, a)
+		}
+		var terms [3]string
+		for i, t := range a.Terms {
+			if t.IsVariable {
+				terms[i] = "?" + strings.TrimPrefix(t.Value, "?")
+			} else {
+				terms[i] = conditionTerm(t.Value, known)
+			}
+		}
+		return TriplePattern{Subject: terms[0], Predicate: terms[1], Object: terms[2]}, nil
+	}
+
+	dl := program.Rules[0]
+	r := &TripleRule{name: name, definition: strings.TrimSpace(clause)}
+	bound := make(map[string]bool)
+	for _, atom := range dl.Body {
+		p, err := pattern(atom)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range []string{p.Subject, p.Predicate, p.Object} {
+			bound[v] = true
+		}
+		r.body = append(r.body, p)
+	}
+	head, err := pattern(dl.Head)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range []string{head.Subject, head.Predicate, head.Object} {
+		if IsVariable(v) && !bound[v] {
+			return nil, fmt.Errorf("invalid rule %s: head variable %s does not occur in the body", name, v)
+		}
+	}
+	r.head = []TriplePattern{head}
+	return r, nil
+}
+
+// Name returns the name given to the rule
+func (r *TripleRule) Name() string {
+	return r.name
+}
+
+// Definition returns the clause the rule was parsed from
+func (r *TripleRule) Definition() string {
+	return r.definition
+}
+
+// Apply matches the body against the store and returns the instances of
+// the head that are well-formed triples not yet in the store
+func (r *TripleRule) Apply(store *TripleStore) []Triple {
+	var inferred []Triple
+	seen := make(map[Triple]bool)
+	for _, b := range store.MatchBGP(r.body) {
+		for _, head := range r.head {
+			t := Triple{
+				Subject:   b.resolve(head.Subject),
+				Predicate: b.resolve(head.Predicate),
+				Object:    b.resolve(head.Object),
+			}
+			if !seen[t] && t.IsWellFormed() && !store.Contains(t) {
+				seen[t] = true
+				inferred = append(inferred, t)
+			}
+		}
+	}
+	return inferred
+}
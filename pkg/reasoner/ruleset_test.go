@@ -0,0 +1,115 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportRules(t *testing.T) {
+	exported := ExportRules(DefaultRules())
+
+	for _, rule := range DefaultRules() {
+		if !strings.Contains(exported, "% "+rule.Name()+"\n") {
+			t.Errorf("Export is missing rule %s", rule.Name())
+		}
+	}
+
+	// The exported rules must be executable by the Datalog engine
+	program := exported + `
+triple(myCar, rdf:type, car).
+triple(car, rdfs:subClassOf, vehicle).
+triple(vehicle, rdfs:subClassOf, transport).
+`
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"?- triple(myCar, rdf:type, transport).", true},
+		{"?- triple(car, rdfs:subClassOf, transport).", true},
+		{"?- triple(transport, rdfs:subClassOf, car).", false},
+	}
+
+	for _, tt := range tests {
+		result, err := DLQuery(program, tt.query)
+		if err != nil {
+			t.Fatalf("DLQuery error for %s: %v", tt.query, err)
+		}
+		if result != tt.expected {
+			t.Errorf("DLQuery(%s) = %v, expected %v", tt.query, result, tt.expected)
+		}
+	}
+}
+
+func TestRuleSetDefinition(t *testing.T) {
+	def := RuleSetDefinition{
+		Rules: []string{"rdfs:subClassOf-transitivity", "rdf:type-inheritance"},
+		Custom: []CustomRuleDefinition{{
+			Name: "in-canton",
+			Rule: "triple(X, ex:inCanton, C) :- triple(X, ex:inDistrict, D), triple(D, ex:inCanton, C).",
+		}},
+		Prefixes: map[string]string{"ex": "http://example.org/"},
+	}
+	rules, err := def.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	var names []string
+	for _, rule := range rules {
+		names = append(names, rule.Name())
+	}
+	if got := strings.Join(names, " "); got != "rdfs:subClassOf-transitivity rdf:type-inheritance in-canton" {
+		t.Errorf("Unexpected rules %s", got)
+	}
+
+	r := NewReasoner(WithRules(rules))
+	if err := r.LoadTurtle(`@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:Town rdfs:subClassOf ex:Municipality .
+ex:winterthur a ex:Town ; ex:inDistrict ex:district .
+ex:district ex:inCanton ex:zurich .
+ex:name rdfs:domain ex:Named .
+ex:winterthur ex:name "Winterthur" .
+`); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	winterthur := "http://example.org/winterthur"
+	if len(r.Query(winterthur, "http://example.org/inCanton", "http://example.org/zurich")) != 1 {
+		t.Error("Expected the custom rule to infer ex:inCanton")
+	}
+	if len(r.Query(winterthur, RDFType, "http://example.org/Municipality")) != 1 {
+		t.Error("Expected type inheritance to infer ex:Municipality")
+	}
+	if len(r.Query(winterthur, RDFType, "http://example.org/Named")) != 0 {
+		t.Error("Expected domain inference to be excluded from the rule set")
+	}
+
+	all, err := RuleSetDefinition{Rules: []string{"default"}}.Build()
+	if err != nil || len(all) != len(DefaultRules()) {
+		t.Errorf("Expected \"default\" to select the %d default rules, got %d (%v)", len(DefaultRules()), len(all), err)
+	}
+}
+
+func TestRuleSetDefinitionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		def  RuleSetDefinition
+		want string
+	}{
+		{"unknown rule", RuleSetDefinition{Rules: []string{"no-such-rule"}}, "unknown rule"},
+		{"duplicate rule", RuleSetDefinition{Rules: []string{"default", "rdf:type-inheritance"}}, "listed twice"},
+		{"fact", RuleSetDefinition{Custom: []CustomRuleDefinition{{Name: "f", Rule: "triple(a, b, c)."}}}, "single clause"},
+		{"other predicate", RuleSetDefinition{Custom: []CustomRuleDefinition{{Name: "p", Rule: "triple(X, a, b) :- parent(X, Y)."}}}, "not a triple"},
+		{"unbound head", RuleSetDefinition{Custom: []CustomRuleDefinition{{Name: "u", Rule: "triple(X, a, Z) :- triple(X, b, Y)."}}}, "head variable ?Z"},
+		{"no name", RuleSetDefinition{Custom: []CustomRuleDefinition{{Rule: "triple(X, a, b) :- triple(X, b, c)."}}}, "no name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.def.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package reasoner
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// AnonymizedNamespace is the namespace of the IRIs SampleGraph gives to
+// anonymized resources
+const AnonymizedNamespace = "http://example.org/anon/"
+
+// SampleOptions configures SampleGraph
+type SampleOptions struct {
+	// Ratio is the fraction of the ABox subjects whose descriptions are
+	// kept, greater than 0 and at most 1. At least one subject is kept.
+	Ratio float64
+	// Seed seeds the random choice of subjects, so that a sample can be
+	// drawn again
+	Seed int64
+	// Anonymize replaces the IRIs of resources that are not part of the
+	// vocabulary and the text of literals by pseudonyms
+	Anonymize bool
+}
+
+// SampleGraph returns a small subset of a store that keeps its structure,
+// for attaching to bug reports. The schema (TBox, as told apart by
+// SplitTBoxABox) is kept in full, together with every triple of a random
+// fraction of the ABox subjects, of the blank nodes they lead to, such as
+// the members of lists, and the rdf:type triples of the resources they
+// refer to. Triples are returned in store order, with their graph.
+//
+// With Anonymize, every IRI that is not in the rdf, rdfs, owl, xsd or skos
+// namespace, a predicate, a class or another term of the TBox is replaced
+// by a numbered IRI in AnonymizedNamespace, graph labels included, and
+// blank nodes are renumbered. Plain, xsd:string and language-tagged
+// literals are replaced by numbered literals keeping their language tag;
+// literals of other datatypes, whose values rules compare, are kept. Equal
+// terms get equal pseudonyms, so the sample is reasoned over as the
+// original would be.
+func SampleGraph(store *TripleStore, opts SampleOptions) ([]Triple, error) {
+	if opts.Ratio <= 0 || opts.Ratio > 1 {
+		return nil, fmt.Errorf("invalid sample ratio %g, must be greater than 0 and at most 1", opts.Ratio)
+	}
+
+	tbox, abox := SplitTBoxABox(store)
+	var subjects []string
+	seen := make(map[string]bool)
+	for _, t := range abox.tripleList {
+		if !seen[t.Subject] {
+			seen[t.Subject] = true
+			subjects = append(subjects, t.Subject)
+		}
+	}
+
+	// Pick the subjects, then follow blank nodes and collect types
+	keep := make(map[string]bool)
+	var pending []string
+	n := int(math.Ceil(opts.Ratio * float64(len(subjects))))
+	for _, i := range rand.New(rand.NewSource(opts.Seed)).Perm(len(subjects))[:n] {
+		keep[subjects[i]] = true
+		pending = append(pending, subjects[i])
+	}
+	referenced := make(map[string]bool)
+	for len(pending) > 0 {
+		subject := pending[0]
+		pending = pending[1:]
+		for _, t := range abox.FindBySubject(subject) {
+			if !isBlankNode(t.Object) {
+				referenced[t.Object] = true
+			} else if !keep[t.Object] {
+				keep[t.Object] = true
+				pending = append(pending, t.Object)
+			}
+		}
+	}
+
+	var sample []Triple
+	for _, t := range store.tripleList {
+		if tbox.Contains(t) || keep[t.Subject] || t.Predicate == RDFType && referenced[t.Subject] {
+			sample = append(sample, t)
+		}
+	}
+	if opts.Anonymize {
+		sample = anonymize(sample, tbox)
+	}
+	return sample, nil
+}
+
+// anonymize replaces the terms of triples that are not vocabulary by
+// numbered pseudonyms
+func anonymize(triples []Triple, tbox *TripleStore) []Triple {
+	vocabulary := make(map[string]bool)
+	for _, t := range tbox.tripleList {
+		vocabulary[t.Subject] = true
+		vocabulary[t.Object] = true
+	}
+	for _, t := range triples {
+		vocabulary[t.Predicate] = true
+		if t.Predicate == RDFType {
+			vocabulary[t.Object] = true
+		}
+	}
+
+	pseudonyms := make(map[string]string)
+	counts := make(map[TermKind]int)
+	pseudonym := func(term string) string {
+		if p, ok := pseudonyms[term]; ok {
+			return p
+		}
+		parsed := ParseTerm(term)
+		switch {
+		case parsed.IsIRI() && (vocabulary[term] || isStandardVocabulary(term)):
+			return term
+		case parsed.IsLiteral() && parsed.Datatype != "" && parsed.Datatype != XSDString:
+			return term
+		}
+		counts[parsed.Kind]++
+		number := strconv.Itoa(counts[parsed.Kind])
+		switch parsed.Kind {
+		case TermBlankNode:
+			parsed.Value = "b" + number
+		case TermLiteral:
+			parsed.Value = "value " + number
+		default:
+			parsed.Value = AnonymizedNamespace + "r" + number
+		}
+		pseudonyms[term] = parsed.String()
+		return pseudonyms[term]
+	}
+
+	anonymized := make([]Triple, len(triples))
+	for i, t := range triples {
+		anonymized[i] = Triple{Subject: pseudonym(t.Subject), Predicate: pseudonym(t.Predicate), Object: pseudonym(t.Object)}
+		if t.Graph != "" {
+			anonymized[i].Graph = pseudonym(t.Graph)
+		}
+	}
+	return anonymized
+}
@@ -0,0 +1,125 @@
+package reasoner
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const sampleSchema = `@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Customer rdfs:subClassOf ex:Person ; rdfs:label "Customer" .
+ex:advisor rdfs:range ex:Employee .
+ex:Employee owl:disjointWith ex:Customer .
+`
+
+// sampleData describes n customers, each with an address and an advisor
+func sampleData(n int) string {
+	var sb strings.Builder
+	sb.WriteString("@prefix ex: <http://example.org/> .\n@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .\n")
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&sb, "ex:customer%d a ex:Customer ; ex:name \"Customer %d\"@de ; ex:born \"19%02d-01-01\"^^xsd:date ;\n", i, i, i)
+		fmt.Fprintf(&sb, "    ex:address _:a%d ; ex:advisor ex:advisor%d .\n_:a%d ex:street \"Street %d\" .\n", i, i%3, i, i)
+		fmt.Fprintf(&sb, "ex:advisor%d a ex:Customer .\n", i%3)
+	}
+	return sb.String()
+}
+
+func TestSampleGraph(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(sampleSchema + sampleData(20)); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	sample, err := SampleGraph(r.GetStore(), SampleOptions{Ratio: 0.1, Seed: 42})
+	if err != nil {
+		t.Fatalf("SampleGraph failed: %v", err)
+	}
+	again, _ := SampleGraph(r.GetStore(), SampleOptions{Ratio: 0.1, Seed: 42})
+	if !equalStrings(tripleStrings(sample), tripleStrings(again)) {
+		t.Errorf("Expected the same sample for the same seed")
+	}
+
+	store := NewTripleStore()
+	for _, triple := range sample {
+		store.Add(triple)
+	}
+	ex := "http://example.org/"
+	if !store.Contains(Triple{Subject: ex + "Employee", Predicate: OWLDisjointWith, Object: ex + "Customer"}) {
+		t.Errorf("Expected the schema to be kept")
+	}
+	// 23 subjects: 20 customers and 3 advisors, of which 3 are kept
+	customers := 0
+	for _, typed := range store.FindByPredicateObject(RDFType, ex+"Customer") {
+		if len(store.FindBySubject(typed.Subject)) > 1 {
+			customers++
+			for _, address := range store.FindBySubjectPredicate(typed.Subject, ex+"address") {
+				if len(store.FindBySubject(address.Object)) != 1 {
+					t.Errorf("Expected the address of %s to be kept", typed.Subject)
+				}
+			}
+			for _, advisor := range store.FindBySubjectPredicate(typed.Subject, ex+"advisor") {
+				if !store.Contains(Triple{Subject: advisor.Object, Predicate: RDFType, Object: ex + "Customer"}) {
+					t.Errorf("Expected the type of %s to be kept", advisor.Object)
+				}
+			}
+		}
+	}
+	if customers == 0 || customers > 3 {
+		t.Errorf("Expected at most 3 described customers, got %d in %v", customers, sample)
+	}
+
+	if _, err := SampleGraph(r.GetStore(), SampleOptions{Ratio: 0}); err == nil {
+		t.Errorf("Expected an error for a ratio of 0")
+	}
+}
+
+func TestSampleGraphAnonymize(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(sampleSchema + sampleData(5)); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	sample, err := SampleGraph(r.GetStore(), SampleOptions{Ratio: 1, Anonymize: true})
+	if err != nil {
+		t.Fatalf("SampleGraph failed: %v", err)
+	}
+	if len(sample) != r.GetStore().Size() {
+		t.Errorf("Expected all %d triples with a ratio of 1, got %d", r.GetStore().Size(), len(sample))
+	}
+
+	text := strings.Join(tripleStrings(sample), "\n")
+	for _, hidden := range []string{"customer1", "advisor1>", "Customer 1", "Street 1"} {
+		if strings.Contains(text, hidden) {
+			t.Errorf("Expected %q to be anonymized", hidden)
+		}
+	}
+	for _, kept := range []string{"http://example.org/Customer", "http://example.org/advisor>", `"1901-01-01"^^`, `"value 2"@de`, AnonymizedNamespace + "r1"} {
+		if !strings.Contains(text, kept) {
+			t.Errorf("Expected %q in the sample", kept)
+		}
+	}
+
+	// The anonymized sample reproduces the inconsistency of the advisors,
+	// customers that the range of ex:advisor makes employees
+	original := NewReasoner()
+	original.LoadTurtle(sampleSchema + sampleData(5))
+	original.RunForwardReasoning()
+	anonymized := NewReasoner()
+	for _, triple := range sample {
+		anonymized.GetStore().Add(triple)
+	}
+	anonymized.RunForwardReasoning()
+	if got, want := len(anonymized.GetStore().CheckConsistency()), len(original.GetStore().CheckConsistency()); got != want || got == 0 {
+		t.Errorf("Expected %d inconsistencies, got %d", want, got)
+	}
+}
+
+// tripleStrings returns the N-Triples lines of triples
+func tripleStrings(triples []Triple) []string {
+	lines := make([]string, len(triples))
+	for i, t := range triples {
+		lines[i] = t.String()
+	}
+	return lines
+}
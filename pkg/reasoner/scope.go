@@ -0,0 +1,116 @@
+package reasoner
+
+// ReasoningScope restricts forward reasoning to the consequences that are
+// relevant to a set of properties and classes of interest.
+//
+// Schema-level conclusions (class and property hierarchies, equivalences,
+// domains, ranges and inverses) are always materialized. Instance-level
+// conclusions are kept only if they are in scope or can contribute to an
+// in-scope conclusion, e.g. typing with a subclass of an in-scope class or
+// assertions of a property whose domain is an in-scope class.
+type ReasoningScope struct {
+	Predicates []string // Properties whose assertions should be materialized
+	Classes    []string // Classes whose rdf:type assertions should be materialized
+}
+
+// WithScope restricts forward reasoning to the given scope
+func WithScope(scope ReasoningScope) Option {
+	return func(r *Reasoner) {
+		r.scope = &scope
+	}
+}
+
+// schemaPredicates are always materialized because other rules depend on them
+var schemaPredicates = map[string]bool{ //nolint:gochecknoglobals
+	RDFSSubClassOf:        true,
+	RDFSSubPropertyOf:     true,
+	RDFSDomain:            true,
+	RDFSRange:             true,
+	OWLEquivalentClass:    true,
+	OWLEquivalentProperty: true,
+	OWLInverseOf:          true,
+}
+
+// scopeFilter decides which inferred triples are relevant to a scope
+type scopeFilter struct {
+	predicates map[string]bool
+	classes    map[string]bool
+}
+
+// newScopeFilter computes the relevant predicates and classes for the
+// current contents of the store
+func newScopeFilter(scope *ReasoningScope, store *TripleStore) *scopeFilter {
+	f := &scopeFilter{
+		predicates: make(map[string]bool),
+		classes:    make(map[string]bool),
+	}
+	for _, p := range scope.Predicates {
+		f.predicates[p] = true
+	}
+	for _, c := range scope.Classes {
+		f.classes[c] = true
+	}
+
+	for changed := true; changed; {
+		changed = false
+		mark := func(set map[string]bool, term string) {
+			if !set[term] {
+				set[term] = true
+				changed = true
+			}
+		}
+		// symmetric marks both terms of the statements of predicate once
+		// one of them is in set
+		symmetric := func(set map[string]bool, predicate string) {
+			for _, t := range store.FindByPredicate(predicate) {
+				if set[t.Object] {
+					mark(set, t.Subject)
+				}
+				if set[t.Subject] {
+					mark(set, t.Object)
+				}
+			}
+		}
+
+		// Instances of a subclass become instances of the in-scope class
+		for _, t := range store.FindByPredicate(RDFSSubClassOf) {
+			if f.classes[t.Object] {
+				mark(f.classes, t.Subject)
+			}
+		}
+
+		// Domain and range axioms turn property assertions into typing
+		for _, pred := range []string{RDFSDomain, RDFSRange} {
+			for _, t := range store.FindByPredicate(pred) {
+				if f.classes[t.Object] {
+					mark(f.predicates, t.Subject)
+				}
+			}
+		}
+
+		// Sub-properties and inverses produce assertions of in-scope properties
+		for _, t := range store.FindByPredicate(RDFSSubPropertyOf) {
+			if f.predicates[t.Object] {
+				mark(f.predicates, t.Subject)
+			}
+		}
+		symmetric(f.predicates, OWLInverseOf)
+
+		// Equivalent classes and properties, and terms that are the same,
+		// are relevant together
+		symmetric(f.classes, OWLEquivalentClass)
+		symmetric(f.predicates, OWLEquivalentProperty)
+		symmetric(f.classes, OWLSameAs)
+		symmetric(f.predicates, OWLSameAs)
+	}
+
+	return f
+}
+
+// allows reports whether an inferred triple should be added to the store
+func (f *scopeFilter) allows(t Triple) bool {
+	if schemaPredicates[t.Predicate] || f.predicates[t.Predicate] {
+		return true
+	}
+	return t.Predicate == RDFType && f.classes[t.Object]
+}
@@ -0,0 +1,107 @@
+package reasoner
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/beyondcivic/goreasoner/pkg/version"
+)
+
+// signaturePayloadVersion identifies the format of the signed payload
+const signaturePayloadVersion = "goreasoner-signature-v1"
+
+// ArtifactSignature is a detached Ed25519 signature of an output file that
+// binds its content to the configuration of the reasoner that produced it,
+// so consumers can check that a closure came from an approved configuration
+type ArtifactSignature struct {
+	// Version is the goreasoner version that produced the file
+	Version string `json:"version"`
+	// Rules is the RulesFingerprint of the rules applied
+	Rules string `json:"rules"`
+	// RuleSet names the configured rule set applied, if any
+	RuleSet string `json:"ruleset,omitempty"`
+	// SHA256 is the hex-encoded SHA-256 hash of the file
+	SHA256 string `json:"sha256"`
+	// Signature signs the fields above
+	Signature []byte `json:"signature"`
+}
+
+// RulesFingerprint returns the hex-encoded SHA-256 hash of the Datalog
+// export of rules, which identifies their semantics
+func RulesFingerprint(rules []Rule) string {
+	sum := sha256.Sum256([]byte(ExportRules(rules)))
+	return hex.EncodeToString(sum[:])
+}
+
+// SignArtifact signs content as produced with the given rules, and the
+// rule set of that name if it is not empty
+func SignArtifact(content []byte, key ed25519.PrivateKey, rules []Rule, ruleSet string) ArtifactSignature {
+	sum := sha256.Sum256(content)
+	s := ArtifactSignature{
+		Version: version.Version,
+		Rules:   RulesFingerprint(rules),
+		RuleSet: ruleSet,
+		SHA256:  hex.EncodeToString(sum[:]),
+	}
+	s.Signature = ed25519.Sign(key, s.payload())
+	return s
+}
+
+// Verify checks that the signature was made with the private key of key
+// and that content is the signed file
+func (s ArtifactSignature) Verify(content []byte, key ed25519.PublicKey) error {
+	if !ed25519.Verify(key, s.payload(), s.Signature) {
+		return fmt.Errorf("invalid signature: not made with this key, or the metadata was modified")
+	}
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != s.SHA256 {
+		return fmt.Errorf("the file does not match the signature: it was modified after signing")
+	}
+	return nil
+}
+
+// payload returns the signed bytes
+func (s ArtifactSignature) payload() []byte {
+	return []byte(strings.Join([]string{signaturePayloadVersion, s.Version, s.Rules, s.RuleSet, s.SHA256}, "\n"))
+}
+
+// ParseSigningKey parses a PEM-encoded PKCS #8 Ed25519 private key, as
+// written by "openssl genpkey -algorithm ed25519"
+func ParseSigningKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM-encoded key found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is a %T, not an Ed25519 key", key)
+	}
+	return private, nil
+}
+
+// ParseVerifyingKey parses a PEM-encoded PKIX Ed25519 public key, as
+// written by "openssl pkey -pubout"
+func ParseVerifyingKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM-encoded key found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	public, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is a %T, not an Ed25519 key", key)
+	}
+	return public, nil
+}
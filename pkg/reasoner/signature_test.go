@@ -0,0 +1,86 @@
+package reasoner
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+)
+
+func TestArtifactSignature(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	content := []byte("<http://example.org/a> <http://example.org/p> <http://example.org/b> .\n")
+
+	signature := SignArtifact(content, private, DefaultRules(), "municipal")
+	if signature.Rules != RulesFingerprint(DefaultRules()) || signature.RuleSet != "municipal" {
+		t.Errorf("Unexpected signature metadata: %+v", signature)
+	}
+	if err := signature.Verify(content, public); err != nil {
+		t.Errorf("Expected a valid signature, got %v", err)
+	}
+
+	// The signature survives a JSON round trip
+	data, err := json.Marshal(signature)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded ArtifactSignature
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if err := decoded.Verify(content, public); err != nil {
+		t.Errorf("Expected the decoded signature to be valid, got %v", err)
+	}
+
+	if err := signature.Verify(append(content, '\n'), public); err == nil {
+		t.Error("Expected a modified file to fail verification")
+	}
+	tampered := signature
+	tampered.RuleSet = "other"
+	if err := tampered.Verify(content, public); err == nil {
+		t.Error("Expected modified metadata to fail verification")
+	}
+	other, _, _ := ed25519.GenerateKey(nil)
+	if err := signature.Verify(content, other); err == nil {
+		t.Error("Expected verification with another key to fail")
+	}
+
+	if RulesFingerprint(DefaultRules()[:1]) == signature.Rules {
+		t.Error("Expected different rules to have different fingerprints")
+	}
+}
+
+func TestParseSigningKeys(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey failed: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey failed: %v", err)
+	}
+
+	parsedPrivate, err := ParseSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	if err != nil || !parsedPrivate.Equal(private) {
+		t.Errorf("ParseSigningKey failed: %v", err)
+	}
+	parsedPublic, err := ParseVerifyingKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if err != nil || !parsedPublic.Equal(public) {
+		t.Errorf("ParseVerifyingKey failed: %v", err)
+	}
+
+	if _, err := ParseSigningKey([]byte("not a key")); err == nil {
+		t.Error("Expected an error for data without a PEM block")
+	}
+	if _, err := ParseVerifyingKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})); err == nil {
+		t.Error("Expected an error for a private key given as public key")
+	}
+}
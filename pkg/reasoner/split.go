@@ -0,0 +1,56 @@
+package reasoner
+
+// SplitTBoxABox separates the triples of a store into a TBox, the schema,
+// and an ABox, the instance assertions, for data mixing both in one
+// document. TBox triples have a schema predicate such as rdfs:subClassOf,
+// owl:inverseOf or owl:hasKey, or declare a class, property or axiom with
+// an RDF, RDFS or OWL type other than owl:NamedIndividual and owl:Thing.
+// Other triples about the classes and properties these relate or declare,
+// such as their labels or the owl:onProperty of a restriction, belong to the
+// TBox as well. All remaining triples form the ABox. The store is not
+// changed, and both stores keep the order of the triples.
+func SplitTBoxABox(store *TripleStore) (*TripleStore, *TripleStore) {
+	inTBox := tboxMembership(store)
+	tbox, abox := NewTripleStore(), NewTripleStore()
+	for _, t := range store.tripleList {
+		if inTBox(t) {
+			tbox.add(t)
+		} else {
+			abox.add(t)
+		}
+	}
+	return tbox, abox
+}
+
+// CountTBoxABox returns the number of triples SplitTBoxABox would put in
+// the TBox and in the ABox, without copying them
+func CountTBoxABox(store *TripleStore) (tbox, abox int) {
+	inTBox := tboxMembership(store)
+	for _, t := range store.tripleList {
+		if inTBox(t) {
+			tbox++
+		} else {
+			abox++
+		}
+	}
+	return tbox, abox
+}
+
+// tboxMembership returns a function reporting whether a triple of the
+// store belongs to its TBox
+func tboxMembership(store *TripleStore) func(Triple) bool {
+	schemaTerms := make(map[string]bool)
+	for _, t := range store.tripleList {
+		switch {
+		case schemaPredicates[t.Predicate]:
+			schemaTerms[t.Subject] = true
+			schemaTerms[t.Object] = true
+		case t.Predicate == RDFType && isSchemaTriple(t):
+			schemaTerms[t.Subject] = true
+		}
+	}
+
+	return func(t Triple) bool {
+		return isSchemaTriple(t) || schemaTerms[t.Subject]
+	}
+}
@@ -0,0 +1,48 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitTBoxABox(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Mayor rdfs:subClassOf ex:Person .
+ex:Person a owl:Class ; rdfs:label "Person" .
+ex:governs a owl:ObjectProperty ; rdfs:domain ex:Mayor ; rdfs:comment "Governs a municipality" .
+_:r a owl:Restriction ; owl:onProperty ex:governs .
+ex:alice a ex:Mayor , owl:NamedIndividual ; rdfs:label "Alice" ; ex:governs ex:bern .
+ex:bern rdfs:label "Bern" .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	tbox, abox := SplitTBoxABox(r.GetStore())
+	if tbox.Size()+abox.Size() != r.GetStore().Size() {
+		t.Fatalf("Expected %d triples in total, got %d + %d", r.GetStore().Size(), tbox.Size(), abox.Size())
+	}
+	if tboxCount, aboxCount := CountTBoxABox(r.GetStore()); tboxCount != tbox.Size() || aboxCount != abox.Size() {
+		t.Errorf("CountTBoxABox() = %d, %d, want %d, %d", tboxCount, aboxCount, tbox.Size(), abox.Size())
+	}
+
+	subjects := func(ts *TripleStore) string {
+		var names []string
+		for _, t := range ts.All() {
+			names = append(names, strings.TrimPrefix(t.Subject, "http://example.org/")+" "+t.Predicate[strings.LastIndexAny(t.Predicate, "#/")+1:])
+		}
+		return strings.Join(names, ", ")
+	}
+	wantTBox := "Mayor subClassOf, Person type, Person label, governs type, governs domain, governs comment, r type, r onProperty"
+	if got := strings.ReplaceAll(subjects(tbox), "_:", ""); got != wantTBox {
+		t.Errorf("TBox = %s, want %s", got, wantTBox)
+	}
+	wantABox := "alice type, alice type, alice label, alice governs, bern label"
+	if got := subjects(abox); got != wantABox {
+		t.Errorf("ABox = %s, want %s", got, wantABox)
+	}
+}
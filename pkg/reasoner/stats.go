@@ -0,0 +1,120 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LiteralStats describes the literal values of one predicate, for checking
+// that data is consistently typed and translated
+type LiteralStats struct {
+	Predicate string `json:"predicate"`
+	// Literals is the number of literal values
+	Literals int `json:"literals"`
+	// Subjects is the number of subjects with at least one literal value
+	Subjects int `json:"subjects"`
+	// Datatypes counts the literal values by datatype. Literals without a
+	// datatype count as xsd:string and language-tagged ones as
+	// rdf:langString.
+	Datatypes map[string]int `json:"datatypes"`
+	// Languages counts the subjects with at least one value in each
+	// lowercased language tag, under "" for values without a tag
+	Languages map[string]int `json:"languages"`
+}
+
+// LanguageCoverage returns the fraction of subjects with a value in the
+// language, or without a language tag if lang is empty
+func (s LiteralStats) LanguageCoverage(lang string) float64 {
+	if s.Subjects == 0 {
+		return 0
+	}
+	return float64(s.Languages[strings.ToLower(lang)]) / float64(s.Subjects)
+}
+
+// LiteralStatistics returns the datatype distribution and language coverage
+// of the literal values of each predicate that has any, sorted by predicate
+func (ts *TripleStore) LiteralStatistics() []LiteralStats {
+	byPredicate := make(map[string]*LiteralStats)
+	subjects := make(map[string]bool)
+	languages := make(map[string]bool)
+
+	for _, t := range ts.tripleList {
+		term := ParseTerm(t.Object)
+		if term.Kind != TermLiteral {
+			continue
+		}
+		s, ok := byPredicate[t.Predicate]
+		if !ok {
+			s = &LiteralStats{
+				Predicate: t.Predicate,
+				Datatypes: make(map[string]int),
+				Languages: make(map[string]int),
+			}
+			byPredicate[t.Predicate] = s
+		}
+		s.Literals++
+
+		datatype, lang := term.Datatype, strings.ToLower(term.Language)
+		switch {
+		case lang != "":
+			datatype = RDF + "langString"
+		case datatype == "":
+			datatype = XSD + "string"
+		}
+		s.Datatypes[datatype]++
+
+		if key := t.Predicate + "|" + t.Subject; !subjects[key] {
+			subjects[key] = true
+			s.Subjects++
+		}
+		if key := t.Predicate + "|" + t.Subject + "|" + lang; !languages[key] {
+			languages[key] = true
+			s.Languages[lang]++
+		}
+	}
+
+	stats := make([]LiteralStats, 0, len(byPredicate))
+	for _, s := range byPredicate {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Predicate < stats[j].Predicate })
+	return stats
+}
+
+// Format renders the statistics for humans, compacting IRIs with the given
+// prefixes and the standard rdf, rdfs, owl and xsd prefixes. Datatypes are
+// listed by share of the literals and languages by share of the subjects,
+// most frequent first.
+func (s LiteralStats) Format(prefixes map[string]string) string {
+	w := newTurtleWriter(withStandardPrefixes(prefixes))
+
+	shares := func(counts map[string]int, total int, name func(string) string) string {
+		keys := make([]string, 0, len(counts))
+		for k := range counts {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if counts[keys[i]] != counts[keys[j]] {
+				return counts[keys[i]] > counts[keys[j]]
+			}
+			return keys[i] < keys[j]
+		})
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = fmt.Sprintf("%s %.0f%% (%d)", name(k), 100*float64(counts[k])/float64(total), counts[k])
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %d literals on %d subjects\n", w.term(s.Predicate), s.Literals, s.Subjects)
+	fmt.Fprintf(&sb, "  datatypes: %s\n", shares(s.Datatypes, s.Literals, w.term))
+	fmt.Fprintf(&sb, "  languages: %s\n", shares(s.Languages, s.Subjects, func(lang string) string {
+		if lang == "" {
+			return "none"
+		}
+		return "@" + lang
+	}))
+	return sb.String()
+}
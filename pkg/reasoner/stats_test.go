@@ -0,0 +1,71 @@
+package reasoner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLiteralStatistics(t *testing.T) {
+	store := NewTripleStore()
+	for _, tr := range loadTriples(t, `@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:a rdfs:label "Zürich"@de , "Zurich"@en , "Zurich"@EN .
+ex:b rdfs:label "Genf"@de , "Geneva" .
+ex:c rdfs:label "Basel"@de-CH .
+ex:d rdfs:label "Bern" .
+ex:a ex:population "420000"^^xsd:integer ; ex:next ex:b .
+ex:b ex:population "200000" .
+`) {
+		store.Add(tr)
+	}
+
+	stats := store.LiteralStatistics()
+	expected := []LiteralStats{
+		{
+			Predicate: "http://example.org/population",
+			Literals:  2,
+			Subjects:  2,
+			Datatypes: map[string]int{XSD + "integer": 1, XSD + "string": 1},
+			Languages: map[string]int{"": 2},
+		},
+		{
+			Predicate: RDFSLabel,
+			Literals:  7,
+			Subjects:  4,
+			Datatypes: map[string]int{RDF + "langString": 5, XSD + "string": 2},
+			Languages: map[string]int{"de": 2, "en": 1, "de-ch": 1, "": 2},
+		},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Fatalf("LiteralStatistics() =\n%+v\nwant\n%+v", stats, expected)
+	}
+
+	labels := stats[1]
+	if got := labels.LanguageCoverage("DE"); got != 0.5 {
+		t.Errorf("Expected coverage of 0.5 for de, got %v", got)
+	}
+	if got := labels.LanguageCoverage(""); got != 0.5 {
+		t.Errorf("Expected coverage of 0.5 without language, got %v", got)
+	}
+	if got := labels.LanguageCoverage("fr"); got != 0 {
+		t.Errorf("Expected no coverage for fr, got %v", got)
+	}
+}
+
+func TestLiteralStatsFormat(t *testing.T) {
+	s := LiteralStats{
+		Predicate: RDFSLabel,
+		Literals:  5,
+		Subjects:  4,
+		Datatypes: map[string]int{RDF + "langString": 4, XSD + "string": 1},
+		Languages: map[string]int{"de": 3, "en": 1, "": 1},
+	}
+	expected := `rdfs:label: 5 literals on 4 subjects
+  datatypes: rdf:langString 80% (4), xsd:string 20% (1)
+  languages: @de 75% (3), none 25% (1), @en 25% (1)
+`
+	if got := s.Format(nil); got != expected {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, expected)
+	}
+}
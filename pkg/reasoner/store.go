@@ -10,6 +10,12 @@ type Triple struct {
 	Subject   string
 	Predicate string
 	Object    string
+	// Graph is the IRI or blank node labelling the graph of a triple loaded
+	// from N-Quads, TriG or JSON-LD, or "" for the default graph. Triples
+	// are identified without it: the store holds the union of all graphs,
+	// keeping the graph of a triple's first occurrence, and inferred
+	// triples belong to the default graph unless reasoning per graph.
+	Graph string
 }
 
 // String returns the triple in N-Triples format
@@ -22,7 +28,7 @@ func (t Triple) String() string {
 
 // formatTerm formats a term for output
 func formatTerm(term string) string {
-	if strings.HasPrefix(term, "http://") || strings.HasPrefix(term, "https://") {
+	if strings.HasPrefix(term, "http://") || strings.HasPrefix(term, "https://") || strings.HasPrefix(term, "urn:") {
 		return "<" + term + ">"
 	}
 	if strings.HasPrefix(term, "<") && strings.HasSuffix(term, ">") {
@@ -46,44 +52,142 @@ type TripleStore struct {
 	bySubject   map[string][]int
 	byPredicate map[string][]int
 	byObject    map[string][]int
+
+	// generation is bumped on every mutation so caches can detect staleness
+	generation uint64
+
+	// sources maps asserted triples to the documents they were loaded
+	// from, if recorded with AddFrom
+	sources map[string][]string
+
+	// history records when triples were added and removed, if versioning
+	// is enabled
+	history *storeHistory
+
+	// subscribers receive the triples added to the store
+	subscribers subscribers
+
+	// frozen stores are immutable and also indexed by subject-predicate
+	// and predicate-object; see Freeze
+	frozen             bool
+	bySubjectPredicate map[string][]int
+	byPredicateObject  map[string][]int
+
+	// blobs holds the literals longer than BlobThreshold by digest
+	blobs map[string]string
+
+	// equivalences compare the objects of predicates by key, and
+	// equivalent holds the keys of the triples with those predicates; see
+	// SetTermEquivalence
+	equivalences map[string]TermEquivalence
+	equivalent   map[string]bool
 }
 
 // NewTripleStore creates a new empty triple store
 func NewTripleStore() *TripleStore {
-	return &TripleStore{
-		triples:     make(map[string]bool),
-		tripleList:  make([]Triple, 0),
-		bySubject:   make(map[string][]int),
-		byPredicate: make(map[string][]int),
-		byObject:    make(map[string][]int),
-	}
+	ts := &TripleStore{}
+	ts.reset()
+	return ts
+}
+
+// reset removes all triples and indexes from the store
+func (ts *TripleStore) reset() {
+	ts.triples = make(map[string]bool)
+	ts.tripleList = make([]Triple, 0)
+	ts.bySubject = make(map[string][]int)
+	ts.byPredicate = make(map[string][]int)
+	ts.byObject = make(map[string][]int)
+	ts.sources = nil
+	ts.blobs = nil
+	ts.equivalent = nil
+	ts.generation++
 }
 
-// tripleKey generates a unique key for a triple
+// tripleKey generates a unique key for a triple, in which long literals
+// are replaced by a digest
 func tripleKey(t Triple) string {
-	return t.Subject + "|" + t.Predicate + "|" + t.Object
+	return t.Subject + "|" + t.Predicate + "|" + termKey(t.Object)
 }
 
-// Add adds a triple to the store, returns true if it was new
+// Add adds a triple to the store, returns true if it was new. A frozen
+// store is left unchanged.
 func (ts *TripleStore) Add(t Triple) bool {
+	if !ts.add(t) {
+		return false
+	}
+	ts.subscribers.notify(t)
+	return true
+}
+
+// add adds a triple without notifying subscribers
+func (ts *TripleStore) add(t Triple) bool {
 	key := tripleKey(t)
-	if ts.triples[key] {
+	if ts.triples[key] || ts.frozen {
 		return false
 	}
+	if equivalentKey, ok := ts.equivalentKey(t); ok {
+		if ts.equivalent[equivalentKey] {
+			return false
+		}
+		if ts.equivalent == nil {
+			ts.equivalent = make(map[string]bool)
+		}
+		ts.equivalent[equivalentKey] = true
+	}
 
 	ts.triples[key] = true
+	if len(t.Object) > BlobThreshold {
+		t.Object = ts.internBlob(t.Object)
+	}
 	idx := len(ts.tripleList)
 	ts.tripleList = append(ts.tripleList, t)
 
 	ts.bySubject[t.Subject] = append(ts.bySubject[t.Subject], idx)
 	ts.byPredicate[t.Predicate] = append(ts.byPredicate[t.Predicate], idx)
 	ts.byObject[t.Object] = append(ts.byObject[t.Object], idx)
+	ts.generation++
+	if ts.history != nil {
+		ts.history.record(t, false)
+	}
 
 	return true
 }
 
-// Contains checks if a triple exists in the store
+// removeAll removes the triples with the given keys and rebuilds the
+// indexes. Reasoners must treat the store as no longer closed afterwards.
+func (ts *TripleStore) removeAll(keys map[string]bool) {
+	if ts.frozen {
+		return
+	}
+	kept := make([]Triple, 0, len(ts.tripleList))
+	history := ts.history
+	for _, t := range ts.tripleList {
+		if !keys[tripleKey(t)] {
+			kept = append(kept, t)
+		} else if history != nil {
+			history.record(t, true)
+		}
+	}
+	sources := ts.sources
+	for key := range keys {
+		delete(sources, key)
+	}
+
+	// The kept triples are not re-added in the history
+	ts.reset()
+	ts.history = nil
+	for _, t := range kept {
+		ts.add(t)
+	}
+	ts.sources = sources
+	ts.history = history
+}
+
+// Contains checks if a triple, or one equivalent to it, exists in the store
 func (ts *TripleStore) Contains(t Triple) bool {
+	if equivalentKey, ok := ts.equivalentKey(t); ok {
+		return ts.equivalent[equivalentKey]
+	}
 	return ts.triples[tripleKey(t)]
 }
 
@@ -116,6 +220,9 @@ func (ts *TripleStore) FindByObject(object string) []Triple {
 
 // FindBySubjectPredicate returns all triples matching subject and predicate
 func (ts *TripleStore) FindBySubjectPredicate(subject, predicate string) []Triple {
+	if ts.frozen {
+		return ts.at(ts.bySubjectPredicate[subject+"|"+predicate])
+	}
 	var result []Triple
 	for _, idx := range ts.bySubject[subject] {
 		t := ts.tripleList[idx]
@@ -126,18 +233,32 @@ func (ts *TripleStore) FindBySubjectPredicate(subject, predicate string) []Tripl
 	return result
 }
 
-// FindByPredicateObject returns all triples matching predicate and object
+// FindByPredicateObject returns all triples matching predicate and object,
+// or an object equivalent to it
 func (ts *TripleStore) FindByPredicateObject(predicate, object string) []Triple {
+	if ts.frozen {
+		return ts.at(ts.byPredicateObject[predicate+"|"+termKey(ts.objectKey(predicate, object))])
+	}
 	var result []Triple
+	key := ts.objectKey(predicate, object)
 	for _, idx := range ts.byPredicate[predicate] {
 		t := ts.tripleList[idx]
-		if t.Object == object {
+		if ts.objectKey(predicate, t.Object) == key {
 			result = append(result, t)
 		}
 	}
 	return result
 }
 
+// at returns the triples at positions of the triple list
+func (ts *TripleStore) at(indexes []int) []Triple {
+	var result []Triple
+	for _, idx := range indexes {
+		result = append(result, ts.tripleList[idx])
+	}
+	return result
+}
+
 // All returns all triples in the store
 func (ts *TripleStore) All() []Triple {
 	result := make([]Triple, len(ts.tripleList))
@@ -145,7 +266,49 @@ func (ts *TripleStore) All() []Triple {
 	return result
 }
 
+// since returns the triples added after the first n, in insertion order
+func (ts *TripleStore) since(n int) []Triple {
+	if n >= len(ts.tripleList) {
+		return nil
+	}
+	result := make([]Triple, len(ts.tripleList)-n)
+	copy(result, ts.tripleList[n:])
+	return result
+}
+
 // Size returns the number of triples in the store
 func (ts *TripleStore) Size() int {
 	return len(ts.tripleList)
-}
\ No newline at end of file
+}
+
+// Generation returns a counter that changes whenever the store is mutated
+func (ts *TripleStore) Generation() uint64 {
+	return ts.generation
+}
+
+// Rough per-entry overheads used by EstimateStoreMemory
+const (
+	mapEntryOverhead   = 48 // Hash map bucket share per key
+	tripleOverhead     = 48 // Three string headers in the triple list
+	indexEntryOverhead = 12 // One int per index, with slice growth slack
+	termOverhead       = 16 // String header per distinct term
+)
+
+// EstimateStoreMemory estimates the bytes a TripleStore holding triples
+// would use. It is a rough guide for sizing jobs, not an exact measure; the
+// closure computed by reasoning is usually larger than the input.
+func EstimateStoreMemory(triples []Triple) int64 {
+	terms := make(map[string]bool)
+	var total int64
+	for _, t := range triples {
+		for _, term := range []string{t.Subject, t.Predicate, t.Object} {
+			if !terms[term] {
+				terms[term] = true
+				total += int64(len(term)) + termOverhead
+			}
+		}
+		keyLen := len(t.Subject) + len(t.Predicate) + len(termKey(t.Object)) + 2
+		total += int64(keyLen) + mapEntryOverhead + tripleOverhead + 3*indexEntryOverhead
+	}
+	return total
+}
@@ -0,0 +1,195 @@
+package reasoner
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriptionBuffer is the number of triples buffered per subscription
+const subscriptionBuffer = 256
+
+// Defaults of BatchOptions
+const (
+	DefaultBatchSize    = 256
+	DefaultBatchLatency = 100 * time.Millisecond
+)
+
+// TriplePattern matches triples by subject, predicate and object, where an
+// empty string matches any term
+type TriplePattern struct {
+	Subject   string
+	Predicate string
+	Object    string
+}
+
+// Matches reports whether a triple matches the pattern
+func (p TriplePattern) Matches(t Triple) bool {
+	return (p.Subject == "" || p.Subject == t.Subject) &&
+		(p.Predicate == "" || p.Predicate == t.Predicate) &&
+		(p.Object == "" || p.Object == t.Object)
+}
+
+// Subscribe returns a channel that receives every triple matching pattern
+// that is added to the store from now on, whether loaded or inferred, and
+// a function that ends the subscription and closes the channel.
+//
+// Delivery is synchronous: once the channel's buffer is full, Add blocks
+// until the subscriber receives or cancels. Subscribers must therefore
+// drain the channel from another goroutine, or cancel when done.
+func (ts *TripleStore) Subscribe(pattern TriplePattern) (<-chan Triple, func()) {
+	sub := &subscription{
+		pattern: pattern,
+		ch:      make(chan Triple, subscriptionBuffer),
+		done:    make(chan struct{}),
+	}
+	ts.subscribers.add(sub)
+
+	cancel := func() {
+		sub.once.Do(func() {
+			// Unblock a pending delivery before waiting for it to finish
+			close(sub.done)
+			ts.subscribers.remove(sub)
+			sub.mu.Lock()
+			close(sub.ch)
+			sub.closed = true
+			sub.mu.Unlock()
+		})
+	}
+	return sub.ch, cancel
+}
+
+// BatchOptions controls how SubscribeBatched groups triples. Zero values
+// take the defaults DefaultBatchSize and DefaultBatchLatency.
+type BatchOptions struct {
+	// MaxSize is the number of triples at which a batch is delivered
+	MaxSize int
+	// MaxLatency is how long after its first triple a batch that is not
+	// full is delivered
+	MaxLatency time.Duration
+}
+
+// SubscribeBatched is like Subscribe but delivers the matching triples in
+// batches of up to opts.MaxSize, in the order they were added, so that
+// consumers are not called once per triple during a large materialization.
+// A batch is delivered when it is full or opts.MaxLatency after its first
+// triple. Cancelling discards the batch being collected.
+func (ts *TripleStore) SubscribeBatched(pattern TriplePattern, opts BatchOptions) (<-chan []Triple, func()) {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = DefaultBatchSize
+	}
+	if opts.MaxLatency <= 0 {
+		opts.MaxLatency = DefaultBatchLatency
+	}
+
+	triples, cancelTriples := ts.Subscribe(pattern)
+	batches := make(chan []Triple)
+	done := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		defer close(batches)
+		var batch []Triple
+		timer := time.NewTimer(opts.MaxLatency)
+		timer.Stop()
+
+		deliver := func() bool {
+			timer.Stop()
+			select {
+			case batches <- batch:
+				batch = nil
+				return true
+			case <-done:
+				return false
+			}
+		}
+		for {
+			select {
+			case t, ok := <-triples:
+				if !ok {
+					return
+				}
+				if len(batch) == 0 {
+					timer.Reset(opts.MaxLatency)
+				}
+				batch = append(batch, t)
+				if len(batch) >= opts.MaxSize && !deliver() {
+					return
+				}
+			case <-timer.C:
+				if len(batch) > 0 && !deliver() {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			cancelTriples()
+		})
+	}
+	return batches, cancel
+}
+
+// subscription is a pattern and the channel its matches are sent to
+type subscription struct {
+	pattern TriplePattern
+	ch      chan Triple
+	done    chan struct{}
+	once    sync.Once
+
+	mu     sync.Mutex // Held while sending, so ch is not closed mid-send
+	closed bool
+}
+
+// send delivers a triple unless the subscription is cancelled
+func (s *subscription) send(t Triple) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.ch <- t:
+	case <-s.done:
+	}
+}
+
+// subscribers is the set of subscriptions of a store
+type subscribers struct {
+	mu   sync.Mutex
+	subs []*subscription
+}
+
+func (s *subscribers) add(sub *subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs = append(s.subs, sub)
+}
+
+func (s *subscribers) remove(sub *subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, other := range s.subs {
+		if other == sub {
+			s.subs = append(s.subs[:i:i], s.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// notify sends a triple to every subscription whose pattern matches
+func (s *subscribers) notify(t Triple) {
+	s.mu.Lock()
+	subs := s.subs
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		if sub.pattern.Matches(t) {
+			sub.send(t)
+		}
+	}
+}
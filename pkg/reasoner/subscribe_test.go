@@ -0,0 +1,113 @@
+package reasoner
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	r := NewReasoner()
+	types, cancel := r.GetStore().Subscribe(TriplePattern{Predicate: RDFType})
+	defer cancel()
+
+	if err := r.LoadTurtle(incrementalSchema + incrementalData); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	expected := r.Query("", RDFType, "")
+	received := make(map[Triple]bool)
+	for len(received) < len(expected) {
+		tr := <-types
+		if tr.Predicate != RDFType {
+			t.Errorf("Received a triple not matching the pattern: %v", tr)
+		}
+		received[tr] = true
+	}
+	for _, tr := range expected {
+		if !received[tr] {
+			t.Errorf("Missing asserted or inferred triple %v", tr)
+		}
+	}
+
+	select {
+	case tr := <-types:
+		t.Errorf("Unexpected extra triple %v", tr)
+	default:
+	}
+}
+
+func TestSubscribeCancelUnblocksAdd(t *testing.T) {
+	store := NewTripleStore()
+	triples, cancel := store.Subscribe(TriplePattern{})
+
+	added := make(chan struct{})
+	go func() {
+		for i := range 2 * subscriptionBuffer {
+			store.Add(Triple{Subject: fmt.Sprintf("http://example.org/s%d", i), Predicate: RDFType, Object: OWLThing})
+		}
+		close(added)
+	}()
+
+	<-triples
+	cancel()
+	<-added
+
+	// The channel is closed once the buffered triples are drained
+	for range triples {
+	}
+	if store.Size() != 2*subscriptionBuffer {
+		t.Errorf("Expected all triples to be added, got %d", store.Size())
+	}
+	cancel()
+}
+
+func TestSubscribeBatched(t *testing.T) {
+	store := NewTripleStore()
+	batches, cancel := store.SubscribeBatched(TriplePattern{Predicate: RDFType}, BatchOptions{MaxSize: 4, MaxLatency: 20 * time.Millisecond})
+	defer cancel()
+
+	for i := range 10 {
+		store.Add(Triple{Subject: fmt.Sprintf("http://example.org/s%d", i), Predicate: RDFType, Object: OWLThing})
+		store.Add(Triple{Subject: fmt.Sprintf("http://example.org/s%d", i), Predicate: RDFSLabel, Object: `"s"`})
+	}
+
+	var sizes []int
+	var received []Triple
+	for len(received) < 10 {
+		batch := <-batches
+		sizes = append(sizes, len(batch))
+		received = append(received, batch...)
+	}
+	// Two full batches, then the rest once the latency has passed
+	if !reflect.DeepEqual(sizes, []int{4, 4, 2}) {
+		t.Errorf("Expected batches of 4, 4 and 2 triples, got %v", sizes)
+	}
+	for i, tr := range received {
+		if want := fmt.Sprintf("http://example.org/s%d", i); tr.Subject != want || tr.Predicate != RDFType {
+			t.Errorf("Triple %d is %v, want an rdf:type triple of %s", i, tr, want)
+		}
+	}
+}
+
+func TestSubscribeBatchedCancel(t *testing.T) {
+	store := NewTripleStore()
+	batches, cancel := store.SubscribeBatched(TriplePattern{}, BatchOptions{MaxSize: 1})
+
+	added := make(chan struct{})
+	go func() {
+		for i := range 2 * subscriptionBuffer {
+			store.Add(Triple{Subject: fmt.Sprintf("http://example.org/s%d", i), Predicate: RDFType, Object: OWLThing})
+		}
+		close(added)
+	}()
+
+	<-batches
+	cancel()
+	<-added
+	for range batches {
+	}
+	cancel()
+}
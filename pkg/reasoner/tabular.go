@@ -0,0 +1,405 @@
+package reasoner
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSheetNotFound is returned when a workbook has no sheet of the given name
+var ErrSheetNotFound = errors.New("sheet not found")
+
+// Table is the header and rows of a CSV file or spreadsheet
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// TableOptions configures ReadCSV and ReadXLSX
+type TableOptions struct {
+	// Sheet is the name of the worksheet to read (default: the first)
+	Sheet string
+	// HeaderRow is the 1-based row holding the column names; rows above it,
+	// such as titles, are skipped (default: 1)
+	HeaderRow int
+	// MaxRows limits the rows read after the header; zero reads all
+	MaxRows int
+}
+
+// Columns returns the columns of the table, without datatypes. Columns with
+// an empty header are named after their position, e.g. "column 3".
+func (t *Table) Columns() []Column {
+	columns := make([]Column, len(t.Header))
+	for i, name := range t.Header {
+		if name == "" {
+			name = fmt.Sprintf("column %d", i+1)
+		}
+		columns[i] = Column{Name: name}
+	}
+	return columns
+}
+
+// add appends a row read after the header, reporting whether more rows are
+// wanted
+func (t *Table) add(row []string, opts TableOptions) bool {
+	empty := true
+	for _, cell := range row {
+		if cell != "" {
+			empty = false
+			break
+		}
+	}
+	if !empty {
+		t.Rows = append(t.Rows, row)
+	}
+	return opts.MaxRows == 0 || len(t.Rows) < opts.MaxRows
+}
+
+// ReadCSV reads a CSV file with a header row. The Sheet option is ignored.
+func ReadCSV(r io.Reader, opts TableOptions) (*Table, error) {
+	if opts.HeaderRow == 0 {
+		opts.HeaderRow = 1
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	t := &Table{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		switch {
+		case line < opts.HeaderRow:
+		case line == opts.HeaderRow:
+			if len(record) > 0 {
+				record[0] = strings.TrimPrefix(record[0], "\ufeff")
+			}
+			t.Header = trimCells(record)
+		case !t.add(trimCells(record), opts):
+			return t, nil
+		}
+	}
+	if t.Header == nil {
+		return nil, fmt.Errorf("invalid CSV: no header in row %d", opts.HeaderRow)
+	}
+	return t, nil
+}
+
+// trimCells removes the spaces around every cell
+func trimCells(cells []string) []string {
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+// ReadXLSX reads a worksheet of an Excel workbook with a header row. Shared
+// and inline strings, numbers and booleans are read as displayed without
+// formatting; numbers formatted as dates are converted to ISO 8601 dates.
+// Sheets are streamed, so with MaxRows only the start of a large sheet is
+// read.
+func ReadXLSX(r io.ReaderAt, size int64, opts TableOptions) (*Table, error) {
+	if opts.HeaderRow == 0 {
+		opts.HeaderRow = 1
+	}
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workbook: %w", err)
+	}
+	wb := &workbook{files: make(map[string]*zip.File)}
+	for _, f := range archive.File {
+		wb.files[f.Name] = f
+	}
+
+	sheetPath, err := wb.sheetPath(opts.Sheet)
+	if err != nil {
+		return nil, err
+	}
+	if err := wb.readSharedStrings(); err != nil {
+		return nil, err
+	}
+	if err := wb.readDateStyles(); err != nil {
+		return nil, err
+	}
+	return wb.readSheet(sheetPath, opts)
+}
+
+// workbook holds the parts of an xlsx file needed to read its sheets
+type workbook struct {
+	files   map[string]*zip.File
+	strings []string
+	// dateStyles marks the cell styles that format numbers as dates
+	dateStyles map[int]bool
+}
+
+// decode unmarshals an XML part of the workbook, reporting false if it does
+// not exist
+func (wb *workbook) decode(name string, v any) (bool, error) {
+	f, ok := wb.files[name]
+	if !ok {
+		return false, nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return false, fmt.Errorf("invalid workbook part %s: %w", name, err)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return false, fmt.Errorf("invalid workbook part %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// sheetPath returns the part holding the named sheet, or the first sheet
+func (wb *workbook) sheetPath(name string) (string, error) {
+	var book struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	ok, err := wb.decode("xl/workbook.xml", &book)
+	if err != nil {
+		return "", err
+	}
+	if !ok || len(book.Sheets) == 0 {
+		return "", errors.New("invalid workbook: no sheets")
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if _, err := wb.decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return "", err
+	}
+
+	var names []string
+	for _, sheet := range book.Sheets {
+		names = append(names, sheet.Name)
+		if name != "" && sheet.Name != name {
+			continue
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID != sheet.ID {
+				continue
+			}
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+		return "", fmt.Errorf("invalid workbook: no part for sheet '%s'", sheet.Name)
+	}
+	return "", fmt.Errorf("%w: '%s' (sheets: %s)", ErrSheetNotFound, name, strings.Join(names, ", "))
+}
+
+// readSharedStrings reads the strings that cells refer to by index
+func (wb *workbook) readSharedStrings() error {
+	var sst struct {
+		Items []struct {
+			Text string `xml:"t"`
+			Runs []struct {
+				Text string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"si"`
+	}
+	if _, err := wb.decode("xl/sharedStrings.xml", &sst); err != nil {
+		return err
+	}
+	for _, item := range sst.Items {
+		text := item.Text
+		for _, run := range item.Runs {
+			text += run.Text
+		}
+		wb.strings = append(wb.strings, text)
+	}
+	return nil
+}
+
+// readDateStyles finds the cell styles with a date number format
+func (wb *workbook) readDateStyles() error {
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if _, err := wb.decode("xl/styles.xml", &styles); err != nil {
+		return err
+	}
+
+	dateFormats := make(map[int]bool)
+	for id := 14; id <= 22; id++ {
+		dateFormats[id] = true
+	}
+	for _, id := range []int{45, 46, 47} {
+		dateFormats[id] = true
+	}
+	for _, f := range styles.NumFmts {
+		code := strings.ToLower(f.Code)
+		dateFormats[f.ID] = strings.Contains(code, "yy") || (strings.Contains(code, "dd") && !strings.Contains(code, "#"))
+	}
+	wb.dateStyles = make(map[int]bool)
+	for i, xf := range styles.CellXfs {
+		if dateFormats[xf.NumFmtID] {
+			wb.dateStyles[i] = true
+		}
+	}
+	return nil
+}
+
+// xlsxCell is a cell of a worksheet
+type xlsxCell struct {
+	Ref    string `xml:"r,attr"`
+	Type   string `xml:"t,attr"`
+	Style  int    `xml:"s,attr"`
+	Value  string `xml:"v"`
+	Inline struct {
+		Text string `xml:"t"`
+	} `xml:"is"`
+}
+
+// readSheet streams the rows of a worksheet into a table
+func (wb *workbook) readSheet(name string, opts TableOptions) (*Table, error) {
+	f, ok := wb.files[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid workbook: missing part %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workbook part %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	t := &Table{}
+	decoder := xml.NewDecoder(rc)
+	line := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid workbook part %s: %w", name, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		var row struct {
+			Ref   int        `xml:"r,attr"`
+			Cells []xlsxCell `xml:"c"`
+		}
+		if err := decoder.DecodeElement(&row, &start); err != nil {
+			return nil, fmt.Errorf("invalid workbook part %s: %w", name, err)
+		}
+		// Rows without content may be omitted, so follow their numbers
+		line++
+		if row.Ref > 0 {
+			line = row.Ref
+		}
+
+		switch {
+		case line < opts.HeaderRow:
+		case line == opts.HeaderRow:
+			t.Header = trimCells(wb.rowValues(row.Cells))
+		case t.Header == nil:
+			return nil, fmt.Errorf("invalid workbook: no header in row %d", opts.HeaderRow)
+		case !t.add(trimCells(wb.rowValues(row.Cells)), opts):
+			return t, nil
+		}
+	}
+	if t.Header == nil {
+		return nil, fmt.Errorf("invalid workbook: no header in row %d", opts.HeaderRow)
+	}
+	return t, nil
+}
+
+// rowValues returns the values of the cells of a row, placed by their
+// column references
+func (wb *workbook) rowValues(cells []xlsxCell) []string {
+	var values []string
+	for i, cell := range cells {
+		column := i
+		if c, ok := columnIndex(cell.Ref); ok {
+			column = c
+		}
+		for len(values) <= column {
+			values = append(values, "")
+		}
+		values[column] = wb.cellValue(cell)
+	}
+	return values
+}
+
+// cellValue returns the text of a cell
+func (wb *workbook) cellValue(cell xlsxCell) string {
+	switch cell.Type {
+	case "s":
+		i, err := strconv.Atoi(cell.Value)
+		if err != nil || i < 0 || i >= len(wb.strings) {
+			return ""
+		}
+		return wb.strings[i]
+	case "inlineStr":
+		return cell.Inline.Text
+	case "b":
+		if cell.Value == "1" {
+			return "true"
+		}
+		return "false"
+	case "", "n":
+		if wb.dateStyles[cell.Style] {
+			if serial, err := strconv.ParseFloat(cell.Value, 64); err == nil {
+				return excelDate(serial)
+			}
+		}
+		return cell.Value
+	default:
+		return cell.Value
+	}
+}
+
+// excelDate converts a date serial of the 1900 date system to an ISO 8601
+// date, or date and time if it has a fraction
+func excelDate(serial float64) string {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	t := epoch.Add(time.Duration(serial * float64(24*time.Hour))).Round(time.Second)
+	if serial == float64(int64(serial)) {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02T15:04:05")
+}
+
+// columnIndex returns the 0-based column of a cell reference such as "C7"
+func columnIndex(ref string) (int, bool) {
+	column := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return column - 1, true
+}
@@ -0,0 +1,100 @@
+package reasoner
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testWorkbook builds an xlsx file with a title row above the header of its
+// second sheet
+func testWorkbook(t *testing.T) []byte {
+	t.Helper()
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Notes" sheetId="1" r:id="rId1"/><sheet name="Gemeinden" sheetId="2" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":     `<sst><si><t>Municipalities 2024</t></si><si><t>name</t></si><si><r><t>founding</t></r><r><t>_date</t></r></si><si><t>Bern</t></si></sst>`,
+		"xl/styles.xml":            `<styleSheet><cellXfs><xf numFmtId="0"/><xf numFmtId="14"/></cellXfs></styleSheet>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>see other sheet</t></is></c></row></sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c></row>
+<row r="2"><c r="A2" t="s"><v>1</v></c><c r="B2" t="s"><v>2</v></c><c r="D2" t="inlineStr"><is><t>capital</t></is></c></row>
+<row r="3"><c r="A3" t="s"><v>3</v></c><c r="B3" s="1"><v>45292</v></c><c r="C3"><v>134.6</v></c><c r="D3" t="b"><v>1</v></c></row>
+<row r="5"><c r="A5" t="inlineStr"><is><t>Thun</t></is></c><c r="D5" t="b"><v>0</v></c></row>
+</sheetData></worksheet>`,
+	}
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadXLSX(t *testing.T) {
+	data := testWorkbook(t)
+	table, err := ReadXLSX(bytes.NewReader(data), int64(len(data)), TableOptions{Sheet: "Gemeinden", HeaderRow: 2})
+	if err != nil {
+		t.Fatalf("ReadXLSX failed: %v", err)
+	}
+	if want := []string{"name", "founding_date", "", "capital"}; !reflect.DeepEqual(table.Header, want) {
+		t.Errorf("Header = %q, want %q", table.Header, want)
+	}
+	want := [][]string{{"Bern", "2024-01-01", "134.6", "true"}, {"Thun", "", "", "false"}}
+	if !reflect.DeepEqual(table.Rows, want) {
+		t.Errorf("Rows = %q, want %q", table.Rows, want)
+	}
+	if got := table.Columns()[2].Name; got != "column 3" {
+		t.Errorf("Expected an unnamed column to be named after its position, got %q", got)
+	}
+
+	table, err = ReadXLSX(bytes.NewReader(data), int64(len(data)), TableOptions{Sheet: "Gemeinden", HeaderRow: 2, MaxRows: 1})
+	if err != nil || len(table.Rows) != 1 {
+		t.Errorf("Expected one row with MaxRows, got %v, %v", table, err)
+	}
+	table, err = ReadXLSX(bytes.NewReader(data), int64(len(data)), TableOptions{})
+	if err != nil || !reflect.DeepEqual(table.Header, []string{"see other sheet"}) {
+		t.Errorf("Expected the first sheet by default, got %v, %v", table, err)
+	}
+	if _, err := ReadXLSX(bytes.NewReader(data), int64(len(data)), TableOptions{Sheet: "Kantone"}); !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("Expected ErrSheetNotFound, got %v", err)
+	}
+	if _, err := ReadXLSX(strings.NewReader("name,age"), 8, TableOptions{}); err == nil {
+		t.Error("Expected an error for a file that is not a workbook")
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	content := "Municipalities 2024\nname, population\nBern,134600\n\n,\nThun,43000\n"
+	table, err := ReadCSV(strings.NewReader(content), TableOptions{HeaderRow: 2})
+	if err != nil {
+		t.Fatalf("ReadCSV failed: %v", err)
+	}
+	if !reflect.DeepEqual(table.Header, []string{"name", "population"}) {
+		t.Errorf("Unexpected header %q", table.Header)
+	}
+	if !reflect.DeepEqual(table.Rows, [][]string{{"Bern", "134600"}, {"Thun", "43000"}}) {
+		t.Errorf("Unexpected rows %q", table.Rows)
+	}
+	table, err = ReadCSV(strings.NewReader("\ufeffname,population\n"), TableOptions{})
+	if err != nil || table.Header[0] != "name" {
+		t.Errorf("Expected the byte order mark to be removed, got %v, %v", table, err)
+	}
+	if _, err := ReadCSV(strings.NewReader("a,b\n"), TableOptions{HeaderRow: 3}); err == nil {
+		t.Error("Expected an error for a missing header row")
+	}
+}
@@ -0,0 +1,234 @@
+package reasoner
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// templatePlaceholder matches a {name} placeholder in a triple template
+//
+//nolint:gochecknoglobals
+var templatePlaceholder = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_.-]*)\}`)
+
+// Template is a set of parameterized triples written in Turtle, expanded
+// once per row of a binding table. Placeholders such as {code} may appear
+// in IRIs, prefixed names, literals, datatypes and language tags:
+//
+//	@prefix ex: <http://example.org/> .
+//	ex:municipality-{bfs} a ex:Municipality ;
+//	    ex:name "{name}"@de ;
+//	    ex:population "{population}"^^xsd:integer .
+//
+// A term that is only a placeholder, such as <{iri}>, takes the bound term
+// as is if it is an IRI or blank node. Otherwise the lexical form of the
+// bound term is inserted: into IRIs with the characters IRIs cannot contain
+// percent-encoded, into literals unchanged. Blank nodes are renamed for
+// every row, so each row describes its own.
+type Template struct {
+	triples   []Triple
+	marker    string
+	variables []string
+	prefixes  map[string]string
+}
+
+// ParseTemplate parses a triple template in Turtle with {name} placeholders
+func ParseTemplate(content string) (*Template, error) {
+	// Placeholders are replaced by markers the Turtle parser accepts
+	// anywhere a placeholder may occur: in IRIs, local names and literals
+	marker := "tplvar"
+	for strings.Contains(content, marker) {
+		marker += "x"
+	}
+	t := &Template{marker: marker}
+	index := make(map[string]int)
+	turtle := templatePlaceholder.ReplaceAllStringFunc(content, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		i, ok := index[name]
+		if !ok {
+			i = len(t.variables)
+			index[name] = i
+			t.variables = append(t.variables, name)
+		}
+		return marker + strconv.Itoa(i) + "z"
+	})
+
+	p := NewTurtleParser()
+	triples, err := p.Parse(turtle)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	if errs := p.Errors(); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid template: %w", errs[0])
+	}
+	t.triples = triples
+	t.prefixes = p.Prefixes()
+	return t, nil
+}
+
+// Variables returns the names of the placeholders, sorted
+func (t *Template) Variables() []string {
+	variables := append([]string(nil), t.variables...)
+	sort.Strings(variables)
+	return variables
+}
+
+// Prefixes returns the prefixes declared by the template
+func (t *Template) Prefixes() map[string]string {
+	return t.prefixes
+}
+
+// Expand instantiates the template once per binding. A triple with a
+// placeholder that a binding leaves unbound or empty is skipped for that
+// binding. Triples generated by several bindings are returned once, in the
+// order they were first generated.
+func (t *Template) Expand(bindings []Binding) []Triple {
+	seen := make(map[string]bool)
+	var result []Triple
+	for row, b := range bindings {
+		for _, triple := range t.triples {
+			expanded := Triple{Graph: triple.Graph}
+			ok := true
+			for i, term := range []string{triple.Subject, triple.Predicate, triple.Object} {
+				value, bound := t.expandTerm(term, b, row)
+				if !bound {
+					ok = false
+					break
+				}
+				switch i {
+				case 0:
+					expanded.Subject = value
+				case 1:
+					expanded.Predicate = value
+				default:
+					expanded.Object = value
+				}
+			}
+			if !ok {
+				continue
+			}
+			if key := tripleKey(expanded); !seen[key] {
+				seen[key] = true
+				result = append(result, expanded)
+			}
+		}
+	}
+	return result
+}
+
+// expandTerm substitutes the placeholders of an encoded term, reporting
+// false if one of them is not bound
+func (t *Template) expandTerm(term string, b Binding, row int) (string, bool) {
+	parsed := ParseTerm(term)
+	if parsed.Kind == TermBlankNode {
+		parsed.Value += "_r" + strconv.Itoa(row+1)
+	}
+	if !strings.Contains(term, t.marker) {
+		return parsed.String(), true
+	}
+
+	// A whole-term placeholder keeps a bound IRI or blank node
+	if parsed.Kind == TermIRI {
+		if name, ok := t.variable(parsed.Value); ok {
+			value := b[name]
+			if value == "" {
+				return "", false
+			}
+			if bound := ParseTerm(value); !bound.IsLiteral() {
+				return value, true
+			}
+		}
+	}
+
+	ok := true
+	substitute := func(s string, escape func(string) string) string {
+		return t.replaceMarkers(s, func(name string) string {
+			value := b[name]
+			if value == "" {
+				ok = false
+				return ""
+			}
+			return escape(ParseTerm(value).Value)
+		})
+	}
+	raw := func(s string) string { return s }
+	switch parsed.Kind {
+	case TermLiteral:
+		parsed.Value = substitute(parsed.Value, raw)
+		parsed.Datatype = substitute(parsed.Datatype, escapeIRIChars)
+		parsed.Language = substitute(parsed.Language, raw)
+	default:
+		parsed.Value = substitute(parsed.Value, escapeIRIChars)
+	}
+	return parsed.String(), ok
+}
+
+// variable returns the name of the variable whose marker is s
+func (t *Template) variable(s string) (string, bool) {
+	rest, ok := strings.CutPrefix(s, t.marker)
+	if !ok || !strings.HasSuffix(rest, "z") {
+		return "", false
+	}
+	i, err := strconv.Atoi(strings.TrimSuffix(rest, "z"))
+	if err != nil || i < 0 || i >= len(t.variables) {
+		return "", false
+	}
+	return t.variables[i], true
+}
+
+// replaceMarkers replaces the markers in s by the values of their variables
+func (t *Template) replaceMarkers(s string, value func(name string) string) string {
+	var sb strings.Builder
+	for {
+		start := strings.Index(s, t.marker)
+		if start < 0 {
+			sb.WriteString(s)
+			return sb.String()
+		}
+		end := start + len(t.marker)
+		for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+			end++
+		}
+		name, ok := t.variable(s[start:min(end+1, len(s))])
+		if !ok {
+			sb.WriteString(s[:end])
+			s = s[end:]
+			continue
+		}
+		sb.WriteString(s[:start])
+		sb.WriteString(value(name))
+		s = s[end+1:]
+	}
+}
+
+// escapeIRIChars percent-encodes the characters IRIs cannot contain
+func escapeIRIChars(s string) string {
+	var sb strings.Builder
+	for _, b := range []byte(s) {
+		if b <= 0x20 || b == 0x7f || strings.IndexByte("<>\"{}|\\^`", b) >= 0 {
+			fmt.Fprintf(&sb, "%%%02X", b)
+			continue
+		}
+		sb.WriteByte(b)
+	}
+	return sb.String()
+}
+
+// BindingsFromTable returns one binding per row of a table, binding the
+// column names to the cells as plain literals. Empty cells are left
+// unbound.
+func BindingsFromTable(table *Table) []Binding {
+	bindings := make([]Binding, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		b := make(Binding, len(table.Header))
+		for i, name := range table.Header {
+			if i < len(row) && row[i] != "" && name != "" {
+				b[name] = NewLiteral(row[i], "").String()
+			}
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings
+}
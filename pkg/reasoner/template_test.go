@@ -0,0 +1,111 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+const municipalityTemplate = `@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:municipality-{bfs} a ex:Municipality ;
+    ex:name "{name}"@de ;
+    ex:population "{population}"^^xsd:integer ;
+    ex:canton <{canton}> ;
+    ex:page <http://example.org/pages/{name}> ;
+    ex:address _:a .
+_:a ex:street "{street}" .
+`
+
+func TestTemplateExpand(t *testing.T) {
+	tmpl, err := ParseTemplate(municipalityTemplate)
+	if err != nil {
+		t.Fatalf("ParseTemplate failed: %v", err)
+	}
+	if got := tmpl.Variables(); strings.Join(got, ",") != "bfs,canton,name,population,street" {
+		t.Errorf("Variables() = %v", got)
+	}
+	if tmpl.Prefixes()["ex"] != "http://example.org/" {
+		t.Errorf("Prefixes() = %v", tmpl.Prefixes())
+	}
+
+	table := &Table{
+		Header: []string{"bfs", "name", "population", "canton", "street"},
+		Rows: [][]string{
+			{"351", "Bern", "134000", "http://example.org/be", "Bundesplatz 3"},
+			{"6621", "Genève", "", "http://example.org/ge"},
+		},
+	}
+	triples := tmpl.Expand(BindingsFromTable(table))
+	store := NewTripleStore()
+	for _, tr := range triples {
+		store.Add(tr)
+	}
+
+	ex := "http://example.org/"
+	for _, want := range []Triple{
+		{Subject: ex + "municipality-351", Predicate: RDFType, Object: ex + "Municipality"},
+		{Subject: ex + "municipality-351", Predicate: ex + "name", Object: `"Bern"@de`},
+		{Subject: ex + "municipality-351", Predicate: ex + "population", Object: `"134000"^^<` + XSD + `integer>`},
+		{Subject: ex + "municipality-351", Predicate: ex + "canton", Object: ex + "be"},
+		{Subject: ex + "municipality-351", Predicate: ex + "page", Object: ex + "pages/Bern"},
+		{Subject: ex + "municipality-351", Predicate: ex + "address", Object: "_:a_r1"},
+		{Subject: "_:a_r1", Predicate: ex + "street", Object: `"Bundesplatz 3"`},
+		{Subject: ex + "municipality-6621", Predicate: ex + "name", Object: `"Genève"@de`},
+		{Subject: ex + "municipality-6621", Predicate: ex + "page", Object: ex + "pages/Genève"},
+		{Subject: ex + "municipality-6621", Predicate: ex + "address", Object: "_:a_r2"},
+	} {
+		if !store.Contains(want) {
+			t.Errorf("Expected %v", want)
+		}
+	}
+	// The population and street of the second row are missing
+	if len(triples) != 12 {
+		t.Errorf("Expected 12 triples, got %d: %v", len(triples), triples)
+	}
+}
+
+func TestTemplateExpandQueryResults(t *testing.T) {
+	tmpl, err := ParseTemplate(`@prefix ex: <http://example.org/> .
+<{place}> ex:label "{place}" ; ex:note "{note}" ; ex:see <http://example.org/search?q={label}> .`)
+	if err != nil {
+		t.Fatalf("ParseTemplate failed: %v", err)
+	}
+	triples := tmpl.Expand([]Binding{
+		{"place": "http://example.org/bern", "note": `"a \"quoted\" note"@en`, "label": `"Bern {BE}"`},
+		{"place": "_:b1"},
+		{"place": "http://example.org/bern", "note": `"a \"quoted\" note"@en`},
+	})
+
+	ex := "http://example.org/"
+	expected := []Triple{
+		{Subject: ex + "bern", Predicate: ex + "label", Object: `"http://example.org/bern"`},
+		{Subject: ex + "bern", Predicate: ex + "note", Object: `"a \"quoted\" note"`},
+		{Subject: ex + "bern", Predicate: ex + "see", Object: ex + "search?q=Bern%20%7BBE%7D"},
+		{Subject: "_:b1", Predicate: ex + "label", Object: `"b1"`},
+	}
+	if len(triples) != len(expected) {
+		t.Fatalf("Expected %d triples, got %v", len(expected), triples)
+	}
+	for i, want := range expected {
+		if triples[i] != want {
+			t.Errorf("Triple %d = %v, want %v", i, triples[i], want)
+		}
+	}
+}
+
+func TestParseTemplateErrors(t *testing.T) {
+	if _, err := ParseTemplate(`@prefix ex: <http://example.org/> .
+ex:a ex:p .`); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error for the invalid statement, got %v", err)
+	}
+
+	// Text that looks like a marker is kept
+	tmpl, err := ParseTemplate(`<http://example.org/{id}> <http://example.org/p> "tplvar0z" .`)
+	if err != nil {
+		t.Fatalf("ParseTemplate failed: %v", err)
+	}
+	triples := tmpl.Expand([]Binding{{"id": `"7"`}})
+	if len(triples) != 1 || triples[0].Subject != "http://example.org/7" || triples[0].Object != `"tplvar0z"` {
+		t.Errorf("Expand() = %v", triples)
+	}
+}
@@ -0,0 +1,167 @@
+package reasoner
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XML Schema temporal datatypes
+const (
+	XSDDate     = XSD + "date"
+	XSDTime     = XSD + "time"
+	XSDDuration = XSD + "duration"
+)
+
+// Layouts of the xsd:dateTime, xsd:date and xsd:time lexical forms, with and
+// without timezone
+var (
+	xsdDateTimeLayouts = []string{"2006-01-02T15:04:05.999999999Z07:00", "2006-01-02T15:04:05.999999999"} //nolint:gochecknoglobals
+	xsdDateLayouts     = []string{"2006-01-02Z07:00", "2006-01-02"}                                       //nolint:gochecknoglobals
+	xsdTimeLayouts     = []string{"15:04:05.999999999Z07:00", "15:04:05.999999999"}                       //nolint:gochecknoglobals
+)
+
+// xsdDurationPattern matches the xsd:duration lexical form PnYnMnDTnHnMnS
+var xsdDurationPattern = regexp.MustCompile( //nolint:gochecknoglobals
+	`^(-)?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDateTime parses an xsd:dateTime, xsd:date or xsd:time literal term.
+// Values without a timezone are taken to be in UTC; xsd:time values fall on
+// January 1st of year 0. Untyped and xsd:string literals are accepted in any
+// of the three forms.
+func ParseDateTime(term string) (time.Time, error) {
+	t := ParseTerm(term)
+	if !t.IsLiteral() {
+		return time.Time{}, fmt.Errorf("%s is not a literal", term)
+	}
+
+	var layouts []string
+	switch t.Datatype {
+	case XSDDateTime:
+		layouts = xsdDateTimeLayouts
+	case XSDDate:
+		layouts = xsdDateLayouts
+	case XSDTime:
+		layouts = xsdTimeLayouts
+	case "", XSDString:
+		layouts = append(append(append([]string{}, xsdDateTimeLayouts...), xsdDateLayouts...), xsdTimeLayouts...)
+	default:
+		return time.Time{}, fmt.Errorf("%s is not a date or time literal", term)
+	}
+
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, t.Value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date or time %q", t.Value)
+}
+
+// ParseDuration parses an xsd:duration literal term. Durations with years or
+// months are rejected because their length depends on the date they are
+// added to.
+func ParseDuration(term string) (time.Duration, error) {
+	t := ParseTerm(term)
+	if !t.IsLiteral() {
+		return 0, fmt.Errorf("%s is not a literal", term)
+	}
+	if t.Datatype != "" && t.Datatype != XSDDuration && t.Datatype != XSDString {
+		return 0, fmt.Errorf("%s is not a duration literal", term)
+	}
+
+	m, err := matchDuration(t.Value)
+	if err != nil {
+		return 0, err
+	}
+	if strings.Trim(m[2]+m[3], "0") != "" {
+		return 0, fmt.Errorf("duration %q has years or months, which have no fixed length", t.Value)
+	}
+
+	var total float64
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[4+i] == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(m[4+i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", t.Value)
+		}
+		total += n * float64(unit)
+	}
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q is too long", t.Value)
+	}
+
+	d := time.Duration(total)
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// matchDuration matches an xsd:duration lexical form and returns its
+// submatches: sign, years, months, days, hours, minutes and seconds
+func matchDuration(s string) ([]string, error) {
+	m := xsdDurationPattern.FindStringSubmatch(s)
+	if m == nil || s == "P" || s == "-P" || s[len(s)-1] == 'T' {
+		return nil, fmt.Errorf("invalid duration %q", s)
+	}
+	return m, nil
+}
+
+// validateTemporalLiteral checks the lexical form of a temporal literal and
+// returns nil for other terms
+func validateTemporalLiteral(term string) error {
+	if len(term) == 0 || term[0] != '"' {
+		return nil
+	}
+
+	t := ParseTerm(term)
+	switch t.Datatype {
+	case XSDDateTime, XSDDate, XSDTime:
+		_, err := ParseDateTime(term)
+		return err
+	case XSDDuration:
+		_, err := matchDuration(t.Value)
+		return err
+	default:
+		return nil
+	}
+}
+
+// ObjectBefore returns a filter matching triples whose object is a date or
+// time literal strictly before instant
+func ObjectBefore(instant time.Time) TripleFilter {
+	return func(t Triple) bool {
+		parsed, err := ParseDateTime(t.Object)
+		return err == nil && parsed.Before(instant)
+	}
+}
+
+// ObjectAfter returns a filter matching triples whose object is a date or
+// time literal strictly after instant
+func ObjectAfter(instant time.Time) TripleFilter {
+	return func(t Triple) bool {
+		parsed, err := ParseDateTime(t.Object)
+		return err == nil && parsed.After(instant)
+	}
+}
+
+// temporalBuiltin adapts a comparison of two instants to a Datalog builtin
+// over quoted date or time constants
+func temporalBuiltin(compare func(a, b time.Time) bool) DLBuiltin {
+	return func(args []string) bool {
+		if len(args) != 2 {
+			return false
+		}
+		a, err := ParseDateTime(`"` + escapeLiteral(datalogLiteralValue(args[0])) + `"`)
+		if err != nil {
+			return false
+		}
+		b, err := ParseDateTime(`"` + escapeLiteral(datalogLiteralValue(args[1])) + `"`)
+		return err == nil && compare(a, b)
+	}
+}
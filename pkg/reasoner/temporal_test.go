@@ -0,0 +1,139 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDateTime(t *testing.T) {
+	tests := []struct {
+		term    string
+		want    time.Time
+		wantErr bool
+	}{
+		{`"2024-03-01T12:30:00Z"^^<` + XSDDateTime + `>`, time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC), false},
+		{`"2024-03-01T14:30:00.5+02:00"^^<` + XSDDateTime + `>`, time.Date(2024, 3, 1, 12, 30, 0, 5e8, time.UTC), false},
+		{`"2024-03-01T12:30:00"^^<` + XSDDateTime + `>`, time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC), false},
+		{`"2024-03-01"^^<` + XSDDate + `>`, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{`"08:15:00"^^<` + XSDTime + `>`, time.Date(0, 1, 1, 8, 15, 0, 0, time.UTC), false},
+		{`"2024-03-01"`, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{`"2024-02-30"^^<` + XSDDate + `>`, time.Time{}, true},
+		{`"2024-03-01"^^<` + XSDDateTime + `>`, time.Time{}, true},
+		{`"yesterday"^^<` + XSDDate + `>`, time.Time{}, true},
+		{`"2024-03-01"^^<` + XSDInteger + `>`, time.Time{}, true},
+		{`http://example.org/date`, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDateTime(tt.term)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDateTime(%s) error = %v, wantErr %v", tt.term, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseDateTime(%s) = %v, want %v", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		lexical string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"P1D", 24 * time.Hour, false},
+		{"PT1H30M", 90 * time.Minute, false},
+		{"-PT0.5S", -500 * time.Millisecond, false},
+		{"P0Y2DT1S", 48*time.Hour + time.Second, false},
+		{"P1Y", 0, true},
+		{"P2M", 0, true},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"P1DT", 0, true},
+		{"1 day", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(`"` + tt.lexical + `"^^<` + XSDDuration + `>`)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDuration(%q) error = %v, wantErr %v", tt.lexical, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, want %v", tt.lexical, got, tt.want)
+		}
+	}
+}
+
+const temporalDocument = `
+@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:permit1 ex:issued "2023-11-20"^^xsd:date ;
+    ex:valid "P30D"^^xsd:duration .
+ex:permit2 ex:issued "2024-02-01T09:00:00+01:00"^^xsd:dateTime .
+ex:permit3 ex:issued "2024-13-01"^^xsd:date .
+ex:permit4 ex:valid "P1Y2M"^^xsd:duration ;
+    ex:renewed "soon"^^xsd:dateTime .
+`
+
+func TestTemporalLoadWarnings(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(temporalDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	warnings := r.Warnings()
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %v", warnings)
+	}
+	if warnings[0].Line != 7 || !strings.Contains(warnings[0].Message, "2024-13-01") {
+		t.Errorf("Unexpected first warning: %v", warnings[0])
+	}
+	if warnings[1].Line != 8 || !strings.Contains(warnings[1].Message, "soon") {
+		t.Errorf("Unexpected second warning: %v", warnings[1])
+	}
+
+	// Malformed literals are still loaded
+	if len(r.Query("http://example.org/permit3", "", "")) != 1 {
+		t.Errorf("Triple with a malformed date should be loaded")
+	}
+}
+
+func TestTemporalFilters(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(temporalDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	newYear := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := r.QueryFiltered("", "http://example.org/issued", "", ObjectBefore(newYear))
+	if len(before) != 1 || before[0].Subject != "http://example.org/permit1" {
+		t.Errorf("Expected permit1 issued before 2024, got %v", before)
+	}
+	after := r.QueryFiltered("", "http://example.org/issued", "", ObjectAfter(newYear))
+	if len(after) != 1 || after[0].Subject != "http://example.org/permit2" {
+		t.Errorf("Expected permit2 issued after 2024, got %v", after)
+	}
+
+	facts := ConvertTriplesToDatalogWithOptions(r.GetAllTriples(), DatalogOptions{QuoteLiterals: true})
+	program := strings.Join(facts, "\n") + `
+Recent(X) :- issued(X, D), dateAfter(D, "2024-01-01T00:00:00Z").
+`
+	for query, expected := range map[string]bool{
+		"?- Recent(permit2).": true,
+		"?- Recent(permit1).": false,
+		"?- Recent(permit3).": false,
+		`?- dateBefore("2023-11-20", "2023-11-21T00:00:00Z").`: true,
+	} {
+		result, err := DLQuery(program, query)
+		if err != nil {
+			t.Errorf("DLQuery error for %s: %v", query, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("DLQuery(%s) = %v, expected %v", query, result, expected)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package reasoner
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TermKind distinguishes the kinds of RDF terms
+type TermKind int
+
+const (
+	// TermIRI is an IRI, or a prefixed name whose prefix was not declared
+	TermIRI TermKind = iota
+	// TermBlankNode is a blank node
+	TermBlankNode
+	// TermLiteral is a literal, optionally with a datatype or language tag
+	TermLiteral
+)
+
+// String returns the name of the term kind
+func (k TermKind) String() string {
+	switch k {
+	case TermBlankNode:
+		return "blank node"
+	case TermLiteral:
+		return "literal"
+	default:
+		return "IRI"
+	}
+}
+
+// Term is a typed RDF term.
+//
+// Triples store their terms as strings for compatibility: IRIs are bare,
+// blank nodes start with "_:" and literals are written as "lexical",
+// "lexical"@lang or "lexical"^^<datatype>, with the lexical form escaped as
+// in N-Triples. Term is the parsed form of that encoding and String converts
+// it back.
+type Term struct {
+	Kind     TermKind
+	Value    string // IRI, blank node label without "_:", or unescaped lexical form
+	Datatype string // Datatype IRI of a typed literal
+	Language string // Language tag of a language-tagged literal
+}
+
+// NewIRI returns an IRI term
+func NewIRI(iri string) Term {
+	return Term{Kind: TermIRI, Value: iri}
+}
+
+// NewBlankNode returns a blank node term with the given label
+func NewBlankNode(label string) Term {
+	return Term{Kind: TermBlankNode, Value: label}
+}
+
+// NewLiteral returns a literal term, typed if datatype is not empty
+func NewLiteral(lexical, datatype string) Term {
+	return Term{Kind: TermLiteral, Value: lexical, Datatype: datatype}
+}
+
+// NewLangLiteral returns a language-tagged literal term
+func NewLangLiteral(lexical, language string) Term {
+	return Term{Kind: TermLiteral, Value: lexical, Language: language}
+}
+
+// ParseTerm parses the string encoding of a term used in Triple
+func ParseTerm(s string) Term {
+	switch {
+	case strings.HasPrefix(s, "_:"):
+		return NewBlankNode(s[2:])
+	case strings.HasPrefix(s, `"`):
+		return parseLiteralTerm(s)
+	default:
+		return NewIRI(strings.TrimSuffix(strings.TrimPrefix(s, "<"), ">"))
+	}
+}
+
+// parseLiteralTerm splits an encoded literal at its closing quote
+func parseLiteralTerm(s string) Term {
+	end := strings.LastIndex(s, `"`)
+	if end <= 0 {
+		return NewLiteral(strings.TrimPrefix(s, `"`), "")
+	}
+
+	lexical := unescapeLiteral(s[1:end])
+	suffix := s[end+1:]
+	switch {
+	case strings.HasPrefix(suffix, "^^"):
+		datatype := strings.TrimSuffix(strings.TrimPrefix(suffix[2:], "<"), ">")
+		return NewLiteral(lexical, datatype)
+	case strings.HasPrefix(suffix, "@"):
+		return NewLangLiteral(lexical, suffix[1:])
+	default:
+		return NewLiteral(lexical, "")
+	}
+}
+
+// String returns the string encoding of the term used in Triple
+func (t Term) String() string {
+	switch t.Kind {
+	case TermBlankNode:
+		return "_:" + t.Value
+	case TermLiteral:
+		s := `"` + escapeLiteral(t.Value) + `"`
+		if t.Language != "" {
+			return s + "@" + t.Language
+		}
+		if t.Datatype != "" {
+			return s + "^^<" + t.Datatype + ">"
+		}
+		return s
+	default:
+		return t.Value
+	}
+}
+
+// IsIRI reports whether the term is an IRI
+func (t Term) IsIRI() bool {
+	return t.Kind == TermIRI
+}
+
+// IsBlankNode reports whether the term is a blank node
+func (t Term) IsBlankNode() bool {
+	return t.Kind == TermBlankNode
+}
+
+// IsLiteral reports whether the term is a literal
+func (t Term) IsLiteral() bool {
+	return t.Kind == TermLiteral
+}
+
+// SubjectTerm returns the subject as a typed term
+func (t Triple) SubjectTerm() Term {
+	return ParseTerm(t.Subject)
+}
+
+// PredicateTerm returns the predicate as a typed term
+func (t Triple) PredicateTerm() Term {
+	return ParseTerm(t.Predicate)
+}
+
+// ObjectTerm returns the object as a typed term
+func (t Triple) ObjectTerm() Term {
+	return ParseTerm(t.Object)
+}
+
+// IsWellFormed reports whether the triple is valid RDF: the subject is an
+// IRI or blank node and the predicate is an IRI. Rules use it to avoid
+// deriving triples with literal subjects or blank node predicates.
+func (t Triple) IsWellFormed() bool {
+	return t.Subject != "" && t.Predicate != "" &&
+		t.SubjectTerm().Kind != TermLiteral && t.PredicateTerm().IsIRI()
+}
+
+// literalEscaper escapes the characters N-Triples requires to be escaped
+var literalEscaper = strings.NewReplacer( //nolint:gochecknoglobals
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+)
+
+// escapeLiteral escapes a lexical form for use between double quotes
+func escapeLiteral(s string) string {
+	return literalEscaper.Replace(s)
+}
+
+// unescapeLiteral decodes Turtle string escapes (ECHAR and UCHAR).
+// Invalid escape sequences are kept as written.
+func unescapeLiteral(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		switch c := s[i+1]; c {
+		case 't':
+			sb.WriteByte('\t')
+		case 'b':
+			sb.WriteByte('\b')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case '"', '\'', '\\':
+			sb.WriteByte(c)
+		case 'u', 'U':
+			size := 4
+			if c == 'U' {
+				size = 8
+			}
+			if i+2+size <= len(s) {
+				if code, err := strconv.ParseUint(s[i+2:i+2+size], 16, 32); err == nil {
+					sb.WriteRune(rune(code))
+					i += 1 + size
+					continue
+				}
+			}
+			sb.WriteString(s[i : i+2])
+		default:
+			sb.WriteString(s[i : i+2])
+		}
+		i++
+	}
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package reasoner
+
+import (
+	"testing"
+)
+
+func TestParseTerm(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Term
+	}{
+		{"http://example.org/alice", NewIRI("http://example.org/alice")},
+		{"<http://example.org/alice>", NewIRI("http://example.org/alice")},
+		{"ex:alice", NewIRI("ex:alice")},
+		{"_:b0", NewBlankNode("b0")},
+		{`"Alice"`, NewLiteral("Alice", "")},
+		{`"Alice"@en-GB`, NewLangLiteral("Alice", "en-GB")},
+		{`"42"^^<http://www.w3.org/2001/XMLSchema#integer>`, NewLiteral("42", "http://www.w3.org/2001/XMLSchema#integer")},
+		{`"say \"hi\"\n"`, NewLiteral("say \"hi\"\n", "")},
+		{`"caf\u00e9"`, NewLiteral("café", "")},
+	}
+
+	for _, tt := range tests {
+		got := ParseTerm(tt.input)
+		if got != tt.expected {
+			t.Errorf("ParseTerm(%s) = %+v, expected %+v", tt.input, got, tt.expected)
+		}
+		if reparsed := ParseTerm(got.String()); reparsed != got {
+			t.Errorf("ParseTerm(%s.String()) = %+v, expected %+v", tt.input, reparsed, got)
+		}
+	}
+}
+
+func TestRulesRespectTermKinds(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(`
+@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+ex:name owl:inverseOf ex:nameOf .
+ex:label a owl:SymmetricProperty .
+ex:alias rdfs:subPropertyOf _:anonymous .
+ex:name rdfs:range ex:Name .
+ex:alice ex:name "Alice" ; ex:label "A" ; ex:alias ex:ally .
+ex:alice owl:sameAs "alice" .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	for _, triple := range r.GetStore().All() {
+		if !triple.IsWellFormed() {
+			t.Errorf("Rule derived an ill-formed triple: %s", triple)
+		}
+	}
+	if len(r.GetInferredTypes(`"Alice"`)) != 0 {
+		t.Errorf("Expected literal not to be typed by rdfs:range")
+	}
+}
@@ -0,0 +1,64 @@
+package reasoner
+
+import "context"
+
+// Span names and attribute keys emitted by the reasoner
+const (
+	SpanLoadTurtle       = "reasoner.LoadTurtle"
+	SpanLoadNTriples     = "reasoner.LoadNTriples"
+	SpanLoadNQuads       = "reasoner.LoadNQuads"
+	SpanLoadRDFXML       = "reasoner.LoadRDFXML"
+	SpanLoadJSONLD       = "reasoner.LoadJSONLD"
+	SpanLoadTriG         = "reasoner.LoadTriG"
+	SpanLoadN3           = "reasoner.LoadN3"
+	SpanForwardReasoning = "reasoner.RunForwardReasoning"
+	SpanReasoningRound   = "reasoner.round"
+	SpanRuleApply        = "reasoner.rule"
+	SpanQuery            = "reasoner.Query"
+
+	AttrTriples  = "reasoner.triples"
+	AttrInferred = "reasoner.inferred"
+	AttrRound    = "reasoner.round"
+	AttrRounds   = "reasoner.rounds"
+	AttrRule     = "reasoner.rule"
+	AttrPattern  = "reasoner.pattern"
+	AttrResults  = "reasoner.results"
+)
+
+// Tracer starts spans around reasoning steps.
+//
+// It mirrors the subset of the OpenTelemetry trace.Tracer API used by the
+// reasoner, so an OpenTelemetry tracer can be plugged in with a small adapter
+// without this package depending on the OpenTelemetry SDK:
+//
+//	type otelTracer struct{ t trace.Tracer }
+//
+//	func (o otelTracer) Start(ctx context.Context, name string) (context.Context, reasoner.Span) {
+//		ctx, span := o.t.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+type Tracer interface {
+	// Start creates a span that is a child of any span in ctx
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced operation
+type Span interface {
+	// SetAttribute records a key/value pair on the span
+	SetAttribute(key string, value any)
+	// End completes the span
+	End()
+}
+
+// noopTracer is used when no tracer has been configured
+type noopTracer struct{}
+
+type noopSpan struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+func (noopSpan) SetAttribute(string, any) {}
+
+func (noopSpan) End() {}
@@ -0,0 +1,98 @@
+package reasoner
+
+import "fmt"
+
+// TriGParser parses TriG: Turtle whose statements may be grouped in graph
+// blocks such as "ex:g { ex:a ex:p ex:b . }", optionally introduced by
+// GRAPH. The label of the block, an IRI or blank node, is kept in
+// Triple.Graph; statements outside blocks and in unlabelled "{ }" blocks
+// belong to the default graph.
+type TriGParser struct {
+	TurtleParser
+}
+
+// NewTriGParser creates a new TriG parser
+func NewTriGParser() *TriGParser {
+	p := &TriGParser{*NewTurtleParser()}
+	p.trig = true
+	return p
+}
+
+// NewTriGParserWithOptions creates a TriG parser that enforces limits
+func NewTriGParserWithOptions(opts ParserOptions) *TriGParser {
+	p := NewTriGParser()
+	p.options = opts
+	return p
+}
+
+// parseGraphBoundary consumes the start or end of a graph block at the
+// current position, reporting whether there was one. A stray '}' is
+// consumed and reported.
+func (p *TurtleParser) parseGraphBoundary() (bool, error) {
+	switch {
+	case p.input[p.pos] == '}':
+		p.pos++
+		if p.graphStart < 0 {
+			return true, fmt.Errorf("unexpected '}' outside a graph block")
+		}
+		p.graph, p.graphStart = "", -1
+		return true, nil
+	case p.graphStart >= 0:
+		// Graph blocks do not nest
+		return false, nil
+	}
+
+	start := p.pos
+	label := ""
+	switch {
+	case p.input[p.pos] == '{':
+	case p.lookingAtCaseInsensitive("GRAPH") && (p.pos+5 >= len(p.input) || !isNameChar(rune(p.input[p.pos+5])) && p.input[p.pos+5] != ':'):
+		p.pos += 5
+		p.skipWhitespaceAndComments()
+		var err error
+		if label, err = p.parseGraphLabel(); err != nil {
+			return false, err
+		}
+		p.skipWhitespaceAndComments()
+		if p.pos >= len(p.input) || p.input[p.pos] != '{' {
+			return false, fmt.Errorf("expected '{' after the graph label")
+		}
+	default:
+		// A label followed by '{' starts a block; anything else is the
+		// subject of a statement and parsed again as such
+		notes := len(p.notes)
+		var err error
+		label, err = p.parseGraphLabel()
+		p.skipWhitespaceAndComments()
+		if err != nil || p.pos >= len(p.input) || p.input[p.pos] != '{' {
+			p.pos = start
+			p.notes = p.notes[:notes]
+			return false, nil
+		}
+	}
+
+	p.pos++ // skip '{'
+	p.graph, p.graphStart = label, start
+	return true, nil
+}
+
+// parseGraphLabel parses the IRI, prefixed name or blank node labelling a
+// graph block
+func (p *TurtleParser) parseGraphLabel() (string, error) {
+	if p.pos >= len(p.input) {
+		return "", fmt.Errorf("unexpected end of input")
+	}
+	switch {
+	case p.input[p.pos] == '<':
+		iri, err := p.parseIRI()
+		if err != nil {
+			return "", err
+		}
+		return p.resolveIRI(iri), nil
+	case p.lookingAt("_:"):
+		return p.parseBlankNode()
+	case p.input[p.pos] == '"' || p.input[p.pos] == '[' || p.input[p.pos] == '(':
+		return "", fmt.Errorf("expected an IRI or blank node as graph label")
+	}
+	return p.parsePrefixedName()
+}
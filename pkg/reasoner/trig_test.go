@@ -0,0 +1,110 @@
+package reasoner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const trigDocument = `@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+
+ex:Municipality rdfs:subClassOf ex:Place .
+
+ex:bfs {
+    ex:bern a ex:Municipality ;
+        ex:population "134000" .
+}
+
+GRAPH <http://example.org/graphs/osm> {
+    ex:bern ex:name "Bern" ; }
+
+{ ex:thun a ex:Municipality }
+
+_:g { ex:biel ex:name "Biel" . }
+`
+
+func TestTriGParser(t *testing.T) {
+	p := NewTriGParser()
+	triples, err := p.Parse(trigDocument)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	ex := "http://example.org/"
+	expected := []Triple{
+		{Subject: ex + "Municipality", Predicate: RDFSSubClassOf, Object: ex + "Place"},
+		{Subject: ex + "bern", Predicate: RDFType, Object: ex + "Municipality", Graph: ex + "bfs"},
+		{Subject: ex + "bern", Predicate: ex + "population", Object: `"134000"`, Graph: ex + "bfs"},
+		{Subject: ex + "bern", Predicate: ex + "name", Object: `"Bern"`, Graph: ex + "graphs/osm"},
+		{Subject: ex + "thun", Predicate: RDFType, Object: ex + "Municipality"},
+		{Subject: ex + "biel", Predicate: ex + "name", Object: `"Biel"`, Graph: "_:g"},
+	}
+	if len(triples) != len(expected) {
+		t.Fatalf("Expected %d triples, got %v", len(expected), triples)
+	}
+	for i, want := range expected {
+		if triples[i] != want {
+			t.Errorf("Triple %d = %v, want %v", i, triples[i], want)
+		}
+	}
+	if errs := p.Errors(); len(errs) != 0 {
+		t.Errorf("Unexpected errors: %v", errs)
+	}
+	if p.Prefixes()["ex"] != ex {
+		t.Errorf("Unexpected prefixes: %v", p.Prefixes())
+	}
+}
+
+func TestTriGParserErrors(t *testing.T) {
+	p := NewTriGParser()
+	triples, err := p.Parse(`@prefix ex: <http://example.org/> .
+ex:g { ex:a ex:p '42' . ex:b ex:p ex:c . }
+}
+GRAPH "g" { ex:d ex:p ex:e . }
+ex:h { ex:f ex:p ex:g .
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	errs := p.Errors()
+	var lines []int
+	for _, e := range errs {
+		lines = append(lines, e.Line)
+	}
+	// The statement after the invalid graph label is skipped, which leaves
+	// its '}' unmatched
+	if len(errs) != 5 || lines[0] != 2 || lines[1] != 3 || lines[2] != 4 || lines[3] != 4 || lines[4] != 5 || !strings.Contains(errs[4].Message, "not closed") {
+		t.Errorf("Unexpected errors: %v", errs)
+	}
+	if len(triples) == 0 || triples[0].Subject != "http://example.org/b" || triples[0].Graph != "http://example.org/g" {
+		t.Errorf("Expected the valid statement of the first block to be kept, got %v", triples)
+	}
+
+	// Turtle does not accept graph blocks
+	turtle := NewTurtleParser()
+	if _, err := turtle.Parse(`<http://example.org/g> { <http://example.org/a> <http://example.org/p> <http://example.org/b> . }`); err != nil || len(turtle.Errors()) == 0 {
+		t.Errorf("Expected the Turtle parser to skip a graph block, got %v", err)
+	}
+
+	_, err = NewTriGParserWithOptions(ParserOptions{MaxTriples: 2}).Parse(trigDocument)
+	if !errors.Is(err, ErrParserLimit) {
+		t.Errorf("Expected a limit error, got %v", err)
+	}
+}
+
+func TestLoadTriG(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadDocument("data.trig", trigDocument, FormatUnknown); err != nil {
+		t.Fatalf("LoadDocument failed: %v", err)
+	}
+	r.RunForwardReasoning()
+
+	bern := r.Query("http://example.org/bern", RDFType, "http://example.org/Place")
+	if len(bern) != 1 || bern[0].Graph != "" {
+		t.Errorf("Expected reasoning over the union of the graphs, got %v", bern)
+	}
+	if got := r.Query("http://example.org/bern", "http://example.org/name", ""); len(got) != 1 || got[0].Graph != "http://example.org/graphs/osm" {
+		t.Errorf("Expected the graph label to be kept, got %v", got)
+	}
+}
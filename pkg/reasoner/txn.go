@@ -0,0 +1,177 @@
+package reasoner
+
+import "errors"
+
+// ErrTxnDone is returned when a transaction is used after Commit or Rollback
+var ErrTxnDone = errors.New("transaction has already been committed or rolled back")
+
+// Txn stages changes to a store, which are applied together by Commit or
+// discarded by Rollback. The staged changes are visible through Store, so
+// they can be queried and reasoned over before committing.
+type Txn struct {
+	base   *TripleStore
+	staged *TripleStore
+	done   bool
+
+	// history is the length of the staged store's history at Begin; the
+	// changes recorded after it are stamped with the time of Commit
+	history int
+
+	// reasoner is set for reasoner transactions; end is called after
+	// Commit or Rollback to restore it
+	reasoner *Reasoner
+	end      func(committed bool)
+}
+
+// Begin starts a transaction on a copy of the store. The store itself is
+// unchanged until Commit; changes made to it directly in the meantime are
+// overwritten by Commit.
+func (ts *TripleStore) Begin() *Txn {
+	txn := &Txn{base: ts, staged: ts.clone()}
+	if txn.staged.history != nil {
+		txn.history = len(txn.staged.history.events)
+	}
+	return txn
+}
+
+// Begin starts a transaction covering everything the reasoner does until
+// Commit or Rollback: loading documents, merging literals and reasoning all
+// apply to the staged store. Rollback also discards the prefixes and
+// diagnostics of documents loaded during the transaction, so a failed load
+// leaves the reasoner as it was.
+func (r *Reasoner) Begin() *Txn {
+	txn := r.store.Begin()
+
+	closed := r.closed
+	prefixes := make(map[string]string, len(r.prefixes))
+	for prefix, iri := range r.prefixes {
+		prefixes[prefix] = iri
+	}
+	loadDiagnostics := len(r.loadDiagnostics)
+	runDiagnostics := r.runDiagnostics
+
+	r.store = txn.staged
+	txn.reasoner = r
+	txn.end = func(committed bool) {
+		r.store = txn.base
+		if !committed {
+			r.closed = closed
+			r.prefixes = prefixes
+			r.loadDiagnostics = r.loadDiagnostics[:loadDiagnostics]
+			r.runDiagnostics = runDiagnostics
+		}
+	}
+	return txn
+}
+
+// Store returns the staged view of the store
+func (txn *Txn) Store() *TripleStore {
+	return txn.staged
+}
+
+// Add stages the addition of a triple and reports whether it was new
+func (txn *Txn) Add(t Triple) bool {
+	if txn.done {
+		return false
+	}
+	return txn.staged.Add(t)
+}
+
+// Remove stages the removal of a triple and reports whether it was present
+func (txn *Txn) Remove(t Triple) bool {
+	if txn.done || !txn.staged.Contains(t) {
+		return false
+	}
+	txn.staged.removeAll(map[string]bool{tripleKey(t): true})
+	if txn.reasoner != nil {
+		// Inferences may depend on the removed triple
+		txn.reasoner.closed = 0
+	}
+	return true
+}
+
+// Commit replaces the contents of the store with the staged view and
+// notifies its subscribers of the added triples. If the store is frozen,
+// the changes are rolled back and ErrReadOnly is returned.
+func (txn *Txn) Commit() error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	if txn.base.frozen {
+		_ = txn.Rollback()
+		return ErrReadOnly
+	}
+	txn.done = true
+
+	var added []Triple
+	for _, t := range txn.staged.tripleList {
+		if !txn.base.Contains(t) {
+			added = append(added, t)
+		}
+	}
+
+	base, staged := txn.base, txn.staged
+	base.triples = staged.triples
+	base.tripleList = staged.tripleList
+	base.bySubject = staged.bySubject
+	base.byPredicate = staged.byPredicate
+	base.byObject = staged.byObject
+	base.sources = staged.sources
+	base.blobs = staged.blobs
+	base.equivalent = staged.equivalent
+	if staged.history != nil {
+		now := staged.history.now()
+		for i := txn.history; i < len(staged.history.events); i++ {
+			staged.history.events[i].at = now
+		}
+		base.history = staged.history
+	}
+	base.generation = max(base.generation, staged.generation) + 1
+
+	if txn.end != nil {
+		txn.end(true)
+	}
+	for _, t := range added {
+		base.subscribers.notify(t)
+	}
+	return nil
+}
+
+// Rollback discards the staged changes
+func (txn *Txn) Rollback() error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	txn.done = true
+
+	// Keep generations of the staged view from being reused, since caches
+	// may hold results computed against them
+	txn.base.generation = max(txn.base.generation, txn.staged.generation) + 1
+
+	if txn.end != nil {
+		txn.end(false)
+	}
+	return nil
+}
+
+// clone returns a copy of the store without its subscribers
+func (ts *TripleStore) clone() *TripleStore {
+	c := NewTripleStore()
+	for predicate, equivalence := range ts.equivalences {
+		c.SetTermEquivalence(predicate, equivalence)
+	}
+	for _, t := range ts.tripleList {
+		c.add(t)
+	}
+	if ts.sources != nil {
+		c.sources = make(map[string][]string, len(ts.sources))
+		for key, sources := range ts.sources {
+			c.sources[key] = append([]string(nil), sources...)
+		}
+	}
+	if ts.history != nil {
+		c.history = ts.history.clone()
+	}
+	c.generation = ts.generation + 1
+	return c
+}
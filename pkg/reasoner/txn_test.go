@@ -0,0 +1,98 @@
+package reasoner
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTxnCommitAndRollback(t *testing.T) {
+	a := Triple{Subject: "http://example.org/a", Predicate: RDFType, Object: "http://example.org/C"}
+	b := Triple{Subject: "http://example.org/b", Predicate: RDFType, Object: "http://example.org/C"}
+
+	store := NewTripleStore()
+	store.Add(a)
+	feed, cancel := store.Subscribe(TriplePattern{})
+	defer cancel()
+
+	txn := store.Begin()
+	txn.Add(b)
+	if !txn.Remove(a) {
+		t.Error("Expected Remove to find the existing triple")
+	}
+	if store.Contains(b) || !store.Contains(a) {
+		t.Error("Staged changes must not be visible in the store before Commit")
+	}
+	if !txn.Store().Contains(b) || txn.Store().Contains(a) {
+		t.Error("Staged changes must be visible in the staged view")
+	}
+	if err := txn.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if !store.Contains(b) || store.Contains(a) || store.Size() != 1 {
+		t.Errorf("Expected only the added triple after Commit, got %v", store.All())
+	}
+	if got := <-feed; got != b {
+		t.Errorf("Expected subscribers to receive %v, got %v", b, got)
+	}
+	if err := txn.Rollback(); !errors.Is(err, ErrTxnDone) {
+		t.Errorf("Expected ErrTxnDone, got %v", err)
+	}
+
+	txn = store.Begin()
+	txn.Add(a)
+	if err := txn.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if store.Contains(a) || store.Size() != 1 {
+		t.Errorf("Expected the store unchanged after Rollback, got %v", store.All())
+	}
+}
+
+func TestReasonerTxn(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(incrementalSchema); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+	before := r.GetAllTriples()
+
+	txn := r.Begin()
+	if err := r.LoadTurtle(incrementalData); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	if r.RunForwardReasoning() == 0 {
+		t.Fatal("Expected inferences within the transaction")
+	}
+	if err := r.LoadTurtle(`@prefix bad: <http://example.org/bad#> .
+bad:x bad:p bad:y .
+bad:x bad:p .
+`); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	if err := txn.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	if after := r.GetAllTriples(); !equalStrings(after, before) {
+		t.Errorf("Rollback left changes:\n got %v\nwant %v", after, before)
+	}
+	if _, ok := r.Prefixes()["bad"]; ok {
+		t.Error("Rollback kept a prefix declared during the transaction")
+	}
+	if len(r.Diagnostics()) != 0 {
+		t.Errorf("Rollback kept diagnostics: %v", r.Diagnostics())
+	}
+
+	txn = r.Begin()
+	if err := r.LoadTurtle(incrementalData); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	r.RunForwardReasoning()
+	staged := r.GetAllTriples()
+	if err := txn.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if after := r.GetAllTriples(); !equalStrings(after, staged) {
+		t.Errorf("Commit lost changes:\n got %v\nwant %v", after, staged)
+	}
+}
@@ -0,0 +1,229 @@
+package reasoner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QUDT and UCUM vocabulary
+const (
+	QUDT             = "http://qudt.org/schema/qudt/"
+	QUDTUnitVocab    = "http://qudt.org/vocab/unit/"
+	QUDTNumericValue = QUDT + "numericValue"
+	QUDTValue        = QUDT + "value"
+	QUDTUnit         = QUDT + "unit"
+	QUDTHasUnit      = QUDT + "hasUnit"
+	UCUMLiteral      = "http://w3id.org/lindt/custom_datatypes#ucum"
+)
+
+// unitDef relates a unit to the base unit of its dimension
+type unitDef struct {
+	dimension string
+	factor    float64 // Size of the unit in base units
+}
+
+// ucumUnits lists the supported units by UCUM code. Units with an offset,
+// such as degrees Celsius, cannot be converted by a factor and are omitted.
+var ucumUnits = map[string]unitDef{ //nolint:gochecknoglobals
+	"m": {"length", 1}, "km": {"length", 1000}, "cm": {"length", 0.01}, "mm": {"length", 0.001},
+	"[mi_i]": {"length", 1609.344}, "[ft_i]": {"length", 0.3048},
+	"g": {"mass", 1}, "kg": {"mass", 1000}, "mg": {"mass", 0.001}, "t": {"mass", 1e6},
+	"s": {"time", 1}, "min": {"time", 60}, "h": {"time", 3600}, "d": {"time", 86400},
+	"m2": {"area", 1}, "km2": {"area", 1e6}, "cm2": {"area", 1e-4}, "har": {"area", 1e4},
+	"m3": {"volume", 1}, "L": {"volume", 0.001}, "l": {"volume", 0.001}, "mL": {"volume", 1e-6},
+	"m/s": {"speed", 1}, "km/h": {"speed", 1000.0 / 3600},
+}
+
+// qudtUnits maps the local names of QUDT unit IRIs to their UCUM code
+var qudtUnits = map[string]string{ //nolint:gochecknoglobals
+	"M": "m", "KiloM": "km", "CentiM": "cm", "MilliM": "mm", "MI": "[mi_i]", "FT": "[ft_i]",
+	"GM": "g", "KiloGM": "kg", "MilliGM": "mg", "TONNE": "t",
+	"SEC": "s", "MIN": "min", "HR": "h", "DAY": "d",
+	"M2": "m2", "KiloM2": "km2", "CentiM2": "cm2", "HA": "har",
+	"M3": "m3", "L": "L", "MilliL": "mL",
+	"M-PER-SEC": "m/s", "KiloM-PER-HR": "km/h",
+}
+
+// Quantity is a numeric value with a unit, identified by its UCUM code
+type Quantity struct {
+	Value float64
+	Unit  string
+}
+
+// String returns the quantity in the UCUM literal form, e.g. "5 km"
+func (q Quantity) String() string {
+	return strconv.FormatFloat(q.Value, 'f', -1, 64) + " " + q.Unit
+}
+
+// ConvertTo returns the quantity expressed in another unit of the same
+// dimension. The unit may be a UCUM code or a QUDT unit IRI.
+func (q Quantity) ConvertTo(unit string) (Quantity, error) {
+	value, err := ConvertUnit(q.Value, q.Unit, unit)
+	if err != nil {
+		return Quantity{}, err
+	}
+	code, _ := normalizeUnit(unit)
+	return Quantity{Value: value, Unit: code}, nil
+}
+
+// ConvertUnit converts a value between two units of the same dimension,
+// given as UCUM codes or QUDT unit IRIs, e.g. ConvertUnit(5, "km", "m") = 5000
+func ConvertUnit(value float64, from, to string) (float64, error) {
+	fromCode, ok := normalizeUnit(from)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+	toCode, ok := normalizeUnit(to)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+
+	f, t := ucumUnits[fromCode], ucumUnits[toCode]
+	if f.dimension != t.dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", fromCode, f.dimension, toCode, t.dimension)
+	}
+	return value * f.factor / t.factor, nil
+}
+
+// CompareQuantities compares two quantities of the same dimension and
+// returns -1, 0 or 1 as a is less than, equal to or greater than b
+func CompareQuantities(a, b Quantity) (int, error) {
+	converted, err := ConvertUnit(a.Value, a.Unit, b.Unit)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case converted < b.Value:
+		return -1, nil
+	case converted > b.Value:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// ParseQuantity parses a UCUM literal term such as "5 km"^^cdt:ucum.
+// Untyped and xsd:string literals in the same form are accepted too.
+func ParseQuantity(term string) (Quantity, error) {
+	t := ParseTerm(term)
+	if !t.IsLiteral() {
+		return Quantity{}, fmt.Errorf("%s is not a literal", term)
+	}
+	if t.Datatype != "" && t.Datatype != UCUMLiteral && t.Datatype != XSDString {
+		return Quantity{}, fmt.Errorf("%s is not a UCUM literal", term)
+	}
+	return parseQuantityLexical(t.Value)
+}
+
+// QuantityOf returns the quantity denoted by a term: a UCUM literal, or a
+// QUDT quantity value node with qudt:numericValue (or qudt:value) and
+// qudt:unit (or qudt:hasUnit)
+func (ts *TripleStore) QuantityOf(term string) (Quantity, error) {
+	if strings.HasPrefix(term, `"`) {
+		return ParseQuantity(term)
+	}
+
+	value := ""
+	for _, p := range []string{QUDTNumericValue, QUDTValue} {
+		if values := ts.FindBySubjectPredicate(term, p); len(values) > 0 {
+			value = values[0].Object
+			break
+		}
+	}
+	unit := ""
+	for _, p := range []string{QUDTUnit, QUDTHasUnit} {
+		if units := ts.FindBySubjectPredicate(term, p); len(units) > 0 {
+			unit = units[0].Object
+			break
+		}
+	}
+	if value == "" || unit == "" {
+		return Quantity{}, fmt.Errorf("%s is not a quantity value", term)
+	}
+
+	number, err := strconv.ParseFloat(ParseTerm(value).Value, 64)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("%s has non-numeric value %s", term, value)
+	}
+	code, ok := normalizeUnit(unit)
+	if !ok {
+		return Quantity{}, fmt.Errorf("%s has unknown unit %s", term, unit)
+	}
+	return Quantity{Value: number, Unit: code}, nil
+}
+
+// ObjectQuantityAbove returns a filter matching triples whose object is a
+// quantity greater than threshold after unit conversion. QUDT quantity
+// value nodes are resolved in store.
+func ObjectQuantityAbove(store *TripleStore, threshold Quantity) TripleFilter {
+	return quantityFilter(store, threshold, 1)
+}
+
+// ObjectQuantityBelow returns a filter matching triples whose object is a
+// quantity less than threshold after unit conversion. QUDT quantity value
+// nodes are resolved in store.
+func ObjectQuantityBelow(store *TripleStore, threshold Quantity) TripleFilter {
+	return quantityFilter(store, threshold, -1)
+}
+
+func quantityFilter(store *TripleStore, threshold Quantity, want int) TripleFilter {
+	return func(t Triple) bool {
+		q, err := store.QuantityOf(t.Object)
+		if err != nil {
+			return false
+		}
+		cmp, err := CompareQuantities(q, threshold)
+		return err == nil && cmp == want
+	}
+}
+
+// parseQuantityLexical parses "<number> <unit>"
+func parseQuantityLexical(s string) (Quantity, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Quantity{}, fmt.Errorf("invalid quantity %q, expected a number and a unit", s)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("invalid quantity %q: %s is not a number", s, fields[0])
+	}
+	code, ok := normalizeUnit(fields[1])
+	if !ok {
+		return Quantity{}, fmt.Errorf("invalid quantity %q: unknown unit %s", s, fields[1])
+	}
+	return Quantity{Value: value, Unit: code}, nil
+}
+
+// normalizeUnit returns the UCUM code of a UCUM code or QUDT unit IRI
+func normalizeUnit(unit string) (string, bool) {
+	iri := strings.TrimSuffix(strings.TrimPrefix(unit, "<"), ">")
+	if local, ok := strings.CutPrefix(iri, QUDTUnitVocab); ok {
+		code, known := qudtUnits[local]
+		return code, known
+	}
+	if _, ok := ucumUnits[unit]; ok {
+		return unit, true
+	}
+	return "", false
+}
+
+// quantityBuiltin adapts a quantity comparison to a Datalog builtin over
+// quoted UCUM constants such as "5 km"
+func quantityBuiltin(want int) DLBuiltin {
+	return func(args []string) bool {
+		if len(args) != 2 {
+			return false
+		}
+		a, err := parseQuantityLexical(datalogLiteralValue(args[0]))
+		if err != nil {
+			return false
+		}
+		b, err := parseQuantityLexical(datalogLiteralValue(args[1]))
+		if err != nil {
+			return false
+		}
+		cmp, err := CompareQuantities(a, b)
+		return err == nil && cmp == want
+	}
+}
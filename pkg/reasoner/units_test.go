@@ -0,0 +1,95 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertUnit(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		want     float64
+		wantErr  bool
+	}{
+		{5, "km", "m", 5000, false},
+		{250, "cm", "m", 2.5, false},
+		{1, QUDTUnitVocab + "KiloM", "m", 1000, false},
+		{3, "h", QUDTUnitVocab + "MIN", 180, false},
+		{36, "km/h", "m/s", 10, false},
+		{2, "har", "m2", 20000, false},
+		{1, "km", "kg", 0, true},
+		{1, "parsec", "m", 0, true},
+		{1, QUDTUnitVocab + "DEG_C", "K", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ConvertUnit(tt.value, tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ConvertUnit(%v, %s, %s) error = %v, wantErr %v", tt.value, tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ConvertUnit(%v, %s, %s) = %v, want %v", tt.value, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+const unitsDocument = `
+@prefix ex: <http://example.org/> .
+@prefix qudt: <http://qudt.org/schema/qudt/> .
+@prefix unit: <http://qudt.org/vocab/unit/> .
+@prefix cdt: <http://w3id.org/lindt/custom_datatypes#> .
+ex:route1 ex:length "7.5 km"^^cdt:ucum .
+ex:route2 ex:length "4200 m"^^cdt:ucum .
+ex:route3 ex:length ex:route3length .
+ex:route3length qudt:numericValue "6" ; qudt:unit unit:KiloM .
+ex:route4 ex:length "3 h"^^cdt:ucum .
+`
+
+func TestQuantityFilters(t *testing.T) {
+	r := NewReasoner()
+	if err := r.LoadTurtle(unitsDocument); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	store := r.GetStore()
+	q, err := store.QuantityOf("http://example.org/route3length")
+	if err != nil || q != (Quantity{Value: 6, Unit: "km"}) {
+		t.Errorf("QuantityOf QUDT node = %v, %v", q, err)
+	}
+
+	fiveKm := Quantity{Value: 5, Unit: "km"}
+	long := r.QueryFiltered("", "http://example.org/length", "", ObjectQuantityAbove(store, fiveKm))
+	var subjects []string
+	for _, triple := range long {
+		subjects = append(subjects, triple.Subject)
+	}
+	if strings.Join(subjects, " ") != "http://example.org/route1 http://example.org/route3" {
+		t.Errorf("Expected route1 and route3 longer than 5 km, got %v", subjects)
+	}
+
+	short := r.QueryFiltered("", "http://example.org/length", "", ObjectQuantityBelow(store, fiveKm))
+	if len(short) != 1 || short[0].Subject != "http://example.org/route2" {
+		t.Errorf("Expected only route2 shorter than 5 km, got %v", short)
+	}
+
+	facts := ConvertTriplesToDatalogWithOptions(r.GetAllTriples(), DatalogOptions{QuoteLiterals: true})
+	program := strings.Join(facts, "\n") + `
+Long(X) :- length(X, L), quantityGreater(L, "5000 m").
+`
+	for query, expected := range map[string]bool{
+		"?- Long(route1).": true,
+		"?- Long(route2).": false,
+		"?- Long(route4).": false,
+	} {
+		result, err := DLQuery(program, query)
+		if err != nil {
+			t.Errorf("DLQuery error for %s: %v", query, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("DLQuery(%s) = %v, expected %v", query, result, expected)
+		}
+	}
+}
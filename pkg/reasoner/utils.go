@@ -9,6 +9,28 @@ import (
 // Each RDF triple (subject, predicate, object) becomes a Datalog fact: predicate(subject, object)
 // IRIs are converted to simplified names by extracting the local part after # or /
 func ConvertTriplesToDatalog(triples []string) []string {
+	return ConvertTriplesToDatalogWithOptions(triples, DatalogOptions{})
+}
+
+// DatalogOptions controls the conversion of triples to Datalog facts
+type DatalogOptions struct {
+	// LiteralMatching selects whether literals with the same value but
+	// different lexical forms become the same Datalog constant
+	LiteralMatching LiteralMatching
+	// QuoteLiterals keeps literal objects as quoted constants holding their
+	// full lexical form, as needed by builtins such as sfWithin, instead of
+	// simplifying them to identifiers
+	QuoteLiterals bool
+	// Constraints additionally emits owl:disjointWith and owl:differentFrom
+	// axioms as integrity constraints, rules without a head such as
+	// ":- type(X, Cat), type(X, Dog).", so that a Datalog engine can detect
+	// the inconsistencies CheckConsistency reports
+	Constraints bool
+}
+
+// ConvertTriplesToDatalogWithOptions is like ConvertTriplesToDatalog but
+// with configurable literal handling
+func ConvertTriplesToDatalogWithOptions(triples []string, opts DatalogOptions) []string {
 	datalogFacts := make([]string, 0, len(triples))
 
 	for _, triple := range triples {
@@ -27,18 +49,46 @@ func ConvertTriplesToDatalog(triples []string) []string {
 			continue // Skip malformed triples
 		}
 
+		if opts.LiteralMatching == ValueMatching {
+			parts[2] = CanonicalLiteral(parts[2])
+		}
+
 		subject := simplifyIRI(parts[0])
 		predicate := simplifyIRI(parts[1])
 		object := simplifyIRI(parts[2])
+		if term := ParseTerm(parts[2]); opts.QuoteLiterals && term.IsLiteral() {
+			object = `"` + escapeLiteral(term.Value) + `"`
+		}
 
 		// Format as Datalog fact: predicate(subject, object)
 		datalogFact := fmt.Sprintf("%s(%s, %s).", predicate, subject, object)
 		datalogFacts = append(datalogFacts, datalogFact)
+
+		if opts.Constraints {
+			if constraint := datalogConstraint(parts); constraint != "" {
+				datalogFacts = append(datalogFacts, constraint)
+			}
+		}
 	}
 
 	return datalogFacts
 }
 
+// datalogConstraint returns the integrity constraint expressing the
+// disjointness or distinctness axiom of a triple, if it is one
+func datalogConstraint(parts []string) string {
+	subject := simplifyIRI(parts[0])
+	object := simplifyIRI(parts[2])
+	switch simplifyIRI(parts[1]) {
+	case simplifyIRI(OWLDisjointWith):
+		typ := simplifyIRI(RDFType)
+		return fmt.Sprintf(":- %s(X, %s), %s(X, %s).", typ, subject, typ, object)
+	case simplifyIRI(OWLDifferentFrom):
+		return fmt.Sprintf(":- %s(%s, %s).", simplifyIRI(OWLSameAs), subject, object)
+	}
+	return ""
+}
+
 // String returns a human-readable summary of the reasoning result
 func (r *ReasoningResult) String() string {
 	var sb strings.Builder
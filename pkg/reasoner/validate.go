@@ -0,0 +1,111 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RDF is the RDF namespace
+const RDF = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+// knownDatatypes lists the datatypes ValidateRDF accepts besides those
+// registered with RegisterDatatype: the XML Schema datatypes usable in RDF,
+// the RDF datatypes and those the reasoner interprets
+//
+//nolint:gochecknoglobals
+var knownDatatypes = func() map[string]bool {
+	known := map[string]bool{
+		RDF + "langString": true, RDF + "HTML": true, RDF + "XMLLiteral": true,
+		RDF + "JSON": true, RDF + "PlainLiteral": true,
+		GeoWKTLiteral: true, UCUMLiteral: true,
+	}
+	for _, name := range []string{
+		"string", "boolean", "decimal", "integer", "double", "float",
+		"date", "time", "dateTime", "dateTimeStamp", "duration",
+		"dayTimeDuration", "yearMonthDuration", "gYear", "gMonth", "gDay",
+		"gYearMonth", "gMonthDay", "hexBinary", "base64Binary", "anyURI",
+		"language", "normalizedString", "token", "NMTOKEN", "Name", "NCName",
+		"long", "int", "short", "byte", "nonNegativeInteger", "positiveInteger",
+		"nonPositiveInteger", "negativeInteger", "unsignedLong", "unsignedInt",
+		"unsignedShort", "unsignedByte",
+	} {
+		known[XSD+name] = true
+	}
+	return known
+}()
+
+// ValidateRDF checks the syntax of a Turtle or N-Triples document without
+// loading it, labelling the diagnostics with source. Unlike loading, which
+// is lenient, it also reports prefixes used without a declaration and
+// relative IRIs without a base as errors, and literals with datatypes that
+// are neither standard nor registered with RegisterDatatype as warnings. It
+// does not check the data against the ontology. An error is returned only
+// if a parser limit is exceeded.
+func ValidateRDF(source, content string, opts ParserOptions) ([]Diagnostic, error) {
+	p := NewTurtleParserWithOptions(opts)
+	if _, err := p.Parse(content); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+	}
+
+	diagnostics := parseDiagnostics(p.Errors(), p.Warnings(), source)
+	for _, note := range p.notes {
+		note.Source = source
+		diagnostics = append(diagnostics, note)
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Line < diagnostics[j].Line
+	})
+	return diagnostics, nil
+}
+
+// note records a problem on a line that only ValidateRDF reports
+func (p *TurtleParser) note(line int, code, format string, args ...any) {
+	d := Diagnostic{
+		Severity: SeverityError,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Line:     line,
+	}
+	switch code {
+	case CodeUndefinedPrefix:
+		d.Suggestion = "declare the prefix with @prefix"
+	case CodeRelativeIRI:
+		d.Suggestion = "use an absolute IRI or declare a base with @base"
+	case CodeUnknownDatatype:
+		d.Severity = SeverityWarning
+		d.Suggestion = "check the datatype IRI for typos, or register the datatype"
+	}
+	p.notes = append(p.notes, d)
+}
+
+// checkDatatype notes a literal of the statement at pos whose datatype is
+// not known
+func (p *TurtleParser) checkDatatype(term string, pos int) {
+	if len(term) == 0 || term[0] != '"' {
+		return
+	}
+	datatype := ParseTerm(term).Datatype
+	if datatype == "" || knownDatatypes[datatype] {
+		return
+	}
+	if _, ok := customDatatypes[datatype]; ok {
+		return
+	}
+	p.note(p.lineAt(pos), CodeUnknownDatatype, "unknown datatype <%s>", datatype)
+}
+
+// isRelativeIRI reports whether an IRI lacks a scheme
+func isRelativeIRI(iri string) bool {
+	end := strings.IndexAny(iri, ":/?#")
+	if end <= 0 || iri[end] != ':' {
+		return true
+	}
+	for i, r := range iri[:end] {
+		isLetter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		if !isLetter && (i == 0 || !(r >= '0' && r <= '9' || r == '+' || r == '-' || r == '.')) {
+			return true
+		}
+	}
+	return false
+}
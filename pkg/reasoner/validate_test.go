@@ -0,0 +1,61 @@
+package reasoner
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateRDF(t *testing.T) {
+	content := `@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+@prefix rel: <vocab/> .
+ex:a ex:p ex:b .
+ex:a foaf:name "Alice" .
+<people/bob> ex:p ex:b .
+ex:a ex:age "42"^^xsd:integr .
+ex:a ex:age "42"^^xsd:integer .
+ex:a ex:p .
+`
+	diagnostics, err := ValidateRDF("data.ttl", content, ParserOptions{})
+	if err != nil {
+		t.Fatalf("ValidateRDF() error = %v", err)
+	}
+
+	want := []struct {
+		line     int
+		code     string
+		severity Severity
+	}{
+		{3, CodeRelativeIRI, SeverityError},
+		{5, CodeUndefinedPrefix, SeverityError},
+		{6, CodeRelativeIRI, SeverityError},
+		{7, CodeUnknownDatatype, SeverityWarning},
+		{9, CodeSkippedStatement, SeverityError},
+	}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("ValidateRDF() = %v, want %d diagnostics", diagnostics, len(want))
+	}
+	for i, w := range want {
+		d := diagnostics[i]
+		if d.Line != w.line || d.Code != w.code || d.Severity != w.severity || d.Source != "data.ttl" {
+			t.Errorf("diagnostic %d = %v, want %s %s on line %d", i, d, w.severity, w.code, w.line)
+		}
+	}
+}
+
+func TestValidateRDFValid(t *testing.T) {
+	registerTestEGID(t)
+
+	content := `@base <http://example.org/> .
+@prefix t: <http://example.org/types#> .
+<a> <p> "190012"^^t:egid , "2024-01-01"^^<http://www.w3.org/2001/XMLSchema#date> .
+`
+	diagnostics, err := ValidateRDF("data.ttl", content, ParserOptions{})
+	if err != nil || len(diagnostics) != 0 {
+		t.Errorf("ValidateRDF() = %v, %v, want no diagnostics", diagnostics, err)
+	}
+
+	if _, err := ValidateRDF("big.ttl", content, ParserOptions{MaxTriples: 1}); !errors.Is(err, ErrParserLimit) {
+		t.Errorf("ValidateRDF() with a limit error = %v, want ErrParserLimit", err)
+	}
+}
@@ -0,0 +1,123 @@
+package reasoner
+
+import "time"
+
+// WithVersioning makes the reasoner's store record when every triple is
+// added and removed, so that the store can be viewed and reasoned over as
+// of a point in time with AsOf
+func WithVersioning() Option {
+	return func(r *Reasoner) {
+		r.store.EnableVersioning()
+	}
+}
+
+// versionEvent is the addition or removal of a triple at a point in time
+type versionEvent struct {
+	triple  Triple
+	at      time.Time
+	removed bool
+}
+
+// storeHistory is the log of changes to a versioned store
+type storeHistory struct {
+	now    func() time.Time
+	events []versionEvent
+}
+
+func (h *storeHistory) record(t Triple, removed bool) {
+	h.events = append(h.events, versionEvent{triple: t, at: h.now(), removed: removed})
+}
+
+func (h *storeHistory) clone() *storeHistory {
+	return &storeHistory{now: h.now, events: append([]versionEvent(nil), h.events...)}
+}
+
+// EnableVersioning makes the store record the time every triple is added
+// and removed from now on. Triples already in the store are recorded as
+// added now. Frozen stores cannot be versioned.
+func (ts *TripleStore) EnableVersioning() {
+	if ts.history != nil || ts.frozen {
+		return
+	}
+	ts.history = &storeHistory{now: time.Now}
+	for _, t := range ts.tripleList {
+		ts.history.record(t, false)
+	}
+}
+
+// Versioned reports whether the store records the history of its triples
+func (ts *TripleStore) Versioned() bool {
+	return ts.history != nil
+}
+
+// AddedAt returns the time a triple in a versioned store was last added.
+// It returns false if the triple is not in the store or the store is not
+// versioned.
+func (ts *TripleStore) AddedAt(t Triple) (time.Time, bool) {
+	if ts.history == nil || !ts.Contains(t) {
+		return time.Time{}, false
+	}
+	for i := len(ts.history.events) - 1; i >= 0; i-- {
+		e := ts.history.events[i]
+		if !e.removed && tripleKey(e.triple) == tripleKey(t) {
+			return e.at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// AsOf returns a new store with the triples a versioned store contained at
+// the given time, with their sources. The new store is not versioned. A
+// store without history is copied as it is.
+func (ts *TripleStore) AsOf(at time.Time) *TripleStore {
+	if ts.history == nil {
+		return ts.clone()
+	}
+	present := make(map[string]bool)
+	var order []Triple
+	for _, e := range ts.history.events {
+		if e.at.After(at) {
+			continue
+		}
+		key := tripleKey(e.triple)
+		if e.removed {
+			delete(present, key)
+			continue
+		}
+		if !present[key] {
+			present[key] = true
+			order = append(order, e.triple)
+		}
+	}
+
+	snapshot := NewTripleStore()
+	for predicate, equivalence := range ts.equivalences {
+		snapshot.SetTermEquivalence(predicate, equivalence)
+	}
+	for _, t := range order {
+		key := tripleKey(t)
+		if !present[key] {
+			continue
+		}
+		snapshot.add(t)
+		if sources := ts.sources[key]; len(sources) > 0 {
+			if snapshot.sources == nil {
+				snapshot.sources = make(map[string][]string)
+			}
+			snapshot.sources[key] = append([]string(nil), sources...)
+		}
+	}
+	return snapshot
+}
+
+// AsOf returns a copy of the reasoner, like Fork, whose store holds the
+// triples the versioned store contained at the given time. The copy is not
+// known to be closed, so RunForwardReasoning reasons over the historical
+// triples with the current rules, reproducing an analysis as it would have
+// run at that time.
+func (r *Reasoner) AsOf(at time.Time) *Reasoner {
+	f := r.Fork()
+	f.store = r.store.AsOf(at)
+	f.closed = 0
+	return f
+}
@@ -0,0 +1,81 @@
+package reasoner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVersionedStoreAsOf(t *testing.T) {
+	r := NewReasoner(WithVersioning())
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.store.history.now = func() time.Time { return clock }
+
+	schema := `@prefix : <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+:Municipality rdfs:subClassOf :Authority .
+`
+	if err := r.LoadTurtle(schema + ":bern a :Municipality .\n"); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+	january := clock
+
+	clock = clock.AddDate(0, 1, 0)
+	if err := r.LoadTurtle(schema + ":thun a :Municipality .\n"); err != nil {
+		t.Fatalf("LoadTurtle failed: %v", err)
+	}
+
+	clock = clock.AddDate(0, 1, 0)
+	txn := r.GetStore().Begin()
+	bern := Triple{Subject: "http://example.org/bern", Predicate: RDFType, Object: "http://example.org/Municipality"}
+	if !txn.Remove(bern) {
+		t.Fatal("Expected the triple to be removed")
+	}
+	clock = clock.AddDate(0, 0, 1)
+	if err := txn.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	march := clock
+	r.RunForwardReasoning()
+
+	if at, ok := r.GetStore().AddedAt(Triple{Subject: "http://example.org/thun", Predicate: RDFType, Object: "http://example.org/Municipality"}); !ok || !at.Equal(january.AddDate(0, 1, 0)) {
+		t.Errorf("Expected thun to have been added in February, got %v, %v", at, ok)
+	}
+	if _, ok := r.GetStore().AddedAt(bern); ok {
+		t.Error("Expected no time for a removed triple")
+	}
+
+	authorities := func(f *Reasoner) int {
+		return len(f.GetStore().FindByPredicateObject(RDFType, "http://example.org/Authority"))
+	}
+	tests := []struct {
+		at       time.Time
+		triples  int
+		inferred int
+	}{
+		{january.Add(-time.Hour), 0, 0},
+		{january, 2, 1},
+		{january.AddDate(0, 1, 0), 3, 2},
+		// The removal is stamped with the time of the commit
+		{march.Add(-time.Hour), 3, 2},
+		// Reasoning ran in March and its inference is part of the store
+		{march, 3, 1},
+	}
+	for _, tt := range tests {
+		f := r.AsOf(tt.at)
+		if f.GetStore().Size() != tt.triples {
+			t.Errorf("AsOf(%v): expected %d triples, got %d", tt.at, tt.triples, f.GetStore().Size())
+		}
+		if f.GetStore().Versioned() {
+			t.Errorf("AsOf(%v): expected an unversioned snapshot", tt.at)
+		}
+		f.RunForwardReasoning()
+		if got := authorities(f); got != tt.inferred {
+			t.Errorf("AsOf(%v): expected %d authorities, got %d", tt.at, tt.inferred, got)
+		}
+	}
+
+	// Inferred triples are recorded too, so the current state includes them
+	if got := r.GetStore().AsOf(march).Size(); got != r.GetStore().Size() {
+		t.Errorf("Expected the latest snapshot to match the store, got %d of %d triples", got, r.GetStore().Size())
+	}
+}
@@ -0,0 +1,150 @@
+package reasoner
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// conditionOperators lists the operators of ParseCondition, symbolic
+// operators first so "!=" is not read as "="
+//
+//nolint:gochecknoglobals
+var conditionOperators = []string{"!=", "=", "<", ">", "startsWith", "endsWith", "contains"}
+
+// standardPrefixes are the prefixes ParseCondition knows without a
+// declaration
+//
+//nolint:gochecknoglobals
+var standardPrefixes = map[string]string{
+	"rdf":  "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"rdfs": "http://www.w3.org/2000/01/rdf-schema#",
+	"owl":  "http://www.w3.org/2002/07/owl#",
+	"xsd":  XSD,
+}
+
+// ParseCondition parses a condition on one position of a triple into a
+// filter for QueryFiltered. A condition names the position as s, p or o
+// (or subject, predicate, object), an operator and a value:
+//
+//	p=rdf:type                   the predicate is rdf:type
+//	o!="draft"                   the object is not the plain literal "draft"
+//	o startsWith http://example.org/
+//	o contains Zürich            the IRI or lexical form contains the text
+//	o > "100"^^xsd:integer       the literal is greater by value
+//
+// Values of = and != are terms: prefixed names are expanded with prefixes,
+// to which the standard rdf, rdfs, owl and xsd prefixes are added, and
+// quoted values are literals, optionally with a language tag or a datatype.
+// startsWith, endsWith and contains compare text against an IRI or the
+// lexical form of a literal; a value starting with a known prefix is
+// expanded. < and > compare literals as CompareLiterals does.
+func ParseCondition(expr string, prefixes map[string]string) (TripleFilter, error) {
+	prefixes = withStandardPrefixes(prefixes)
+
+	rest := strings.TrimSpace(expr)
+	end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsLetter(r) })
+	if end < 0 {
+		return nil, fmt.Errorf("invalid condition %q: missing operator", expr)
+	}
+
+	var position func(t Triple) string
+	switch rest[:end] {
+	case "s", "subject":
+		position = func(t Triple) string { return t.Subject }
+	case "p", "predicate":
+		position = func(t Triple) string { return t.Predicate }
+	case "o", "object":
+		position = func(t Triple) string { return t.Object }
+	default:
+		return nil, fmt.Errorf("invalid condition %q: position must be s, p or o", expr)
+	}
+	rest = strings.TrimSpace(rest[end:])
+
+	operator := ""
+	for _, op := range conditionOperators {
+		if strings.HasPrefix(rest, op) {
+			operator = op
+			break
+		}
+	}
+	if operator == "" {
+		return nil, fmt.Errorf("invalid condition %q: operator must be one of %s", expr, strings.Join(conditionOperators, ", "))
+	}
+	value := strings.TrimSpace(rest[len(operator):])
+	if value == "" {
+		return nil, fmt.Errorf("invalid condition %q: missing value", expr)
+	}
+
+	switch operator {
+	case "=", "!=":
+		term := conditionTerm(value, prefixes)
+		negate := operator == "!="
+		return func(t Triple) bool { return (position(t) == term) != negate }, nil
+	case "<", ">":
+		term := conditionTerm(value, prefixes)
+		if !ParseTerm(term).IsLiteral() {
+			term = NewLiteral(value, "").String()
+		}
+		sign := 1
+		if operator == "<" {
+			sign = -1
+		}
+		return func(t Triple) bool {
+			c, ok := CompareLiterals(position(t), term)
+			return ok && c*sign > 0
+		}, nil
+	default:
+		text := value
+		if strings.HasPrefix(text, `"`) {
+			text = ParseTerm(text).Value
+		} else {
+			text = ExpandPrefixedName(text, prefixes)
+		}
+		match := strings.HasPrefix
+		switch operator {
+		case "endsWith":
+			match = strings.HasSuffix
+		case "contains":
+			match = strings.Contains
+		}
+		return func(t Triple) bool { return match(ParseTerm(position(t)).Value, text) }, nil
+	}
+}
+
+// StandardPrefixes returns the rdf, rdfs, owl and xsd prefixes, which
+// conditions and queries may use without declaring them
+func StandardPrefixes() map[string]string {
+	return withStandardPrefixes(nil)
+}
+
+// withStandardPrefixes returns a copy of prefixes to which the standard
+// prefixes are added, unless declared otherwise
+func withStandardPrefixes(prefixes map[string]string) map[string]string {
+	known := make(map[string]string, len(standardPrefixes)+len(prefixes))
+	for prefix, ns := range standardPrefixes {
+		known[prefix] = ns
+	}
+	for prefix, ns := range prefixes {
+		known[prefix] = ns
+	}
+	return known
+}
+
+// conditionTerm converts the value of a condition to a term, expanding
+// prefixed names and prefixed datatypes of literals
+func conditionTerm(value string, prefixes map[string]string) string {
+	if !strings.HasPrefix(value, `"`) {
+		if strings.HasPrefix(value, "_:") {
+			return value
+		}
+		return ExpandPrefixedName(value, prefixes)
+	}
+
+	end := strings.LastIndex(value, `"`)
+	if end > 0 && strings.HasPrefix(value[end+1:], "^^") {
+		datatype := ExpandPrefixedName(value[end+3:], prefixes)
+		return NewLiteral(ParseTerm(value[:end+1]).Value, datatype).String()
+	}
+	return ParseTerm(value).String()
+}
@@ -0,0 +1,69 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCondition(t *testing.T) {
+	r := NewReasoner()
+	err := r.LoadTurtle(`@prefix ex: <http://example.org/> .
+@prefix other: <http://other.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:a a ex:City ; ex:name "Zürich"@de ; ex:population "421878"^^xsd:integer .
+ex:b a other:Town ; ex:name "Bern und Umgebung" ; ex:population "134591"^^xsd:integer .
+ex:b ex:twin ex:a .
+`)
+	if err != nil {
+		t.Fatalf("LoadTurtle() error = %v", err)
+	}
+	prefixes := r.Prefixes()
+
+	tests := []struct {
+		conditions []string
+		want       []string // Subject and object of each match
+	}{
+		{[]string{"p=rdf:type"}, []string{"a ex:City", "b other:Town"}},
+		{[]string{"p=rdf:type", "o startsWith http://example.org/"}, []string{"a ex:City"}},
+		{[]string{"predicate = rdf:type", "object startsWith ex:"}, []string{"a ex:City"}},
+		{[]string{`o="Zürich"@de`}, []string{`a "Zürich"@de`}},
+		{[]string{`o contains und Umg`}, []string{`b "Bern und Umgebung"`}},
+		{[]string{`o endsWith "Umgebung"`}, []string{`b "Bern und Umgebung"`}},
+		{[]string{`o>"200000"^^xsd:integer`}, []string{`a "421878"^^<http://www.w3.org/2001/XMLSchema#integer>`}},
+		{[]string{`o < "200000"`, "p=ex:population"}, []string{`b "134591"^^<http://www.w3.org/2001/XMLSchema#integer>`}},
+		{[]string{"s=ex:b", "p!=rdf:type", "p!=ex:population", "p!=ex:name"}, []string{"b ex:a"}},
+	}
+
+	for _, tt := range tests {
+		var filters []TripleFilter
+		for _, c := range tt.conditions {
+			filter, err := ParseCondition(c, prefixes)
+			if err != nil {
+				t.Fatalf("ParseCondition(%q) error = %v", c, err)
+			}
+			filters = append(filters, filter)
+		}
+
+		var got []string
+		for _, m := range r.QueryFiltered("", "", "", filters...) {
+			object := m.Object
+			for prefix, ns := range map[string]string{"ex:": "http://example.org/", "other:": "http://other.org/"} {
+				if strings.HasPrefix(object, ns) {
+					object = prefix + strings.TrimPrefix(object, ns)
+				}
+			}
+			got = append(got, strings.TrimPrefix(m.Subject, "http://example.org/")+" "+object)
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("conditions %q matched %q, want %q", tt.conditions, got, tt.want)
+		}
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	for _, expr := range []string{"", "p", "x=rdf:type", "p~rdf:type", "o startsWith", "o="} {
+		if _, err := ParseCondition(expr, nil); err == nil {
+			t.Errorf("ParseCondition(%q) succeeded, want error", expr)
+		}
+	}
+}
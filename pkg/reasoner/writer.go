@@ -0,0 +1,226 @@
+package reasoner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SerializeTurtle writes triples as Turtle, declaring the given prefixes and
+// using them to compact IRIs. Triples are grouped by subject in order of
+// first appearance, with predicate lists (;) and object lists (,).
+//
+// The output is guaranteed to parse back into a graph isomorphic to the
+// input: escapes, datatypes, language tags and blank nodes are preserved.
+// RoundTripCheck verifies this for a given document.
+func SerializeTurtle(triples []Triple, prefixes map[string]string) string {
+	w := newTurtleWriter(prefixes)
+
+	var subjects []string
+	bySubject := make(map[string][]Triple)
+	for _, t := range triples {
+		if _, ok := bySubject[t.Subject]; !ok {
+			subjects = append(subjects, t.Subject)
+		}
+		bySubject[t.Subject] = append(bySubject[t.Subject], t)
+	}
+
+	var sb strings.Builder
+	w.writePrefixes(&sb)
+	for i, subject := range subjects {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		w.writeSubject(&sb, subject, bySubject[subject])
+	}
+	return sb.String()
+}
+
+// SerializeTurtleSorted is like SerializeTurtle but writes a deterministic
+// snapshot suited to version control: duplicate triples are dropped,
+// subjects are sorted with IRIs before blank nodes, and each subject lists
+// rdf:type first, then the other predicates and their objects in order.
+func SerializeTurtleSorted(triples []Triple, prefixes map[string]string) string {
+	seen := make(map[string]bool, len(triples))
+	sorted := make([]Triple, 0, len(triples))
+	for _, t := range triples {
+		if key := tripleKey(t); !seen[key] {
+			seen[key] = true
+			sorted = append(sorted, t)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Subject != b.Subject {
+			aBlank, bBlank := strings.HasPrefix(a.Subject, "_:"), strings.HasPrefix(b.Subject, "_:")
+			if aBlank != bBlank {
+				return bBlank
+			}
+			return a.Subject < b.Subject
+		}
+		if a.Predicate != b.Predicate {
+			if a.Predicate == RDFType || b.Predicate == RDFType {
+				return a.Predicate == RDFType
+			}
+			return a.Predicate < b.Predicate
+		}
+		return a.Object < b.Object
+	})
+
+	return SerializeTurtle(sorted, prefixes)
+}
+
+// RoundTripCheck parses Turtle content, serializes it with SerializeTurtle
+// and parses the result again. It returns an error if any statement of the
+// input could not be parsed or if the two parsed graphs are not isomorphic.
+func RoundTripCheck(content string) error {
+	parser := NewTurtleParser()
+	original, err := parser.Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse input: %w", err)
+	}
+	if errs := parser.Errors(); len(errs) > 0 {
+		return fmt.Errorf("%d statements could not be parsed, first at %w", len(errs), errs[0])
+	}
+
+	serialized := SerializeTurtle(original, parser.Prefixes())
+
+	reparser := NewTurtleParser()
+	reparsed, err := reparser.Parse(serialized)
+	if err != nil {
+		return fmt.Errorf("failed to parse serialized output: %w", err)
+	}
+	if errs := reparser.Errors(); len(errs) > 0 {
+		return fmt.Errorf("serialized output could not be parsed at %w", errs[0])
+	}
+
+	diff := diffGraphs(original, reparsed, false)
+	if !diff.Equal() {
+		var example Triple
+		if len(diff.OnlyInFirst) > 0 {
+			example = diff.OnlyInFirst[0]
+		} else {
+			example = diff.OnlyInSecond[0]
+		}
+		return fmt.Errorf("round trip changed the graph: %d triples lost, %d added, e.g. %s",
+			len(diff.OnlyInFirst), len(diff.OnlyInSecond), example)
+	}
+
+	return nil
+}
+
+// turtleWriter compacts terms using a set of prefixes
+type turtleWriter struct {
+	prefixes map[string]string
+	names    []string // Prefix names, longest namespace first
+}
+
+func newTurtleWriter(prefixes map[string]string) *turtleWriter {
+	w := &turtleWriter{prefixes: prefixes}
+	for name := range prefixes {
+		w.names = append(w.names, name)
+	}
+	sort.Slice(w.names, func(i, j int) bool {
+		a, b := prefixes[w.names[i]], prefixes[w.names[j]]
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return w.names[i] < w.names[j]
+	})
+	return w
+}
+
+func (w *turtleWriter) writePrefixes(sb *strings.Builder) {
+	if len(w.names) == 0 {
+		return
+	}
+	names := make([]string, len(w.names))
+	copy(names, w.names)
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(sb, "@prefix %s: <%s> .\n", name, w.prefixes[name])
+	}
+	sb.WriteString("\n")
+}
+
+func (w *turtleWriter) writeSubject(sb *strings.Builder, subject string, triples []Triple) {
+	var predicates []string
+	objects := make(map[string][]string)
+	for _, t := range triples {
+		if _, ok := objects[t.Predicate]; !ok {
+			predicates = append(predicates, t.Predicate)
+		}
+		objects[t.Predicate] = append(objects[t.Predicate], t.Object)
+	}
+
+	sb.WriteString(w.term(subject))
+	for i, predicate := range predicates {
+		if i > 0 {
+			sb.WriteString(" ;\n   ")
+		}
+		sb.WriteString(" ")
+		if predicate == RDFType {
+			sb.WriteString("a")
+		} else {
+			sb.WriteString(w.term(predicate))
+		}
+		for j, object := range objects[predicate] {
+			if j > 0 {
+				sb.WriteString(" ,")
+			}
+			sb.WriteString(" ")
+			sb.WriteString(w.term(object))
+		}
+	}
+	sb.WriteString(" .\n")
+}
+
+// term formats a term as Turtle
+func (w *turtleWriter) term(s string) string {
+	t := ParseTerm(s)
+	switch t.Kind {
+	case TermBlankNode:
+		return s
+	case TermLiteral:
+		lexical := `"` + escapeLiteral(t.Value) + `"`
+		if t.Language != "" {
+			return lexical + "@" + t.Language
+		}
+		if t.Datatype != "" {
+			return lexical + "^^" + w.iri(t.Datatype)
+		}
+		return lexical
+	default:
+		return w.iri(t.Value)
+	}
+}
+
+// iri compacts an IRI to a prefixed name if its local part is safe to write,
+// otherwise writes it in angle brackets
+func (w *turtleWriter) iri(iri string) string {
+	for _, name := range w.names {
+		ns := w.prefixes[name]
+		if ns == "" || !strings.HasPrefix(iri, ns) {
+			continue
+		}
+		if local := iri[len(ns):]; isSafeLocalName(local) {
+			return name + ":" + local
+		}
+	}
+	return "<" + iri + ">"
+}
+
+// isSafeLocalName reports whether a local name can be written after a
+// prefix and read back unchanged by the parser
+func isSafeLocalName(local string) bool {
+	if strings.HasSuffix(local, ".") {
+		return false
+	}
+	for _, r := range local {
+		if !isNameChar(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package reasoner
+
+import (
+	"strings"
+	"testing"
+)
+
+const roundTripDocument = `@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+
+ex:alice a ex:Person , ex:Agent ;
+    rdfs:label "Alice \"Al\" Smith"@en-GB , "Alice\nline two" ;
+    ex:age "42"^^xsd:integer ;
+    ex:motto """She said "hi"
+and left""" ;
+    ex:path "C:\\temp\\file" ;
+    ex:accent "caf\u00e9" ;
+    ex:homepage <http://example.org/~alice/index.html?x=1> ;
+    ex:address _:addr .
+
+_:addr ex:city "Zürich" ;
+    ex:next _:addr .
+
+<http://other.example/thing> ex:code "x"^^<http://other.example/dt> .
+`
+
+func TestSerializeTurtle(t *testing.T) {
+	p := NewTurtleParser()
+	triples, err := p.Parse(roundTripDocument)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	out := SerializeTurtle(triples, p.Prefixes())
+
+	for _, expected := range []string{
+		"@prefix ex: <http://example.org/> .",
+		"ex:alice a ex:Person , ex:Agent ;",
+		`"42"^^xsd:integer`,
+		`"Alice \"Al\" Smith"@en-GB`,
+		`"She said \"hi\"\nand left"`,
+		`"C:\\temp\\file"`,
+		`"café"`,
+		"<http://example.org/~alice/index.html?x=1>",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, out)
+		}
+	}
+}
+
+func TestRoundTripCheck(t *testing.T) {
+	if err := RoundTripCheck(roundTripDocument); err != nil {
+		t.Errorf("RoundTripCheck failed: %v", err)
+	}
+
+	err := RoundTripCheck(`@prefix ex: <http://example.org/> .
+ex:count ex:value '42' .
+`)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error for the unparsable statement, got %v", err)
+	}
+}
+
+func TestSerializeTurtleSorted(t *testing.T) {
+	p := NewTurtleParser()
+	triples, err := p.Parse(`@prefix ex: <http://example.org/> .
+_:b ex:name "b" .
+ex:zed ex:name "Zed" ; a ex:Person .
+ex:amy ex:knows ex:zed , ex:bob ; ex:name "Amy" ; a ex:Person .
+ex:amy ex:name "Amy" .
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expected := `@prefix ex: <http://example.org/> .
+
+ex:amy a ex:Person ;
+    ex:knows ex:bob , ex:zed ;
+    ex:name "Amy" .
+
+ex:zed a ex:Person ;
+    ex:name "Zed" .
+
+_:b ex:name "b" .
+`
+	out := SerializeTurtleSorted(triples, p.Prefixes())
+	if out != expected {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", out, expected)
+	}
+
+	// The order of the input does not matter
+	reversed := make([]Triple, len(triples))
+	for i, tr := range triples {
+		reversed[len(triples)-1-i] = tr
+	}
+	if again := SerializeTurtleSorted(reversed, p.Prefixes()); again != out {
+		t.Errorf("Output depends on input order:\n%s", again)
+	}
+}